@@ -22,6 +22,7 @@ import (
 	"emperror.dev/errors"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -85,6 +86,9 @@ func (r *filebeatValidator) ValidateCreate(ctx context.Context, obj runtime.Obje
 		if err := filebeatObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 			allErrs = append(allErrs, err)
 		}
+		if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, filebeatObj, *filebeatObj.Spec.ElasticsearchRef); err != nil {
+			allErrs = append(allErrs, err)
+		}
 	}
 
 	if len(allErrs) > 0 {
@@ -127,6 +131,9 @@ func (r *filebeatValidator) ValidateUpdate(ctx context.Context, oldObj runtime.O
 		if err := filebeatObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 			allErrs = append(allErrs, err)
 		}
+		if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, filebeatObj, *filebeatObj.Spec.ElasticsearchRef); err != nil {
+			allErrs = append(allErrs, err)
+		}
 	}
 
 	if len(allErrs) > 0 {
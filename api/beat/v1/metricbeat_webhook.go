@@ -22,6 +22,7 @@ import (
 	"emperror.dev/errors"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -67,6 +68,9 @@ func (r *metricbeatValidator) ValidateCreate(ctx context.Context, obj runtime.Ob
 	if err := metricbeatObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, metricbeatObj, metricbeatObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
@@ -90,6 +94,9 @@ func (r *metricbeatValidator) ValidateUpdate(ctx context.Context, oldObj runtime
 	if err := metricbeatObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, metricbeatObj, metricbeatObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
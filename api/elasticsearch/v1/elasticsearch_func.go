@@ -1,7 +1,16 @@
 package v1
 
 import (
+	"context"
+
+	"emperror.dev/errors"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/thoas/go-funk"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // GetStatus implement the object.MultiPhaseObject
@@ -72,6 +81,24 @@ func (h *Elasticsearch) IsBoostrapping() bool {
 	return true
 }
 
+// IsRestoreFromPending return true if a snapshot must be restored and it's not yet done
+func (h *Elasticsearch) IsRestoreFromPending() bool {
+	if h.Spec.RestoreFrom == nil || h.Status.RestoredSnapshot != "" {
+		return false
+	}
+
+	return true
+}
+
+// IsUpgradeSnapshotCompleted return true if the snapshot before upgrade is successfully completed for the current version
+func (h *Elasticsearch) IsUpgradeSnapshotCompleted() bool {
+	if h.Status.UpgradeSnapshot == nil || h.Status.UpgradeSnapshot.Version != h.Spec.Version || h.Status.UpgradeSnapshot.State != "SUCCESS" {
+		return false
+	}
+
+	return true
+}
+
 // NumberOfReplicas permit to get the total of replicas
 func (h *Elasticsearch) NumberOfReplicas() int32 {
 	nbReplica := int32(0)
@@ -81,3 +108,124 @@ func (h *Elasticsearch) NumberOfReplicas() int32 {
 
 	return nbReplica
 }
+
+// ElasticsearchRealmRef is a realm with its type and its position on the realm list
+// +kubebuilder:object:generate=false
+type ElasticsearchRealmRef struct {
+	ElasticsearchRealmSpec
+
+	// Type is the realm type
+	Type string
+
+	// Index is the position of the realm on the list of the same type
+	Index int
+}
+
+// GetRealms return all realms defined on security spec
+func (h *Elasticsearch) GetRealms() (realms []ElasticsearchRealmRef) {
+	realms = make([]ElasticsearchRealmRef, 0)
+	if h.Spec.Security == nil || h.Spec.Security.Realms == nil {
+		return realms
+	}
+
+	for i, realm := range h.Spec.Security.Realms.Ldap {
+		realms = append(realms, ElasticsearchRealmRef{ElasticsearchRealmSpec: realm.ElasticsearchRealmSpec, Type: RealmTypeLdap, Index: i})
+	}
+	for i, realm := range h.Spec.Security.Realms.Saml {
+		realms = append(realms, ElasticsearchRealmRef{ElasticsearchRealmSpec: realm.ElasticsearchRealmSpec, Type: RealmTypeSaml, Index: i})
+	}
+	for i, realm := range h.Spec.Security.Realms.Oidc {
+		realms = append(realms, ElasticsearchRealmRef{ElasticsearchRealmSpec: realm.ElasticsearchRealmSpec, Type: RealmTypeOidc, Index: i})
+	}
+	for i, realm := range h.Spec.Security.Realms.Jwt {
+		realms = append(realms, ElasticsearchRealmRef{ElasticsearchRealmSpec: realm.ElasticsearchRealmSpec, Type: RealmTypeJwt, Index: i})
+	}
+
+	return realms
+}
+
+// GetRealmSecretNames return the name of all secrets used by realms
+func (h *Elasticsearch) GetRealmSecretNames() (secretNames []string) {
+	secretNames = make([]string, 0)
+	if h.Spec.Security == nil || h.Spec.Security.Realms == nil {
+		return secretNames
+	}
+
+	for _, realm := range h.Spec.Security.Realms.Ldap {
+		if realm.BindPasswordSecretRef != nil {
+			secretNames = append(secretNames, realm.BindPasswordSecretRef.Name)
+		}
+	}
+	for _, realm := range h.Spec.Security.Realms.Saml {
+		if realm.IdpMetadataSecretRef != nil {
+			secretNames = append(secretNames, realm.IdpMetadataSecretRef.Name)
+		}
+	}
+	for _, realm := range h.Spec.Security.Realms.Oidc {
+		if realm.RpClientSecretRef != nil {
+			secretNames = append(secretNames, realm.RpClientSecretRef.Name)
+		}
+	}
+	for _, realm := range h.Spec.Security.Realms.Jwt {
+		if realm.HmacKeySecretRef != nil {
+			secretNames = append(secretNames, realm.HmacKeySecretRef.Name)
+		}
+		if realm.ClientAuthenticationSharedSecretRef != nil {
+			secretNames = append(secretNames, realm.ClientAuthenticationSharedSecretRef.Name)
+		}
+	}
+
+	return funk.UniqString(secretNames)
+}
+
+// ValidateElasticsearchRef check that the reference policy of the managed Elasticsearch cluster allow the object to reference it
+// It's used by webhooks, so it not return error when the cluster not yet exist
+func ValidateElasticsearchRef(ctx context.Context, c client.Client, o client.Object, esRef shared.ElasticsearchRef) *field.Error {
+	if !esRef.IsManaged() || esRef.ManagedElasticsearchRef.Namespace == "" || esRef.ManagedElasticsearchRef.Namespace == o.GetNamespace() {
+		return nil
+	}
+	path := field.NewPath("spec").Child("elasticsearchRef", "managed", "namespace")
+
+	es := &Elasticsearch{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: esRef.ManagedElasticsearchRef.Namespace, Name: esRef.ManagedElasticsearchRef.Name}, es); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return field.InternalError(path, err)
+	}
+
+	if err := shared.CheckReferencePolicy(ctx, c, o, es, es.Spec.ReferencePolicy); err != nil {
+		if errors.Is(err, shared.ErrReferenceNotAllowed) {
+			return field.Forbidden(path, err.Error())
+		}
+		return field.InternalError(path, err)
+	}
+
+	return nil
+}
+
+// GetElasticsearchVersionFromRef return the version of the managed Elasticsearch cluster
+// It's used by webhooks, so it return empty string when the version is not known: external cluster, cluster not yet exist or latest version
+func GetElasticsearchVersionFromRef(ctx context.Context, c client.Client, o client.Object, esRef shared.ElasticsearchRef) (version string, err error) {
+	if !esRef.IsManaged() {
+		return "", nil
+	}
+	namespace := o.GetNamespace()
+	if esRef.ManagedElasticsearchRef.Namespace != "" {
+		namespace = esRef.ManagedElasticsearchRef.Namespace
+	}
+
+	es := &Elasticsearch{}
+	if err = c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: esRef.ManagedElasticsearchRef.Name}, es); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "Error when get Elasticsearch %s/%s", namespace, esRef.ManagedElasticsearchRef.Name)
+	}
+
+	if es.Spec.Version == "latest" {
+		return "", nil
+	}
+
+	return es.Spec.Version, nil
+}
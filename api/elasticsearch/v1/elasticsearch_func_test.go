@@ -231,6 +231,63 @@ func TestIsBootstrapping(t *testing.T) {
 	assert.True(t, o.IsBoostrapping())
 }
 
+func TestIsRestoreFromPending(t *testing.T) {
+	// With default values
+	o := &Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: ElasticsearchSpec{},
+	}
+	assert.False(t, o.IsRestoreFromPending())
+
+	// When restore is expected
+	o.Spec.RestoreFrom = &ElasticsearchRestoreFromSpec{
+		Repository: ElasticsearchRestoreFromRepositorySpec{
+			Name: "backup",
+			Type: "fs",
+		},
+		Snapshot: "latest",
+	}
+	assert.True(t, o.IsRestoreFromPending())
+
+	// When restore is already done
+	o.Status.RestoredSnapshot = "snapshot"
+	assert.False(t, o.IsRestoreFromPending())
+}
+
+func TestIsUpgradeSnapshotCompleted(t *testing.T) {
+	// With default values
+	o := &Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: ElasticsearchSpec{
+			Version: "8.15.0",
+		},
+	}
+	assert.False(t, o.IsUpgradeSnapshotCompleted())
+
+	// When snapshot is in progress
+	o.Status.UpgradeSnapshot = &ElasticsearchUpgradeSnapshotStatus{
+		Name:       "test-upgrade-8.15.0",
+		Repository: "backup",
+		Version:    "8.15.0",
+		State:      "IN_PROGRESS",
+	}
+	assert.False(t, o.IsUpgradeSnapshotCompleted())
+
+	// When snapshot is completed
+	o.Status.UpgradeSnapshot.State = "SUCCESS"
+	assert.True(t, o.IsUpgradeSnapshotCompleted())
+
+	// When snapshot is completed for another version
+	o.Spec.Version = "8.16.0"
+	assert.False(t, o.IsUpgradeSnapshotCompleted())
+}
+
 func TestNumberOfReplicas(t *testing.T) {
 	// With default value
 	o := &Elasticsearch{
@@ -294,3 +351,92 @@ func TestIsRouteEnabled(t *testing.T) {
 	o.Spec.Endpoint.Route.Enabled = true
 	assert.True(t, o.IsRouteEnabled())
 }
+
+func TestGetRealms(t *testing.T) {
+	// With default values
+	o := &Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: ElasticsearchSpec{},
+	}
+	assert.Empty(t, o.GetRealms())
+	assert.Empty(t, o.GetRealmSecretNames())
+
+	// With realms
+	o.Spec.Security = &ElasticsearchSecuritySpec{
+		Realms: &ElasticsearchRealmsSpec{
+			Ldap: []ElasticsearchLdapRealmSpec{
+				{
+					ElasticsearchRealmSpec: ElasticsearchRealmSpec{
+						Name:  "ldap1",
+						Order: 0,
+					},
+					BindPasswordSecretRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "realms",
+						},
+						Key: "ldap",
+					},
+				},
+			},
+			Oidc: []ElasticsearchOidcRealmSpec{
+				{
+					ElasticsearchRealmSpec: ElasticsearchRealmSpec{
+						Name:  "oidc1",
+						Order: 1,
+					},
+					RpClientSecretRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "realms",
+						},
+						Key: "oidc",
+					},
+				},
+			},
+			Jwt: []ElasticsearchJwtRealmSpec{
+				{
+					ElasticsearchRealmSpec: ElasticsearchRealmSpec{
+						Name:  "jwt1",
+						Order: 2,
+					},
+					ClientAuthenticationSharedSecretRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "jwt",
+						},
+						Key: "secret",
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []ElasticsearchRealmRef{
+		{
+			ElasticsearchRealmSpec: ElasticsearchRealmSpec{
+				Name:  "ldap1",
+				Order: 0,
+			},
+			Type:  RealmTypeLdap,
+			Index: 0,
+		},
+		{
+			ElasticsearchRealmSpec: ElasticsearchRealmSpec{
+				Name:  "oidc1",
+				Order: 1,
+			},
+			Type:  RealmTypeOidc,
+			Index: 0,
+		},
+		{
+			ElasticsearchRealmSpec: ElasticsearchRealmSpec{
+				Name:  "jwt1",
+				Order: 2,
+			},
+			Type:  RealmTypeJwt,
+			Index: 0,
+		},
+	}, o.GetRealms())
+	assert.Equal(t, []string{"realms", "jwt"}, o.GetRealmSecretNames())
+}
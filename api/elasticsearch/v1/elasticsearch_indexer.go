@@ -41,6 +41,13 @@ func SetupElasticsearchIndexer(k8sManager manager.Manager) (err error) {
 		return err
 	}
 
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &Elasticsearch{}, "spec.security.realms.secretRef.name", func(o client.Object) []string {
+		p := o.(*Elasticsearch)
+		return p.GetRealmSecretNames()
+	}); err != nil {
+		return err
+	}
+
 	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &Elasticsearch{}, "spec.globalNodeGroup.cacertsSecretRef.name", func(o client.Object) []string {
 		p := o.(*Elasticsearch)
 		if p.Spec.GlobalNodeGroup.CacertsSecretRef != nil {
@@ -216,5 +223,15 @@ func SetupElasticsearchIndexer(k8sManager manager.Manager) (err error) {
 		return err
 	}
 
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &Elasticsearch{}, "spec.classRef.name", func(o client.Object) []string {
+		p := o.(*Elasticsearch)
+		if p.Spec.ClassRef != nil {
+			return []string{p.Spec.ClassRef.Name}
+		}
+		return []string{}
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -112,6 +112,27 @@ func (t *TestSuite) TestSetupElasticsearchIndexer() {
 					},
 				},
 			},
+			Security: &ElasticsearchSecuritySpec{
+				Realms: &ElasticsearchRealmsSpec{
+					Ldap: []ElasticsearchLdapRealmSpec{
+						{
+							ElasticsearchRealmSpec: ElasticsearchRealmSpec{
+								Name:  "ldap1",
+								Order: 0,
+							},
+							Urls:   []string{"ldaps://ldap.example.com:636"},
+							BindDn: "cn=admin,dc=example,dc=com",
+							BindPasswordSecretRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "test",
+								},
+								Key: "password",
+							},
+							UserDnTemplates: []string{"cn={0},ou=users,dc=example,dc=com"},
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -27,6 +27,11 @@ import (
 
 const (
 	ElasticsearchAnnotationKey = "elasticsearch.k8s.webcenter.fr"
+
+	RealmTypeLdap = "ldap"
+	RealmTypeSaml = "saml"
+	RealmTypeOidc = "oidc"
+	RealmTypeJwt  = "jwt"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -40,6 +45,12 @@ type ElasticsearchSpec struct {
 
 	shared.ImageSpec `json:",inline"`
 
+	// ClassRef is the ElasticsearchClass that provide the default settings of the cluster
+	// The settings set on the cluster override the class settings
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ClassRef *ElasticsearchClassRef `json:"classRef,omitempty"`
+
 	// Version is the Elasticsearch version to use
 	// Default is use the latest
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -99,6 +110,111 @@ type ElasticsearchSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Monitoring shared.MonitoringSpec `json:"monitoring,omitempty"`
+
+	// Security permit to set the security settings like authentication realms
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Security *ElasticsearchSecuritySpec `json:"security,omitempty"`
+
+	// ReferencePolicy permit to allow the resources on other namespaces to reference the cluster, like Kibana or Role
+	// Default, only the resources on the same namespace can reference it
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ReferencePolicy *shared.ReferencePolicy `json:"referencePolicy,omitempty"`
+
+	// RestoreFrom permit to restore a snapshot when the cluster is bootstrapped, like to clone an environment or for disaster recovery
+	// The cluster is ready only when the restore is completed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RestoreFrom *ElasticsearchRestoreFromSpec `json:"restoreFrom,omitempty"`
+
+	// UpgradeSnapshot permit to take a snapshot before upgrade the cluster version
+	// The upgrade is blocked until the snapshot is successfully completed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	UpgradeSnapshot *ElasticsearchUpgradeSnapshotSpec `json:"upgradeSnapshot,omitempty"`
+}
+
+type ElasticsearchUpgradeSnapshotSpec struct {
+	// Repository is the snapshot repository name where store the snapshot
+	// It need to be registered on the cluster, for instance with a SnapshotRepository
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Repository string `json:"repository"`
+
+	// Indices is the list of data streams and indices to snapshot
+	// Default it snapshot all regular data streams and indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+
+	// IncludeGlobalState include the cluster state on snapshot
+	// Default to true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IncludeGlobalState *bool `json:"includeGlobalState,omitempty"`
+}
+
+type ElasticsearchRestoreFromSpec struct {
+	// Repository is the snapshot repository that store the snapshot
+	// It's registered on the cluster before to restore the snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Repository ElasticsearchRestoreFromRepositorySpec `json:"repository"`
+
+	// Snapshot is the snapshot name to restore
+	// Use `latest` to restore the most recent successful snapshot of the repository
+	// Default to latest
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=latest
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// Indices is the list of data streams and indices to restore
+	// Default it restore all regular data streams and indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+
+	// IgnoreUnavailable ignore the data streams and indices missing on snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IgnoreUnavailable bool `json:"ignoreUnavailable,omitempty"`
+
+	// IncludeGlobalState restore the cluster state
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IncludeGlobalState bool `json:"includeGlobalState,omitempty"`
+
+	// FeatureStates is the list of feature states to restore
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	FeatureStates []string `json:"featureStates,omitempty"`
+
+	// IncludeAliases restore the aliases
+	// Default to true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IncludeAliases *bool `json:"includeAliases,omitempty"`
+
+	// Partial allow to restore indices with unavailable shards on snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Partial bool `json:"partial,omitempty"`
+}
+
+type ElasticsearchRestoreFromRepositorySpec struct {
+	// Name is the snapshot repository name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+
+	// Type the Snapshot repository type
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Type string `json:"type"`
+
+	// The config of snapshot repository
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Settings *apis.MapAny `json:"settings,omitempty"`
 }
 
 type ElasticsearchEndpointSpec struct {
@@ -145,6 +261,294 @@ type ElasticsearchRouteSpec struct {
 	TargetNodeGroupName string `json:"targetNodeGroupName,omitempty"`
 }
 
+type ElasticsearchSecuritySpec struct {
+	// Realms permit to set the authentication realms
+	// The file and native realms are always enabled with order -100 and -99
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Realms *ElasticsearchRealmsSpec `json:"realms,omitempty"`
+}
+
+type ElasticsearchRealmsSpec struct {
+	// Ldap is the list of LDAP or Active Directory realms
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Ldap []ElasticsearchLdapRealmSpec `json:"ldap,omitempty"`
+
+	// Saml is the list of SAML realms
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Saml []ElasticsearchSamlRealmSpec `json:"saml,omitempty"`
+
+	// Oidc is the list of OpenID Connect realms
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Oidc []ElasticsearchOidcRealmSpec `json:"oidc,omitempty"`
+
+	// Jwt is the list of JWT realms
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Jwt []ElasticsearchJwtRealmSpec `json:"jwt,omitempty"`
+}
+
+type ElasticsearchRealmSpec struct {
+	// Name is the realm name
+	// It must be unique across all realms
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_-]+$`
+	Name string `json:"name"`
+
+	// Order is the realm position on the realm chain
+	// It must be unique across all realms
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Order int64 `json:"order"`
+
+	// Enabled permit to disable the realm without remove it
+	// Default to true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Settings is the extra realm settings, without the prefix `xpack.security.authc.realms.<type>.<name>`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Settings *apis.MapAny `json:"settings,omitempty"`
+}
+
+type ElasticsearchRealmClaimsSpec struct {
+	// Principal is the claim or attribute that contain the username
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Principal string `json:"principal,omitempty"`
+
+	// Groups is the claim or attribute that contain the groups
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Groups string `json:"groups,omitempty"`
+
+	// Name is the claim or attribute that contain the full name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Mail is the claim or attribute that contain the email
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Mail string `json:"mail,omitempty"`
+}
+
+type ElasticsearchLdapSearchSpec struct {
+	// BaseDn is the container DN to search
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	BaseDn string `json:"baseDn"`
+
+	// Filter is the LDAP filter
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Filter string `json:"filter,omitempty"`
+
+	// Scope is the search scope
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:validation:Enum=sub_tree;one_level;base
+	Scope string `json:"scope,omitempty"`
+}
+
+type ElasticsearchLdapRealmSpec struct {
+	ElasticsearchRealmSpec `json:",inline"`
+
+	// Urls is the list of LDAP servers
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems=1
+	Urls []string `json:"urls"`
+
+	// BindDn is the DN used to bind on LDAP server
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	BindDn string `json:"bindDn,omitempty"`
+
+	// BindPasswordSecretRef is the secret key that store the bind password
+	// It will be injected on keystore
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	BindPasswordSecretRef *corev1.SecretKeySelector `json:"bindPasswordSecretRef,omitempty"`
+
+	// UserDnTemplates is the list of DN templates used to find user
+	// You can't use it with UserSearch
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	UserDnTemplates []string `json:"userDnTemplates,omitempty"`
+
+	// UserSearch permit to search user on LDAP
+	// You can't use it with UserDnTemplates
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	UserSearch *ElasticsearchLdapSearchSpec `json:"userSearch,omitempty"`
+
+	// GroupSearch permit to search user's groups on LDAP
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	GroupSearch *ElasticsearchLdapSearchSpec `json:"groupSearch,omitempty"`
+
+	// UnmappedGroupsAsRoles permit to use LDAP groups as roles when there are no role mapping
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	UnmappedGroupsAsRoles bool `json:"unmappedGroupsAsRoles,omitempty"`
+}
+
+type ElasticsearchSamlRealmSpec struct {
+	ElasticsearchRealmSpec `json:",inline"`
+
+	// IdpEntityId is the entity ID of the identity provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	IdpEntityId string `json:"idpEntityId"`
+
+	// IdpMetadataUrl is the URL to get the identity provider metadata
+	// You can't use it with IdpMetadataSecretRef
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IdpMetadataUrl string `json:"idpMetadataUrl,omitempty"`
+
+	// IdpMetadataSecretRef is the secret key that store the identity provider metadata
+	// You can't use it with IdpMetadataUrl
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IdpMetadataSecretRef *corev1.SecretKeySelector `json:"idpMetadataSecretRef,omitempty"`
+
+	// SpEntityId is the entity ID of Kibana
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SpEntityId string `json:"spEntityId"`
+
+	// SpAcs is the assertion consumer service URL of Kibana
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SpAcs string `json:"spAcs"`
+
+	// SpLogout is the logout URL of Kibana
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SpLogout string `json:"spLogout,omitempty"`
+
+	// Attributes permit to map the SAML attributes on user properties
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Attributes ElasticsearchRealmClaimsSpec `json:"attributes"`
+}
+
+type ElasticsearchOidcRealmSpec struct {
+	ElasticsearchRealmSpec `json:",inline"`
+
+	// RpClientId is the client ID
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RpClientId string `json:"rpClientId"`
+
+	// RpClientSecretRef is the secret key that store the client secret
+	// It will be injected on keystore
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RpClientSecretRef *corev1.SecretKeySelector `json:"rpClientSecretRef,omitempty"`
+
+	// RpResponseType is the OAuth 2.0 response type
+	// Default to code
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:default=code
+	// +kubebuilder:validation:Enum=code;id_token;id_token token
+	RpResponseType string `json:"rpResponseType,omitempty"`
+
+	// RpRedirectUri is the redirect URI of Kibana
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RpRedirectUri string `json:"rpRedirectUri"`
+
+	// RpPostLogoutRedirectUri is the URL where redirect user after logout
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RpPostLogoutRedirectUri string `json:"rpPostLogoutRedirectUri,omitempty"`
+
+	// RpRequestedScopes is the list of scopes to request
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RpRequestedScopes []string `json:"rpRequestedScopes,omitempty"`
+
+	// OpIssuer is the issuer of the OpenID Connect provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	OpIssuer string `json:"opIssuer"`
+
+	// OpAuthorizationEndpoint is the authorization endpoint of the OpenID Connect provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	OpAuthorizationEndpoint string `json:"opAuthorizationEndpoint"`
+
+	// OpTokenEndpoint is the token endpoint of the OpenID Connect provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	OpTokenEndpoint string `json:"opTokenEndpoint,omitempty"`
+
+	// OpUserinfoEndpoint is the user info endpoint of the OpenID Connect provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	OpUserinfoEndpoint string `json:"opUserinfoEndpoint,omitempty"`
+
+	// OpEndSessionEndpoint is the logout endpoint of the OpenID Connect provider
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	OpEndSessionEndpoint string `json:"opEndSessionEndpoint,omitempty"`
+
+	// OpJwkSetPath is the URL or the path of the JSON Web Key Set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	OpJwkSetPath string `json:"opJwkSetPath"`
+
+	// Claims permit to map the OpenID Connect claims on user properties
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Claims ElasticsearchRealmClaimsSpec `json:"claims"`
+}
+
+type ElasticsearchJwtRealmSpec struct {
+	ElasticsearchRealmSpec `json:",inline"`
+
+	// AllowedIssuer is the expected issuer of JWT
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	AllowedIssuer string `json:"allowedIssuer"`
+
+	// AllowedAudiences is the list of expected audiences of JWT
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems=1
+	AllowedAudiences []string `json:"allowedAudiences"`
+
+	// AllowedSignatureAlgorithms is the list of allowed signature algorithms
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	AllowedSignatureAlgorithms []string `json:"allowedSignatureAlgorithms,omitempty"`
+
+	// PkcJwkSetPath is the URL or the path of the public JSON Web Key Set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	PkcJwkSetPath string `json:"pkcJwkSetPath,omitempty"`
+
+	// HmacKeySecretRef is the secret key that store the HMAC key
+	// It will be injected on keystore
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	HmacKeySecretRef *corev1.SecretKeySelector `json:"hmacKeySecretRef,omitempty"`
+
+	// ClientAuthenticationType is the client authentication type
+	// Default to shared_secret
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:default=shared_secret
+	// +kubebuilder:validation:Enum=shared_secret;none
+	ClientAuthenticationType string `json:"clientAuthenticationType,omitempty"`
+
+	// ClientAuthenticationSharedSecretRef is the secret key that store the client shared secret
+	// It will be injected on keystore
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ClientAuthenticationSharedSecretRef *corev1.SecretKeySelector `json:"clientAuthenticationSharedSecretRef,omitempty"`
+
+	// Claims permit to map the JWT claims on user properties
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Claims ElasticsearchRealmClaimsSpec `json:"claims,omitempty"`
+}
+
 type ElasticsearchGlobalNodeGroupSpec struct {
 	// AdditionalVolumes permit to use additionnal volumes
 	// Default is empty
@@ -235,8 +639,10 @@ type ElasticsearchNodeGroupSpec struct {
 	Name string `json:"name"`
 
 	// Roles is the list of Elasticsearch roles
+	// It can be omitted when the node group is provided by the class
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	Roles []string `json:"roles"`
+	// +optional
+	Roles []string `json:"roles,omitempty"`
 
 	// Persistence is the spec to persist data
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -302,6 +708,36 @@ type ElasticsearchStatus struct {
 	// Health is the cluster health
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	Health string `json:"health,omitempty"`
+
+	// RestoredSnapshot is the snapshot restored when the cluster is bootstrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	RestoredSnapshot string `json:"restoredSnapshot,omitempty"`
+
+	// UpgradeSnapshot is the snapshot taken before the last version upgrade
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	UpgradeSnapshot *ElasticsearchUpgradeSnapshotStatus `json:"upgradeSnapshot,omitempty"`
+}
+
+type ElasticsearchUpgradeSnapshotStatus struct {
+	// Name is the snapshot name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Repository is the repository that store the snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Repository string `json:"repository"`
+
+	// Version is the target version of the upgrade
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Version string `json:"version"`
+
+	// State is the snapshot state
+	// It can be IN_PROGRESS, SUCCESS, PARTIAL or FAILED
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	State string `json:"state,omitempty"`
 }
 
 //+kubebuilder:object:root=true
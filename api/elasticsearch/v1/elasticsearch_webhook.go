@@ -0,0 +1,273 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// reservedRealms is the realms always set by operator
+var reservedRealms = map[string]int64{
+	"file1":   -100,
+	"native1": -99,
+}
+
+// restoreFromSecurityFeatureState is the feature state that store the users and the roles
+const restoreFromSecurityFeatureState = "security"
+
+type elasticsearchValidator struct {
+	logger *logrus.Entry
+	client client.Client
+}
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func SetupElasticsearchWebhookWithManager(logger *logrus.Entry) controller.WebhookRegister {
+	return func(mgr ctrl.Manager, client client.Client) error {
+		return ctrl.NewWebhookManagedBy(mgr).
+			For(&Elasticsearch{}).
+			WithValidator(&elasticsearchValidator{
+				logger: logger.WithField("webhook", "elasticsearchValidator"),
+				client: client,
+			}).
+			Complete()
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-elasticsearch-k8s-webcenter-fr-v1-elasticsearch,mutating=false,failurePolicy=fail,sideEffects=None,groups=elasticsearch.k8s.webcenter.fr,resources=elasticsearches,verbs=create;update,versions=v1,name=elasticsearch.elasticsearch.k8s.webcenter.fr,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &elasticsearchValidator{}
+
+// validateRestoreFrom check the snapshot restore is only set when create the cluster
+// It can't be changed while the restore is in progress
+func (r *elasticsearchValidator) validateRestoreFrom(current, old *Elasticsearch) *field.Error {
+	if current.Spec.RestoreFrom == nil || !old.IsBoostrapping() {
+		return nil
+	}
+
+	if old.Spec.RestoreFrom == nil {
+		return field.Forbidden(field.NewPath("spec").Child("restoreFrom"), "The field 'spec.restoreFrom' can only be set when create the cluster")
+	}
+
+	if old.IsRestoreFromPending() && !equality.Semantic.DeepEqual(current.Spec.RestoreFrom, old.Spec.RestoreFrom) {
+		return field.Forbidden(field.NewPath("spec").Child("restoreFrom"), "The field 'spec.restoreFrom' can't be changed while the restore is in progress")
+	}
+
+	return nil
+}
+
+// validateRestoreFromFeatureStates check the restore not overwrite the security feature state
+// It store the system users and the elastic credentials managed by operator, so the operator lost the access on cluster
+// Elasticsearch restore all feature states when the global state is restored without feature states
+func (r *elasticsearchValidator) validateRestoreFromFeatureStates(obj *Elasticsearch) (allErrs field.ErrorList) {
+	allErrs = field.ErrorList{}
+	if obj.Spec.RestoreFrom == nil {
+		return allErrs
+	}
+	featureStatesPath := field.NewPath("spec").Child("restoreFrom").Child("featureStates")
+
+	for i, featureState := range obj.Spec.RestoreFrom.FeatureStates {
+		if strings.EqualFold(featureState, restoreFromSecurityFeatureState) {
+			allErrs = append(allErrs, field.Forbidden(featureStatesPath.Index(i), "The feature state 'security' can't be restored, it overwrite the system users and the credentials managed by operator"))
+		}
+	}
+
+	if obj.Spec.RestoreFrom.IncludeGlobalState && len(obj.Spec.RestoreFrom.FeatureStates) == 0 {
+		allErrs = append(allErrs, field.Required(featureStatesPath, "You need to provide the feature states to restore when 'includeGlobalState' is true, else the feature state 'security' is restored. Use 'none' to not restore feature states"))
+	}
+
+	return allErrs
+}
+
+// validateNodeGroups check each node group has roles, after merge the class when it's set
+// The class can be created after the cluster, so a missing class is not an error there
+func (r *elasticsearchValidator) validateNodeGroups(ctx context.Context, obj *Elasticsearch) (allErrs field.ErrorList) {
+	allErrs = field.ErrorList{}
+	nodeGroupsPath := field.NewPath("spec").Child("nodeGroups")
+
+	o := obj.DeepCopy()
+	if o.Spec.ClassRef != nil {
+		class := &ElasticsearchClass{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: o.Spec.ClassRef.Name}, class); err != nil {
+			if !apierrors.IsNotFound(err) {
+				allErrs = append(allErrs, field.InternalError(field.NewPath("spec").Child("classRef"), errors.Wrapf(err, "Error when read ElasticsearchClass %s", o.Spec.ClassRef.Name)))
+			}
+			return allErrs
+		}
+		if err := o.ApplyClass(class); err != nil {
+			allErrs = append(allErrs, field.InternalError(field.NewPath("spec").Child("classRef"), err))
+			return allErrs
+		}
+	}
+
+	for i, nodeGroup := range o.Spec.NodeGroups {
+		if len(nodeGroup.Roles) == 0 {
+			allErrs = append(allErrs, field.Required(nodeGroupsPath.Index(i).Child("roles"), fmt.Sprintf("You need to provide the roles of node group %s, on cluster or on class", nodeGroup.Name)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateRealms check the realm names and orders are unique and each realm is consistent
+func (r *elasticsearchValidator) validateRealms(obj *Elasticsearch) (allErrs field.ErrorList) {
+	allErrs = field.ErrorList{}
+	if obj.Spec.Security == nil || obj.Spec.Security.Realms == nil {
+		return allErrs
+	}
+
+	realmsPath := field.NewPath("spec").Child("security").Child("realms")
+	names := map[string]string{}
+	orders := map[int64]string{}
+	for name, order := range reservedRealms {
+		names[name] = name
+		orders[order] = name
+	}
+
+	for _, realm := range obj.GetRealms() {
+		path := realmsPath.Child(realm.Type).Index(realm.Index)
+		if existing, ok := names[realm.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), fmt.Sprintf("Realm name %s is already used by realm %s", realm.Name, existing)))
+		} else {
+			names[realm.Name] = fmt.Sprintf("%s.%s", realm.Type, realm.Name)
+		}
+		if existing, ok := orders[realm.Order]; ok {
+			allErrs = append(allErrs, field.Duplicate(path.Child("order"), fmt.Sprintf("Realm order %d is already used by realm %s", realm.Order, existing)))
+		} else {
+			orders[realm.Order] = fmt.Sprintf("%s.%s", realm.Type, realm.Name)
+		}
+	}
+
+	for i, realm := range obj.Spec.Security.Realms.Ldap {
+		path := realmsPath.Child(RealmTypeLdap).Index(i)
+		if len(realm.UserDnTemplates) > 0 && realm.UserSearch != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("userSearch"), "When you set field 'userDnTemplates', you can't set field 'userSearch'"))
+		}
+		if len(realm.UserDnTemplates) == 0 && realm.UserSearch == nil {
+			allErrs = append(allErrs, field.Required(path, "You need to provide 'userDnTemplates' or 'userSearch'"))
+		}
+		if realm.UserSearch != nil && realm.BindDn == "" {
+			allErrs = append(allErrs, field.Required(path.Child("bindDn"), "You need to provide 'bindDn' when you use 'userSearch'"))
+		}
+		if realm.BindDn != "" && realm.BindPasswordSecretRef == nil {
+			allErrs = append(allErrs, field.Required(path.Child("bindPasswordSecretRef"), "You need to provide 'bindPasswordSecretRef' when you set 'bindDn'"))
+		}
+	}
+
+	for i, realm := range obj.Spec.Security.Realms.Saml {
+		path := realmsPath.Child(RealmTypeSaml).Index(i)
+		if realm.IdpMetadataUrl != "" && realm.IdpMetadataSecretRef != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("idpMetadataSecretRef"), "When you set field 'idpMetadataUrl', you can't set field 'idpMetadataSecretRef'"))
+		}
+		if realm.IdpMetadataUrl == "" && realm.IdpMetadataSecretRef == nil {
+			allErrs = append(allErrs, field.Required(path, "You need to provide 'idpMetadataUrl' or 'idpMetadataSecretRef'"))
+		}
+		if realm.Attributes.Principal == "" {
+			allErrs = append(allErrs, field.Required(path.Child("attributes").Child("principal"), "You need to provide the principal attribute"))
+		}
+	}
+
+	for i, realm := range obj.Spec.Security.Realms.Oidc {
+		path := realmsPath.Child(RealmTypeOidc).Index(i)
+		if realm.RpClientSecretRef == nil {
+			allErrs = append(allErrs, field.Required(path.Child("rpClientSecretRef"), "You need to provide the client secret"))
+		}
+		if realm.Claims.Principal == "" {
+			allErrs = append(allErrs, field.Required(path.Child("claims").Child("principal"), "You need to provide the principal claim"))
+		}
+	}
+
+	for i, realm := range obj.Spec.Security.Realms.Jwt {
+		path := realmsPath.Child(RealmTypeJwt).Index(i)
+		if realm.PkcJwkSetPath == "" && realm.HmacKeySecretRef == nil {
+			allErrs = append(allErrs, field.Required(path, "You need to provide 'pkcJwkSetPath' or 'hmacKeySecretRef'"))
+		}
+		if (realm.ClientAuthenticationType == "" || realm.ClientAuthenticationType == "shared_secret") && realm.ClientAuthenticationSharedSecretRef == nil {
+			allErrs = append(allErrs, field.Required(path.Child("clientAuthenticationSharedSecretRef"), "You need to provide the shared secret when client authentication type is 'shared_secret'"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *elasticsearchValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	elasticsearchObj, ok := obj.(*Elasticsearch)
+	if !ok {
+		return nil, errors.Errorf("expected an Elasticsearch object but got %T", obj)
+	}
+	r.logger.Debugf("validate create %s/%s", elasticsearchObj.GetNamespace(), elasticsearchObj.GetName())
+
+	allErrs = append(allErrs, r.validateRealms(elasticsearchObj)...)
+	allErrs = append(allErrs, r.validateNodeGroups(ctx, elasticsearchObj)...)
+	allErrs = append(allErrs, r.validateRestoreFromFeatureStates(elasticsearchObj)...)
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			elasticsearchObj.GroupVersionKind().GroupKind(),
+			elasticsearchObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *elasticsearchValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	elasticsearchObj, ok := newObj.(*Elasticsearch)
+	if !ok {
+		return nil, errors.Errorf("expected an Elasticsearch object but got %T", newObj)
+	}
+	r.logger.Debugf("validate update %s/%s", elasticsearchObj.GetNamespace(), elasticsearchObj.GetName())
+
+	allErrs = append(allErrs, r.validateRealms(elasticsearchObj)...)
+	allErrs = append(allErrs, r.validateNodeGroups(ctx, elasticsearchObj)...)
+	allErrs = append(allErrs, r.validateRestoreFromFeatureStates(elasticsearchObj)...)
+	if err := r.validateRestoreFrom(elasticsearchObj, oldObj.(*Elasticsearch)); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			elasticsearchObj.GroupVersionKind().GroupKind(),
+			elasticsearchObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *elasticsearchValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func (t *TestSuite) TestElasticsearchWebhook() {
+	var (
+		o   *Elasticsearch
+		err error
+	)
+
+	ldapRealm := ElasticsearchLdapRealmSpec{
+		ElasticsearchRealmSpec: ElasticsearchRealmSpec{
+			Name:  "ldap1",
+			Order: 0,
+		},
+		Urls:   []string{"ldaps://ldap.example.com:636"},
+		BindDn: "cn=admin,dc=example,dc=com",
+		BindPasswordSecretRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: "ldap",
+			},
+			Key: "password",
+		},
+		UserSearch: &ElasticsearchLdapSearchSpec{
+			BaseDn: "ou=users,dc=example,dc=com",
+		},
+	}
+	samlRealm := ElasticsearchSamlRealmSpec{
+		ElasticsearchRealmSpec: ElasticsearchRealmSpec{
+			Name:  "saml1",
+			Order: 1,
+		},
+		IdpEntityId:    "https://idp.example.com",
+		IdpMetadataUrl: "https://idp.example.com/metadata.xml",
+		SpEntityId:     "https://kibana.example.com",
+		SpAcs:          "https://kibana.example.com/api/security/saml/callback",
+		Attributes: ElasticsearchRealmClaimsSpec{
+			Principal: "nameid",
+		},
+	}
+
+	// Need succeed when realms are valid
+	o = &Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook",
+			Namespace: "default",
+		},
+		Spec: ElasticsearchSpec{
+			Security: &ElasticsearchSecuritySpec{
+				Realms: &ElasticsearchRealmsSpec{
+					Ldap: []ElasticsearchLdapRealmSpec{ldapRealm},
+					Saml: []ElasticsearchSamlRealmSpec{samlRealm},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	// Need failed when duplicate realm order
+	samlRealm.Order = 0
+	o = &Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook2",
+			Namespace: "default",
+		},
+		Spec: ElasticsearchSpec{
+			Security: &ElasticsearchSecuritySpec{
+				Realms: &ElasticsearchRealmsSpec{
+					Ldap: []ElasticsearchLdapRealmSpec{ldapRealm},
+					Saml: []ElasticsearchSamlRealmSpec{samlRealm},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when realm order is the same as native realm
+	samlRealm.Order = -99
+	o.Spec.Security.Realms.Saml = []ElasticsearchSamlRealmSpec{samlRealm}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when duplicate realm name
+	samlRealm.Order = 1
+	samlRealm.Name = "ldap1"
+	o.Spec.Security.Realms.Saml = []ElasticsearchSamlRealmSpec{samlRealm}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when SAML metadata is set twice
+	samlRealm.Name = "saml1"
+	samlRealm.IdpMetadataSecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "saml",
+		},
+		Key: "metadata.xml",
+	}
+	o.Spec.Security.Realms.Saml = []ElasticsearchSamlRealmSpec{samlRealm}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when LDAP use user search and user DN templates
+	samlRealm.IdpMetadataSecretRef = nil
+	ldapRealm.UserDnTemplates = []string{"cn={0},ou=users,dc=example,dc=com"}
+	o.Spec.Security.Realms.Saml = []ElasticsearchSamlRealmSpec{samlRealm}
+	o.Spec.Security.Realms.Ldap = []ElasticsearchLdapRealmSpec{ldapRealm}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when restore the security feature state
+	o = &Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook-restore-security",
+			Namespace: "default",
+		},
+		Spec: ElasticsearchSpec{
+			RestoreFrom: &ElasticsearchRestoreFromSpec{
+				Repository: ElasticsearchRestoreFromRepositorySpec{
+					Name: "backup",
+					Type: "fs",
+				},
+				FeatureStates: []string{"kibana", "security"},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when restore the global state without feature states
+	o.Spec.RestoreFrom.IncludeGlobalState = true
+	o.Spec.RestoreFrom.FeatureStates = nil
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need succeed when restore the global state with feature states
+	o.Spec.RestoreFrom.FeatureStates = []string{"kibana"}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	// Need succeed when set restore from on new cluster
+	o = &Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook-restore",
+			Namespace: "default",
+		},
+		Spec: ElasticsearchSpec{
+			RestoreFrom: &ElasticsearchRestoreFromSpec{
+				Repository: ElasticsearchRestoreFromRepositorySpec{
+					Name: "backup",
+					Type: "fs",
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	assert.Equal(t.T(), "latest", o.Spec.RestoreFrom.Snapshot)
+
+	// Need failed when change restore from while restore is in progress
+	o.Status.IsBootstrapping = ptr.To[bool](true)
+	err = t.k8sClient.Status().Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+	o.Spec.RestoreFrom.Snapshot = "snapshot"
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when add restore from on existing cluster
+	o = &Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook-restore2",
+			Namespace: "default",
+		},
+		Spec: ElasticsearchSpec{},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	o.Status.IsBootstrapping = ptr.To[bool](true)
+	err = t.k8sClient.Status().Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+	o.Spec.RestoreFrom = &ElasticsearchRestoreFromSpec{
+		Repository: ElasticsearchRestoreFromRepositorySpec{
+			Name: "backup",
+			Type: "fs",
+		},
+	}
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.Error(t.T(), err)
+}
@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"reflect"
+
+	"dario.cat/mergo"
+	"emperror.dev/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// classTransformer keep the values set on Elasticsearch when merge the class
+// The pointers on scalar, like *bool, are kept even if they point on zero value, to not override `false` by class
+// The quantity and int or string are merged as scalar
+type classTransformer struct{}
+
+func (classTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
+	switch {
+	case typ == reflect.TypeOf(resource.Quantity{}), typ == reflect.TypeOf(intstr.IntOrString{}):
+		return func(dst, src reflect.Value) error {
+			if dst.CanSet() && dst.IsZero() {
+				dst.Set(src)
+			}
+			return nil
+		}
+	case typ.Kind() == reflect.Pointer && typ.Elem().Kind() != reflect.Struct:
+		// The transformer is only called when the pointer is not nil
+		return func(dst, src reflect.Value) error {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// mergeClass fill the settings not set on dst from src
+func mergeClass(dst any, src any) error {
+	return mergo.Merge(dst, src, mergo.WithTransformers(classTransformer{}))
+}
+
+// ApplyClass merge the class settings on the Elasticsearch spec
+// The settings set on Elasticsearch take precedence, the class only fill the settings not set.
+// The node groups are merged by name, the class node groups not set on Elasticsearch are added.
+func (h *Elasticsearch) ApplyClass(class *ElasticsearchClass) (err error) {
+	if class == nil {
+		return nil
+	}
+	classSpec := class.Spec.DeepCopy()
+
+	if h.Spec.Version == "" || h.Spec.Version == "latest" {
+		if classSpec.Version != "" {
+			h.Spec.Version = classSpec.Version
+		}
+	}
+
+	if err = mergeClass(&h.Spec.ImageSpec, classSpec.ImageSpec); err != nil {
+		return errors.Wrap(err, "Error when merge image settings from class")
+	}
+
+	if len(h.Spec.PluginsList) == 0 {
+		h.Spec.PluginsList = classSpec.PluginsList
+	}
+
+	if err = mergeClass(&h.Spec.GlobalNodeGroup, classSpec.GlobalNodeGroup); err != nil {
+		return errors.Wrap(err, "Error when merge global node group from class")
+	}
+
+	if err = mergeClass(&h.Spec.Tls, classSpec.Tls); err != nil {
+		return errors.Wrap(err, "Error when merge TLS settings from class")
+	}
+
+	if err = mergeClass(&h.Spec.Monitoring, classSpec.Monitoring); err != nil {
+		return errors.Wrap(err, "Error when merge monitoring settings from class")
+	}
+
+	nodeGroups := make([]ElasticsearchNodeGroupSpec, 0, len(classSpec.NodeGroups)+len(h.Spec.NodeGroups))
+	for _, classNodeGroup := range classSpec.NodeGroups {
+		isFound := false
+		for _, nodeGroup := range h.Spec.NodeGroups {
+			if nodeGroup.Name == classNodeGroup.Name {
+				if err = mergeClass(&nodeGroup, classNodeGroup); err != nil {
+					return errors.Wrapf(err, "Error when merge node group %s from class", nodeGroup.Name)
+				}
+				nodeGroups = append(nodeGroups, nodeGroup)
+				isFound = true
+				break
+			}
+		}
+		if !isFound {
+			nodeGroups = append(nodeGroups, classNodeGroup)
+		}
+	}
+	for _, nodeGroup := range h.Spec.NodeGroups {
+		isFound := false
+		for _, classNodeGroup := range classSpec.NodeGroups {
+			if nodeGroup.Name == classNodeGroup.Name {
+				isFound = true
+				break
+			}
+		}
+		if !isFound {
+			nodeGroups = append(nodeGroups, nodeGroup)
+		}
+	}
+	h.Spec.NodeGroups = nodeGroups
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestElasticsearchApplyClass(t *testing.T) {
+	class := &ElasticsearchClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "standard",
+		},
+		Spec: ElasticsearchClassSpec{
+			Version:     "8.15.0",
+			PluginsList: []string{"repository-s3"},
+			GlobalNodeGroup: ElasticsearchGlobalNodeGroupSpec{
+				Jvm: "-Xms1g -Xmx1g",
+				Config: &apis.MapAny{
+					Data: map[string]any{
+						"action.destructive_requires_name":                true,
+						"cluster.routing.allocation.awareness.attributes": "zone",
+					},
+				},
+				PodTemplate: &corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						PriorityClassName: "high",
+						NodeSelector: map[string]string{
+							"pool": "elasticsearch",
+						},
+					},
+				},
+			},
+			NodeGroups: []ElasticsearchNodeGroupSpec{
+				{
+					Name:  "master",
+					Roles: []string{"master"},
+					Deployment: shared.Deployment{
+						Replicas: 3,
+						Resources: &corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory: resource.MustParse("2Gi"),
+							},
+						},
+					},
+				},
+				{
+					Name:  "data",
+					Roles: []string{"data"},
+					Deployment: shared.Deployment{
+						Replicas: 3,
+					},
+				},
+			},
+			Tls: shared.TlsSpec{
+				Enabled:      ptr.To(true),
+				ValidityDays: ptr.To(365),
+			},
+			Monitoring: shared.MonitoringSpec{
+				Prometheus: &shared.MonitoringPrometheusSpec{
+					Enabled: ptr.To(true),
+				},
+			},
+		},
+	}
+
+	o := &Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: ElasticsearchSpec{
+			ClassRef: &ElasticsearchClassRef{
+				Name: "standard",
+			},
+			Version: "latest",
+			GlobalNodeGroup: ElasticsearchGlobalNodeGroupSpec{
+				Config: &apis.MapAny{
+					Data: map[string]any{
+						"cluster.routing.allocation.awareness.attributes": "rack",
+					},
+				},
+			},
+			NodeGroups: []ElasticsearchNodeGroupSpec{
+				{
+					Name: "data",
+					Deployment: shared.Deployment{
+						Replicas: 5,
+					},
+				},
+				{
+					Name:  "ingest",
+					Roles: []string{"ingest"},
+					Deployment: shared.Deployment{
+						Replicas: 1,
+					},
+				},
+			},
+			Tls: shared.TlsSpec{
+				Enabled: ptr.To(false),
+			},
+		},
+	}
+
+	// When no class
+	assert.NoError(t, o.DeepCopy().ApplyClass(nil))
+
+	err := o.ApplyClass(class)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "8.15.0", o.Spec.Version)
+	assert.Equal(t, []string{"repository-s3"}, o.Spec.PluginsList)
+
+	// Global node group is merged
+	assert.Equal(t, "-Xms1g -Xmx1g", o.Spec.GlobalNodeGroup.Jvm)
+	assert.Equal(t, map[string]any{
+		"action.destructive_requires_name":                true,
+		"cluster.routing.allocation.awareness.attributes": "rack",
+	}, o.Spec.GlobalNodeGroup.Config.Data)
+	assert.Equal(t, "high", o.Spec.GlobalNodeGroup.PodTemplate.Spec.PriorityClassName)
+
+	// Node groups are merged by name
+	assert.Len(t, o.Spec.NodeGroups, 3)
+	assert.Equal(t, "master", o.Spec.NodeGroups[0].Name)
+	assert.Equal(t, int32(3), o.Spec.NodeGroups[0].Replicas)
+	assert.Equal(t, resource.MustParse("2Gi"), o.Spec.NodeGroups[0].Resources.Requests[corev1.ResourceMemory])
+	assert.Equal(t, "data", o.Spec.NodeGroups[1].Name)
+	assert.Equal(t, int32(5), o.Spec.NodeGroups[1].Replicas)
+	assert.Equal(t, []string{"data"}, o.Spec.NodeGroups[1].Roles)
+	assert.Equal(t, "ingest", o.Spec.NodeGroups[2].Name)
+
+	// Value set on cluster is kept, even when it's false
+	assert.False(t, *o.Spec.Tls.Enabled)
+	assert.Equal(t, 365, *o.Spec.Tls.ValidityDays)
+	assert.True(t, *o.Spec.Monitoring.Prometheus.Enabled)
+
+	// The class is not altered
+	assert.Equal(t, "zone", class.Spec.GlobalNodeGroup.Config.Data["cluster.routing.allocation.awareness.attributes"])
+	assert.True(t, *class.Spec.Tls.Enabled)
+
+	// Version set on cluster is kept
+	o = &Elasticsearch{
+		Spec: ElasticsearchSpec{
+			Version: "8.14.0",
+		},
+	}
+	assert.NoError(t, o.ApplyClass(class))
+	assert.Equal(t, "8.14.0", o.Spec.Version)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// ElasticsearchClassSpec defines the default settings of the Elasticsearch clusters that use the class
+// +k8s:openapi-gen=true
+type ElasticsearchClassSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	shared.ImageSpec `json:",inline"`
+
+	// Version is the default Elasticsearch version
+	// It's used when the cluster not set version or set it to `latest`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// PluginsList is the default list of additionnal plugin to install on each Elasticsearch node
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	PluginsList []string `json:"pluginsList,omitempty"`
+
+	// GlobalNodeGroup is the default parameters for each node groups, like JVM settings or PodTemplate
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	GlobalNodeGroup ElasticsearchGlobalNodeGroupSpec `json:"globalNodeGroup,omitempty"`
+
+	// NodeGroups is the default node groups
+	// The node groups of the cluster are merged with them by name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	NodeGroups []ElasticsearchNodeGroupSpec `json:"nodeGroups,omitempty"`
+
+	// Tls is the default TLS setting for API access
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Tls shared.TlsSpec `json:"tls,omitempty"`
+
+	// Monitoring is the default monitoring settings
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Monitoring shared.MonitoringSpec `json:"monitoring,omitempty"`
+}
+
+// ElasticsearchClassRef is the reference of ElasticsearchClass
+type ElasticsearchClassRef struct {
+	// Name is the ElasticsearchClass name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Name string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:storageversion
+
+// ElasticsearchClass is the Schema for the elasticsearchclasses API
+// It hold the standard settings of the Elasticsearch clusters, like StorageClass do for volumes
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ElasticsearchClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ElasticsearchClassSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticsearchClassList contains a list of ElasticsearchClass
+type ElasticsearchClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticsearchClass `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchClass{}, &ElasticsearchClassList{})
+}
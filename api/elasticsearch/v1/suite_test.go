@@ -14,6 +14,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 var testEnv *envtest.Environment
@@ -39,7 +41,10 @@ func (t *TestSuite) SetupSuite() {
 		CRDDirectoryPaths: []string{
 			filepath.Join("../../..", "config", "crd", "bases"),
 		},
-		ErrorIfCRDPathMissing:    true,
+		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			Paths: []string{filepath.Join("..", "..", "..", "config", "webhook")},
+		},
 		ControlPlaneStopTimeout:  120 * time.Second,
 		ControlPlaneStartTimeout: 120 * time.Second,
 	}
@@ -59,8 +64,16 @@ func (t *TestSuite) SetupSuite() {
 	}
 
 	// Init k8smanager and k8sclient
+	webhookInstallOptions := &testEnv.WebhookInstallOptions
 	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme.Scheme,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    webhookInstallOptions.LocalServingHost,
+			Port:    webhookInstallOptions.LocalServingPort,
+			CertDir: webhookInstallOptions.LocalServingCertDir,
+		}),
+		LeaderElection: false,
+		Metrics:        metricsserver.Options{BindAddress: "0"},
 	})
 	if err != nil {
 		panic(err)
@@ -68,6 +81,7 @@ func (t *TestSuite) SetupSuite() {
 	k8sClient := k8sManager.GetClient()
 	t.k8sClient = k8sClient
 
+	// Setup indexer
 	if err := controller.SetupIndexerWithManager(
 		k8sManager,
 		SetupElasticsearchIndexer,
@@ -75,6 +89,15 @@ func (t *TestSuite) SetupSuite() {
 		panic(err)
 	}
 
+	// Setup webhook
+	if err := controller.SetupWebhookWithManager(
+		k8sManager,
+		k8sClient,
+		SetupElasticsearchWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+	); err != nil {
+		panic(err)
+	}
+
 	go func() {
 		err = k8sManager.Start(ctrl.SetupSignalHandler())
 		if err != nil {
@@ -24,7 +24,7 @@ import (
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -54,6 +54,110 @@ func (in *Elasticsearch) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchClass) DeepCopyInto(out *ElasticsearchClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchClass.
+func (in *ElasticsearchClass) DeepCopy() *ElasticsearchClass {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchClassList) DeepCopyInto(out *ElasticsearchClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchClassList.
+func (in *ElasticsearchClassList) DeepCopy() *ElasticsearchClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchClassRef) DeepCopyInto(out *ElasticsearchClassRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchClassRef.
+func (in *ElasticsearchClassRef) DeepCopy() *ElasticsearchClassRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchClassRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchClassSpec) DeepCopyInto(out *ElasticsearchClassSpec) {
+	*out = *in
+	in.ImageSpec.DeepCopyInto(&out.ImageSpec)
+	if in.PluginsList != nil {
+		in, out := &in.PluginsList, &out.PluginsList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.GlobalNodeGroup.DeepCopyInto(&out.GlobalNodeGroup)
+	if in.NodeGroups != nil {
+		in, out := &in.NodeGroups, &out.NodeGroups
+		*out = make([]ElasticsearchNodeGroupSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Tls.DeepCopyInto(&out.Tls)
+	in.Monitoring.DeepCopyInto(&out.Monitoring)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchClassSpec.
+func (in *ElasticsearchClassSpec) DeepCopy() *ElasticsearchClassSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchClassSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticsearchEndpointSpec) DeepCopyInto(out *ElasticsearchEndpointSpec) {
 	*out = *in
@@ -191,6 +295,99 @@ func (in *ElasticsearchIngressSpec) DeepCopy() *ElasticsearchIngressSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchJwtRealmSpec) DeepCopyInto(out *ElasticsearchJwtRealmSpec) {
+	*out = *in
+	in.ElasticsearchRealmSpec.DeepCopyInto(&out.ElasticsearchRealmSpec)
+	if in.AllowedAudiences != nil {
+		in, out := &in.AllowedAudiences, &out.AllowedAudiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedSignatureAlgorithms != nil {
+		in, out := &in.AllowedSignatureAlgorithms, &out.AllowedSignatureAlgorithms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HmacKeySecretRef != nil {
+		in, out := &in.HmacKeySecretRef, &out.HmacKeySecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientAuthenticationSharedSecretRef != nil {
+		in, out := &in.ClientAuthenticationSharedSecretRef, &out.ClientAuthenticationSharedSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Claims = in.Claims
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchJwtRealmSpec.
+func (in *ElasticsearchJwtRealmSpec) DeepCopy() *ElasticsearchJwtRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchJwtRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchLdapRealmSpec) DeepCopyInto(out *ElasticsearchLdapRealmSpec) {
+	*out = *in
+	in.ElasticsearchRealmSpec.DeepCopyInto(&out.ElasticsearchRealmSpec)
+	if in.Urls != nil {
+		in, out := &in.Urls, &out.Urls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BindPasswordSecretRef != nil {
+		in, out := &in.BindPasswordSecretRef, &out.BindPasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserDnTemplates != nil {
+		in, out := &in.UserDnTemplates, &out.UserDnTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserSearch != nil {
+		in, out := &in.UserSearch, &out.UserSearch
+		*out = new(ElasticsearchLdapSearchSpec)
+		**out = **in
+	}
+	if in.GroupSearch != nil {
+		in, out := &in.GroupSearch, &out.GroupSearch
+		*out = new(ElasticsearchLdapSearchSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchLdapRealmSpec.
+func (in *ElasticsearchLdapRealmSpec) DeepCopy() *ElasticsearchLdapRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchLdapRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchLdapSearchSpec) DeepCopyInto(out *ElasticsearchLdapSearchSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchLdapSearchSpec.
+func (in *ElasticsearchLdapSearchSpec) DeepCopy() *ElasticsearchLdapSearchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchLdapSearchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticsearchList) DeepCopyInto(out *ElasticsearchList) {
 	*out = *in
@@ -286,6 +483,165 @@ func (in *ElasticsearchNodeGroupSpec) DeepCopy() *ElasticsearchNodeGroupSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchOidcRealmSpec) DeepCopyInto(out *ElasticsearchOidcRealmSpec) {
+	*out = *in
+	in.ElasticsearchRealmSpec.DeepCopyInto(&out.ElasticsearchRealmSpec)
+	if in.RpClientSecretRef != nil {
+		in, out := &in.RpClientSecretRef, &out.RpClientSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RpRequestedScopes != nil {
+		in, out := &in.RpRequestedScopes, &out.RpRequestedScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Claims = in.Claims
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchOidcRealmSpec.
+func (in *ElasticsearchOidcRealmSpec) DeepCopy() *ElasticsearchOidcRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchOidcRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRealmClaimsSpec) DeepCopyInto(out *ElasticsearchRealmClaimsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRealmClaimsSpec.
+func (in *ElasticsearchRealmClaimsSpec) DeepCopy() *ElasticsearchRealmClaimsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRealmClaimsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRealmSpec) DeepCopyInto(out *ElasticsearchRealmSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRealmSpec.
+func (in *ElasticsearchRealmSpec) DeepCopy() *ElasticsearchRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRealmsSpec) DeepCopyInto(out *ElasticsearchRealmsSpec) {
+	*out = *in
+	if in.Ldap != nil {
+		in, out := &in.Ldap, &out.Ldap
+		*out = make([]ElasticsearchLdapRealmSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Saml != nil {
+		in, out := &in.Saml, &out.Saml
+		*out = make([]ElasticsearchSamlRealmSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Oidc != nil {
+		in, out := &in.Oidc, &out.Oidc
+		*out = make([]ElasticsearchOidcRealmSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Jwt != nil {
+		in, out := &in.Jwt, &out.Jwt
+		*out = make([]ElasticsearchJwtRealmSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRealmsSpec.
+func (in *ElasticsearchRealmsSpec) DeepCopy() *ElasticsearchRealmsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRealmsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRestoreFromRepositorySpec) DeepCopyInto(out *ElasticsearchRestoreFromRepositorySpec) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRestoreFromRepositorySpec.
+func (in *ElasticsearchRestoreFromRepositorySpec) DeepCopy() *ElasticsearchRestoreFromRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRestoreFromRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRestoreFromSpec) DeepCopyInto(out *ElasticsearchRestoreFromSpec) {
+	*out = *in
+	in.Repository.DeepCopyInto(&out.Repository)
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureStates != nil {
+		in, out := &in.FeatureStates, &out.FeatureStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeAliases != nil {
+		in, out := &in.IncludeAliases, &out.IncludeAliases
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRestoreFromSpec.
+func (in *ElasticsearchRestoreFromSpec) DeepCopy() *ElasticsearchRestoreFromSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRestoreFromSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticsearchRouteSpec) DeepCopyInto(out *ElasticsearchRouteSpec) {
 	*out = *in
@@ -302,10 +658,57 @@ func (in *ElasticsearchRouteSpec) DeepCopy() *ElasticsearchRouteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSamlRealmSpec) DeepCopyInto(out *ElasticsearchSamlRealmSpec) {
+	*out = *in
+	in.ElasticsearchRealmSpec.DeepCopyInto(&out.ElasticsearchRealmSpec)
+	if in.IdpMetadataSecretRef != nil {
+		in, out := &in.IdpMetadataSecretRef, &out.IdpMetadataSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Attributes = in.Attributes
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSamlRealmSpec.
+func (in *ElasticsearchSamlRealmSpec) DeepCopy() *ElasticsearchSamlRealmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSamlRealmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchSecuritySpec) DeepCopyInto(out *ElasticsearchSecuritySpec) {
+	*out = *in
+	if in.Realms != nil {
+		in, out := &in.Realms, &out.Realms
+		*out = new(ElasticsearchRealmsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSecuritySpec.
+func (in *ElasticsearchSecuritySpec) DeepCopy() *ElasticsearchSecuritySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchSecuritySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ElasticsearchSpec) DeepCopyInto(out *ElasticsearchSpec) {
 	*out = *in
 	in.ImageSpec.DeepCopyInto(&out.ImageSpec)
+	if in.ClassRef != nil {
+		in, out := &in.ClassRef, &out.ClassRef
+		*out = new(ElasticsearchClassRef)
+		**out = **in
+	}
 	if in.SetVMMaxMapCount != nil {
 		in, out := &in.SetVMMaxMapCount, &out.SetVMMaxMapCount
 		*out = new(bool)
@@ -332,6 +735,26 @@ func (in *ElasticsearchSpec) DeepCopyInto(out *ElasticsearchSpec) {
 		**out = **in
 	}
 	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(ElasticsearchSecuritySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReferencePolicy != nil {
+		in, out := &in.ReferencePolicy, &out.ReferencePolicy
+		*out = new(shared.ReferencePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(ElasticsearchRestoreFromSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeSnapshot != nil {
+		in, out := &in.UpgradeSnapshot, &out.UpgradeSnapshot
+		*out = new(ElasticsearchUpgradeSnapshotSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchSpec.
@@ -354,6 +777,11 @@ func (in *ElasticsearchStatus) DeepCopyInto(out *ElasticsearchStatus) {
 		**out = **in
 	}
 	out.CredentialsRef = in.CredentialsRef
+	if in.UpgradeSnapshot != nil {
+		in, out := &in.UpgradeSnapshot, &out.UpgradeSnapshot
+		*out = new(ElasticsearchUpgradeSnapshotStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchStatus.
@@ -365,3 +793,43 @@ func (in *ElasticsearchStatus) DeepCopy() *ElasticsearchStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchUpgradeSnapshotSpec) DeepCopyInto(out *ElasticsearchUpgradeSnapshotSpec) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeGlobalState != nil {
+		in, out := &in.IncludeGlobalState, &out.IncludeGlobalState
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchUpgradeSnapshotSpec.
+func (in *ElasticsearchUpgradeSnapshotSpec) DeepCopy() *ElasticsearchUpgradeSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchUpgradeSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchUpgradeSnapshotStatus) DeepCopyInto(out *ElasticsearchUpgradeSnapshotStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchUpgradeSnapshotStatus.
+func (in *ElasticsearchUpgradeSnapshotStatus) DeepCopy() *ElasticsearchUpgradeSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchUpgradeSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
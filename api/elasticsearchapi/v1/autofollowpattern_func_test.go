@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAutoFollowPatternGetStatus(t *testing.T) {
+	status := AutoFollowPatternStatus{
+		DefaultRemoteObjectStatus: remote.DefaultRemoteObjectStatus{
+			LastAppliedConfiguration: "test",
+		},
+	}
+	o := &AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Status: status,
+	}
+
+	assert.Equal(t, &status, o.GetStatus())
+}
+
+func TestAutoFollowPatternExternalName(t *testing.T) {
+	var o *AutoFollowPattern
+
+	// When name is set
+	o = &AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: AutoFollowPatternSpec{
+			Name: "test2",
+		},
+	}
+
+	assert.Equal(t, "test2", o.GetExternalName())
+
+	// When name isn't set
+	o = &AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: AutoFollowPatternSpec{},
+	}
+
+	assert.Equal(t, "test", o.GetExternalName())
+}
+
+func TestAutoFollowPatternGetExpectedState(t *testing.T) {
+	var o *AutoFollowPattern
+
+	// When state is not set
+	o = &AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: AutoFollowPatternSpec{},
+	}
+	assert.Equal(t, FollowerIndexStateActive, o.GetExpectedState())
+
+	// When state is set
+	o = &AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: AutoFollowPatternSpec{
+			State: FollowerIndexStatePaused,
+		},
+	}
+	assert.Equal(t, FollowerIndexStatePaused, o.GetExpectedState())
+}
@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupAutoFollowPatternIndexer() {
+	// Add AutoFollowPattern to force indexer execution
+
+	autoFollowPattern := &AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: AutoFollowPatternSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			RemoteCluster:       "remote",
+			LeaderIndexPatterns: []string{"logs-*"},
+		},
+	}
+
+	err := t.k8sClient.Create(context.Background(), autoFollowPattern)
+	assert.NoError(t.T(), err)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// AutoFollowPatternSpec defines the desired state of AutoFollowPattern
+// +k8s:openapi-gen=true
+type AutoFollowPatternSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ElasticsearchRef is the Elasticsearch ref to connect on.
+	// It's the follower cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
+
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// Name is the auto-follow pattern name
+	// If empty, it use the ressource name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// RemoteCluster is the remote cluster alias that contain the leader indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RemoteCluster string `json:"remoteCluster"`
+
+	// LeaderIndexPatterns is the list of index patterns to follow on remote cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:MinItems=1
+	LeaderIndexPatterns []string `json:"leaderIndexPatterns"`
+
+	// LeaderIndexExclusionPatterns is the list of index patterns to not follow on remote cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	LeaderIndexExclusionPatterns []string `json:"leaderIndexExclusionPatterns,omitempty"`
+
+	// FollowIndexPattern is the name of follower index. The template `{{leader_index}}` can be used to derive the name from leader index
+	// Default it use the leader index name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	FollowIndexPattern string `json:"followIndexPattern,omitempty"`
+
+	// State is the expected state of the auto-follow pattern
+	// When paused, the new leader indices are not followed, but the existing follower indices are not paused
+	// Default to active
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:default=active
+	// +kubebuilder:validation:Enum=active;paused
+	State FollowerIndexState `json:"state,omitempty"`
+
+	// Settings is the index settings to override on the follower indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Settings *apis.MapAny `json:"settings,omitempty"`
+
+	// Parameters is the settings used by the replication of the follower indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Parameters *FollowerIndexParameters `json:"parameters,omitempty"`
+}
+
+// AutoFollowPatternStatus defines the observed state of AutoFollowPattern
+type AutoFollowPatternStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// State is the current auto-follow pattern state on Elasticsearch
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	State string `json:"state,omitempty"`
+
+	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// AutoFollowPattern is the Schema for the autofollowpatterns API
+// +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type AutoFollowPattern struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AutoFollowPatternSpec   `json:"spec,omitempty"`
+	Status AutoFollowPatternStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// AutoFollowPatternList contains a list of AutoFollowPattern
+type AutoFollowPatternList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AutoFollowPattern `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoFollowPattern{}, &AutoFollowPatternList{})
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupAutoFollowPatternWebhook() {
+	var (
+		o   *AutoFollowPattern
+		err error
+	)
+
+	// Need failed when create same resource by external name on same managed cluster
+	// Check we can update it
+	o = &AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook",
+			Namespace: "default",
+		},
+		Spec: AutoFollowPatternSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name:                "webhook",
+			RemoteCluster:       "remote",
+			LeaderIndexPatterns: []string{"logs-*"},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	// Need failed when change the name
+	o.Spec.Name = "webhook2"
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	o = &AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook2",
+			Namespace: "default",
+		},
+		Spec: AutoFollowPatternSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name:                "webhook",
+			RemoteCluster:       "remote",
+			LeaderIndexPatterns: []string{"logs-*"},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+}
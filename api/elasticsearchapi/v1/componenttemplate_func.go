@@ -1,6 +1,9 @@
 package v1
 
-import "github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
 
 // GetStatus return the status object
 func (o *ComponentTemplate) GetStatus() object.RemoteObjectStatus {
@@ -21,3 +24,33 @@ func (o *ComponentTemplate) GetExternalName() string {
 func (o *ComponentTemplate) IsRawTemplate() bool {
 	return o.Spec.RawTemplate != nil
 }
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *ComponentTemplate) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *ComponentTemplate) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *ComponentTemplate) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *ComponentTemplate) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *ComponentTemplate) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *ComponentTemplate) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
@@ -36,6 +36,23 @@ type ComponentTemplateSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
 
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
 	// Name is the custom component template name
 	// If empty, it use the ressource name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -73,6 +90,16 @@ type ComponentTemplateStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
 }
 
 //+kubebuilder:object:root=true
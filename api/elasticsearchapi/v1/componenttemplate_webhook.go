@@ -25,6 +25,7 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -129,6 +130,9 @@ func (r *componentTemplateValidator) ValidateCreate(ctx context.Context, obj run
 	if err := componentTemplateObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, componentTemplateObj, componentTemplateObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(componentTemplateObj); err != nil {
 		allErrs = append(allErrs, err)
@@ -165,6 +169,9 @@ func (r *componentTemplateValidator) ValidateUpdate(ctx context.Context, oldObj
 	if err := componentTemplateObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, componentTemplateObj, componentTemplateObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := validateImmutableName(componentTemplateObj, oldO); err != nil {
 		allErrs = append(allErrs, err)
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
+
+// GetStatus return the status object
+func (o *FollowerIndex) GetStatus() object.RemoteObjectStatus {
+	return &o.Status
+}
+
+// GetExternalName return the follower index name
+// If name is empty, it use the ressource name
+func (o *FollowerIndex) GetExternalName() string {
+	if o.Spec.Name == "" {
+		return o.Name
+	}
+
+	return o.Spec.Name
+}
+
+// GetExpectedState return the expected state
+// Default to active
+func (o *FollowerIndex) GetExpectedState() FollowerIndexState {
+	if o.Spec.State == "" {
+		return FollowerIndexStateActive
+	}
+
+	return o.Spec.State
+}
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *FollowerIndex) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *FollowerIndex) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *FollowerIndex) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *FollowerIndex) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *FollowerIndex) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *FollowerIndex) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
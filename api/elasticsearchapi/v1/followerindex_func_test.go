@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFollowerIndexGetStatus(t *testing.T) {
+	status := FollowerIndexStatus{
+		DefaultRemoteObjectStatus: remote.DefaultRemoteObjectStatus{
+			LastAppliedConfiguration: "test",
+		},
+	}
+	o := &FollowerIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Status: status,
+	}
+
+	assert.Equal(t, &status, o.GetStatus())
+}
+
+func TestFollowerIndexExternalName(t *testing.T) {
+	var o *FollowerIndex
+
+	// When name is set
+	o = &FollowerIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: FollowerIndexSpec{
+			Name: "test2",
+		},
+	}
+
+	assert.Equal(t, "test2", o.GetExternalName())
+
+	// When name isn't set
+	o = &FollowerIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: FollowerIndexSpec{},
+	}
+
+	assert.Equal(t, "test", o.GetExternalName())
+}
+
+func TestFollowerIndexGetExpectedState(t *testing.T) {
+	var o *FollowerIndex
+
+	// When state is not set
+	o = &FollowerIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: FollowerIndexSpec{},
+	}
+	assert.Equal(t, FollowerIndexStateActive, o.GetExpectedState())
+
+	// When state is set
+	o = &FollowerIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: FollowerIndexSpec{
+			State: FollowerIndexStatePaused,
+		},
+	}
+	assert.Equal(t, FollowerIndexStatePaused, o.GetExpectedState())
+}
@@ -0,0 +1,32 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupFollowerIndexIndexer() {
+	// Add FollowerIndex to force indexer execution
+
+	followerIndex := &FollowerIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: FollowerIndexSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			RemoteCluster: "remote",
+			LeaderIndex:   "test",
+		},
+	}
+
+	err := t.k8sClient.Create(context.Background(), followerIndex)
+	assert.NoError(t.T(), err)
+}
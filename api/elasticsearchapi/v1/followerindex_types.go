@@ -0,0 +1,216 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// FollowerIndexSpec defines the desired state of FollowerIndex
+// +k8s:openapi-gen=true
+type FollowerIndexSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ElasticsearchRef is the Elasticsearch ref to connect on.
+	// It's the follower cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
+
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// Name is the follower index name
+	// If empty, it use the ressource name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// RemoteCluster is the remote cluster alias that contain the leader index
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	RemoteCluster string `json:"remoteCluster"`
+
+	// LeaderIndex is the index name on remote cluster to follow
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	LeaderIndex string `json:"leaderIndex"`
+
+	// State is the expected state of the replication
+	// Default to active
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:default=active
+	// +kubebuilder:validation:Enum=active;paused
+	State FollowerIndexState `json:"state,omitempty"`
+
+	// Parameters is the settings used by the replication
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Parameters *FollowerIndexParameters `json:"parameters,omitempty"`
+}
+
+// FollowerIndexParameters is the settings used by the replication of a follower index
+type FollowerIndexParameters struct {
+	// MaxReadRequestOperationCount is the maximum number of operations to pull per read from the remote cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxReadRequestOperationCount *int64 `json:"maxReadRequestOperationCount,omitempty"`
+
+	// MaxOutstandingReadRequests is the maximum number of outstanding reads requests from the remote cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxOutstandingReadRequests *int64 `json:"maxOutstandingReadRequests,omitempty"`
+
+	// MaxReadRequestSize is the maximum size in bytes of per read of a batch of operations pulled from the remote cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxReadRequestSize string `json:"maxReadRequestSize,omitempty"`
+
+	// MaxWriteRequestOperationCount is the maximum number of operations per bulk write request executed on the follower
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxWriteRequestOperationCount *int64 `json:"maxWriteRequestOperationCount,omitempty"`
+
+	// MaxWriteRequestSize is the maximum total bytes of operations per bulk write request executed on the follower
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxWriteRequestSize string `json:"maxWriteRequestSize,omitempty"`
+
+	// MaxOutstandingWriteRequests is the maximum number of outstanding write requests on the follower
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxOutstandingWriteRequests *int64 `json:"maxOutstandingWriteRequests,omitempty"`
+
+	// MaxWriteBufferCount is the maximum number of operations that can be queued for writing
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxWriteBufferCount *int64 `json:"maxWriteBufferCount,omitempty"`
+
+	// MaxWriteBufferSize is the maximum total bytes of operations that can be queued for writing
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxWriteBufferSize string `json:"maxWriteBufferSize,omitempty"`
+
+	// MaxRetryDelay is the maximum time to wait before retrying an operation that failed exceptionally
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxRetryDelay string `json:"maxRetryDelay,omitempty"`
+
+	// ReadPollTimeout is the maximum time to wait for new operations on the remote cluster when the follower index is synchronized with the leader index
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ReadPollTimeout string `json:"readPollTimeout,omitempty"`
+}
+
+// FollowerIndexState is the expected state of the replication
+type FollowerIndexState string
+
+const (
+	// FollowerIndexStateActive is the state when the follower index replicate the leader index
+	FollowerIndexStateActive FollowerIndexState = "active"
+
+	// FollowerIndexStatePaused is the state when the replication is paused
+	FollowerIndexStatePaused FollowerIndexState = "paused"
+)
+
+// FollowerIndexStatus defines the observed state of FollowerIndex
+type FollowerIndexStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// State is the current replication state on Elasticsearch
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	State string `json:"state,omitempty"`
+
+	// OperationsBehind is the number of operations the follower index is behind the leader index
+	// It's the sum on all shards of the difference between the leader and the follower global checkpoints
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	OperationsBehind int64 `json:"operationsBehind"`
+
+	// LastReadTime is the last time the follower index read operations from the leader index
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastReadTime *metav1.Time `json:"lastReadTime,omitempty"`
+
+	// FatalError is the fatal exception that stop the replication
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	FatalError string `json:"fatalError,omitempty"`
+
+	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// FollowerIndex is the Schema for the followerindices API
+// +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Behind",type="integer",JSONPath=".status.operationsBehind"
+// +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type FollowerIndex struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FollowerIndexSpec   `json:"spec,omitempty"`
+	Status FollowerIndexStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// FollowerIndexList contains a list of FollowerIndex
+type FollowerIndexList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FollowerIndex `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FollowerIndex{}, &FollowerIndexList{})
+}
@@ -34,3 +34,8 @@ var (
 	// AddToScheme adds the types in this group-version to the given scheme.
 	AddToScheme = SchemeBuilder.AddToScheme
 )
+
+const (
+	// ElasticsearchApiAnnotationKey is the base annotation key used on elasticsearchapi resources
+	ElasticsearchApiAnnotationKey = "elasticsearchapi.k8s.webcenter.fr"
+)
@@ -1,6 +1,15 @@
 package v1
 
-import "github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
+
+const (
+	// IndexLifecyclePolicyForceDeleteAnnotation is the annotation to delete the policy even if it's in use
+	// The policy is removed from the indices that use it before delete it
+	IndexLifecyclePolicyForceDeleteAnnotation = ElasticsearchApiAnnotationKey + "/force-delete"
+)
 
 // GetStatus return the status object
 func (o *IndexLifecyclePolicy) GetStatus() object.RemoteObjectStatus {
@@ -24,3 +33,108 @@ func (o *IndexLifecyclePolicy) IsRawPolicy() bool {
 	}
 	return false
 }
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *IndexLifecyclePolicy) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *IndexLifecyclePolicy) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *IndexLifecyclePolicy) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *IndexLifecyclePolicy) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *IndexLifecyclePolicy) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *IndexLifecyclePolicy) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
+
+// IsForceDelete return true if the annotation to delete the policy even if it's in use is set
+func (o *IndexLifecyclePolicy) IsForceDelete() bool {
+	return o.GetAnnotations()[IndexLifecyclePolicyForceDeleteAnnotation] == "true"
+}
+
+// GetPhases return the phases set on the policy by name
+func (h *IndexLifecyclePolicySpecPolicyPhases) GetPhases() map[string]*IndexLifecyclePolicySpecPolicyPhasesPhase {
+	phases := map[string]*IndexLifecyclePolicySpecPolicyPhasesPhase{}
+	if h.Hot != nil {
+		phases["hot"] = h.Hot
+	}
+	if h.Warm != nil {
+		phases["warm"] = h.Warm
+	}
+	if h.Cold != nil {
+		phases["cold"] = h.Cold
+	}
+	if h.Frozen != nil {
+		phases["frozen"] = h.Frozen
+	}
+	if h.Delete != nil {
+		phases["delete"] = h.Delete
+	}
+
+	return phases
+}
+
+// GetTypedActions return the typed actions set on the phase by name
+func (h *IndexLifecyclePolicySpecPolicyPhasesPhase) GetTypedActions() map[string]any {
+	actions := map[string]any{}
+	if h.Rollover != nil {
+		actions["rollover"] = h.Rollover
+	}
+	if h.Shrink != nil {
+		actions["shrink"] = h.Shrink
+	}
+	if h.Forcemerge != nil {
+		actions["forcemerge"] = h.Forcemerge
+	}
+	if h.SearchableSnapshot != nil {
+		actions["searchable_snapshot"] = h.SearchableSnapshot
+	}
+	if h.Allocate != nil {
+		actions["allocate"] = h.Allocate
+	}
+	if h.Migrate != nil {
+		actions["migrate"] = h.Migrate
+	}
+	if h.Freeze != nil {
+		actions["freeze"] = h.Freeze
+	}
+	if h.Readonly != nil {
+		actions["readonly"] = h.Readonly
+	}
+	if h.Delete != nil {
+		actions["delete"] = h.Delete
+	}
+
+	return actions
+}
+
+// GetActions return all the actions of the phase, the raw actions merged with the typed actions
+// The typed actions take precedence over the raw actions
+func (h *IndexLifecyclePolicySpecPolicyPhasesPhase) GetActions() map[string]any {
+	actions := make(map[string]any, len(h.Actions.Data))
+	for name, action := range h.Actions.Data {
+		actions[name] = action
+	}
+	for name, action := range h.GetTypedActions() {
+		actions[name] = action
+	}
+
+	return actions
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"testing"
 
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -81,3 +82,48 @@ func TestIndexStateManagemenIsRawPolicy(t *testing.T) {
 
 	assert.True(t, o.IsRawPolicy())
 }
+
+func TestIndexLifecyclePolicyGetActions(t *testing.T) {
+	phases := IndexLifecyclePolicySpecPolicyPhases{
+		Hot: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+			Actions: apis.MapAny{
+				Data: map[string]any{
+					"set_priority": map[string]any{
+						"priority": 100,
+					},
+					"rollover": map[string]any{
+						"max_age": "7d",
+					},
+				},
+			},
+			Rollover: &IndexLifecyclePolicyRolloverAction{
+				MaxAge: ptr.To("1d"),
+			},
+		},
+		Delete: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+			MinAge: ptr.To("30d"),
+			Delete: &IndexLifecyclePolicyDeleteAction{},
+		},
+	}
+
+	// Get phases
+	assert.Equal(t, map[string]*IndexLifecyclePolicySpecPolicyPhasesPhase{
+		"hot":    phases.Hot,
+		"delete": phases.Delete,
+	}, phases.GetPhases())
+
+	// Typed actions take precedence over raw actions
+	assert.Equal(t, map[string]any{
+		"set_priority": map[string]any{
+			"priority": 100,
+		},
+		"rollover": &IndexLifecyclePolicyRolloverAction{
+			MaxAge: ptr.To("1d"),
+		},
+	}, phases.Hot.GetActions())
+
+	// Without raw actions
+	assert.Equal(t, map[string]any{
+		"delete": &IndexLifecyclePolicyDeleteAction{},
+	}, phases.Delete.GetActions())
+}
@@ -36,6 +36,23 @@ type IndexLifecyclePolicySpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
 
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
 	// Name is the custom index lifecycle policy name
 	// If empty, it use the ressource name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -98,10 +115,209 @@ type IndexLifecyclePolicySpecPolicyPhasesPhase struct {
 	// +optional
 	MinAge *string `json:"min_age,omitempty"`
 
-	// The ILM action
+	// The raw ILM actions
+	// Use it for the actions that are not typed, or the options not yet supported by the typed actions
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:pruning:PreserveUnknownFields
-	Actions apis.MapAny `json:"actions"`
+	// +optional
+	Actions apis.MapAny `json:"actions,omitempty"`
+
+	// Rollover action. Only on hot phase
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Rollover *IndexLifecyclePolicyRolloverAction `json:"rollover,omitempty"`
+
+	// Shrink action. Only on hot and warm phases
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Shrink *IndexLifecyclePolicyShrinkAction `json:"shrink,omitempty"`
+
+	// Forcemerge action. Only on hot and warm phases
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Forcemerge *IndexLifecyclePolicyForcemergeAction `json:"forcemerge,omitempty"`
+
+	// SearchableSnapshot action. Only on hot, cold and frozen phases
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SearchableSnapshot *IndexLifecyclePolicySearchableSnapshotAction `json:"searchable_snapshot,omitempty"`
+
+	// Allocate action. Only on warm and cold phases
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Allocate *IndexLifecyclePolicyAllocateAction `json:"allocate,omitempty"`
+
+	// Migrate action. Only on warm and cold phases
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Migrate *IndexLifecyclePolicyMigrateAction `json:"migrate,omitempty"`
+
+	// Freeze action. Only on cold phase, it's removed on Elasticsearch 8
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Freeze *IndexLifecyclePolicyFreezeAction `json:"freeze,omitempty"`
+
+	// Readonly action. Only on hot, warm and cold phases
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Readonly *IndexLifecyclePolicyReadonlyAction `json:"readonly,omitempty"`
+
+	// Delete action. Only on delete phase
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Delete *IndexLifecyclePolicyDeleteAction `json:"delete,omitempty"`
+}
+
+// IndexLifecyclePolicyRolloverAction is the rollover action
+// It need at least one max condition
+type IndexLifecyclePolicyRolloverAction struct {
+	// MaxAge is the maximum elapsed time from index creation
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxAge *string `json:"max_age,omitempty"`
+
+	// MaxDocs is the maximum number of documents
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxDocs *int64 `json:"max_docs,omitempty"`
+
+	// MaxSize is the maximum size of all primary shards
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxSize *string `json:"max_size,omitempty"`
+
+	// MaxPrimaryShardSize is the maximum size of the largest primary shard
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxPrimaryShardSize *string `json:"max_primary_shard_size,omitempty"`
+
+	// MaxPrimaryShardDocs is the maximum number of documents of the largest primary shard
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxPrimaryShardDocs *int64 `json:"max_primary_shard_docs,omitempty"`
+
+	// MinAge is the minimum elapsed time from index creation
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MinAge *string `json:"min_age,omitempty"`
+
+	// MinDocs is the minimum number of documents
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MinDocs *int64 `json:"min_docs,omitempty"`
+
+	// MinSize is the minimum size of all primary shards
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MinSize *string `json:"min_size,omitempty"`
+
+	// MinPrimaryShardSize is the minimum size of the largest primary shard
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MinPrimaryShardSize *string `json:"min_primary_shard_size,omitempty"`
+
+	// MinPrimaryShardDocs is the minimum number of documents of the largest primary shard
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MinPrimaryShardDocs *int64 `json:"min_primary_shard_docs,omitempty"`
+}
+
+// IndexLifecyclePolicyShrinkAction is the shrink action
+// It need number_of_shards or max_primary_shard_size
+type IndexLifecyclePolicyShrinkAction struct {
+	// NumberOfShards is the number of shards of the shrunken index
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	NumberOfShards *int64 `json:"number_of_shards,omitempty"`
+
+	// MaxPrimaryShardSize is the maximum size of the primary shards of the shrunken index
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	MaxPrimaryShardSize *string `json:"max_primary_shard_size,omitempty"`
+
+	// AllowWriteAfterShrink keep the shrunken index writable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	AllowWriteAfterShrink *bool `json:"allow_write_after_shrink,omitempty"`
+}
+
+// IndexLifecyclePolicyForcemergeAction is the forcemerge action
+type IndexLifecyclePolicyForcemergeAction struct {
+	// MaxNumSegments is the number of segments to merge to
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=1
+	MaxNumSegments int64 `json:"max_num_segments"`
+
+	// IndexCodec is the codec used to compress the document store
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum=best_compression
+	// +optional
+	IndexCodec *string `json:"index_codec,omitempty"`
+}
+
+// IndexLifecyclePolicySearchableSnapshotAction is the searchable snapshot action
+type IndexLifecyclePolicySearchableSnapshotAction struct {
+	// SnapshotRepository is the repository used to store the snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	SnapshotRepository string `json:"snapshot_repository"`
+
+	// ForceMergeIndex force merge the index to one segment before snapshot it
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ForceMergeIndex *bool `json:"force_merge_index,omitempty"`
+}
+
+// IndexLifecyclePolicyAllocateAction is the allocate action
+type IndexLifecyclePolicyAllocateAction struct {
+	// NumberOfReplicas is the number of replicas to allocate
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	NumberOfReplicas *int64 `json:"number_of_replicas,omitempty"`
+
+	// TotalShardsPerNode is the maximum number of shards of the index on a single node
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Minimum=-1
+	// +optional
+	TotalShardsPerNode *int64 `json:"total_shards_per_node,omitempty"`
+
+	// Include assign the index to nodes that have at least one of the attributes
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Include map[string]string `json:"include,omitempty"`
+
+	// Exclude assign the index to nodes that have none of the attributes
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Exclude map[string]string `json:"exclude,omitempty"`
+
+	// Require assign the index to nodes that have all of the attributes
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Require map[string]string `json:"require,omitempty"`
+}
+
+// IndexLifecyclePolicyMigrateAction is the migrate action
+type IndexLifecyclePolicyMigrateAction struct {
+	// Enabled is set to false to disable the automatic migration on data tier
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// IndexLifecyclePolicyFreezeAction is the freeze action
+type IndexLifecyclePolicyFreezeAction struct{}
+
+// IndexLifecyclePolicyReadonlyAction is the readonly action
+type IndexLifecyclePolicyReadonlyAction struct{}
+
+// IndexLifecyclePolicyDeleteAction is the delete action
+type IndexLifecyclePolicyDeleteAction struct {
+	// DeleteSearchableSnapshot delete the snapshot of the searchable snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	DeleteSearchableSnapshot *bool `json:"delete_searchable_snapshot,omitempty"`
 }
 
 // IndexLifecyclePolicyStatus defines the observed state of IndexLifecyclePolicy
@@ -110,6 +326,81 @@ type IndexLifecyclePolicyStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+
+	// InUseBy is the index templates, the data streams and the indices that use the policy
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	InUseBy *IndexLifecyclePolicyUsage `json:"inUseBy,omitempty"`
+
+	// FailedIndices is the indices stuck on ERROR step
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	FailedIndices []IndexLifecyclePolicyFailedIndex `json:"failedIndices,omitempty"`
+
+	// LastRetryTime is the last time the failed indices are retried with the retry annotation
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastRetryTime *metav1.Time `json:"lastRetryTime,omitempty"`
+}
+
+// IndexLifecyclePolicyUsage is the objects that use the policy
+type IndexLifecyclePolicyUsage struct {
+	// IndicesCount is the number of indices that use the policy
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	IndicesCount int `json:"indicesCount"`
+
+	// Indices is the indices that use the policy
+	// It's limited to the first 100 indices
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+
+	// DataStreams is the data streams that use the policy
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DataStreams []string `json:"dataStreams,omitempty"`
+
+	// ComposableTemplates is the index templates that use the policy
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ComposableTemplates []string `json:"composableTemplates,omitempty"`
+}
+
+// IndexLifecyclePolicyFailedIndex is an index stuck on ERROR step
+type IndexLifecyclePolicyFailedIndex struct {
+	// Index is the index name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Index string `json:"index"`
+
+	// Phase is the current phase of the index
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Action is the current action of the index
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Action string `json:"action,omitempty"`
+
+	// FailedStep is the step that failed
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	FailedStep string `json:"failedStep,omitempty"`
+
+	// Reason is the failure reason
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 //+kubebuilder:object:root=true
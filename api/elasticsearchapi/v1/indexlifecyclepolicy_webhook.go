@@ -20,21 +20,56 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
+var (
+	// ilmPhases is the ILM phases on execution order
+	ilmPhases = []string{"hot", "warm", "cold", "frozen", "delete"}
+
+	// ilmPhaseActions is the actions allowed on each phase
+	ilmPhaseActions = map[string][]string{
+		"hot":    {"set_priority", "unfollow", "rollover", "readonly", "downsample", "shrink", "forcemerge", "searchable_snapshot"},
+		"warm":   {"set_priority", "unfollow", "readonly", "downsample", "allocate", "migrate", "shrink", "forcemerge"},
+		"cold":   {"set_priority", "unfollow", "readonly", "downsample", "searchable_snapshot", "allocate", "migrate", "freeze"},
+		"frozen": {"unfollow", "searchable_snapshot"},
+		"delete": {"wait_for_snapshot", "delete"},
+	}
+
+	// ilmHotActionsNeedRollover is the actions that need the rollover action when they are used on hot phase
+	ilmHotActionsNeedRollover = []string{"shrink", "forcemerge", "searchable_snapshot"}
+
+	// ilmMinVersions is the first Elasticsearch version that support the phases and the actions
+	ilmMinVersions = map[string]string{
+		"frozen":              "7.12.0",
+		"searchable_snapshot": "7.10.0",
+		"migrate":             "7.10.0",
+		"downsample":          "8.5.0",
+	}
+
+	// ilmRemovedVersions is the first Elasticsearch version that not support anymore the actions
+	ilmRemovedVersions = map[string]string{
+		"freeze": "8.0.0",
+	}
+)
+
 type indexLifecyclePolicyValidator struct {
 	logger *logrus.Entry
 	client client.Client
@@ -108,6 +143,140 @@ func (r *indexLifecyclePolicyValidator) validateRawPolicy(obj *IndexLifecyclePol
 	return nil
 }
 
+// validatePolicyActions check the actions of each phase, according to the version of the managed Elasticsearch cluster
+func (r *indexLifecyclePolicyValidator) validatePolicyActions(ctx context.Context, obj *IndexLifecyclePolicy) field.ErrorList {
+	if obj.IsRawPolicy() || obj.Spec.Policy == nil {
+		return nil
+	}
+
+	esVersion, err := elasticsearchcrd.GetElasticsearchVersionFromRef(ctx, r.client, obj, obj.Spec.ElasticsearchRef)
+	if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec").Child("elasticsearchRef"), err)}
+	}
+
+	return validateIndexLifecyclePolicyActions(obj.Spec.Policy, esVersion)
+}
+
+// validateIndexLifecyclePolicyActions check that the actions are allowed on their phase and supported by the Elasticsearch version
+// The checks related to version are skipped when the version is empty
+func validateIndexLifecyclePolicyActions(policy *IndexLifecyclePolicySpecPolicy, esVersion string) (allErrs field.ErrorList) {
+	var currentVersion *version.Version
+	if esVersion != "" {
+		// The version can be a custom tag, in this case the checks related to version are skipped
+		currentVersion, _ = version.ParseGeneric(esVersion)
+	}
+	isSupported := func(name string) bool {
+		if currentVersion == nil {
+			return true
+		}
+		if minVersion, ok := ilmMinVersions[name]; ok && currentVersion.LessThan(version.MustParseGeneric(minVersion)) {
+			return false
+		}
+		if removedVersion, ok := ilmRemovedVersions[name]; ok && currentVersion.AtLeast(version.MustParseGeneric(removedVersion)) {
+			return false
+		}
+		return true
+	}
+
+	phases := policy.Phases.GetPhases()
+	for _, phaseName := range ilmPhases {
+		phase, ok := phases[phaseName]
+		if !ok {
+			continue
+		}
+		path := field.NewPath("spec").Child("policy", "phases", phaseName)
+		if !isSupported(phaseName) {
+			allErrs = append(allErrs, field.Forbidden(path, fmt.Sprintf("The %s phase is not supported by Elasticsearch %s", phaseName, esVersion)))
+			continue
+		}
+
+		typedActions := phase.GetTypedActions()
+		actionPaths := map[string]*field.Path{}
+		for _, actionName := range slices.Sorted(maps.Keys(typedActions)) {
+			actionPaths[actionName] = path.Child(actionName)
+			if !funk.ContainsString(ilmPhaseActions[phaseName], actionName) {
+				allErrs = append(allErrs, field.Forbidden(path.Child(actionName), fmt.Sprintf("The %s action is not allowed on the %s phase", actionName, phaseName)))
+			}
+		}
+		for _, actionName := range slices.Sorted(maps.Keys(phase.Actions.Data)) {
+			actionPaths[actionName] = path.Child("actions").Key(actionName)
+			if _, ok := typedActions[actionName]; ok {
+				allErrs = append(allErrs, field.Duplicate(path.Child("actions").Key(actionName), fmt.Sprintf("The %s action is already set with the typed action", actionName)))
+			} else if !funk.ContainsString(ilmPhaseActions[phaseName], actionName) {
+				allErrs = append(allErrs, field.NotSupported(path.Child("actions").Key(actionName), actionName, ilmPhaseActions[phaseName]))
+			}
+		}
+
+		for _, actionName := range slices.Sorted(maps.Keys(actionPaths)) {
+			if !isSupported(actionName) {
+				allErrs = append(allErrs, field.Forbidden(actionPaths[actionName], fmt.Sprintf("The %s action is not supported by Elasticsearch %s", actionName, esVersion)))
+			}
+		}
+
+		if phaseName == "hot" {
+			if _, ok := actionPaths["rollover"]; !ok {
+				for _, actionName := range ilmHotActionsNeedRollover {
+					if actionPath, ok := actionPaths[actionName]; ok {
+						allErrs = append(allErrs, field.Forbidden(actionPath, fmt.Sprintf("The %s action need the rollover action on the hot phase", actionName)))
+					}
+				}
+			}
+		}
+
+		if phase.Rollover != nil {
+			allErrs = append(allErrs, validateIndexLifecyclePolicyRollover(phase.Rollover, path.Child("rollover"), currentVersion)...)
+		}
+		if phase.Shrink != nil {
+			allErrs = append(allErrs, validateIndexLifecyclePolicyShrink(phase.Shrink, path.Child("shrink"), currentVersion)...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateIndexLifecyclePolicyRollover check the rollover conditions
+func validateIndexLifecyclePolicyRollover(rollover *IndexLifecyclePolicyRolloverAction, path *field.Path, currentVersion *version.Version) (allErrs field.ErrorList) {
+	if rollover.MaxAge == nil && rollover.MaxDocs == nil && rollover.MaxSize == nil && rollover.MaxPrimaryShardSize == nil && rollover.MaxPrimaryShardDocs == nil {
+		allErrs = append(allErrs, field.Required(path, "The rollover action need at least one max condition"))
+	}
+
+	if currentVersion == nil {
+		return allErrs
+	}
+	if rollover.MaxPrimaryShardSize != nil && currentVersion.LessThan(version.MustParseGeneric("7.13.0")) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("max_primary_shard_size"), "It need Elasticsearch 7.13.0 or later"))
+	}
+	if rollover.MaxPrimaryShardDocs != nil && currentVersion.LessThan(version.MustParseGeneric("8.2.0")) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("max_primary_shard_docs"), "It need Elasticsearch 8.2.0 or later"))
+	}
+	if (rollover.MinAge != nil || rollover.MinDocs != nil || rollover.MinSize != nil || rollover.MinPrimaryShardSize != nil || rollover.MinPrimaryShardDocs != nil) && currentVersion.LessThan(version.MustParseGeneric("8.4.0")) {
+		allErrs = append(allErrs, field.Forbidden(path, "The min conditions need Elasticsearch 8.4.0 or later"))
+	}
+
+	return allErrs
+}
+
+// validateIndexLifecyclePolicyShrink check the shrink options
+func validateIndexLifecyclePolicyShrink(shrink *IndexLifecyclePolicyShrinkAction, path *field.Path, currentVersion *version.Version) (allErrs field.ErrorList) {
+	if shrink.NumberOfShards == nil && shrink.MaxPrimaryShardSize == nil {
+		allErrs = append(allErrs, field.Required(path, "The shrink action need number_of_shards or max_primary_shard_size"))
+	} else if shrink.NumberOfShards != nil && shrink.MaxPrimaryShardSize != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("max_primary_shard_size"), "You can't set max_primary_shard_size with number_of_shards"))
+	}
+
+	if currentVersion == nil {
+		return allErrs
+	}
+	if shrink.MaxPrimaryShardSize != nil && currentVersion.LessThan(version.MustParseGeneric("7.13.0")) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("max_primary_shard_size"), "It need Elasticsearch 7.13.0 or later"))
+	}
+	if shrink.AllowWriteAfterShrink != nil && currentVersion.LessThan(version.MustParseGeneric("8.14.0")) {
+		allErrs = append(allErrs, field.Forbidden(path.Child("allow_write_after_shrink"), "It need Elasticsearch 8.14.0 or later"))
+	}
+
+	return allErrs
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *indexLifecyclePolicyValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
@@ -126,9 +295,14 @@ func (r *indexLifecyclePolicyValidator) ValidateCreate(ctx context.Context, obj
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, r.validatePolicyActions(ctx, indexStateManagementObj)...)
+
 	if err := indexStateManagementObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, indexStateManagementObj, indexStateManagementObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(indexStateManagementObj); err != nil {
 		allErrs = append(allErrs, err)
@@ -162,9 +336,14 @@ func (r *indexLifecyclePolicyValidator) ValidateUpdate(ctx context.Context, oldO
 		allErrs = append(allErrs, err)
 	}
 
+	allErrs = append(allErrs, r.validatePolicyActions(ctx, indexStateManagementObj)...)
+
 	if err := indexStateManagementObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, indexStateManagementObj, indexStateManagementObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := validateImmutableName(indexStateManagementObj, oldO); err != nil {
 		allErrs = append(allErrs, err)
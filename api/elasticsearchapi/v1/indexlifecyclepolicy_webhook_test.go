@@ -2,7 +2,9 @@ package v1
 
 import (
 	"context"
+	"testing"
 
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	"github.com/stretchr/testify/assert"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -161,4 +163,249 @@ func (t *TestSuite) TestSetupIndexLifecyclePolicyWebhook() {
 	}
 	err = t.k8sClient.Create(context.Background(), o)
 	assert.Error(t.T(), err)
+
+	// Need succeed when create policy with typed actions
+	o = &IndexLifecyclePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook10",
+			Namespace: "default",
+		},
+		Spec: IndexLifecyclePolicySpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test.local"},
+				},
+			},
+			Policy: &IndexLifecyclePolicySpecPolicy{
+				Phases: IndexLifecyclePolicySpecPolicyPhases{
+					Hot: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+						Rollover: &IndexLifecyclePolicyRolloverAction{
+							MaxAge: ptr.To("1d"),
+						},
+						Forcemerge: &IndexLifecyclePolicyForcemergeAction{
+							MaxNumSegments: 1,
+						},
+						Actions: apis.MapAny{
+							Data: map[string]any{
+								"set_priority": map[string]any{
+									"priority": 100,
+								},
+							},
+						},
+					},
+					Delete: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+						MinAge: ptr.To("30d"),
+						Delete: &IndexLifecyclePolicyDeleteAction{},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	// Need failed when shrink on hot phase without rollover
+	o = &IndexLifecyclePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook11",
+			Namespace: "default",
+		},
+		Spec: IndexLifecyclePolicySpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test.local"},
+				},
+			},
+			Policy: &IndexLifecyclePolicySpecPolicy{
+				Phases: IndexLifecyclePolicySpecPolicyPhases{
+					Hot: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+						Shrink: &IndexLifecyclePolicyShrinkAction{
+							NumberOfShards: ptr.To[int64](1),
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when rollover outside hot phase
+	o = &IndexLifecyclePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook12",
+			Namespace: "default",
+		},
+		Spec: IndexLifecyclePolicySpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test.local"},
+				},
+			},
+			Policy: &IndexLifecyclePolicySpecPolicy{
+				Phases: IndexLifecyclePolicySpecPolicyPhases{
+					Warm: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+						Rollover: &IndexLifecyclePolicyRolloverAction{
+							MaxAge: ptr.To("1d"),
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when unknown raw action
+	o = &IndexLifecyclePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook13",
+			Namespace: "default",
+		},
+		Spec: IndexLifecyclePolicySpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test.local"},
+				},
+			},
+			Policy: &IndexLifecyclePolicySpecPolicy{
+				Phases: IndexLifecyclePolicySpecPolicyPhases{
+					Warm: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+						Actions: apis.MapAny{
+							Data: map[string]any{
+								"foo": map[string]any{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+}
+
+func TestValidateIndexLifecyclePolicyActions(t *testing.T) {
+	var policy *IndexLifecyclePolicySpecPolicy
+
+	// When all actions are allowed
+	policy = &IndexLifecyclePolicySpecPolicy{
+		Phases: IndexLifecyclePolicySpecPolicyPhases{
+			Hot: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+				Rollover: &IndexLifecyclePolicyRolloverAction{
+					MaxPrimaryShardSize: ptr.To("50gb"),
+				},
+				Shrink: &IndexLifecyclePolicyShrinkAction{
+					NumberOfShards: ptr.To[int64](1),
+				},
+			},
+			Cold: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+				Freeze: &IndexLifecyclePolicyFreezeAction{},
+				Actions: apis.MapAny{
+					Data: map[string]any{
+						"set_priority": map[string]any{
+							"priority": 0,
+						},
+					},
+				},
+			},
+			Delete: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+				Delete: &IndexLifecyclePolicyDeleteAction{},
+			},
+		},
+	}
+	assert.Empty(t, validateIndexLifecyclePolicyActions(policy, ""))
+	assert.Empty(t, validateIndexLifecyclePolicyActions(policy, "7.17.0"))
+
+	// When action is removed from Elasticsearch version
+	errs := validateIndexLifecyclePolicyActions(policy, "8.15.0")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.policy.phases.cold.freeze", errs[0].Field)
+
+	// When options are not yet supported by Elasticsearch version
+	errs = validateIndexLifecyclePolicyActions(policy, "7.10.0")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.policy.phases.hot.rollover.max_primary_shard_size", errs[0].Field)
+
+	// When phase is not yet supported by Elasticsearch version
+	policy = &IndexLifecyclePolicySpecPolicy{
+		Phases: IndexLifecyclePolicySpecPolicyPhases{
+			Frozen: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+				SearchableSnapshot: &IndexLifecyclePolicySearchableSnapshotAction{
+					SnapshotRepository: "snapshot",
+				},
+			},
+		},
+	}
+	assert.Empty(t, validateIndexLifecyclePolicyActions(policy, "8.15.0"))
+	errs = validateIndexLifecyclePolicyActions(policy, "7.11.0")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.policy.phases.frozen", errs[0].Field)
+
+	// When action is not allowed on phase
+	policy = &IndexLifecyclePolicySpecPolicy{
+		Phases: IndexLifecyclePolicySpecPolicyPhases{
+			Warm: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+				Rollover: &IndexLifecyclePolicyRolloverAction{
+					MaxAge: ptr.To("1d"),
+				},
+				Actions: apis.MapAny{
+					Data: map[string]any{
+						"delete": map[string]any{},
+					},
+				},
+			},
+		},
+	}
+	errs = validateIndexLifecyclePolicyActions(policy, "")
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "spec.policy.phases.warm.rollover", errs[0].Field)
+	assert.Equal(t, "spec.policy.phases.warm.actions[delete]", errs[1].Field)
+
+	// When shrink on hot phase without rollover
+	policy = &IndexLifecyclePolicySpecPolicy{
+		Phases: IndexLifecyclePolicySpecPolicyPhases{
+			Hot: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+				Shrink: &IndexLifecyclePolicyShrinkAction{
+					NumberOfShards: ptr.To[int64](1),
+				},
+			},
+		},
+	}
+	errs = validateIndexLifecyclePolicyActions(policy, "")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.policy.phases.hot.shrink", errs[0].Field)
+
+	// When action is set on typed action and raw action
+	policy = &IndexLifecyclePolicySpecPolicy{
+		Phases: IndexLifecyclePolicySpecPolicyPhases{
+			Delete: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+				Delete: &IndexLifecyclePolicyDeleteAction{},
+				Actions: apis.MapAny{
+					Data: map[string]any{
+						"delete": map[string]any{},
+					},
+				},
+			},
+		},
+	}
+	errs = validateIndexLifecyclePolicyActions(policy, "")
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "spec.policy.phases.delete.actions[delete]", errs[0].Field)
+
+	// When rollover and shrink options are invalid
+	policy = &IndexLifecyclePolicySpecPolicy{
+		Phases: IndexLifecyclePolicySpecPolicyPhases{
+			Hot: &IndexLifecyclePolicySpecPolicyPhasesPhase{
+				Rollover: &IndexLifecyclePolicyRolloverAction{
+					MinDocs: ptr.To[int64](1),
+				},
+				Shrink: &IndexLifecyclePolicyShrinkAction{},
+			},
+		},
+	}
+	errs = validateIndexLifecyclePolicyActions(policy, "")
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "spec.policy.phases.hot.rollover", errs[0].Field)
+	assert.Equal(t, "spec.policy.phases.hot.shrink", errs[1].Field)
 }
@@ -1,6 +1,12 @@
 package v1
 
-import "github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+import (
+	"encoding/json"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	olivere "github.com/olivere/elastic/v7"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
 
 // GetStatus return the status object
 func (o *IndexTemplate) GetStatus() object.RemoteObjectStatus {
@@ -21,3 +27,105 @@ func (o *IndexTemplate) GetExternalName() string {
 func (o *IndexTemplate) IsRawTemplate() bool {
 	return o.Spec.RawTemplate != nil
 }
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *IndexTemplate) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *IndexTemplate) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *IndexTemplate) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *IndexTemplate) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *IndexTemplate) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *IndexTemplate) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
+
+// GetIndexPatterns return the index patterns of the template
+// It read them from the raw template when it's set
+func (o *IndexTemplate) GetIndexPatterns() []string {
+	if o.IsRawTemplate() {
+		return o.getRawTemplate().IndexPatterns
+	}
+
+	return o.Spec.IndexPatterns
+}
+
+// GetComposedOf return the component templates of the template
+// It read them from the raw template when it's set
+func (o *IndexTemplate) GetComposedOf() []string {
+	if o.IsRawTemplate() {
+		return o.getRawTemplate().ComposedOf
+	}
+
+	return o.Spec.ComposedOf
+}
+
+// GetPriority return the priority of the template
+// It read it from the raw template when it's set
+func (o *IndexTemplate) GetPriority() int {
+	if o.IsRawTemplate() {
+		return o.getRawTemplate().Priority
+	}
+
+	return o.Spec.Priority
+}
+
+// getRawTemplate return the raw template
+// It return empty template when the raw template is invalid, the webhook refuse it
+func (o *IndexTemplate) getRawTemplate() *olivere.IndicesGetIndexTemplate {
+	template := &olivere.IndicesGetIndexTemplate{}
+	if o.Spec.RawTemplate != nil {
+		_ = json.Unmarshal([]byte(*o.Spec.RawTemplate), template)
+	}
+
+	return template
+}
+
+// IsIndexPatternsOverlap return true if some indices can match the two index patterns
+// The index patterns only support the wildcard '*'
+func IsIndexPatternsOverlap(pattern1, pattern2 string) bool {
+	memo := map[[2]int]bool{}
+	var overlap func(i, j int) bool
+	overlap = func(i, j int) bool {
+		key := [2]int{i, j}
+		if result, ok := memo[key]; ok {
+			return result
+		}
+
+		var result bool
+		switch {
+		case i == len(pattern1) && j == len(pattern2):
+			result = true
+		case i < len(pattern1) && pattern1[i] == '*':
+			// The wildcard match nothing, or consume the next character of the other pattern
+			result = overlap(i+1, j) || (j < len(pattern2) && overlap(i, j+1))
+		case j < len(pattern2) && pattern2[j] == '*':
+			result = overlap(i, j+1) || (i < len(pattern1) && overlap(i+1, j))
+		case i < len(pattern1) && j < len(pattern2):
+			result = pattern1[i] == pattern2[j] && overlap(i+1, j+1)
+		}
+		memo[key] = result
+
+		return result
+	}
+
+	return overlap(0, 0)
+}
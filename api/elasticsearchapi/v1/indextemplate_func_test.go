@@ -81,3 +81,45 @@ func TestIndexIsRawTemplate(t *testing.T) {
 
 	assert.False(t, o.IsRawTemplate())
 }
+
+func TestIndexTemplateGetComposition(t *testing.T) {
+	o := &IndexTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: IndexTemplateSpec{
+			IndexPatterns: []string{"logs-*"},
+			ComposedOf:    []string{"logs-settings"},
+			Priority:      100,
+		},
+	}
+
+	// With template
+	assert.Equal(t, []string{"logs-*"}, o.GetIndexPatterns())
+	assert.Equal(t, []string{"logs-settings"}, o.GetComposedOf())
+	assert.Equal(t, 100, o.GetPriority())
+
+	// With raw template
+	o.Spec.RawTemplate = ptr.To(`{"index_patterns": ["metrics-*"], "composed_of": ["metrics-settings"], "priority": 200}`)
+	assert.Equal(t, []string{"metrics-*"}, o.GetIndexPatterns())
+	assert.Equal(t, []string{"metrics-settings"}, o.GetComposedOf())
+	assert.Equal(t, 200, o.GetPriority())
+
+	// With invalid raw template
+	o.Spec.RawTemplate = ptr.To(`{"sfdfdf"}`)
+	assert.Empty(t, o.GetIndexPatterns())
+	assert.Empty(t, o.GetComposedOf())
+	assert.Equal(t, 0, o.GetPriority())
+}
+
+func TestIsIndexPatternsOverlap(t *testing.T) {
+	assert.True(t, IsIndexPatternsOverlap("logs", "logs"))
+	assert.True(t, IsIndexPatternsOverlap("*", "logs"))
+	assert.True(t, IsIndexPatternsOverlap("logs-*", "logs-app-*"))
+	assert.True(t, IsIndexPatternsOverlap("logs-*", "*-app"))
+	assert.True(t, IsIndexPatternsOverlap("*-app-*", "logs-*-prod"))
+	assert.False(t, IsIndexPatternsOverlap("logs", "logs2"))
+	assert.False(t, IsIndexPatternsOverlap("logs-*", "metrics-*"))
+	assert.False(t, IsIndexPatternsOverlap("logs-*-prod", "logs-*-dev"))
+}
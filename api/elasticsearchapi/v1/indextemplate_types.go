@@ -36,6 +36,23 @@ type IndexTemplateSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
 
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
 	// Name is the custom index template name
 	// If empty, it use the ressource name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -112,6 +129,65 @@ type IndexTemplateStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+
+	// Simulation is the summary of the index template resolved by Elasticsearch with its component templates
+	// It's computed from the output of the API _index_template/_simulate
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Simulation *IndexTemplateSimulation `json:"simulation,omitempty"`
+}
+
+// IndexTemplateSimulation is the summary of the index template resolved by Elasticsearch
+// The mappings are not recorded, they can be too big to be stored on status
+type IndexTemplateSimulation struct {
+	// Settings is the index settings applied on the new indices
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Settings *apis.MapAny `json:"settings,omitempty"`
+
+	// Aliases is the name of the aliases applied on the new indices
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Aliases []string `json:"aliases,omitempty"`
+
+	// ComposedOf is the component templates merged on the index template
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ComposedOf []string `json:"composedOf,omitempty"`
+
+	// TemplateHash is the sha256 of the resolved template, with the settings, the mappings and the aliases
+	// It change when the template applied on the new indices change
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	TemplateHash string `json:"templateHash,omitempty"`
+
+	// Overlapping is the templates that match the same index patterns with a lower priority
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Overlapping []IndexTemplateOverlapping `json:"overlapping,omitempty"`
+}
+
+// IndexTemplateOverlapping is a template that match the same index patterns
+type IndexTemplateOverlapping struct {
+	// Name is the template name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// IndexPatterns is the index patterns of the template
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	IndexPatterns []string `json:"indexPatterns,omitempty"`
 }
 
 //+kubebuilder:object:root=true
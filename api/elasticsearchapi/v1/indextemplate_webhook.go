@@ -25,6 +25,7 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -108,6 +109,62 @@ func (r *indexTemplateValidator) validateRawTemplate(obj *IndexTemplate) *field.
 	return nil
 }
 
+// validateComposedOf check that each component template is managed by a ComponentTemplate on the same Elasticsearch cluster
+// The component template can be created on the cluster without the operator, so it only return warnings. The controller check it exist before apply the template
+func (r *indexTemplateValidator) validateComposedOf(ctx context.Context, obj *IndexTemplate) (warnings admission.Warnings, err *field.Error) {
+	for _, componentTemplateName := range obj.GetComposedOf() {
+		listObjects := &ComponentTemplateList{}
+		fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.externalName=%s,spec.targetCluster=%s", componentTemplateName, obj.Spec.ElasticsearchRef.GetTargetCluster(obj.Namespace)))
+		if err := r.client.List(ctx, listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
+			return nil, field.InternalError(field.NewPath("spec").Child("composedOf"), err)
+		}
+		if len(listObjects.Items) == 0 {
+			warnings = append(warnings, fmt.Sprintf("The component template '%s' is not managed by a ComponentTemplate on the same Elasticsearch cluster, it need to exist on the cluster", componentTemplateName))
+		}
+	}
+
+	return warnings, nil
+}
+
+// validateIndexPatternsConflict check that no other template on the same Elasticsearch cluster have the same priority with overlapping index patterns
+// Elasticsearch refuse it because it can't choose the template to apply
+func (r *indexTemplateValidator) validateIndexPatternsConflict(ctx context.Context, obj *IndexTemplate) *field.Error {
+	listObjects := &IndexTemplateList{}
+	fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.targetCluster=%s", obj.Spec.ElasticsearchRef.GetTargetCluster(obj.Namespace)))
+	if err := r.client.List(ctx, listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
+		return field.InternalError(field.NewPath("spec").Child("indexPatterns"), err)
+	}
+
+	conflicts := make([]string, 0)
+	for _, template := range listObjects.Items {
+		// exclude themself
+		if template.UID == obj.UID || template.GetPriority() != obj.GetPriority() {
+			continue
+		}
+		if isIndexPatternsListOverlap(obj.GetIndexPatterns(), template.GetIndexPatterns()) {
+			conflicts = append(conflicts, fmt.Sprintf("'%s/%s'", template.Namespace, template.Name))
+		}
+	}
+	if len(conflicts) > 0 {
+		return field.Invalid(field.NewPath("spec").Child("indexPatterns"), obj.GetIndexPatterns(), fmt.Sprintf("There are some templates with the same priority %d and overlapping index patterns on the same Elasticsearch cluster: %s", obj.GetPriority(), strings.Join(conflicts, ", ")))
+	}
+
+	return nil
+}
+
+// isIndexPatternsListOverlap return true if one index pattern of the first list overlap one index pattern of the second list
+func isIndexPatternsListOverlap(indexPatterns1, indexPatterns2 []string) bool {
+	for _, indexPattern1 := range indexPatterns1 {
+		for _, indexPattern2 := range indexPatterns2 {
+			if IsIndexPatternsOverlap(indexPattern1, indexPattern2) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *indexTemplateValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
@@ -129,18 +186,30 @@ func (r *indexTemplateValidator) ValidateCreate(ctx context.Context, obj runtime
 	if err := indexTemplateObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, indexTemplateObj, indexTemplateObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(indexTemplateObj); err != nil {
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateIndexPatternsConflict(ctx, indexTemplateObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	warnings, err := r.validateComposedOf(ctx, indexTemplateObj)
+	if err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if len(allErrs) > 0 {
-		return nil, apierrors.NewInvalid(
+		return warnings, apierrors.NewInvalid(
 			indexTemplateObj.GroupVersionKind().GroupKind(),
 			indexTemplateObj.Name, allErrs)
 	}
 
-	return nil, nil
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -165,6 +234,9 @@ func (r *indexTemplateValidator) ValidateUpdate(ctx context.Context, oldObj runt
 	if err := indexTemplateObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, indexTemplateObj, indexTemplateObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := validateImmutableName(indexTemplateObj, oldO); err != nil {
 		allErrs = append(allErrs, err)
@@ -174,13 +246,22 @@ func (r *indexTemplateValidator) ValidateUpdate(ctx context.Context, oldObj runt
 		allErrs = append(allErrs, err)
 	}
 
+	if err := r.validateIndexPatternsConflict(ctx, indexTemplateObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	warnings, err := r.validateComposedOf(ctx, indexTemplateObj)
+	if err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	if len(allErrs) > 0 {
-		return nil, apierrors.NewInvalid(
+		return warnings, apierrors.NewInvalid(
 			indexTemplateObj.GroupVersionKind().GroupKind(),
 			indexTemplateObj.Name, allErrs)
 	}
 
-	return nil, nil
+	return warnings, nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
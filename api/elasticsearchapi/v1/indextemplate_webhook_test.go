@@ -161,4 +161,58 @@ func (t *TestSuite) TestSetupIndexTemplateWebhook() {
 	}
 	err = t.k8sClient.Create(context.Background(), o)
 	assert.Error(t.T(), err)
+
+	// Need succeed when create template composed of component templates not managed by operator
+	o = &IndexTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook9",
+			Namespace: "default",
+		},
+		Spec: IndexTemplateSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test-conflict.local"},
+				},
+			},
+			IndexPatterns: []string{"logs-*"},
+			ComposedOf:    []string{"logs-settings"},
+			Priority:      100,
+			Template:      &IndexTemplateData{},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	// Need failed when create template with overlapping index patterns and same priority on same cluster
+	o = &IndexTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook10",
+			Namespace: "default",
+		},
+		Spec: IndexTemplateSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test-conflict.local"},
+				},
+			},
+			IndexPatterns: []string{"logs-app-*"},
+			Priority:      100,
+			Template:      &IndexTemplateData{},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when the overlapping template is a raw template
+	o.Spec.Template = nil
+	o.Spec.IndexPatterns = nil
+	o.Spec.Priority = 0
+	o.Spec.RawTemplate = ptr.To(`{"index_patterns": ["logs-app-*"], "priority": 100}`)
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need succeed when create template with overlapping index patterns and other priority
+	o.Spec.RawTemplate = ptr.To(`{"index_patterns": ["logs-app-*"], "priority": 200}`)
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
 }
@@ -1,6 +1,9 @@
 package v1
 
-import "github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
 
 // GetStatus return the status object
 func (o *License) GetStatus() object.RemoteObjectStatus {
@@ -14,9 +17,42 @@ func (h *License) GetExternalName() string {
 
 // IsBasicLicense return true if is basic license
 func (h *License) IsBasicLicense() bool {
+	if h.IsTrialLicense() {
+		return false
+	}
 	if (h.Spec.Basic == nil && h.Spec.SecretRef != nil) || (h.Spec.Basic != nil && !*h.Spec.Basic) {
 		return false
 	}
 
 	return true
 }
+
+// IsTrialLicense return true if is trial license
+func (h *License) IsTrialLicense() bool {
+	return h.Spec.Trial != nil && *h.Spec.Trial
+}
+
+// GetExpirationWarningDays return the number of days before the license expire to set the degraded condition
+// Default to 30
+func (h *License) GetExpirationWarningDays() int {
+	if h.Spec.ExpirationWarningDays == nil {
+		return 30
+	}
+
+	return *h.Spec.ExpirationWarningDays
+}
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *License) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *License) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *License) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
@@ -105,4 +105,60 @@ func TestIsBasicLicense(t *testing.T) {
 		},
 	}
 	assert.False(t, o.IsBasicLicense())
+
+	// When trial license is set
+	o = &License{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: LicenseSpec{
+			Trial: ptr.To[bool](true),
+		},
+	}
+	assert.False(t, o.IsBasicLicense())
+}
+
+func TestIsTrialLicense(t *testing.T) {
+	var o *License
+
+	// With default parameters
+	o = &License{
+		Spec: LicenseSpec{},
+	}
+	assert.False(t, o.IsTrialLicense())
+
+	// When trial license is set to true
+	o = &License{
+		Spec: LicenseSpec{
+			Trial: ptr.To[bool](true),
+		},
+	}
+	assert.True(t, o.IsTrialLicense())
+
+	// When trial license is set to false
+	o = &License{
+		Spec: LicenseSpec{
+			Trial: ptr.To[bool](false),
+		},
+	}
+	assert.False(t, o.IsTrialLicense())
+}
+
+func TestLicenseGetExpirationWarningDays(t *testing.T) {
+	var o *License
+
+	// With default parameters
+	o = &License{
+		Spec: LicenseSpec{},
+	}
+	assert.Equal(t, 30, o.GetExpirationWarningDays())
+
+	// When set
+	o = &License{
+		Spec: LicenseSpec{
+			ExpirationWarningDays: ptr.To(60),
+		},
+	}
+	assert.Equal(t, 60, o.GetExpirationWarningDays())
 }
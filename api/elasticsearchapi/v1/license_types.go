@@ -33,6 +33,14 @@ type LicenseSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
 
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
 	// SecretName is the secret that contain the license
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
@@ -43,6 +51,19 @@ type LicenseSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Basic *bool `json:"isBasic,omitempty"`
+
+	// Trial permit to start a trial license of 30 days
+	// It can be started only one time per major version of Elasticsearch
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Trial *bool `json:"isTrial,omitempty"`
+
+	// ExpirationWarningDays is the number of days before the license expire to set the degraded condition
+	// Default to 30
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:default=30
+	ExpirationWarningDays *int `json:"expirationWarningDays,omitempty"`
 }
 
 // LicenseStatus defines the observed state of License
@@ -56,6 +77,11 @@ type LicenseStatus struct {
 	ExpireAt string `json:"expireAt,omitempty"`
 
 	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -66,6 +92,7 @@ type LicenseStatus struct {
 // +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
 // +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".status.licenseType"
 // +kubebuilder:printcolumn:name="expireAt",type="string",JSONPath=".status.expireAt"
+// +kubebuilder:printcolumn:name="Degraded",type="string",JSONPath=".status.conditions[?(@.type=='Degraded')].status",description="Is expiring"
 // +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
 // +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
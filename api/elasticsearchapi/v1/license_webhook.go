@@ -23,6 +23,7 @@ import (
 
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -56,7 +57,14 @@ func SetupLicenseWebhookWithManager(logger *logrus.Entry) controller.WebhookRegi
 var _ webhook.CustomValidator = &licenseValidator{}
 
 func (r *licenseValidator) validateBasicOrLicense(obj *License) *field.Error {
-	if obj.IsBasicLicense() {
+	if obj.IsTrialLicense() {
+		if obj.Spec.SecretRef != nil {
+			return field.Forbidden(field.NewPath("spec").Child("secretRef"), "When you set field 'spec.isTrial' to true, you can't set field 'spec.secretRef'")
+		}
+		if obj.Spec.Basic != nil && *obj.Spec.Basic {
+			return field.Forbidden(field.NewPath("spec").Child("isBasic"), "When you set field 'spec.isTrial' to true, you can't set field 'spec.isBasic' to true")
+		}
+	} else if obj.IsBasicLicense() {
 		if obj.Spec.SecretRef != nil {
 			return field.Forbidden(field.NewPath("spec").Child("secretRef"), "When you set field 'spec.isBasic' to true, you can't set field 'spec.secretRef'")
 		}
@@ -107,6 +115,9 @@ func (r *licenseValidator) ValidateCreate(ctx context.Context, obj runtime.Objec
 	if err := licenseObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, licenseObj, licenseObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateBasicOrLicense(licenseObj); err != nil {
 		allErrs = append(allErrs, err)
@@ -139,6 +150,9 @@ func (r *licenseValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Ob
 	if err := licenseObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, licenseObj, licenseObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := validateImmutableName(licenseObj, oldO); err != nil {
 		allErrs = append(allErrs, err)
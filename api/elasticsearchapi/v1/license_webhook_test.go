@@ -142,4 +142,62 @@ func (t *TestSuite) TestSetupLicenseWebhook() {
 	}
 	err = t.k8sClient.Create(context.Background(), o)
 	assert.Error(t.T(), err)
+
+	// Need failed when specify trial license and provide secret
+	o = &License{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook6",
+			Namespace: "default",
+		},
+		Spec: LicenseSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test4.local"},
+				},
+			},
+			Trial: ptr.To(true),
+			SecretRef: &v1.LocalObjectReference{
+				Name: "test",
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when specify trial and basic license
+	o = &License{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook6",
+			Namespace: "default",
+		},
+		Spec: LicenseSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test4.local"},
+				},
+			},
+			Trial: ptr.To(true),
+			Basic: ptr.To(true),
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need succeed when specify only trial license
+	o = &License{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook6",
+			Namespace: "default",
+		},
+		Spec: LicenseSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test4.local"},
+				},
+			},
+			Trial: ptr.To(true),
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
 }
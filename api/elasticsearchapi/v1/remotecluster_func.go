@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
+
+// GetStatus return the status object
+func (o *RemoteCluster) GetStatus() object.RemoteObjectStatus {
+	return &o.Status
+}
+
+// GetExternalName return the remote cluster alias
+// If name is empty, it use the ressource name
+func (o *RemoteCluster) GetExternalName() string {
+	if o.Spec.Name == "" {
+		return o.Name
+	}
+
+	return o.Spec.Name
+}
+
+// GetMode return the connection mode
+// Default to sniff
+func (o *RemoteCluster) GetMode() RemoteClusterMode {
+	if o.Spec.Mode == "" {
+		return RemoteClusterModeSniff
+	}
+
+	return o.Spec.Mode
+}
+
+// GetSecurityMode return the security model
+// Default to Certificate
+func (o *RemoteCluster) GetSecurityMode() RemoteClusterSecurityMode {
+	if o.Spec.Security == nil || o.Spec.Security.Mode == "" {
+		return RemoteClusterSecurityModeCertificate
+	}
+
+	return o.Spec.Security.Mode
+}
+
+// IsManagedRemote return true if the remote cluster is deployed by operator
+func (o *RemoteCluster) IsManagedRemote() bool {
+	return o.Spec.Remote.Managed != nil && o.Spec.Remote.Managed.Name != ""
+}
+
+// IsManagedApiKey return true if the operator need to create the cross-cluster API key on remote cluster
+func (o *RemoteCluster) IsManagedApiKey() bool {
+	return o.GetSecurityMode() == RemoteClusterSecurityModeApiKey && o.Spec.Security.ApiKeySecretRef == nil && o.Spec.Security.Access != nil
+}
+
+// GetRemoteElasticsearchRef return the Elasticsearch ref of the managed remote cluster
+func (o *RemoteCluster) GetRemoteElasticsearchRef() shared.ElasticsearchRef {
+	return shared.ElasticsearchRef{
+		ManagedElasticsearchRef: o.Spec.Remote.Managed,
+	}
+}
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *RemoteCluster) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *RemoteCluster) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *RemoteCluster) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *RemoteCluster) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *RemoteCluster) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *RemoteCluster) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRemoteClusterGetStatus(t *testing.T) {
+	status := RemoteClusterStatus{
+		DefaultRemoteObjectStatus: remote.DefaultRemoteObjectStatus{
+			LastAppliedConfiguration: "test",
+		},
+	}
+	o := &RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Status: status,
+	}
+
+	assert.Equal(t, &status, o.GetStatus())
+}
+
+func TestRemoteClusterExternalName(t *testing.T) {
+	var o *RemoteCluster
+
+	// When name is set
+	o = &RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: RemoteClusterSpec{
+			Name: "test2",
+		},
+	}
+
+	assert.Equal(t, "test2", o.GetExternalName())
+
+	// When name isn't set
+	o = &RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: RemoteClusterSpec{},
+	}
+
+	assert.Equal(t, "test", o.GetExternalName())
+}
+
+func TestRemoteClusterGetMode(t *testing.T) {
+	o := &RemoteCluster{}
+	assert.Equal(t, RemoteClusterModeSniff, o.GetMode())
+
+	o.Spec.Mode = RemoteClusterModeProxy
+	assert.Equal(t, RemoteClusterModeProxy, o.GetMode())
+}
+
+func TestRemoteClusterGetSecurityMode(t *testing.T) {
+	o := &RemoteCluster{}
+	assert.Equal(t, RemoteClusterSecurityModeCertificate, o.GetSecurityMode())
+
+	o.Spec.Security = &RemoteClusterSecurity{}
+	assert.Equal(t, RemoteClusterSecurityModeCertificate, o.GetSecurityMode())
+
+	o.Spec.Security.Mode = RemoteClusterSecurityModeApiKey
+	assert.Equal(t, RemoteClusterSecurityModeApiKey, o.GetSecurityMode())
+}
+
+func TestRemoteClusterIsManagedApiKey(t *testing.T) {
+	var o *RemoteCluster
+
+	// When certificate mode
+	o = &RemoteCluster{}
+	assert.False(t, o.IsManagedApiKey())
+
+	// When API key is provided
+	o = &RemoteCluster{
+		Spec: RemoteClusterSpec{
+			Security: &RemoteClusterSecurity{
+				Mode: RemoteClusterSecurityModeApiKey,
+				ApiKeySecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: "test",
+					},
+					Key: "apiKey",
+				},
+			},
+		},
+	}
+	assert.False(t, o.IsManagedApiKey())
+
+	// When API key is created by operator
+	o = &RemoteCluster{
+		Spec: RemoteClusterSpec{
+			Security: &RemoteClusterSecurity{
+				Mode: RemoteClusterSecurityModeApiKey,
+				Access: &RemoteClusterApiKeyAccess{
+					Search: []RemoteClusterApiKeyAccessIndices{
+						{
+							Names: []string{"logs-*"},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, o.IsManagedApiKey())
+}
+
+func TestRemoteClusterIsManagedRemote(t *testing.T) {
+	o := &RemoteCluster{
+		Spec: RemoteClusterSpec{
+			Remote: RemoteClusterTarget{
+				Seeds: []string{"es.remote.local:9300"},
+			},
+		},
+	}
+	assert.False(t, o.IsManagedRemote())
+
+	o.Spec.Remote = RemoteClusterTarget{
+		Managed: &shared.ElasticsearchManagedRef{
+			Name:      "remote",
+			Namespace: "other",
+		},
+	}
+	assert.True(t, o.IsManagedRemote())
+	assert.Equal(t, shared.ElasticsearchRef{ManagedElasticsearchRef: o.Spec.Remote.Managed}, o.GetRemoteElasticsearchRef())
+}
@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupRemoteClusterIndexer() {
+	// Add RemoteCluster to force indexer execution
+
+	remoteCluster := &RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: RemoteClusterSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Remote: RemoteClusterTarget{
+				Seeds: []string{"es.remote.local:9300"},
+			},
+		},
+	}
+
+	err := t.k8sClient.Create(context.Background(), remoteCluster)
+	assert.NoError(t.T(), err)
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// RemoteClusterSpec defines the desired state of RemoteCluster
+// +k8s:openapi-gen=true
+type RemoteClusterSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ElasticsearchRef is the Elasticsearch ref to connect on.
+	// It's the local cluster where the remote cluster is configured
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
+
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// Name is the remote cluster alias, used as `cluster.remote.<alias>`
+	// If empty, it use the ressource name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Mode is the connection mode to the remote cluster
+	// Default to sniff
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=sniff
+	// +kubebuilder:validation:Enum=sniff;proxy
+	// +optional
+	Mode RemoteClusterMode `json:"mode,omitempty"`
+
+	// Remote is the remote cluster to connect on
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Remote RemoteClusterTarget `json:"remote"`
+
+	// SkipUnavailable permit to skip the remote cluster when it's not available on cross-cluster search
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	SkipUnavailable *bool `json:"skipUnavailable,omitempty"`
+
+	// NodeConnections is the number of gateway nodes to connect to on sniff mode
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	NodeConnections *int64 `json:"nodeConnections,omitempty"`
+
+	// ProxySocketConnections is the number of socket connections to open on proxy mode
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ProxySocketConnections *int64 `json:"proxySocketConnections,omitempty"`
+
+	// Security is the security model used to connect on remote cluster
+	// Default, it use the certificate based security
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Security *RemoteClusterSecurity `json:"security,omitempty"`
+}
+
+// RemoteClusterTarget is the remote cluster to connect on
+// You need to set the managed cluster or the addresses of the external cluster
+type RemoteClusterTarget struct {
+	// Managed is the remote Elasticsearch cluster deployed by operator
+	// The operator compute the addresses and exchange the transport CA certificates between the two clusters
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Managed *shared.ElasticsearchManagedRef `json:"managed,omitempty"`
+
+	// Seeds is the list of the transport addresses of the external cluster, used on sniff mode
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Seeds []string `json:"seeds,omitempty"`
+
+	// ProxyAddress is the transport address of the external cluster, used on proxy mode
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ProxyAddress string `json:"proxyAddress,omitempty"`
+
+	// ServerName is the server name sent on TLS SNI on proxy mode
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// RemoteClusterSecurity is the security model used to connect on remote cluster
+type RemoteClusterSecurity struct {
+	// Mode is the security model
+	// Certificate use the TLS certificate of transport layer, the two clusters need to trust the CA of each other
+	// ApiKey use cross-cluster API key, it need Elasticsearch 8.x and the remote cluster server enabled on remote cluster
+	// Default to Certificate
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Certificate
+	// +kubebuilder:validation:Enum=Certificate;ApiKey
+	// +optional
+	Mode RemoteClusterSecurityMode `json:"mode,omitempty"`
+
+	// ApiKeySecretRef is the secret that store the encoded cross-cluster API key
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ApiKeySecretRef *corev1.SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+
+	// Access is the access granted to the cross-cluster API key created by the operator on the managed remote cluster
+	// It's used when ApiKeySecretRef is not set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Access *RemoteClusterApiKeyAccess `json:"access,omitempty"`
+}
+
+// RemoteClusterApiKeyAccess is the access granted to the cross-cluster API key
+type RemoteClusterApiKeyAccess struct {
+	// Search is the indices allowed for cross-cluster search
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Search []RemoteClusterApiKeyAccessIndices `json:"search,omitempty"`
+
+	// Replication is the indices allowed for cross-cluster replication
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Replication []RemoteClusterApiKeyAccessIndices `json:"replication,omitempty"`
+}
+
+// RemoteClusterApiKeyAccessIndices is the indices allowed by the cross-cluster API key
+type RemoteClusterApiKeyAccessIndices struct {
+	// Names is the list of indices or patterns
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Names []string `json:"names"`
+}
+
+// RemoteClusterMode is the connection mode to the remote cluster
+type RemoteClusterMode string
+
+const (
+	// RemoteClusterModeSniff connect on seed nodes, then on the gateway nodes discovered
+	RemoteClusterModeSniff RemoteClusterMode = "sniff"
+
+	// RemoteClusterModeProxy connect on a single address, like load balancer
+	RemoteClusterModeProxy RemoteClusterMode = "proxy"
+)
+
+// RemoteClusterSecurityMode is the security model used to connect on remote cluster
+type RemoteClusterSecurityMode string
+
+const (
+	// RemoteClusterSecurityModeCertificate use the TLS certificate of transport layer
+	RemoteClusterSecurityModeCertificate RemoteClusterSecurityMode = "Certificate"
+
+	// RemoteClusterSecurityModeApiKey use cross-cluster API key
+	RemoteClusterSecurityModeApiKey RemoteClusterSecurityMode = "ApiKey"
+)
+
+// RemoteClusterStatus defines the observed state of RemoteCluster
+type RemoteClusterStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// Connected is true when the local cluster is connected on remote cluster
+	// It's read from `_remote/info`
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Connected bool `json:"connected"`
+
+	// NumNodesConnected is the number of remote nodes connected on sniff mode
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	NumNodesConnected int64 `json:"numNodesConnected,omitempty"`
+
+	// NumProxySocketsConnected is the number of socket connected on proxy mode
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	NumProxySocketsConnected int64 `json:"numProxySocketsConnected,omitempty"`
+
+	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// RemoteCluster is the Schema for the remoteclusters API
+// +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+// +kubebuilder:printcolumn:name="Connected",type="boolean",JSONPath=".status.connected"
+// +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RemoteCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteClusterSpec   `json:"spec,omitempty"`
+	Status RemoteClusterStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RemoteClusterList contains a list of RemoteCluster
+type RemoteClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RemoteCluster{}, &RemoteClusterList{})
+}
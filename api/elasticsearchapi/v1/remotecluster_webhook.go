@@ -0,0 +1,234 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type remoteClusterValidator struct {
+	logger *logrus.Entry
+	client client.Client
+}
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func SetupRemoteClusterWebhookWithManager(logger *logrus.Entry) controller.WebhookRegister {
+	return func(mgr ctrl.Manager, client client.Client) error {
+		return ctrl.NewWebhookManagedBy(mgr).
+			For(&RemoteCluster{}).
+			WithValidator(&remoteClusterValidator{
+				logger: logger.WithField("webhook", "remoteClusterValidator"),
+				client: client,
+			}).
+			Complete()
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-elasticsearchapi-k8s-webcenter-fr-v1-remotecluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=elasticsearchapi.k8s.webcenter.fr,resources=remoteclusters,verbs=create;update,versions=v1,name=remotecluster.elasticsearchapi.k8s.webcenter.fr,admissionReviewVersions=v1,timeoutSeconds=30
+
+var _ webhook.CustomValidator = &remoteClusterValidator{}
+
+func (r *remoteClusterValidator) validateResourceUnicity(obj *RemoteCluster) *field.Error {
+	// Check if resource already exist with same name on some remote cluster target
+	listObjects := &RemoteClusterList{}
+	fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.externalName=%s,spec.targetCluster=%s", obj.GetExternalName(), obj.Spec.ElasticsearchRef.GetTargetCluster(obj.Namespace)))
+	if err := r.client.List(context.Background(), listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
+		panic(err)
+	}
+	if len(listObjects.Items) > 0 {
+		isError := false
+		existingResources := make([]string, 0, len(listObjects.Items))
+		for _, ag := range listObjects.Items {
+			// exclude themself
+			if ag.UID != obj.UID {
+				existingResources = append(existingResources, fmt.Sprintf("'%s/%s'", ag.Namespace, ag.Name))
+				isError = true
+			}
+		}
+		if isError {
+			return field.Duplicate(field.NewPath("spec").Child("name"), fmt.Sprintf("There are some same resource that already target the same Elasticsearch cluster with the same name: %s", strings.Join(existingResources, ", ")))
+		}
+	}
+
+	return nil
+}
+
+// validateRemote check the remote cluster is set according to the connection mode
+func (r *remoteClusterValidator) validateRemote(ctx context.Context, obj *RemoteCluster) (allErrs field.ErrorList) {
+	path := field.NewPath("spec").Child("remote")
+
+	if obj.IsManagedRemote() {
+		if len(obj.Spec.Remote.Seeds) > 0 || obj.Spec.Remote.ProxyAddress != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("managed"), "You can't set seeds or proxyAddress with managed remote cluster"))
+		}
+
+		// The remote cluster need to allow the reference too
+		if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, obj, obj.GetRemoteElasticsearchRef()); err != nil {
+			err.Field = path.Child("managed", "namespace").String()
+			allErrs = append(allErrs, err)
+		}
+	} else {
+		switch obj.GetMode() {
+		case RemoteClusterModeSniff:
+			if len(obj.Spec.Remote.Seeds) == 0 {
+				allErrs = append(allErrs, field.Required(path.Child("seeds"), "You need to set seeds or managed remote cluster on sniff mode"))
+			}
+			if obj.Spec.Remote.ProxyAddress != "" {
+				allErrs = append(allErrs, field.Forbidden(path.Child("proxyAddress"), "You can't set proxyAddress on sniff mode"))
+			}
+		case RemoteClusterModeProxy:
+			if obj.Spec.Remote.ProxyAddress == "" {
+				allErrs = append(allErrs, field.Required(path.Child("proxyAddress"), "You need to set proxyAddress or managed remote cluster on proxy mode"))
+			}
+			if len(obj.Spec.Remote.Seeds) > 0 {
+				allErrs = append(allErrs, field.Forbidden(path.Child("seeds"), "You can't set seeds on proxy mode"))
+			}
+		}
+	}
+
+	if obj.GetMode() == RemoteClusterModeSniff && obj.Spec.ProxySocketConnections != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("proxySocketConnections"), "You can't set proxySocketConnections on sniff mode"))
+	}
+	if obj.GetMode() == RemoteClusterModeProxy && obj.Spec.NodeConnections != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("nodeConnections"), "You can't set nodeConnections on proxy mode"))
+	}
+
+	return allErrs
+}
+
+// validateSecurity check the API key settings according to the security mode
+func (r *remoteClusterValidator) validateSecurity(obj *RemoteCluster) *field.Error {
+	if obj.Spec.Security == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("security")
+
+	if obj.GetSecurityMode() == RemoteClusterSecurityModeCertificate {
+		if obj.Spec.Security.ApiKeySecretRef != nil || obj.Spec.Security.Access != nil {
+			return field.Forbidden(path.Child("mode"), "You can't set apiKeySecretRef or access with Certificate security mode")
+		}
+		return nil
+	}
+
+	if obj.Spec.Security.ApiKeySecretRef != nil && obj.Spec.Security.Access != nil {
+		return field.Forbidden(path.Child("access"), "You can't set access and apiKeySecretRef at the same time")
+	}
+	if obj.Spec.Security.ApiKeySecretRef == nil && obj.Spec.Security.Access == nil {
+		return field.Required(path.Child("apiKeySecretRef"), "You need to set apiKeySecretRef or access with ApiKey security mode")
+	}
+	if obj.Spec.Security.Access != nil && !obj.IsManagedRemote() {
+		return field.Forbidden(path.Child("access"), "The operator can only create the API key on managed remote cluster, use apiKeySecretRef with external remote cluster")
+	}
+
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *remoteClusterValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	remoteClusterObj, ok := obj.(*RemoteCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a RemoteCluster object but got %T", obj)
+	}
+	r.logger.Debugf("validate create %s/%s", remoteClusterObj.GetNamespace(), remoteClusterObj.GetName())
+
+	if err := remoteClusterObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, remoteClusterObj, remoteClusterObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateResourceUnicity(remoteClusterObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, r.validateRemote(ctx, remoteClusterObj)...)
+
+	if err := r.validateSecurity(remoteClusterObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			remoteClusterObj.GroupVersionKind().GroupKind(),
+			remoteClusterObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *remoteClusterValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	oldO := oldObj.(*RemoteCluster)
+
+	remoteClusterObj, ok := newObj.(*RemoteCluster)
+	if !ok {
+		return nil, fmt.Errorf("expected a RemoteCluster object but got %T", newObj)
+	}
+	r.logger.Debugf("validate update %s/%s", remoteClusterObj.Namespace, remoteClusterObj.Name)
+
+	if err := remoteClusterObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, remoteClusterObj, remoteClusterObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateImmutableName(remoteClusterObj, oldO); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateResourceUnicity(remoteClusterObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, r.validateRemote(ctx, remoteClusterObj)...)
+
+	if err := r.validateSecurity(remoteClusterObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			remoteClusterObj.GroupVersionKind().GroupKind(),
+			remoteClusterObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *remoteClusterValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
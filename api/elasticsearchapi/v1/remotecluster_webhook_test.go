@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupRemoteClusterWebhook() {
+	var (
+		o   *RemoteCluster
+		err error
+	)
+
+	// Need failed when create same resource by external name on same managed cluster
+	// Check we can update it
+	o = &RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook",
+			Namespace: "default",
+		},
+		Spec: RemoteClusterSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name: "webhook",
+			Remote: RemoteClusterTarget{
+				Seeds: []string{"es.remote.local:9300"},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	o = &RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook2",
+			Namespace: "default",
+		},
+		Spec: RemoteClusterSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name: "webhook",
+			Remote: RemoteClusterTarget{
+				Seeds: []string{"es.remote.local:9300"},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when sniff mode without seeds
+	o = &RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook3",
+			Namespace: "default",
+		},
+		Spec: RemoteClusterSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Remote: RemoteClusterTarget{
+				ProxyAddress: "es.remote.local:9300",
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need success when proxy mode with proxy address
+	o.Spec.Mode = RemoteClusterModeProxy
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	// Need failed when set managed and seeds
+	o = &RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook4",
+			Namespace: "default",
+		},
+		Spec: RemoteClusterSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Remote: RemoteClusterTarget{
+				Managed: &shared.ElasticsearchManagedRef{
+					Name: "remote",
+				},
+				Seeds: []string{"es.remote.local:9300"},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when API key mode without API key
+	o = &RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook5",
+			Namespace: "default",
+		},
+		Spec: RemoteClusterSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Remote: RemoteClusterTarget{
+				Seeds: []string{"es.remote.local:9443"},
+			},
+			Security: &RemoteClusterSecurity{
+				Mode: RemoteClusterSecurityModeApiKey,
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when operator need to create API key on external cluster
+	o.Spec.Security.Access = &RemoteClusterApiKeyAccess{
+		Search: []RemoteClusterApiKeyAccessIndices{
+			{
+				Names: []string{"logs-*"},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need success when API key is provided
+	o.Spec.Security.Access = nil
+	o.Spec.Security.ApiKeySecretRef = &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: "remote-api-key",
+		},
+		Key: "apiKey",
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+}
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// ResourceSetLabel is the label set on resources created by a resource set, with the resource set name as value
+	ResourceSetLabel = ElasticsearchApiAnnotationKey + "/resourceset"
+
+	// ResourceSetTemplateLabel is the label set on resources created by a resource set, with the template name as value
+	ResourceSetTemplateLabel = ElasticsearchApiAnnotationKey + "/resourceset-template"
+
+	// ResourceSetClusterNameLabel is the label set on resources created by a resource set, with the Elasticsearch cluster name as value
+	ResourceSetClusterNameLabel = ElasticsearchApiAnnotationKey + "/cluster-name"
+
+	// ResourceSetClusterNamespaceLabel is the label set on resources created by a resource set, with the Elasticsearch cluster namespace as value
+	ResourceSetClusterNamespaceLabel = ElasticsearchApiAnnotationKey + "/cluster-namespace"
+)
+
+// GetStatus implement the object.MultiPhaseObject
+func (h *ResourceSet) GetStatus() object.MultiPhaseObjectStatus {
+	return &h.Status
+}
+
+// GetResourceName return the name of the resource created from template for the Elasticsearch cluster
+// The cluster namespace is added when the cluster is not on the same namespace to avoid conflict
+// The name is truncated with a hash suffix when it's too long to be a valid resource name
+func (h *ResourceSet) GetResourceName(template ResourceSetTemplate, clusterName string, clusterNamespace string) string {
+	var name string
+	if clusterNamespace == "" || clusterNamespace == h.Namespace {
+		name = fmt.Sprintf("%s-%s-%s", h.Name, template.Name, clusterName)
+	} else {
+		name = fmt.Sprintf("%s-%s-%s-%s", h.Name, template.Name, clusterNamespace, clusterName)
+	}
+
+	if len(name) <= validation.DNS1123SubdomainMaxLength {
+		return name
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:8]
+	return fmt.Sprintf("%s-%s", strings.TrimRight(name[:validation.DNS1123SubdomainMaxLength-len(hash)-1], "-."), hash)
+}
+
+// GetResourceSetLabelValue return the value to use on resource set labels
+// The value is truncated with a hash suffix when it's too long to be a valid label value
+func GetResourceSetLabelValue(value string) string {
+	if len(value) <= validation.LabelValueMaxLength {
+		return value
+	}
+
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(value)))[:8]
+	return fmt.Sprintf("%s-%s", strings.TrimRight(value[:validation.LabelValueMaxLength-len(hash)-1], "-._"), hash)
+}
+
+// GetTemplates return the templates of the given kind
+func (h *ResourceSet) GetTemplates(kind string) (templates []ResourceSetTemplate) {
+	templates = make([]ResourceSetTemplate, 0)
+	for _, template := range h.Spec.Templates {
+		if template.Kind == kind {
+			templates = append(templates, template)
+		}
+	}
+
+	return templates
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/multiphase"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestResourceSetGetStatus(t *testing.T) {
+	status := ResourceSetStatus{
+		DefaultMultiPhaseObjectStatus: multiphase.DefaultMultiPhaseObjectStatus{
+			PhaseName: "test",
+		},
+	}
+	o := &ResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Status: status,
+	}
+
+	assert.Equal(t, &status, o.GetStatus())
+}
+
+func TestResourceSetGetResourceName(t *testing.T) {
+	o := &ResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+	}
+	template := ResourceSetTemplate{
+		Kind: "Role",
+		Name: "admin",
+	}
+
+	// When cluster is on same namespace
+	assert.Equal(t, "test-admin-es", o.GetResourceName(template, "es", "default"))
+
+	// When cluster is on other namespace
+	assert.Equal(t, "test-admin-prod-es", o.GetResourceName(template, "es", "prod"))
+
+	// When name is too long
+	o.Name = strings.Repeat("a", 200)
+	name := o.GetResourceName(template, "es", strings.Repeat("b", 63))
+	assert.Len(t, name, 253)
+	assert.NotEqual(t, name, o.GetResourceName(template, "es2", strings.Repeat("b", 63)))
+	assert.Empty(t, validation.IsDNS1123Subdomain(name))
+}
+
+func TestGetResourceSetLabelValue(t *testing.T) {
+	// When value is short
+	assert.Equal(t, "test", GetResourceSetLabelValue("test"))
+
+	// When value is too long
+	value := GetResourceSetLabelValue(strings.Repeat("a", 200))
+	assert.Len(t, value, validation.LabelValueMaxLength)
+	assert.NotEqual(t, value, GetResourceSetLabelValue(strings.Repeat("a", 201)))
+	assert.Empty(t, validation.IsValidLabelValue(value))
+
+	// When the truncated value end with a non alphanumeric character
+	value = GetResourceSetLabelValue(strings.Repeat("a", 53) + "-.b" + strings.Repeat("c", 50))
+	assert.Empty(t, validation.IsValidLabelValue(value))
+}
+
+func TestResourceSetGetTemplates(t *testing.T) {
+	o := &ResourceSet{
+		Spec: ResourceSetSpec{
+			Templates: []ResourceSetTemplate{
+				{
+					Kind: "Role",
+					Name: "admin",
+				},
+				{
+					Kind: "User",
+					Name: "admin",
+				},
+				{
+					Kind: "Role",
+					Name: "reader",
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, []ResourceSetTemplate{{Kind: "Role", Name: "admin"}, {Kind: "Role", Name: "reader"}}, o.GetTemplates("Role"))
+	assert.Empty(t, o.GetTemplates("Watch"))
+}
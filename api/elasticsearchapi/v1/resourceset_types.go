@@ -0,0 +1,152 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/multiphase"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// ResourceSetSpec defines the desired state of ResourceSet
+// +k8s:openapi-gen=true
+type ResourceSetSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ElasticsearchSelector select the Elasticsearch clusters managed by operator where to apply the resources
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ElasticsearchSelector metav1.LabelSelector `json:"elasticsearchSelector"`
+
+	// NamespaceSelector select the namespaces where to look for the Elasticsearch clusters
+	// Default to the namespace of the resource set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Templates is the list of resources to apply on each selected Elasticsearch cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +listType=map
+	// +listMapKey=kind
+	// +listMapKey=name
+	Templates []ResourceSetTemplate `json:"templates"`
+}
+
+// ResourceSetTemplate is a resource to apply on each selected Elasticsearch cluster
+type ResourceSetTemplate struct {
+	// Kind is the kind of resource to create
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Enum=ComponentTemplate;IndexLifecyclePolicy;IndexTemplate;Role;RoleMapping;SnapshotLifecyclePolicy;SnapshotRepository;StoredScript;Transform;User;Watch
+	Kind string `json:"kind"`
+
+	// Name is the template name. It's used to compute the resource names and as remote object name when not set on spec
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Labels is the extra labels to set on the resources
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations is the extra annotations to set on the resources, like the dry-run annotation
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Spec is the resource spec, without the elasticsearchRef that is set for each selected cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec *apis.MapAny `json:"spec"`
+}
+
+// ResourceSetStatus defines the observed state of ResourceSet
+type ResourceSetStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	multiphase.DefaultMultiPhaseObjectStatus `json:",inline"`
+
+	// Clusters is the sync status of resources per selected Elasticsearch cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Clusters []ResourceSetClusterStatus `json:"clusters,omitempty"`
+}
+
+// ResourceSetClusterStatus is the sync status of resources on a selected Elasticsearch cluster
+type ResourceSetClusterStatus struct {
+	// Name is the Elasticsearch cluster name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Namespace is the Elasticsearch cluster namespace
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Namespace string `json:"namespace"`
+
+	// Resources is the number of resources applied on the cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Resources int `json:"resources"`
+
+	// SyncedResources is the number of resources in sync with the cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SyncedResources int `json:"syncedResources"`
+
+	// IsSync is true when all resources are in sync with the cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	IsSync bool `json:"isSync"`
+
+	// ResourcesOnError is the list of resources on error, on format `<kind>/<name>`
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ResourcesOnError []string `json:"resourcesOnError,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// ResourceSet is the Schema for the resourcesets API
+// It apply the same resources on every Elasticsearch cluster managed by operator that match the selector
+// +operator-sdk:csv:customresourcedefinitions:resources={{ComponentTemplate,elasticsearchapi.k8s.webcenter.fr/v1},{IndexLifecyclePolicy,elasticsearchapi.k8s.webcenter.fr/v1},{IndexTemplate,elasticsearchapi.k8s.webcenter.fr/v1},{Role,elasticsearchapi.k8s.webcenter.fr/v1},{RoleMapping,elasticsearchapi.k8s.webcenter.fr/v1},{SnapshotLifecyclePolicy,elasticsearchapi.k8s.webcenter.fr/v1},{SnapshotRepository,elasticsearchapi.k8s.webcenter.fr/v1},{StoredScript,elasticsearchapi.k8s.webcenter.fr/v1},{Transform,elasticsearchapi.k8s.webcenter.fr/v1},{User,elasticsearchapi.k8s.webcenter.fr/v1},{Watch,elasticsearchapi.k8s.webcenter.fr/v1}}
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ResourceSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourceSetSpec   `json:"spec,omitempty"`
+	Status ResourceSetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ResourceSetList contains a list of ResourceSet
+type ResourceSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourceSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ResourceSet{}, &ResourceSetList{})
+}
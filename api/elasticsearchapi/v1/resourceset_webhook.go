@@ -0,0 +1,151 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type resourceSetValidator struct {
+	logger *logrus.Entry
+}
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func SetupResourceSetWebhookWithManager(logger *logrus.Entry) controller.WebhookRegister {
+	return func(mgr ctrl.Manager, client client.Client) error {
+		return ctrl.NewWebhookManagedBy(mgr).
+			For(&ResourceSet{}).
+			WithValidator(&resourceSetValidator{
+				logger: logger.WithField("webhook", "resourceSetValidator"),
+			}).
+			Complete()
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-elasticsearchapi-k8s-webcenter-fr-v1-resourceset,mutating=false,failurePolicy=fail,sideEffects=None,groups=elasticsearchapi.k8s.webcenter.fr,resources=resourcesets,verbs=create;update,versions=v1,name=resourceset.elasticsearchapi.k8s.webcenter.fr,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &resourceSetValidator{}
+
+// validateSelectors check the label selectors can be converted
+func (r *resourceSetValidator) validateSelectors(obj *ResourceSet) (allErrs field.ErrorList) {
+	if _, err := metav1.LabelSelectorAsSelector(&obj.Spec.ElasticsearchSelector); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("elasticsearchSelector"), obj.Spec.ElasticsearchSelector, err.Error()))
+	}
+	if obj.Spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(obj.Spec.NamespaceSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("namespaceSelector"), obj.Spec.NamespaceSelector, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateName check the resource set name can be used as label value on the created resources
+func (r *resourceSetValidator) validateName(obj *ResourceSet) (allErrs field.ErrorList) {
+	for _, msg := range validation.IsValidLabelValue(obj.Name) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata").Child("name"), obj.Name, msg))
+	}
+
+	return allErrs
+}
+
+// validateTemplates check the templates are unique and not target a cluster by themself
+func (r *resourceSetValidator) validateTemplates(obj *ResourceSet) (allErrs field.ErrorList) {
+	names := map[string]bool{}
+	for i, template := range obj.Spec.Templates {
+		path := field.NewPath("spec").Child("templates").Index(i)
+		key := fmt.Sprintf("%s/%s", template.Kind, template.Name)
+		if names[key] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), key))
+		}
+		names[key] = true
+
+		if template.Spec == nil || template.Spec.Data == nil {
+			allErrs = append(allErrs, field.Required(path.Child("spec"), "You need to provide the resource spec"))
+			continue
+		}
+		if _, ok := template.Spec.Data["elasticsearchRef"]; ok {
+			allErrs = append(allErrs, field.Forbidden(path.Child("spec").Child("elasticsearchRef"), "The Elasticsearch ref is set for each cluster selected by elasticsearchSelector"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *resourceSetValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	resourceSetObj, ok := obj.(*ResourceSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a ResourceSet object but got %T", obj)
+	}
+	r.logger.Debugf("validate create %s/%s", resourceSetObj.GetNamespace(), resourceSetObj.GetName())
+
+	allErrs = append(allErrs, r.validateName(resourceSetObj)...)
+	allErrs = append(allErrs, r.validateSelectors(resourceSetObj)...)
+	allErrs = append(allErrs, r.validateTemplates(resourceSetObj)...)
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			resourceSetObj.GroupVersionKind().GroupKind(),
+			resourceSetObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *resourceSetValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	resourceSetObj, ok := newObj.(*ResourceSet)
+	if !ok {
+		return nil, fmt.Errorf("expected a ResourceSet object but got %T", newObj)
+	}
+	r.logger.Debugf("validate update %s/%s", resourceSetObj.GetNamespace(), resourceSetObj.GetName())
+
+	allErrs = append(allErrs, r.validateName(resourceSetObj)...)
+	allErrs = append(allErrs, r.validateSelectors(resourceSetObj)...)
+	allErrs = append(allErrs, r.validateTemplates(resourceSetObj)...)
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			resourceSetObj.GroupVersionKind().GroupKind(),
+			resourceSetObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *resourceSetValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
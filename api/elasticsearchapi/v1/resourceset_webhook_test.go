@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupResourceSetWebhook() {
+	var (
+		o   *ResourceSet
+		err error
+	)
+
+	// Need to be ok and we can update it
+	o = &ResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook",
+			Namespace: "default",
+		},
+		Spec: ResourceSetSpec{
+			ElasticsearchSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"env": "prod",
+				},
+			},
+			Templates: []ResourceSetTemplate{
+				{
+					Kind: "Role",
+					Name: "reader",
+					Spec: &apis.MapAny{
+						Data: map[string]any{
+							"cluster": []any{"monitor"},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	// Need failed when template set the Elasticsearch ref
+	o = &ResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook2",
+			Namespace: "default",
+		},
+		Spec: ResourceSetSpec{
+			ElasticsearchSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"env": "prod",
+				},
+			},
+			Templates: []ResourceSetTemplate{
+				{
+					Kind: "Role",
+					Name: "reader",
+					Spec: &apis.MapAny{
+						Data: map[string]any{
+							"elasticsearchRef": map[string]any{
+								"managed": map[string]any{
+									"name": "test",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when selector is invalid
+	o = &ResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook3",
+			Namespace: "default",
+		},
+		Spec: ResourceSetSpec{
+			ElasticsearchSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "env",
+						Operator: metav1.LabelSelectorOpIn,
+					},
+				},
+			},
+			Templates: []ResourceSetTemplate{
+				{
+					Kind: "Role",
+					Name: "reader",
+					Spec: &apis.MapAny{
+						Data: map[string]any{
+							"cluster": []any{"monitor"},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when name is too long to be used as label value
+	o = &ResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.Repeat("a", 64),
+			Namespace: "default",
+		},
+		Spec: ResourceSetSpec{
+			ElasticsearchSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"env": "prod",
+				},
+			},
+			Templates: []ResourceSetTemplate{
+				{
+					Kind: "Role",
+					Name: "reader",
+					Spec: &apis.MapAny{
+						Data: map[string]any{
+							"cluster": []any{"monitor"},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+}
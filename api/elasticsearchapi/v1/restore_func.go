@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+)
+
+// GetStatus return the status object
+func (o *Restore) GetStatus() object.RemoteObjectStatus {
+	return &o.Status
+}
+
+// GetExternalName return the snapshot name to restore
+func (o *Restore) GetExternalName() string {
+	return o.Spec.Snapshot
+}
+
+// GetSnapshotName return the snapshot name to restore
+// It return the resolved snapshot name when spec.snapshot is `latest`
+func (o *Restore) GetSnapshotName() string {
+	if o.Status.Snapshot != "" {
+		return o.Status.Snapshot
+	}
+
+	return o.Spec.Snapshot
+}
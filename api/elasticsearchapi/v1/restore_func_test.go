@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRestoreGetStatus(t *testing.T) {
+	status := RestoreStatus{
+		DefaultRemoteObjectStatus: remote.DefaultRemoteObjectStatus{
+			LastAppliedConfiguration: "test",
+		},
+	}
+	o := &Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Status: status,
+	}
+
+	assert.Equal(t, &status, o.GetStatus())
+}
+
+func TestRestoreExternalName(t *testing.T) {
+	o := &Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: RestoreSpec{
+			Repository: "backup",
+			Snapshot:   "snapshot",
+		},
+	}
+
+	assert.Equal(t, "snapshot", o.GetExternalName())
+}
+
+func TestRestoreGetSnapshotName(t *testing.T) {
+	o := &Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: RestoreSpec{
+			Repository: "backup",
+			Snapshot:   RestoreLatestSnapshot,
+		},
+	}
+
+	// When not yet resolved
+	assert.Equal(t, "latest", o.GetSnapshotName())
+
+	// When resolved
+	o.Status.Snapshot = "snapshot-2"
+	assert.Equal(t, "snapshot-2", o.GetSnapshotName())
+}
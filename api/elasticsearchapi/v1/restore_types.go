@@ -0,0 +1,177 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// RestoreSpec defines the desired state of Restore
+// +k8s:openapi-gen=true
+type RestoreSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ElasticsearchRef is the Elasticsearch ref to connect on.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
+
+	// Repository is the repository that store the snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Repository string `json:"repository"`
+
+	// Snapshot is the snapshot name to restore
+	// Use `latest` to restore the most recent successful snapshot of the repository
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Snapshot string `json:"snapshot"`
+
+	// Indices is the list of data streams and indices to restore
+	// Default it restore all regular data streams and indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+
+	// IgnoreUnavailable ignore the data streams and indices missing on snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IgnoreUnavailable bool `json:"ignoreUnavailable,omitempty"`
+
+	// IncludeGlobalState restore the cluster state
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IncludeGlobalState bool `json:"includeGlobalState,omitempty"`
+
+	// FeatureStates is the list of feature states to restore
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	FeatureStates []string `json:"featureStates,omitempty"`
+
+	// IncludeAliases restore the aliases
+	// Default to true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IncludeAliases *bool `json:"includeAliases,omitempty"`
+
+	// Partial allow to restore indices with unavailable shards on snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Partial bool `json:"partial,omitempty"`
+
+	// RenamePattern is the regex used to rename the restored data streams and indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RenamePattern string `json:"renamePattern,omitempty"`
+
+	// RenameReplacement is the replacement string used with the rename pattern
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RenameReplacement string `json:"renameReplacement,omitempty"`
+
+	// IndexSettings is the index settings to override on restored indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	IndexSettings *apis.MapAny `json:"indexSettings,omitempty"`
+
+	// IgnoreIndexSettings is the list of index settings to not restore
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IgnoreIndexSettings []string `json:"ignoreIndexSettings,omitempty"`
+}
+
+// RestoreStatus defines the observed state of Restore
+type RestoreStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// State is the restore state
+	// It can be IN_PROGRESS, SUCCESS or FAILED
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// Snapshot is the snapshot name restored
+	// It's the resolved snapshot name when spec.snapshot is `latest`
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// StartTime is the time when the restore is started
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time when the restore is completed
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Shards is the shard stats of the restore
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Shards *SnapshotShardsStatus `json:"shards,omitempty"`
+
+	// Indices is the list of restored indices
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+
+	remote.DefaultRemoteObjectStatus `json:",inline"`
+}
+
+const (
+	// RestoreLatestSnapshot is the snapshot name used to restore the most recent successful snapshot
+	RestoreLatestSnapshot = "latest"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// Restore is the Schema for the restores API
+// +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
+// +kubebuilder:printcolumn:name="Snapshot",type="string",JSONPath=".spec.snapshot"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Restore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RestoreSpec   `json:"spec,omitempty"`
+	Status RestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RestoreList contains a list of Restore
+type RestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Restore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Restore{}, &RestoreList{})
+}
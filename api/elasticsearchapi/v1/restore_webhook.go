@@ -0,0 +1,141 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type restoreValidator struct {
+	logger *logrus.Entry
+	client client.Client
+}
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func SetupRestoreWebhookWithManager(logger *logrus.Entry) controller.WebhookRegister {
+	return func(mgr ctrl.Manager, client client.Client) error {
+		return ctrl.NewWebhookManagedBy(mgr).
+			For(&Restore{}).
+			WithValidator(&restoreValidator{
+				logger: logger.WithField("webhook", "restoreValidator"),
+				client: client,
+			}).
+			Complete()
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-elasticsearchapi-k8s-webcenter-fr-v1-restore,mutating=false,failurePolicy=fail,sideEffects=None,groups=elasticsearchapi.k8s.webcenter.fr,resources=restores,verbs=create;update,versions=v1,name=restore.elasticsearchapi.k8s.webcenter.fr,admissionReviewVersions=v1,timeoutSeconds=30
+
+var _ webhook.CustomValidator = &restoreValidator{}
+
+// validateImmutableRestore check the spec is not changed
+// The restore is only run one time
+func (r *restoreValidator) validateImmutableRestore(current, old *Restore) *field.Error {
+	if !equality.Semantic.DeepEqual(current.Spec, old.Spec) {
+		return field.Forbidden(field.NewPath("spec"), "The field 'spec' is immutable, create a new resource to run a new restore")
+	}
+
+	return nil
+}
+
+// validateRename check the rename replacement is set with the rename pattern
+func (r *restoreValidator) validateRename(o *Restore) *field.Error {
+	if o.Spec.RenamePattern != "" && o.Spec.RenameReplacement == "" {
+		return field.Required(field.NewPath("spec").Child("renameReplacement"), "The field 'spec.renameReplacement' is required when 'spec.renamePattern' is set")
+	}
+
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *restoreValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	restoreObj, ok := obj.(*Restore)
+	if !ok {
+		return nil, fmt.Errorf("expected a Restore object but got %T", obj)
+	}
+	r.logger.Debugf("validate create %s/%s", restoreObj.GetNamespace(), restoreObj.GetName())
+
+	if err := restoreObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, restoreObj, restoreObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateRename(restoreObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			restoreObj.GroupVersionKind().GroupKind(),
+			restoreObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *restoreValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	oldO := oldObj.(*Restore)
+
+	restoreObj, ok := newObj.(*Restore)
+	if !ok {
+		return nil, fmt.Errorf("expected a Restore object but got %T", newObj)
+	}
+	r.logger.Debugf("validate update %s/%s", restoreObj.Namespace, restoreObj.Name)
+
+	if err := restoreObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, restoreObj, restoreObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateImmutableRestore(restoreObj, oldO); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			restoreObj.GroupVersionKind().GroupKind(),
+			restoreObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *restoreValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
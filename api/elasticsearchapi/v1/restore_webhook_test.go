@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupRestoreWebhook() {
+	var (
+		o   *Restore
+		err error
+	)
+
+	// Need failed when rename pattern is set without replacement
+	o = &Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook",
+			Namespace: "default",
+		},
+		Spec: RestoreSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Repository:    "backup",
+			Snapshot:      "snapshot",
+			RenamePattern: "(.+)",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	o.Spec.RenameReplacement = "restored-$1"
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	// Need failed when change the spec
+	o.Spec.Snapshot = "snapshot2"
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.Error(t.T(), err)
+}
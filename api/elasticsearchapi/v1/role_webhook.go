@@ -23,6 +23,7 @@ import (
 
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -93,6 +94,9 @@ func (r *roleValidator) ValidateCreate(ctx context.Context, obj runtime.Object)
 	if err := roletObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, roletObj, roletObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(roletObj); err != nil {
 		allErrs = append(allErrs, err)
@@ -121,6 +125,9 @@ func (r *roleValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Objec
 	if err := roletObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, roletObj, roletObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(roletObj); err != nil {
 		allErrs = append(allErrs, err)
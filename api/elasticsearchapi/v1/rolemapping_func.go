@@ -1,6 +1,9 @@
 package v1
 
-import "github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
 
 // GetStatus return the status object
 func (o *RoleMapping) GetStatus() object.RemoteObjectStatus {
@@ -16,3 +19,33 @@ func (o *RoleMapping) GetExternalName() string {
 
 	return o.Spec.Name
 }
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *RoleMapping) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *RoleMapping) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *RoleMapping) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *RoleMapping) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *RoleMapping) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *RoleMapping) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
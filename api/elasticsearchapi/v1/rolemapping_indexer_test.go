@@ -26,9 +26,11 @@ func (t *TestSuite) TestSetupRoleMappingIndexer() {
 			Roles: []string{
 				"superuser",
 			},
-			Rules: &apis.MapAny{
-				Data: map[string]any{
-					"username": "*",
+			Rules: &RoleMappingRule{
+				Field: &apis.MapAny{
+					Data: map[string]any{
+						"username": "*",
+					},
 				},
 			},
 		},
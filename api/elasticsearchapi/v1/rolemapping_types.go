@@ -36,6 +36,23 @@ type RoleMappingSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
 
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
 	// Name is the custom role mapping name
 	// If empty, it use the ressource name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -49,13 +66,20 @@ type RoleMappingSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 
 	// Roles is the list of role to map
+	// You can't use it with RoleTemplates
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	Roles []string `json:"roles"`
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+
+	// RoleTemplates is the list of mustache templates that compute the roles to map
+	// You can't use it with Roles
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RoleTemplates []RoleMappingRoleTemplate `json:"roleTemplates,omitempty"`
 
 	// Rules is the mapping rules
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
-	// +kubebuilder:pruning:PreserveUnknownFields
-	Rules *apis.MapAny `json:"rules"`
+	Rules *RoleMappingRule `json:"rules"`
 
 	// Metadata is the meta data
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -64,12 +88,72 @@ type RoleMappingSpec struct {
 	Metadata *apis.MapAny `json:"metadata,omitempty"`
 }
 
+// RoleMappingRule is a node of the rules tree
+// Each node must have only one of any, all, field or except
+type RoleMappingRule struct {
+	// Any match when at least one of the rules match
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Any []RoleMappingRule `json:"any,omitempty"`
+
+	// All match when all rules match
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	All []RoleMappingRule `json:"all,omitempty"`
+
+	// Field match when the user field match the value
+	// The key must be username, dn, groups, realm.name or metadata.*
+	// The value can be a single value or a list of values
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Field *apis.MapAny `json:"field,omitempty"`
+
+	// Except match when the rule not match
+	// It can only be used as member of all
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Except *RoleMappingRule `json:"except,omitempty"`
+}
+
+// RoleMappingRoleTemplate is a mustache template that compute the roles
+type RoleMappingRoleTemplate struct {
+	// Source is the mustache template
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Source string `json:"source"`
+
+	// Format is the template output format
+	// Use json when the template return an array of roles
+	// Default to string
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:default=string
+	// +kubebuilder:validation:Enum=string;json
+	Format string `json:"format,omitempty"`
+}
+
 // RoleMappingStatus defines the observed state of RoleMapping
 type RoleMappingStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
 	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -18,11 +18,15 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/sirupsen/logrus"
+	"github.com/thoas/go-funk"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -80,6 +84,126 @@ func (r *roleMappingValidator) validateResourceUnicity(obj *RoleMapping) *field.
 	return nil
 }
 
+// roleMappingFieldNames is the user attributes that can be used on field rule
+// The metadata attributes are allowed with the prefix `metadata.`
+var roleMappingFieldNames = []string{"username", "dn", "groups", "realm.name"}
+
+// roleMappingRuleKeys is the allowed keys on each node of rules tree
+var roleMappingRuleKeys = []string{"any", "all", "field", "except"}
+
+func (r *roleMappingValidator) validateRolesOrRoleTemplates(obj *RoleMapping) *field.Error {
+	if len(obj.Spec.Roles) > 0 && len(obj.Spec.RoleTemplates) > 0 {
+		return field.Forbidden(field.NewPath("spec").Child("roleTemplates"), "When you set field 'spec.roles', you can't set field 'spec.roleTemplates'")
+	}
+	if len(obj.Spec.Roles) == 0 && len(obj.Spec.RoleTemplates) == 0 {
+		return field.Required(field.NewPath("spec"), "You need to provide 'spec.roles' or 'spec.roleTemplates'")
+	}
+
+	return nil
+}
+
+// validateRules check recursively the rules tree
+func (r *roleMappingValidator) validateRules(rule *RoleMappingRule, path *field.Path, isAllMember bool) (allErrs field.ErrorList) {
+	allErrs = field.ErrorList{}
+	if rule == nil {
+		return append(allErrs, field.Required(path, "You need to provide a rule"))
+	}
+
+	nbRules := 0
+	if rule.Any != nil {
+		nbRules++
+		for i := range rule.Any {
+			allErrs = append(allErrs, r.validateRules(&rule.Any[i], path.Child("any").Index(i), false)...)
+		}
+	}
+	if rule.All != nil {
+		nbRules++
+		for i := range rule.All {
+			allErrs = append(allErrs, r.validateRules(&rule.All[i], path.Child("all").Index(i), true)...)
+		}
+	}
+	if rule.Except != nil {
+		nbRules++
+		if !isAllMember {
+			allErrs = append(allErrs, field.Forbidden(path.Child("except"), "The rule 'except' can only be used as member of rule 'all'"))
+		}
+		allErrs = append(allErrs, r.validateRules(rule.Except, path.Child("except"), false)...)
+	}
+	if rule.Field != nil {
+		nbRules++
+		if len(rule.Field.Data) != 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("field"), rule.Field.Data, "The rule 'field' must have only one user attribute"))
+		}
+		for name := range rule.Field.Data {
+			if !strings.HasPrefix(name, "metadata.") && !funk.ContainsString(roleMappingFieldNames, name) {
+				allErrs = append(allErrs, field.NotSupported(path.Child("field").Key(name), name, append(roleMappingFieldNames, "metadata.*")))
+			}
+		}
+	}
+
+	if nbRules != 1 {
+		allErrs = append(allErrs, field.Invalid(path, nbRules, "Each rule must have only one of 'any', 'all', 'field' or 'except'"))
+	}
+
+	return allErrs
+}
+
+// validateRawRules check that the rules tree not contain unknown keys
+// The nested rules are not typed by the CRD schema, so unknown keys are silently dropped without this check
+func (r *roleMappingValidator) validateRawRules(ctx context.Context) (allErrs field.ErrorList) {
+	allErrs = field.ErrorList{}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || len(req.Object.Raw) == 0 {
+		return allErrs
+	}
+
+	raw := struct {
+		Spec struct {
+			Rules any `json:"rules"`
+		} `json:"spec"`
+	}{}
+	if err = json.Unmarshal(req.Object.Raw, &raw); err != nil {
+		return append(allErrs, field.Invalid(field.NewPath("spec").Child("rules"), string(req.Object.Raw), err.Error()))
+	}
+
+	var walk func(node any, path *field.Path)
+	walk = func(node any, path *field.Path) {
+		m, ok := node.(map[string]any)
+		if !ok {
+			allErrs = append(allErrs, field.TypeInvalid(path, node, "The rule must be an object"))
+			return
+		}
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			switch key {
+			case "any", "all":
+				rules, ok := m[key].([]any)
+				if !ok {
+					allErrs = append(allErrs, field.TypeInvalid(path.Child(key), m[key], "The rule must be a list"))
+					continue
+				}
+				for i, rule := range rules {
+					walk(rule, path.Child(key).Index(i))
+				}
+			case "except":
+				walk(m[key], path.Child(key))
+			case "field":
+			default:
+				allErrs = append(allErrs, field.NotSupported(path.Child(key), key, roleMappingRuleKeys))
+			}
+		}
+	}
+	if raw.Spec.Rules != nil {
+		walk(raw.Spec.Rules, field.NewPath("spec").Child("rules"))
+	}
+
+	return allErrs
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *roleMappingValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
@@ -90,9 +214,19 @@ func (r *roleMappingValidator) ValidateCreate(ctx context.Context, obj runtime.O
 	}
 	r.logger.Debugf("validate create %s/%s", roleMappingObj.GetNamespace(), roleMappingObj.GetName())
 
+	if err := r.validateRolesOrRoleTemplates(roleMappingObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, r.validateRawRules(ctx)...)
+	allErrs = append(allErrs, r.validateRules(roleMappingObj.Spec.Rules, field.NewPath("spec").Child("rules"), false)...)
+
 	if err := roleMappingObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, roleMappingObj, roleMappingObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(roleMappingObj); err != nil {
 		allErrs = append(allErrs, err)
@@ -118,9 +252,19 @@ func (r *roleMappingValidator) ValidateUpdate(ctx context.Context, oldObj runtim
 	}
 	r.logger.Debugf("validate update %s/%s", roleMappingObj.Namespace, roleMappingObj.Name)
 
+	if err := r.validateRolesOrRoleTemplates(roleMappingObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	allErrs = append(allErrs, r.validateRawRules(ctx)...)
+	allErrs = append(allErrs, r.validateRules(roleMappingObj.Spec.Rules, field.NewPath("spec").Child("rules"), false)...)
+
 	if err := roleMappingObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, roleMappingObj, roleMappingObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(roleMappingObj); err != nil {
 		allErrs = append(allErrs, err)
@@ -32,9 +32,11 @@ func (t *TestSuite) TestSetupRoleMappingWebhook() {
 			Roles: []string{
 				"test",
 			},
-			Rules: &apis.MapAny{
-				Data: map[string]any{
-					"username": "*",
+			Rules: &RoleMappingRule{
+				Field: &apis.MapAny{
+					Data: map[string]any{
+						"username": "*",
+					},
 				},
 			},
 		},
@@ -59,9 +61,11 @@ func (t *TestSuite) TestSetupRoleMappingWebhook() {
 			Roles: []string{
 				"test",
 			},
-			Rules: &apis.MapAny{
-				Data: map[string]any{
-					"username": "*",
+			Rules: &RoleMappingRule{
+				Field: &apis.MapAny{
+					Data: map[string]any{
+						"username": "*",
+					},
 				},
 			},
 		},
@@ -86,9 +90,11 @@ func (t *TestSuite) TestSetupRoleMappingWebhook() {
 			Roles: []string{
 				"test",
 			},
-			Rules: &apis.MapAny{
-				Data: map[string]any{
-					"username": "*",
+			Rules: &RoleMappingRule{
+				Field: &apis.MapAny{
+					Data: map[string]any{
+						"username": "*",
+					},
 				},
 			},
 		},
@@ -111,9 +117,11 @@ func (t *TestSuite) TestSetupRoleMappingWebhook() {
 			Roles: []string{
 				"test",
 			},
-			Rules: &apis.MapAny{
-				Data: map[string]any{
-					"username": "*",
+			Rules: &RoleMappingRule{
+				Field: &apis.MapAny{
+					Data: map[string]any{
+						"username": "*",
+					},
 				},
 			},
 		},
@@ -133,13 +141,186 @@ func (t *TestSuite) TestSetupRoleMappingWebhook() {
 			Roles: []string{
 				"test",
 			},
-			Rules: &apis.MapAny{
-				Data: map[string]any{
-					"username": "*",
+			Rules: &RoleMappingRule{
+				Field: &apis.MapAny{
+					Data: map[string]any{
+						"username": "*",
+					},
 				},
 			},
 		},
 	}
 	err = t.k8sClient.Create(context.Background(), o)
 	assert.Error(t.T(), err)
+
+	// Need failed when set roles and role templates
+	o = &RoleMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook6",
+			Namespace: "default",
+		},
+		Spec: RoleMappingSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Roles: []string{
+				"test",
+			},
+			RoleTemplates: []RoleMappingRoleTemplate{
+				{
+					Source: "_user_{{username}}",
+				},
+			},
+			Rules: &RoleMappingRule{
+				Field: &apis.MapAny{
+					Data: map[string]any{
+						"username": "*",
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when field rule use unknown user attribute
+	o = &RoleMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook7",
+			Namespace: "default",
+		},
+		Spec: RoleMappingSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Roles: []string{
+				"test",
+			},
+			Rules: &RoleMappingRule{
+				Any: []RoleMappingRule{
+					{
+						Field: &apis.MapAny{
+							Data: map[string]any{
+								"group": "admins",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when except is not member of all
+	o = &RoleMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook8",
+			Namespace: "default",
+		},
+		Spec: RoleMappingSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Roles: []string{
+				"test",
+			},
+			Rules: &RoleMappingRule{
+				Except: &RoleMappingRule{
+					Field: &apis.MapAny{
+						Data: map[string]any{
+							"username": "admin",
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when rule has more than one operator
+	o = &RoleMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook9",
+			Namespace: "default",
+		},
+		Spec: RoleMappingSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Roles: []string{
+				"test",
+			},
+			Rules: &RoleMappingRule{
+				Any: []RoleMappingRule{
+					{
+						Field: &apis.MapAny{
+							Data: map[string]any{
+								"username": "admin",
+							},
+						},
+					},
+				},
+				Field: &apis.MapAny{
+					Data: map[string]any{
+						"username": "admin",
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need success with role templates and metadata attribute
+	o = &RoleMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook10",
+			Namespace: "default",
+		},
+		Spec: RoleMappingSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			RoleTemplates: []RoleMappingRoleTemplate{
+				{
+					Source: "{{#tojson}}groups{{/tojson}}",
+					Format: "json",
+				},
+			},
+			Rules: &RoleMappingRule{
+				All: []RoleMappingRule{
+					{
+						Field: &apis.MapAny{
+							Data: map[string]any{
+								"metadata.department": "ops",
+							},
+						},
+					},
+					{
+						Except: &RoleMappingRule{
+							Field: &apis.MapAny{
+								Data: map[string]any{
+									"realm.name": "file1",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
 }
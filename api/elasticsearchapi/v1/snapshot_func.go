@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
+
+// GetStatus return the status object
+func (o *Snapshot) GetStatus() object.RemoteObjectStatus {
+	return &o.Status
+}
+
+// GetExternalName return the snapshot name
+// If name is empty, it use the ressource name
+func (o *Snapshot) GetExternalName() string {
+	if o.Spec.Name == "" {
+		return o.Name
+	}
+
+	return o.Spec.Name
+}
+
+// GetDeletionPolicy return the policy applied on the snapshot when the resource is deleted
+// Default it keep the snapshot, a backup must not be lost because of the resource is deleted
+func (o *Snapshot) GetDeletionPolicy() shared.DeletionPolicy {
+	if o.Spec.DeletionPolicy == "" {
+		return shared.DeletionPolicyOrphan
+	}
+
+	return o.Spec.DeletionPolicy
+}
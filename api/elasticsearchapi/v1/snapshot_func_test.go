@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSnapshotGetStatus(t *testing.T) {
+	status := SnapshotStatus{
+		DefaultRemoteObjectStatus: remote.DefaultRemoteObjectStatus{
+			LastAppliedConfiguration: "test",
+		},
+	}
+	o := &Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Status: status,
+	}
+
+	assert.Equal(t, &status, o.GetStatus())
+}
+
+func TestSnapshotExternalName(t *testing.T) {
+	var o *Snapshot
+
+	// When name is set
+	o = &Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: SnapshotSpec{
+			Name: "test2",
+		},
+	}
+
+	assert.Equal(t, "test2", o.GetExternalName())
+
+	// When name isn't set
+	o = &Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: SnapshotSpec{},
+	}
+
+	assert.Equal(t, "test", o.GetExternalName())
+}
+
+func TestSnapshotGetDeletionPolicy(t *testing.T) {
+	var o *Snapshot
+
+	// When deletion policy is not set
+	o = &Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: SnapshotSpec{},
+	}
+
+	assert.Equal(t, shared.DeletionPolicyOrphan, o.GetDeletionPolicy())
+
+	// When deletion policy is set
+	o.Spec.DeletionPolicy = shared.DeletionPolicyDelete
+	assert.Equal(t, shared.DeletionPolicyDelete, o.GetDeletionPolicy())
+}
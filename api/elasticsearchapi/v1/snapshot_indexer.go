@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// SetupSnapshotIndexer setup indexer for Snapshot
+func SetupSnapshotIndexer(k8sManager manager.Manager) (err error) {
+	// Index external name needed by webhook to controle unicity
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &Snapshot{}, "spec.externalName", func(o client.Object) []string {
+		p := o.(*Snapshot)
+		return []string{p.GetExternalName()}
+	}); err != nil {
+		return err
+	}
+
+	// Index repository needed by webhook to controle unicity
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &Snapshot{}, "spec.repository", func(o client.Object) []string {
+		p := o.(*Snapshot)
+		return []string{p.Spec.Repository}
+	}); err != nil {
+		return err
+	}
+
+	// Index target cluster needed by webhook to controle unicity
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &Snapshot{}, "spec.targetCluster", func(o client.Object) []string {
+		p := o.(*Snapshot)
+		return []string{p.Spec.ElasticsearchRef.GetTargetCluster(p.Namespace)}
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
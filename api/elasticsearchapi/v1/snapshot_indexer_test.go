@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupSnapshotIndexer() {
+	// Add Snapshot to force indexer execution
+
+	snapshot := &Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: SnapshotSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Repository: "backup",
+		},
+	}
+
+	err := t.k8sClient.Create(context.Background(), snapshot)
+	assert.NoError(t.T(), err)
+}
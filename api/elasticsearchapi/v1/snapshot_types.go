@@ -0,0 +1,177 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// SnapshotSpec defines the desired state of Snapshot
+// +k8s:openapi-gen=true
+type SnapshotSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ElasticsearchRef is the Elasticsearch ref to connect on.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
+
+	// DeletionPolicy is the policy applied on the snapshot when the resource is deleted
+	// Use Delete to remove the snapshot from repository
+	// Default to Orphan
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Orphan
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Name is the snapshot name
+	// If empty, it use the ressource name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Repository is the repository where to store the snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Repository string `json:"repository"`
+
+	// Indices is the list of data streams and indices to include on snapshot
+	// Default it include all data streams and indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+
+	// IgnoreUnavailable ignore the missing or closed data streams and indices
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IgnoreUnavailable bool `json:"ignoreUnavailable,omitempty"`
+
+	// IncludeGlobalState include the cluster state on snapshot
+	// Default to true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	IncludeGlobalState *bool `json:"includeGlobalState,omitempty"`
+
+	// FeatureStates is the list of feature states to include on snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	FeatureStates []string `json:"featureStates,omitempty"`
+
+	// Partial allow the snapshot of indices that have unavailable primary shards
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Partial bool `json:"partial,omitempty"`
+
+	// Metadata is the custom metadata attached to the snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Metadata *apis.MapAny `json:"metadata,omitempty"`
+
+	// WaitForCompletion keep the resource not ready until the snapshot is completed
+	// Default it is ready when the snapshot is started
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	WaitForCompletion bool `json:"waitForCompletion,omitempty"`
+}
+
+// SnapshotShardsStatus is the shard stats of snapshot or restore
+type SnapshotShardsStatus struct {
+	// Total is the number of shards
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Total int64 `json:"total"`
+
+	// Successful is the number of shards successfully processed
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Successful int64 `json:"successful"`
+
+	// Failed is the number of shards on failure
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Failed int64 `json:"failed"`
+}
+
+// SnapshotStatus defines the observed state of Snapshot
+type SnapshotStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// State is the snapshot state on Elasticsearch
+	// It can be IN_PROGRESS, SUCCESS, PARTIAL, FAILED or INCOMPATIBLE
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// StartTime is the time when the snapshot is started
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the time when the snapshot is completed
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Shards is the shard stats of the snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Shards *SnapshotShardsStatus `json:"shards,omitempty"`
+
+	// Failure is the first shard failure reason
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Failure string `json:"failure,omitempty"`
+
+	remote.DefaultRemoteObjectStatus `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// Snapshot is the Schema for the snapshots API
+// +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
+// +kubebuilder:printcolumn:name="Repository",type="string",JSONPath=".spec.repository"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Snapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SnapshotSpec   `json:"spec,omitempty"`
+	Status SnapshotStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// SnapshotList contains a list of Snapshot
+type SnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Snapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Snapshot{}, &SnapshotList{})
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type snapshotValidator struct {
+	logger *logrus.Entry
+	client client.Client
+}
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func SetupSnapshotWebhookWithManager(logger *logrus.Entry) controller.WebhookRegister {
+	return func(mgr ctrl.Manager, client client.Client) error {
+		return ctrl.NewWebhookManagedBy(mgr).
+			For(&Snapshot{}).
+			WithValidator(&snapshotValidator{
+				logger: logger.WithField("webhook", "snapshotValidator"),
+				client: client,
+			}).
+			Complete()
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-elasticsearchapi-k8s-webcenter-fr-v1-snapshot,mutating=false,failurePolicy=fail,sideEffects=None,groups=elasticsearchapi.k8s.webcenter.fr,resources=snapshots,verbs=create;update,versions=v1,name=snapshot.elasticsearchapi.k8s.webcenter.fr,admissionReviewVersions=v1,timeoutSeconds=30
+
+var _ webhook.CustomValidator = &snapshotValidator{}
+
+func (r *snapshotValidator) validateResourceUnicity(obj *Snapshot) *field.Error {
+	// Check if resource already exist with same name on some remote cluster target
+	listObjects := &SnapshotList{}
+	fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.externalName=%s,spec.repository=%s,spec.targetCluster=%s", obj.GetExternalName(), obj.Spec.Repository, obj.Spec.ElasticsearchRef.GetTargetCluster(obj.Namespace)))
+	if err := r.client.List(context.Background(), listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
+		panic(err)
+	}
+	if len(listObjects.Items) > 0 {
+		isError := false
+		existingResources := make([]string, 0, len(listObjects.Items))
+		for _, ag := range listObjects.Items {
+			// exclude themself
+			if ag.UID != obj.UID {
+				existingResources = append(existingResources, fmt.Sprintf("'%s/%s'", ag.Namespace, ag.Name))
+				isError = true
+			}
+		}
+		if isError {
+			return field.Duplicate(field.NewPath("spec").Child("name"), fmt.Sprintf("There are some same resource that already target the same Elasticsearch cluster and repository with the same name: %s", strings.Join(existingResources, ", ")))
+		}
+	}
+
+	return nil
+}
+
+// validateImmutableSnapshot check the snapshot settings are not changed
+// A snapshot can't be changed after it has been taken
+func (r *snapshotValidator) validateImmutableSnapshot(current, old *Snapshot) *field.Error {
+	currentSpec := current.Spec.DeepCopy()
+	oldSpec := old.Spec.DeepCopy()
+
+	// Only the operator behaviors can be changed
+	currentSpec.DeletionPolicy = oldSpec.DeletionPolicy
+	currentSpec.WaitForCompletion = oldSpec.WaitForCompletion
+
+	if !equality.Semantic.DeepEqual(currentSpec, oldSpec) {
+		return field.Forbidden(field.NewPath("spec"), "Only the fields 'spec.deletionPolicy' and 'spec.waitForCompletion' can be changed")
+	}
+
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *snapshotValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	snapshotObj, ok := obj.(*Snapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a Snapshot object but got %T", obj)
+	}
+	r.logger.Debugf("validate create %s/%s", snapshotObj.GetNamespace(), snapshotObj.GetName())
+
+	if err := snapshotObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, snapshotObj, snapshotObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateResourceUnicity(snapshotObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			snapshotObj.GroupVersionKind().GroupKind(),
+			snapshotObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *snapshotValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	oldO := oldObj.(*Snapshot)
+
+	snapshotObj, ok := newObj.(*Snapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a Snapshot object but got %T", newObj)
+	}
+	r.logger.Debugf("validate update %s/%s", snapshotObj.Namespace, snapshotObj.Name)
+
+	if err := snapshotObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, snapshotObj, snapshotObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateImmutableName(snapshotObj, oldO); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateImmutableSnapshot(snapshotObj, oldO); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateResourceUnicity(snapshotObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			snapshotObj.GroupVersionKind().GroupKind(),
+			snapshotObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *snapshotValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupSnapshotWebhook() {
+	var (
+		o   *Snapshot
+		err error
+	)
+
+	// Need failed when create same resource by external name on same managed cluster and repository
+	// Check we can update it
+	o = &Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook",
+			Namespace: "default",
+		},
+		Spec: SnapshotSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name:       "webhook",
+			Repository: "backup",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	o.Spec.DeletionPolicy = shared.DeletionPolicyOrphan
+	o.Spec.WaitForCompletion = true
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	// Need failed when change the snapshot settings
+	o.Spec.Indices = []string{"logs-*"}
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	o = &Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook2",
+			Namespace: "default",
+		},
+		Spec: SnapshotSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name:       "webhook",
+			Repository: "backup",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Can create same name on other repository
+	o.Spec.Repository = "backup2"
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+}
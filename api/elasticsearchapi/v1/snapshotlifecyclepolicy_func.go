@@ -1,6 +1,9 @@
 package v1
 
-import "github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
 
 // GetStatus return the status object
 func (o *SnapshotLifecyclePolicy) GetStatus() object.RemoteObjectStatus {
@@ -16,3 +19,33 @@ func (o *SnapshotLifecyclePolicy) GetExternalName() string {
 
 	return o.Spec.SnapshotLifecyclePolicyName
 }
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *SnapshotLifecyclePolicy) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *SnapshotLifecyclePolicy) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *SnapshotLifecyclePolicy) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *SnapshotLifecyclePolicy) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *SnapshotLifecyclePolicy) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *SnapshotLifecyclePolicy) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
@@ -36,6 +36,23 @@ type SnapshotLifecyclePolicySpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
 
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
 	// SnapshotLifecyclePolicyName is the custom snapshot lifecycle policy name
 	// If empty, it use the ressource name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -126,6 +143,77 @@ type SnapshotLifecyclePolicyStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+
+	// LastSuccess is the last snapshot successfully taken by the policy
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastSuccess *SLMInvocation `json:"lastSuccess,omitempty"`
+
+	// LastFailure is the last snapshot failed by the policy
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastFailure *SLMInvocation `json:"lastFailure,omitempty"`
+
+	// NextExecution is the time of the next scheduled snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	NextExecution *metav1.Time `json:"nextExecution,omitempty"`
+
+	// Stats is the snapshot statistics of the policy
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Stats *SLMStats `json:"stats,omitempty"`
+
+	// LastExecution is the last snapshot requested with the execute annotation
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastExecution *SLMInvocation `json:"lastExecution,omitempty"`
+}
+
+// SLMInvocation is a snapshot taken by the policy
+type SLMInvocation struct {
+	// SnapshotName is the snapshot name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SnapshotName string `json:"snapshotName"`
+
+	// Time is the time of the snapshot
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// Details is the failure details
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Details string `json:"details,omitempty"`
+}
+
+// SLMStats is the snapshot statistics of the policy
+type SLMStats struct {
+	// SnapshotsTaken is the number of snapshots taken
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SnapshotsTaken int64 `json:"snapshotsTaken"`
+
+	// SnapshotsFailed is the number of snapshots failed
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SnapshotsFailed int64 `json:"snapshotsFailed"`
+
+	// SnapshotsDeleted is the number of snapshots deleted by the retention
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SnapshotsDeleted int64 `json:"snapshotsDeleted"`
+
+	// SnapshotDeletionFailures is the number of snapshots failed to be deleted by the retention
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	SnapshotDeletionFailures int64 `json:"snapshotDeletionFailures"`
 }
 
 //+kubebuilder:object:root=true
@@ -137,6 +225,7 @@ type SnapshotLifecyclePolicyStatus struct {
 // +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
 // +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Last success",type="date",JSONPath=".status.lastSuccess.time"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type SnapshotLifecyclePolicy struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -23,6 +23,7 @@ import (
 
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -93,6 +94,9 @@ func (r *snapshotLifecyclePolicyValidator) ValidateCreate(ctx context.Context, o
 	if err := slmObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, slmObj, slmObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(slmObj); err != nil {
 		allErrs = append(allErrs, err)
@@ -121,6 +125,9 @@ func (r *snapshotLifecyclePolicyValidator) ValidateUpdate(ctx context.Context, o
 	if err := slmObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, slmObj, slmObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(slmObj); err != nil {
 		allErrs = append(allErrs, err)
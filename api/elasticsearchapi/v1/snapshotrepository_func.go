@@ -1,6 +1,19 @@
 package v1
 
-import "github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+import (
+	"regexp"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
+
+// snapshotRepositoryCredentialsKeyRegexp match the keystore settings of the repository clients
+var snapshotRepositoryCredentialsKeyRegexp = regexp.MustCompile(`^(s3|gcs|azure)\.client\.[^.]+\..+$`)
+
+// IsSnapshotRepositoryCredentialsKey return true if the key is a keystore setting of a repository client, like `s3.client.default.access_key`
+func IsSnapshotRepositoryCredentialsKey(key string) bool {
+	return snapshotRepositoryCredentialsKeyRegexp.MatchString(key)
+}
 
 // GetStatus return the status object
 func (o *SnapshotRepository) GetStatus() object.RemoteObjectStatus {
@@ -16,3 +29,33 @@ func (o *SnapshotRepository) GetExternalName() string {
 
 	return o.Spec.Name
 }
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *SnapshotRepository) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *SnapshotRepository) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *SnapshotRepository) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *SnapshotRepository) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *SnapshotRepository) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *SnapshotRepository) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
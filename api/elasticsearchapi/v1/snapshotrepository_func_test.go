@@ -52,3 +52,14 @@ func TestSnapshotRepositoryExternalName(t *testing.T) {
 
 	assert.Equal(t, "test", o.GetExternalName())
 }
+
+func TestIsSnapshotRepositoryCredentialsKey(t *testing.T) {
+	assert.True(t, IsSnapshotRepositoryCredentialsKey("s3.client.default.access_key"))
+	assert.True(t, IsSnapshotRepositoryCredentialsKey("gcs.client.backup.credentials_file"))
+	assert.True(t, IsSnapshotRepositoryCredentialsKey("azure.client.secondary.sas_token"))
+
+	assert.False(t, IsSnapshotRepositoryCredentialsKey("bootstrap.password"))
+	assert.False(t, IsSnapshotRepositoryCredentialsKey("s3.client.access_key"))
+	assert.False(t, IsSnapshotRepositoryCredentialsKey("xpack.security.http.ssl.keystore.secure_password"))
+	assert.False(t, IsSnapshotRepositoryCredentialsKey(""))
+}
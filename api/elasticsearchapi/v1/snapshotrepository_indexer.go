@@ -25,5 +25,16 @@ func SetupSnapshotRepositoryIndexer(k8sManager manager.Manager) (err error) {
 		return err
 	}
 
+	// Index credentials secret ref
+	if err = k8sManager.GetFieldIndexer().IndexField(context.Background(), &SnapshotRepository{}, "spec.credentialsSecretRef.name", func(o client.Object) []string {
+		p := o.(*SnapshotRepository)
+		if p.Spec.CredentialsSecretRef != nil {
+			return []string{p.Spec.CredentialsSecretRef.Name}
+		}
+		return []string{}
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
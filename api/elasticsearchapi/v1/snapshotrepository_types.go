@@ -20,6 +20,7 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -36,6 +37,23 @@ type SnapshotRepositorySpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
 
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
 	// Name is the custom snapshot repository name
 	// If empty, it use the ressource name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
@@ -51,6 +69,47 @@ type SnapshotRepositorySpec struct {
 	// +optional
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Settings *apis.MapAny `json:"settings,omitempty"`
+
+	// CredentialsSecretRef is the secret that store the client credentials of the repository, like for s3, gcs or azure
+	// The keys are the keystore settings, like `s3.client.default.access_key`
+	// They are merged on the keystore of the managed Elasticsearch, then the secure settings are reloaded
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// SnapshotRepositoryVerificationStatus is the result of the repository verification
+type SnapshotRepositoryVerificationStatus struct {
+	// IsVerified is true when all nodes can access to the repository
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	IsVerified bool `json:"isVerified"`
+
+	// LastVerificationTime is the time of the last verification
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+
+	// Nodes is the list of nodes that have successfully verified the repository
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Nodes []SnapshotRepositoryVerifiedNode `json:"nodes,omitempty"`
+
+	// Error is the verification error, with the nodes that can't access to the repository
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// SnapshotRepositoryVerifiedNode is a node that have verified the repository
+type SnapshotRepositoryVerifiedNode struct {
+	// Id is the node ID
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Id string `json:"id"`
+
+	// Name is the node name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Name string `json:"name,omitempty"`
 }
 
 // SnapshotRepositoryStatus defines the observed state of SnapshotRepository
@@ -59,6 +118,31 @@ type SnapshotRepositoryStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+
+	// Verification is the result of the last repository verification
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Verification *SnapshotRepositoryVerificationStatus `json:"verification,omitempty"`
+
+	// CredentialsChecksum is the checksum of the credentials loaded on the cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	CredentialsChecksum string `json:"credentialsChecksum,omitempty"`
+
+	// CredentialsKeys is the list of keystore settings injected from the credentials secret
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	CredentialsKeys []string `json:"credentialsKeys,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -68,6 +152,7 @@ type SnapshotRepositoryStatus struct {
 // SnapshotRepository is the Schema for the snapshotrepositories API
 // +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
 // +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
+// +kubebuilder:printcolumn:name="Verified",type="boolean",JSONPath=".status.verification.isVerified"
 // +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
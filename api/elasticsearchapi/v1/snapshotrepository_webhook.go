@@ -19,13 +19,18 @@ package v1
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -80,6 +85,78 @@ func (r *snapshotRepositoryValidator) validateResourceUnicity(obj *SnapshotRepos
 	return nil
 }
 
+// validateCredentials check the credentials are only used with a managed Elasticsearch
+// The operator can't update the keystore of external cluster
+// The credentials are merged on the same keystore for all repositories of the cluster, so the keys need to be client settings not already used by other repository
+func (r *snapshotRepositoryValidator) validateCredentials(ctx context.Context, obj *SnapshotRepository) *field.Error {
+	if obj.Spec.CredentialsSecretRef == nil {
+		return nil
+	}
+	path := field.NewPath("spec").Child("credentialsSecretRef")
+
+	if !obj.Spec.ElasticsearchRef.IsManaged() {
+		return field.Forbidden(path, "The credentials can only be injected on the keystore of Elasticsearch managed by operator")
+	}
+
+	keys, err := r.getCredentialsKeys(ctx, obj)
+	if err != nil {
+		return field.InternalError(path, err)
+	}
+	for _, key := range keys {
+		if !IsSnapshotRepositoryCredentialsKey(key) {
+			return field.Invalid(path, obj.Spec.CredentialsSecretRef.Name, fmt.Sprintf("The key %s is not a client setting, it need to match `<s3|gcs|azure>.client.<name>.<setting>`", key))
+		}
+	}
+
+	// Check if the keys are already used by other repository on the same cluster
+	listObjects := &SnapshotRepositoryList{}
+	fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.targetCluster=%s", obj.Spec.ElasticsearchRef.GetTargetCluster(obj.Namespace)))
+	if err := r.client.List(ctx, listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
+		return field.InternalError(path, err)
+	}
+	for _, other := range listObjects.Items {
+		if other.UID == obj.UID {
+			continue
+		}
+		otherKeys, err := r.getCredentialsKeys(ctx, &other)
+		if err != nil {
+			return field.InternalError(path, err)
+		}
+		otherKeys = append(otherKeys, other.Status.CredentialsKeys...)
+		for _, key := range keys {
+			if slices.Contains(otherKeys, key) {
+				return field.Duplicate(path, fmt.Sprintf("The key %s is already injected on the keystore by the repository '%s/%s'", key, other.Namespace, other.Name))
+			}
+		}
+	}
+
+	return nil
+}
+
+// getCredentialsKeys return the keys of the credentials secret
+// It return nothing when the secret not yet exist
+func (r *snapshotRepositoryValidator) getCredentialsKeys(ctx context.Context, obj *SnapshotRepository) (keys []string, err error) {
+	if obj.Spec.CredentialsSecretRef == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err = r.client.Get(ctx, types.NamespacedName{Namespace: obj.Namespace, Name: obj.Spec.CredentialsSecretRef.Name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	keys = make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *snapshotRepositoryValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	var allErrs field.ErrorList
@@ -93,10 +170,16 @@ func (r *snapshotRepositoryValidator) ValidateCreate(ctx context.Context, obj ru
 	if err := snapshotRepositoryObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, snapshotRepositoryObj, snapshotRepositoryObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(snapshotRepositoryObj); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := r.validateCredentials(ctx, snapshotRepositoryObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
@@ -121,6 +204,9 @@ func (r *snapshotRepositoryValidator) ValidateUpdate(ctx context.Context, oldObj
 	if err := snapshotRepositoryObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, snapshotRepositoryObj, snapshotRepositoryObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := validateImmutableName(snapshotRepositoryObj, oldO); err != nil {
 		allErrs = append(allErrs, err)
@@ -129,6 +215,9 @@ func (r *snapshotRepositoryValidator) ValidateUpdate(ctx context.Context, oldObj
 	if err := r.validateResourceUnicity(snapshotRepositoryObj); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := r.validateCredentials(ctx, snapshotRepositoryObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if len(allErrs) > 0 {
 		return nil, apierrors.NewInvalid(
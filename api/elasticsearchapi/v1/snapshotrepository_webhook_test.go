@@ -6,6 +6,7 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	"github.com/stretchr/testify/assert"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -132,4 +133,124 @@ func (t *TestSuite) TestSetupSnapshotRepositoryWebhook() {
 	}
 	err = t.k8sClient.Create(context.Background(), o)
 	assert.Error(t.T(), err)
+
+	// Need failed when inject credentials on external cluster
+	o = &SnapshotRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook6",
+			Namespace: "default",
+		},
+		Spec: SnapshotRepositorySpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test.local"},
+				},
+			},
+			Name: "webhook-credentials",
+			Type: "s3",
+			Settings: &apis.MapAny{
+				Data: map[string]any{
+					"bucket": "backup",
+				},
+			},
+			CredentialsSecretRef: &corev1.LocalObjectReference{
+				Name: "s3-credentials",
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when the credentials are not client settings
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "webhook-bad-credentials",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"bootstrap.password": []byte("password"),
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), secret)
+	assert.NoError(t.T(), err)
+	o = newSnapshotRepositoryWithCredentials("test-webhook7", "webhook-bad-credentials")
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when the credentials are already injected by other repository on the same cluster
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "webhook-credentials",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"s3.client.default.access_key": []byte("access"),
+			"s3.client.default.secret_key": []byte("secret"),
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), secret)
+	assert.NoError(t.T(), err)
+	o = newSnapshotRepositoryWithCredentials("test-webhook8", "webhook-credentials")
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "webhook-credentials2",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"s3.client.default.access_key": []byte("access2"),
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), secret)
+	assert.NoError(t.T(), err)
+	o = newSnapshotRepositoryWithCredentials("test-webhook9", "webhook-credentials2")
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need to accept the credentials of other client on the same cluster
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "webhook-credentials3",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"s3.client.other.access_key": []byte("access3"),
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), secret)
+	assert.NoError(t.T(), err)
+	o = newSnapshotRepositoryWithCredentials("test-webhook10", "webhook-credentials3")
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+}
+
+func newSnapshotRepositoryWithCredentials(name string, secretName string) *SnapshotRepository {
+	return &SnapshotRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: SnapshotRepositorySpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name: name,
+			Type: "s3",
+			Settings: &apis.MapAny{
+				Data: map[string]any{
+					"bucket": "backup",
+					"client": "default",
+				},
+			},
+			CredentialsSecretRef: &corev1.LocalObjectReference{
+				Name: secretName,
+			},
+		},
+	}
 }
@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
+
+// GetStatus return the status object
+func (o *StoredScript) GetStatus() object.RemoteObjectStatus {
+	return &o.Status
+}
+
+// GetExternalName return the script ID
+// If name is empty, it use the ressource name
+func (o *StoredScript) GetExternalName() string {
+	if o.Spec.Name == "" {
+		return o.Name
+	}
+
+	return o.Spec.Name
+}
+
+// GetLang return the script language
+// Default to painless
+func (o *StoredScript) GetLang() StoredScriptLang {
+	if o.Spec.Lang == "" {
+		return StoredScriptLangPainless
+	}
+
+	return o.Spec.Lang
+}
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *StoredScript) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *StoredScript) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *StoredScript) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *StoredScript) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *StoredScript) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *StoredScript) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStoredScriptGetStatus(t *testing.T) {
+	status := StoredScriptStatus{
+		DefaultRemoteObjectStatus: remote.DefaultRemoteObjectStatus{
+			LastAppliedConfiguration: "test",
+		},
+	}
+	o := &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Status: status,
+	}
+
+	assert.Equal(t, &status, o.GetStatus())
+}
+
+func TestStoredScriptExternalName(t *testing.T) {
+	var o *StoredScript
+
+	// When name is set
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: StoredScriptSpec{
+			Name: "test2",
+		},
+	}
+
+	assert.Equal(t, "test2", o.GetExternalName())
+
+	// When name isn't set
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: StoredScriptSpec{},
+	}
+
+	assert.Equal(t, "test", o.GetExternalName())
+}
+
+func TestStoredScriptGetLang(t *testing.T) {
+	var o *StoredScript
+
+	// When lang is not set
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: StoredScriptSpec{},
+	}
+	assert.Equal(t, StoredScriptLangPainless, o.GetLang())
+
+	// When lang is set
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: StoredScriptSpec{
+			Lang: StoredScriptLangMustache,
+		},
+	}
+	assert.Equal(t, StoredScriptLangMustache, o.GetLang())
+}
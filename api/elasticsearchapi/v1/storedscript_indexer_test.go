@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupStoredScriptIndexer() {
+	// Add StoredScript to force indexer execution
+
+	storedScript := &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Source: "Math.log(_score * 2) + params['my_modifier']",
+		},
+	}
+
+	err := t.k8sClient.Create(context.Background(), storedScript)
+	assert.NoError(t.T(), err)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// StoredScriptSpec defines the desired state of StoredScript
+// +k8s:openapi-gen=true
+type StoredScriptSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ElasticsearchRef is the Elasticsearch ref to connect on.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
+
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// Name is the custom script ID
+	// If empty, it use the ressource name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Lang is the script language
+	// Use `mustache` for search template
+	// Default to painless
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:default=painless
+	// +kubebuilder:validation:Enum=painless;mustache
+	Lang StoredScriptLang `json:"lang,omitempty"`
+
+	// Source is the script or the search template
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Source string `json:"source"`
+
+	// Context is the context the painless script is compiled on
+	// The webhook not check the script when context is set
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Context string `json:"context,omitempty"`
+
+	// Options is the script options
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Options map[string]string `json:"options,omitempty"`
+}
+
+// StoredScriptLang is the script language
+type StoredScriptLang string
+
+const (
+	// StoredScriptLangPainless is the painless language
+	StoredScriptLangPainless StoredScriptLang = "painless"
+
+	// StoredScriptLangMustache is the mustache language used by search template
+	StoredScriptLangMustache StoredScriptLang = "mustache"
+)
+
+// StoredScriptStatus defines the observed state of StoredScript
+type StoredScriptStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ContentHash is the hash of the script applied on Elasticsearch
+	// It permit to detect when the script or the search template change
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ContentHash string `json:"contentHash,omitempty"`
+
+	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// StoredScript is the Schema for the storedscripts API
+// +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
+// +kubebuilder:printcolumn:name="Lang",type="string",JSONPath=".spec.lang"
+// +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type StoredScript struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StoredScriptSpec   `json:"spec,omitempty"`
+	Status StoredScriptStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// StoredScriptList contains a list of StoredScript
+type StoredScriptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StoredScript `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&StoredScript{}, &StoredScriptList{})
+}
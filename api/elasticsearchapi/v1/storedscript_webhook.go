@@ -0,0 +1,183 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// StoredScriptCompiler permit to compile the script on the target Elasticsearch cluster
+// It must return nil error when the cluster is not reachable
+// +kubebuilder:object:generate=false
+type StoredScriptCompiler func(ctx context.Context, c client.Client, o *StoredScript) (err error)
+
+type storedScriptValidator struct {
+	logger   *logrus.Entry
+	client   client.Client
+	compiler StoredScriptCompiler
+}
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+// The compiler is optional, it permit to check the script on Elasticsearch
+func SetupStoredScriptWebhookWithManager(logger *logrus.Entry, compiler StoredScriptCompiler) controller.WebhookRegister {
+	return func(mgr ctrl.Manager, client client.Client) error {
+		return ctrl.NewWebhookManagedBy(mgr).
+			For(&StoredScript{}).
+			WithValidator(&storedScriptValidator{
+				logger:   logger.WithField("webhook", "storedScriptValidator"),
+				client:   client,
+				compiler: compiler,
+			}).
+			Complete()
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-elasticsearchapi-k8s-webcenter-fr-v1-storedscript,mutating=false,failurePolicy=fail,sideEffects=None,groups=elasticsearchapi.k8s.webcenter.fr,resources=storedscripts,verbs=create;update,versions=v1,name=storedscript.elasticsearchapi.k8s.webcenter.fr,admissionReviewVersions=v1,timeoutSeconds=30
+
+var _ webhook.CustomValidator = &storedScriptValidator{}
+
+func (r *storedScriptValidator) validateResourceUnicity(obj *StoredScript) *field.Error {
+	// Check if resource already exist with same name on some remote cluster target
+	listObjects := &StoredScriptList{}
+	fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.externalName=%s,spec.targetCluster=%s", obj.GetExternalName(), obj.Spec.ElasticsearchRef.GetTargetCluster(obj.Namespace)))
+	if err := r.client.List(context.Background(), listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
+		panic(err)
+	}
+	if len(listObjects.Items) > 0 {
+		isError := false
+		existingResources := make([]string, 0, len(listObjects.Items))
+		for _, ag := range listObjects.Items {
+			// exclude themself
+			if ag.UID != obj.UID {
+				existingResources = append(existingResources, fmt.Sprintf("'%s/%s'", ag.Namespace, ag.Name))
+				isError = true
+			}
+		}
+		if isError {
+			return field.Duplicate(field.NewPath("spec").Child("name"), fmt.Sprintf("There are some same resource that already target the same Elasticsearch cluster with the same name: %s", strings.Join(existingResources, ", ")))
+		}
+	}
+
+	return nil
+}
+
+func (r *storedScriptValidator) validateScript(ctx context.Context, obj *StoredScript) *field.Error {
+	// Painless execute API only check painless script without specific context
+	if r.compiler == nil || obj.GetLang() != StoredScriptLangPainless || obj.Spec.Context != "" {
+		return nil
+	}
+
+	if err := r.compiler(ctx, r.client, obj); err != nil {
+		return field.Invalid(field.NewPath("spec").Child("source"), obj.Spec.Source, err.Error())
+	}
+
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *storedScriptValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	storedScriptObj, ok := obj.(*StoredScript)
+	if !ok {
+		return nil, fmt.Errorf("expected a StoredScript object but got %T", obj)
+	}
+	r.logger.Debugf("validate create %s/%s", storedScriptObj.GetNamespace(), storedScriptObj.GetName())
+
+	if err := storedScriptObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, storedScriptObj, storedScriptObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateResourceUnicity(storedScriptObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateScript(ctx, storedScriptObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			storedScriptObj.GroupVersionKind().GroupKind(),
+			storedScriptObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *storedScriptValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	oldO := oldObj.(*StoredScript)
+
+	storedScriptObj, ok := newObj.(*StoredScript)
+	if !ok {
+		return nil, fmt.Errorf("expected a StoredScript object but got %T", newObj)
+	}
+	r.logger.Debugf("validate update %s/%s", storedScriptObj.Namespace, storedScriptObj.Name)
+
+	if err := storedScriptObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, storedScriptObj, storedScriptObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateImmutableName(storedScriptObj, oldO); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateResourceUnicity(storedScriptObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if storedScriptObj.Spec.Source != oldO.Spec.Source || storedScriptObj.GetLang() != oldO.GetLang() || storedScriptObj.Spec.Context != oldO.Spec.Context {
+		if err := r.validateScript(ctx, storedScriptObj); err != nil {
+			allErrs = append(allErrs, err)
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			storedScriptObj.GroupVersionKind().GroupKind(),
+			storedScriptObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *storedScriptValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
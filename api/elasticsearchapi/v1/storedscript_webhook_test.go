@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupStoredScriptWebhook() {
+	var (
+		o   *StoredScript
+		err error
+	)
+
+	// Need failed when create same resource by external name on same managed cluster
+	// Check we can update it
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook",
+			Namespace: "default",
+		},
+		Spec: StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name:   "webhook",
+			Source: "Math.log(_score * 2) + params['my_modifier']",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook2",
+			Namespace: "default",
+		},
+		Spec: StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name:   "webhook",
+			Source: "Math.log(_score * 2) + params['my_modifier']",
+		},
+	}
+
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when create same resource by external name on same external cluster
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook3",
+			Namespace: "default",
+		},
+		Spec: StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test.local"},
+				},
+			},
+			Name:   "webhook",
+			Source: "Math.log(_score * 2) + params['my_modifier']",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook4",
+			Namespace: "default",
+		},
+		Spec: StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test.local"},
+				},
+			},
+			Name:   "webhook",
+			Source: "Math.log(_score * 2) + params['my_modifier']",
+		},
+	}
+
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when not specify target Elasticsearch cluster
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook5",
+			Namespace: "default",
+		},
+		Spec: StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{},
+			Source:           "Math.log(_score * 2) + params['my_modifier']",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when script not compile
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook6",
+			Namespace: "default",
+		},
+		Spec: StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Source: "invalid script",
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need not check search template
+	o = &StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook7",
+			Namespace: "default",
+		},
+		Spec: StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Lang:   StoredScriptLangMustache,
+			Source: `{"query": {"match": {"message": "{{query_string}} invalid script"}}}`,
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+}
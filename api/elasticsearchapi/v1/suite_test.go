@@ -1,10 +1,13 @@
 package v1
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -66,6 +70,10 @@ func (t *TestSuite) SetupSuite() {
 	if err != nil {
 		panic(err)
 	}
+	err = elasticsearchcrd.AddToScheme(scheme.Scheme)
+	if err != nil {
+		panic(err)
+	}
 
 	// Init k8smanager and k8sclient
 	webhookInstallOptions := &testEnv.WebhookInstallOptions
@@ -92,10 +100,16 @@ func (t *TestSuite) SetupSuite() {
 		SetupIndexLifecyclePolicyIndexer,
 		SetupIndexTemplateIndexer,
 		SetupLicenceIndexer,
+		SetupRemoteClusterIndexer,
+		SetupFollowerIndexIndexer,
+		SetupAutoFollowPatternIndexer,
+		SetupSnapshotIndexer,
 		SetupRoleIndexer,
 		SetupRoleMappingIndexer,
 		SetupSnapshotLifecyclePolicyIndexer,
 		SetupSnapshotRepositoryIndexer,
+		SetupStoredScriptIndexer,
+		SetupTransformIndexer,
 		SetupUserIndexexer,
 		SetupWatchIndexer,
 	); err != nil {
@@ -110,10 +124,18 @@ func (t *TestSuite) SetupSuite() {
 		SetupIndexLifecyclePolicyWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		SetupIndexTemplateWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		SetupLicenseWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		SetupRemoteClusterWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		SetupFollowerIndexWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		SetupAutoFollowPatternWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		SetupSnapshotWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		SetupRestoreWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		SetupRoleWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		SetupResourceSetWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		SetupRoleMappingWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		SetupSnapshotLifecyclePolicyWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		SetupSnapshotRepositoryWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		SetupStoredScriptWebhookWithManager(logrus.NewEntry(logrus.StandardLogger()), fakeStoredScriptCompiler),
+		SetupTransformWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		SetupUserWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		SetupWatchWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 	); err != nil {
@@ -157,3 +179,12 @@ func (t *TestSuite) BeforeTest(suiteName, testName string) {
 
 func (t *TestSuite) AfterTest(suiteName, testName string) {
 }
+
+// fakeStoredScriptCompiler reject the scripts that contain "invalid"
+func fakeStoredScriptCompiler(ctx context.Context, c client.Client, o *StoredScript) error {
+	if strings.Contains(o.Spec.Source, "invalid") {
+		return errors.New("compile error")
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
+
+// GetStatus return the status object
+func (o *Transform) GetStatus() object.RemoteObjectStatus {
+	return &o.Status
+}
+
+// GetExternalName return the transform name
+// If name is empty, it use the ressource name
+func (o *Transform) GetExternalName() string {
+	if o.Spec.Name == "" {
+		return o.Name
+	}
+
+	return o.Spec.Name
+}
+
+// GetExpectedState return the expected transform state
+// Default to started
+func (o *Transform) GetExpectedState() TransformState {
+	if o.Spec.State == "" {
+		return TransformStateStarted
+	}
+
+	return o.Spec.State
+}
+
+// IsResetNeeded return true if the transform need to be reset for the current generation
+func (o *Transform) IsResetNeeded() bool {
+	return o.GetExpectedState() == TransformStateReset && o.Status.LastResetGeneration != o.GetGeneration()
+}
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *Transform) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *Transform) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *Transform) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *Transform) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *Transform) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *Transform) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
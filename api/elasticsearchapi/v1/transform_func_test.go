@@ -0,0 +1,129 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTransformGetStatus(t *testing.T) {
+	status := TransformStatus{
+		DefaultRemoteObjectStatus: remote.DefaultRemoteObjectStatus{
+			LastAppliedConfiguration: "test",
+		},
+	}
+	o := &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Status: status,
+	}
+
+	assert.Equal(t, &status, o.GetStatus())
+}
+
+func TestTransformExternalName(t *testing.T) {
+	var o *Transform
+
+	// When name is set
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: TransformSpec{
+			Name: "test2",
+		},
+	}
+
+	assert.Equal(t, "test2", o.GetExternalName())
+
+	// When name isn't set
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: TransformSpec{},
+	}
+
+	assert.Equal(t, "test", o.GetExternalName())
+}
+
+func TestTransformGetExpectedState(t *testing.T) {
+	var o *Transform
+
+	// When state is not set
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: TransformSpec{},
+	}
+	assert.Equal(t, TransformStateStarted, o.GetExpectedState())
+
+	// When state is set
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: TransformSpec{
+			State: TransformStateStopped,
+		},
+	}
+	assert.Equal(t, TransformStateStopped, o.GetExpectedState())
+}
+
+func TestTransformIsResetNeeded(t *testing.T) {
+	var o *Transform
+
+	// When state is not reset
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "test",
+			Generation: 2,
+		},
+		Spec: TransformSpec{
+			State: TransformStateStarted,
+		},
+	}
+	assert.False(t, o.IsResetNeeded())
+
+	// When state is reset and not yet reset on this generation
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "test",
+			Generation: 2,
+		},
+		Spec: TransformSpec{
+			State: TransformStateReset,
+		},
+		Status: TransformStatus{
+			LastResetGeneration: 1,
+		},
+	}
+	assert.True(t, o.IsResetNeeded())
+
+	// When state is reset and already reset on this generation
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "test",
+			Generation: 2,
+		},
+		Spec: TransformSpec{
+			State: TransformStateReset,
+		},
+		Status: TransformStatus{
+			LastResetGeneration: 2,
+		},
+	}
+	assert.False(t, o.IsResetNeeded())
+}
@@ -0,0 +1,57 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupTransformIndexer() {
+	// Add Transform to force indexer execution
+
+	transform := &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Source: TransformSource{
+				Indices: []string{"kibana_sample_data_ecommerce"},
+			},
+			Dest: TransformDest{
+				Index: "kibana_sample_data_ecommerce_transform",
+			},
+			Pivot: &TransformPivot{
+				GroupBy: &apis.MapAny{
+					Data: map[string]any{
+						"customer_id": map[string]any{
+							"terms": map[string]any{
+								"field": "customer_id",
+							},
+						},
+					},
+				},
+				Aggregations: &apis.MapAny{
+					Data: map[string]any{
+						"max_price": map[string]any{
+							"max": map[string]any{
+								"field": "taxful_total_price",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := t.k8sClient.Create(context.Background(), transform)
+	assert.NoError(t.T(), err)
+}
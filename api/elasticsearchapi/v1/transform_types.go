@@ -0,0 +1,285 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// TransformSpec defines the desired state of Transform
+// +k8s:openapi-gen=true
+type TransformSpec struct {
+	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// ElasticsearchRef is the Elasticsearch ref to connect on.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
+
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// Name is the custom transform name
+	// If empty, it use the ressource name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// State is the expected state of the transform
+	// Set `reset` to reset the transform (destination index and checkpoint). The transform stay stopped after reset.
+	// Default to started
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:default=started
+	// +kubebuilder:validation:Enum=started;stopped;reset
+	State TransformState `json:"state,omitempty"`
+
+	// Description is the free text description of the transform
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Source is the source of the data for the transform
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Source TransformSource `json:"source"`
+
+	// Dest is the destination for the transform
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Dest TransformDest `json:"dest"`
+
+	// Frequency is the interval between checks for changes in the source indices when the transform is running continuously
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Frequency string `json:"frequency,omitempty"`
+
+	// Pivot is the pivot method transforms the data by aggregating and grouping it
+	// You can't set it with latest. It's immutable.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Pivot *TransformPivot `json:"pivot,omitempty"`
+
+	// Latest is the latest method transforms the data by finding the latest document for each unique key
+	// You can't set it with pivot. It's immutable.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Latest *TransformLatest `json:"latest,omitempty"`
+
+	// Sync is the properties that contain the synchronization config
+	// It permit to run continuous transform
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Sync *TransformSync `json:"sync,omitempty"`
+
+	// RetentionPolicy is the properties that contain the retention policy for the transform
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	RetentionPolicy *TransformRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// Settings is the properties that contain additional settings
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Settings *apis.MapAny `json:"settings,omitempty"`
+
+	// Metadata is the optional metadata
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Metadata *apis.MapAny `json:"metadata,omitempty"`
+}
+
+// TransformState is the expected state of the transform
+type TransformState string
+
+const (
+	// TransformStateStarted is the started state
+	TransformStateStarted TransformState = "started"
+
+	// TransformStateStopped is the stopped state
+	TransformStateStopped TransformState = "stopped"
+
+	// TransformStateReset permit to reset the transform
+	TransformStateReset TransformState = "reset"
+)
+
+type TransformSource struct {
+	// Indices is the source indices for the transform
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Indices []string `json:"indices"`
+
+	// Query is the query clause that retrieves a subset of data from the source index
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Query *apis.MapAny `json:"query,omitempty"`
+
+	// RuntimeMappings is the definitions of search-time runtime fields that can be used by the transform
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	RuntimeMappings *apis.MapAny `json:"runtimeMappings,omitempty"`
+}
+
+type TransformDest struct {
+	// Index is the destination index for the transform
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Index string `json:"index"`
+
+	// Pipeline is the unique identifier for an ingest pipeline
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Pipeline string `json:"pipeline,omitempty"`
+}
+
+type TransformPivot struct {
+	// GroupBy defines how to group the data
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:pruning:PreserveUnknownFields
+	GroupBy *apis.MapAny `json:"groupBy"`
+
+	// Aggregations defines how to aggregate the grouped data
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Aggregations *apis.MapAny `json:"aggregations"`
+}
+
+type TransformLatest struct {
+	// Sort is the date field that is used to identify the latest documents
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Sort string `json:"sort"`
+
+	// UniqueKey is the list of fields that is used to group the data
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	UniqueKey []string `json:"uniqueKey"`
+}
+
+type TransformSync struct {
+	// Field is the date field that is used to identify new documents in the source
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Field string `json:"field"`
+
+	// Delay is the time delay between the current time and the latest input data time
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Delay string `json:"delay,omitempty"`
+}
+
+type TransformRetentionPolicy struct {
+	// Field is the date field that is used to calculate the age of the document
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	Field string `json:"field"`
+
+	// MaxAge is the age of the document to be retained in the destination index
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	MaxAge string `json:"maxAge"`
+}
+
+// TransformStatus defines the observed state of Transform
+type TransformStatus struct {
+	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
+	// Important: Run "make" to regenerate code after modifying this file
+
+	// State is the current transform state on Elasticsearch
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	State string `json:"state,omitempty"`
+
+	// Health is the current transform health on Elasticsearch
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Health string `json:"health,omitempty"`
+
+	// Checkpoint is the last completed checkpoint
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Checkpoint int64 `json:"checkpoint,omitempty"`
+
+	// DocumentsProcessed is the number of documents that have been processed from the source index
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	DocumentsProcessed int64 `json:"documentsProcessed,omitempty"`
+
+	// DocumentsIndexed is the number of documents that have been indexed into the destination index
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	DocumentsIndexed int64 `json:"documentsIndexed,omitempty"`
+
+	// LastResetGeneration is the generation where the transform has been reset
+	// It avoid to reset the transform on each reconcile
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	LastResetGeneration int64 `json:"lastResetGeneration,omitempty"`
+
+	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+
+// Transform is the Schema for the transforms API
+// +operator-sdk:csv:customresourcedefinitions:resources={{None,None,None}}
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Health",type="string",JSONPath=".status.health"
+// +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
+// +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Transform struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TransformSpec   `json:"spec,omitempty"`
+	Status TransformStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TransformList contains a list of Transform
+type TransformList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Transform `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Transform{}, &TransformList{})
+}
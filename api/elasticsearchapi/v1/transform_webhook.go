@@ -0,0 +1,190 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+type transformValidator struct {
+	logger *logrus.Entry
+	client client.Client
+}
+
+// SetupWebhookWithManager will setup the manager to manage the webhooks
+func SetupTransformWebhookWithManager(logger *logrus.Entry) controller.WebhookRegister {
+	return func(mgr ctrl.Manager, client client.Client) error {
+		return ctrl.NewWebhookManagedBy(mgr).
+			For(&Transform{}).
+			WithValidator(&transformValidator{
+				logger: logger.WithField("webhook", "transformValidator"),
+				client: client,
+			}).
+			Complete()
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-elasticsearchapi-k8s-webcenter-fr-v1-transform,mutating=false,failurePolicy=fail,sideEffects=None,groups=elasticsearchapi.k8s.webcenter.fr,resources=transforms,verbs=create;update,versions=v1,name=transform.elasticsearchapi.k8s.webcenter.fr,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &transformValidator{}
+
+func (r *transformValidator) validateResourceUnicity(obj *Transform) *field.Error {
+	// Check if resource already exist with same name on some remote cluster target
+	listObjects := &TransformList{}
+	fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.externalName=%s,spec.targetCluster=%s", obj.GetExternalName(), obj.Spec.ElasticsearchRef.GetTargetCluster(obj.Namespace)))
+	if err := r.client.List(context.Background(), listObjects, &client.ListOptions{FieldSelector: fs}); err != nil {
+		panic(err)
+	}
+	if len(listObjects.Items) > 0 {
+		isError := false
+		existingResources := make([]string, 0, len(listObjects.Items))
+		for _, ag := range listObjects.Items {
+			// exclude themself
+			if ag.UID != obj.UID {
+				existingResources = append(existingResources, fmt.Sprintf("'%s/%s'", ag.Namespace, ag.Name))
+				isError = true
+			}
+		}
+		if isError {
+			return field.Duplicate(field.NewPath("spec").Child("name"), fmt.Sprintf("There are some same resource that already target the same Elasticsearch cluster with the same name: %s", strings.Join(existingResources, ", ")))
+		}
+	}
+
+	return nil
+}
+
+func (r *transformValidator) validatePivotOrLatest(obj *Transform) *field.Error {
+	if obj.Spec.Pivot != nil && obj.Spec.Latest != nil {
+		return field.Forbidden(field.NewPath("spec").Child("latest"), "When you set field 'spec.pivot', you can't set field 'spec.latest'")
+	}
+	if obj.Spec.Pivot == nil && obj.Spec.Latest == nil {
+		return field.Required(field.NewPath("spec"), "You need to provide 'spec.pivot' or 'spec.latest'")
+	}
+
+	return nil
+}
+
+// validateOneShotReset check the spec is not updated while the state stay on reset
+// The reset delete the destination index, so it need to be an explicit one-shot action and not a side effect of a spec update
+func (r *transformValidator) validateOneShotReset(current, old *Transform) *field.Error {
+	if current.GetExpectedState() != TransformStateReset || old.GetExpectedState() != TransformStateReset {
+		return nil
+	}
+
+	if !equality.Semantic.DeepEqual(current.Spec, old.Spec) {
+		return field.Forbidden(field.NewPath("spec").Child("state"), "The transform is already reset, set the field 'spec.state' to 'started' or 'stopped' before to update the spec")
+	}
+
+	return nil
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *transformValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+
+	transformObj, ok := obj.(*Transform)
+	if !ok {
+		return nil, fmt.Errorf("expected a Transform object but got %T", obj)
+	}
+	r.logger.Debugf("validate create %s/%s", transformObj.GetNamespace(), transformObj.GetName())
+
+	if err := r.validatePivotOrLatest(transformObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := transformObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, transformObj, transformObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateResourceUnicity(transformObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			transformObj.GroupVersionKind().GroupKind(),
+			transformObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *transformValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	var allErrs field.ErrorList
+	oldO := oldObj.(*Transform)
+
+	transformObj, ok := newObj.(*Transform)
+	if !ok {
+		return nil, fmt.Errorf("expected a Transform object but got %T", newObj)
+	}
+	r.logger.Debugf("validate update %s/%s", transformObj.Namespace, transformObj.Name)
+
+	if err := r.validatePivotOrLatest(transformObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := transformObj.Spec.ElasticsearchRef.ValidateField(); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := elasticsearchcrd.ValidateElasticsearchRef(ctx, r.client, transformObj, transformObj.Spec.ElasticsearchRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := validateImmutableName(transformObj, oldO); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateOneShotReset(transformObj, oldO); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if err := r.validateResourceUnicity(transformObj); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
+	if len(allErrs) > 0 {
+		return nil, apierrors.NewInvalid(
+			transformObj.GroupVersionKind().GroupKind(),
+			transformObj.Name, allErrs)
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *transformValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,342 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (t *TestSuite) TestSetupTransformWebhook() {
+	var (
+		o   *Transform
+		err error
+	)
+
+	// Need failed when create same resource by external name on same managed cluster
+	// Check we can update it
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook",
+			Namespace: "default",
+		},
+		Spec: TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name: "webhook",
+			Source: TransformSource{
+				Indices: []string{"kibana_sample_data_ecommerce"},
+			},
+			Dest: TransformDest{
+				Index: "kibana_sample_data_ecommerce_transform",
+			},
+			Pivot: &TransformPivot{
+				GroupBy: &apis.MapAny{
+					Data: map[string]any{
+						"customer_id": map[string]any{
+							"terms": map[string]any{
+								"field": "customer_id",
+							},
+						},
+					},
+				},
+				Aggregations: &apis.MapAny{
+					Data: map[string]any{
+						"max_price": map[string]any{
+							"max": map[string]any{
+								"field": "taxful_total_price",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook2",
+			Namespace: "default",
+		},
+		Spec: TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name: "webhook",
+			Source: TransformSource{
+				Indices: []string{"kibana_sample_data_ecommerce"},
+			},
+			Dest: TransformDest{
+				Index: "kibana_sample_data_ecommerce_transform",
+			},
+			Pivot: &TransformPivot{
+				GroupBy: &apis.MapAny{
+					Data: map[string]any{
+						"customer_id": map[string]any{
+							"terms": map[string]any{
+								"field": "customer_id",
+							},
+						},
+					},
+				},
+				Aggregations: &apis.MapAny{
+					Data: map[string]any{
+						"max_price": map[string]any{
+							"max": map[string]any{
+								"field": "taxful_total_price",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when create same resource by external name on same external cluster
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook3",
+			Namespace: "default",
+		},
+		Spec: TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test.local"},
+				},
+			},
+			Name: "webhook",
+			Source: TransformSource{
+				Indices: []string{"kibana_sample_data_ecommerce"},
+			},
+			Dest: TransformDest{
+				Index: "kibana_sample_data_ecommerce_transform",
+			},
+			Pivot: &TransformPivot{
+				GroupBy: &apis.MapAny{
+					Data: map[string]any{
+						"customer_id": map[string]any{
+							"terms": map[string]any{
+								"field": "customer_id",
+							},
+						},
+					},
+				},
+				Aggregations: &apis.MapAny{
+					Data: map[string]any{
+						"max_price": map[string]any{
+							"max": map[string]any{
+								"field": "taxful_total_price",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook4",
+			Namespace: "default",
+		},
+		Spec: TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+					Addresses: []string{"https://test.local"},
+				},
+			},
+			Name: "webhook",
+			Source: TransformSource{
+				Indices: []string{"kibana_sample_data_ecommerce"},
+			},
+			Dest: TransformDest{
+				Index: "kibana_sample_data_ecommerce_transform",
+			},
+			Pivot: &TransformPivot{
+				GroupBy: &apis.MapAny{
+					Data: map[string]any{
+						"customer_id": map[string]any{
+							"terms": map[string]any{
+								"field": "customer_id",
+							},
+						},
+					},
+				},
+				Aggregations: &apis.MapAny{
+					Data: map[string]any{
+						"max_price": map[string]any{
+							"max": map[string]any{
+								"field": "taxful_total_price",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when not specify target Elasticsearch cluster
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook5",
+			Namespace: "default",
+		},
+		Spec: TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{},
+			Source: TransformSource{
+				Indices: []string{"kibana_sample_data_ecommerce"},
+			},
+			Dest: TransformDest{
+				Index: "kibana_sample_data_ecommerce_transform",
+			},
+			Pivot: &TransformPivot{
+				GroupBy: &apis.MapAny{
+					Data: map[string]any{
+						"customer_id": map[string]any{
+							"terms": map[string]any{
+								"field": "customer_id",
+							},
+						},
+					},
+				},
+				Aggregations: &apis.MapAny{
+					Data: map[string]any{
+						"max_price": map[string]any{
+							"max": map[string]any{
+								"field": "taxful_total_price",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when set pivot and latest
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook6",
+			Namespace: "default",
+		},
+		Spec: TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Source: TransformSource{
+				Indices: []string{"kibana_sample_data_ecommerce"},
+			},
+			Dest: TransformDest{
+				Index: "kibana_sample_data_ecommerce_transform",
+			},
+			Pivot: &TransformPivot{
+				GroupBy: &apis.MapAny{
+					Data: map[string]any{
+						"customer_id": map[string]any{
+							"terms": map[string]any{
+								"field": "customer_id",
+							},
+						},
+					},
+				},
+				Aggregations: &apis.MapAny{
+					Data: map[string]any{
+						"max_price": map[string]any{
+							"max": map[string]any{
+								"field": "taxful_total_price",
+							},
+						},
+					},
+				},
+			},
+			Latest: &TransformLatest{
+				Sort:      "order_date",
+				UniqueKey: []string{"customer_id"},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when not set pivot or latest
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook7",
+			Namespace: "default",
+		},
+		Spec: TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Source: TransformSource{
+				Indices: []string{"kibana_sample_data_ecommerce"},
+			},
+			Dest: TransformDest{
+				Index: "kibana_sample_data_ecommerce_transform",
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need failed when update the spec and keep the reset state
+	o = &Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-webhook8",
+			Namespace: "default",
+		},
+		Spec: TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Name:  "webhook8",
+			State: TransformStateReset,
+			Source: TransformSource{
+				Indices: []string{"kibana_sample_data_ecommerce"},
+			},
+			Dest: TransformDest{
+				Index: "kibana_sample_data_ecommerce_transform",
+			},
+			Latest: &TransformLatest{
+				Sort:      "order_date",
+				UniqueKey: []string{"customer_id"},
+			},
+		},
+	}
+	err = t.k8sClient.Create(context.Background(), o)
+	assert.NoError(t.T(), err)
+	o.Spec.Description = "new description"
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.Error(t.T(), err)
+
+	// Need succeed when leave the reset state
+	o.Spec.State = TransformStateStopped
+	err = t.k8sClient.Update(context.Background(), o)
+	assert.NoError(t.T(), err)
+}
@@ -1,6 +1,9 @@
 package v1
 
-import "github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+)
 
 // GetStatus return the status object
 func (o *Watch) GetStatus() object.RemoteObjectStatus {
@@ -16,3 +19,43 @@ func (o *Watch) GetExternalName() string {
 
 	return o.Spec.Name
 }
+
+// GetDeletionPolicy return the policy applied on the remote object when the resource is deleted
+func (o *Watch) GetDeletionPolicy() shared.DeletionPolicy {
+	return o.Spec.DeletionPolicy
+}
+
+// GetAdoptionPolicy return the policy applied when the remote object already exist
+func (o *Watch) GetAdoptionPolicy() shared.AdoptionPolicy {
+	return o.Spec.AdoptionPolicy
+}
+
+// GetAdoptionStatus return the adoption status
+func (o *Watch) GetAdoptionStatus() *shared.AdoptionStatus {
+	return o.Status.Adoption
+}
+
+// SetAdoptionStatus set the adoption status
+func (o *Watch) SetAdoptionStatus(status *shared.AdoptionStatus) {
+	o.Status.Adoption = status
+}
+
+// GetDryRunStatus return the dry-run status
+func (o *Watch) GetDryRunStatus() *shared.DryRunStatus {
+	return o.Status.DryRun
+}
+
+// SetDryRunStatus set the dry-run status
+func (o *Watch) SetDryRunStatus(status *shared.DryRunStatus) {
+	o.Status.DryRun = status
+}
+
+// IsActive return true if the watch must be active
+// Default to true
+func (o *Watch) IsActive() bool {
+	if o.Spec.Active == nil {
+		return true
+	}
+
+	return *o.Spec.Active
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/remote"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestWatchGetStatus(t *testing.T) {
@@ -52,3 +53,29 @@ func TestWatchGetExternalName(t *testing.T) {
 
 	assert.Equal(t, "test", o.GetExternalName())
 }
+
+func TestWatchIsActive(t *testing.T) {
+	var o *Watch
+
+	// When active isn't set
+	o = &Watch{
+		Spec: WatchSpec{},
+	}
+	assert.True(t, o.IsActive())
+
+	// When active is true
+	o = &Watch{
+		Spec: WatchSpec{
+			Active: ptr.To(true),
+		},
+	}
+	assert.True(t, o.IsActive())
+
+	// When active is false
+	o = &Watch{
+		Spec: WatchSpec{
+			Active: ptr.To(false),
+		},
+	}
+	assert.False(t, o.IsActive())
+}
@@ -36,12 +36,37 @@ type WatchSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	ElasticsearchRef shared.ElasticsearchRef `json:"elasticsearchRef"`
 
+	// DeletionPolicy is the policy applied on the remote object when the resource is deleted
+	// Use Orphan to keep the remote object, for instance to migrate the resource on another namespace
+	// Default to Delete
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy shared.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+	// Apply record the remote object and the diff on status, then apply the resource
+	// Manual record the remote object and the diff on status, then wait the annotation `adopt` before apply the resource
+	// Default to Apply
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=Apply
+	// +optional
+	AdoptionPolicy shared.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
 	// Name is the custom watch name
 	// If empty, it use the ressource name
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Name string `json:"name,omitempty"`
 
+	// Active is the watch state
+	// Set false to deactivate the watch without delete it
+	// Default to true
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +kubebuilder:default=true
+	// +optional
+	Active *bool `json:"active,omitempty"`
+
 	// Trigger
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -91,6 +116,128 @@ type WatchStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	remote.DefaultRemoteObjectStatus `json:",inline"`
+
+	// Adoption is the adoption status when the remote object already exist before the operator take the control on it
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Adoption *shared.AdoptionStatus `json:"adoption,omitempty"`
+
+	// DryRun is the change that will be applied on the remote object when the dry-run annotation is set
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	DryRun *shared.DryRunStatus `json:"dryRun,omitempty"`
+
+	// Active is the current state of the watch
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Active *bool `json:"active,omitempty"`
+
+	// LastChecked is the last time the watch is executed
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastChecked *metav1.Time `json:"lastChecked,omitempty"`
+
+	// LastMetCondition is the last time the condition of the watch is met
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastMetCondition *metav1.Time `json:"lastMetCondition,omitempty"`
+
+	// ConditionMet is true when the condition is met on the last execution
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ConditionMet *bool `json:"conditionMet,omitempty"`
+
+	// ExecutionState is the state of the last execution
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	ExecutionState string `json:"executionState,omitempty"`
+
+	// Actions is the status of the actions
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Actions []WatchActionStatus `json:"actions,omitempty"`
+
+	// LastSimulation is the last execution requested with the execute annotation
+	// The actions are simulated, so no notification is sent
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastSimulation *WatchSimulation `json:"lastSimulation,omitempty"`
+}
+
+// WatchActionStatus is the status of an action
+type WatchActionStatus struct {
+	// Name is the action name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// AckState is the acknowledgement state of the action
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	AckState string `json:"ackState,omitempty"`
+
+	// LastExecution is the last time the action is executed
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastExecution *metav1.Time `json:"lastExecution,omitempty"`
+
+	// Successful is true when the last execution of the action is successful
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Successful *bool `json:"successful,omitempty"`
+
+	// Reason is the failure reason of the last execution
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// LastThrottle is the last time the action is throttled
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	LastThrottle *metav1.Time `json:"lastThrottle,omitempty"`
+}
+
+// WatchSimulation is the result of a simulated execution of the watch
+type WatchSimulation struct {
+	// Time is the time of the execution
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// State is the state of the execution
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	State string `json:"state,omitempty"`
+
+	// ConditionMet is true when the condition is met
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	ConditionMet bool `json:"conditionMet"`
+
+	// Actions is the result of the simulated actions
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Actions []WatchSimulatedAction `json:"actions,omitempty"`
+}
+
+// WatchSimulatedAction is the result of a simulated action
+type WatchSimulatedAction struct {
+	// Name is the action name
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Name string `json:"name"`
+
+	// Type is the action type
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Status is the status of the action, like simulated, throttled or failure
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// Reason is the reason when the action is not simulated
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -102,6 +249,7 @@ type WatchStatus struct {
 // +kubebuilder:printcolumn:name="Sync",type="boolean",JSONPath=".status.isSync"
 // +kubebuilder:printcolumn:name="Error",type="boolean",JSONPath=".status.isOnError",description="Is on error"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="health"
+// +kubebuilder:printcolumn:name="Active",type="boolean",JSONPath=".status.active",description="Is active"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type Watch struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -21,10 +21,132 @@ limitations under the License.
 package v1
 
 import (
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFollowPattern) DeepCopyInto(out *AutoFollowPattern) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFollowPattern.
+func (in *AutoFollowPattern) DeepCopy() *AutoFollowPattern {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFollowPattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoFollowPattern) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFollowPatternList) DeepCopyInto(out *AutoFollowPatternList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoFollowPattern, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFollowPatternList.
+func (in *AutoFollowPatternList) DeepCopy() *AutoFollowPatternList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFollowPatternList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoFollowPatternList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFollowPatternSpec) DeepCopyInto(out *AutoFollowPatternSpec) {
+	*out = *in
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	if in.LeaderIndexPatterns != nil {
+		in, out := &in.LeaderIndexPatterns, &out.LeaderIndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LeaderIndexExclusionPatterns != nil {
+		in, out := &in.LeaderIndexExclusionPatterns, &out.LeaderIndexExclusionPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = (*in).DeepCopy()
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(FollowerIndexParameters)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFollowPatternSpec.
+func (in *AutoFollowPatternSpec) DeepCopy() *AutoFollowPatternSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFollowPatternSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoFollowPatternStatus) DeepCopyInto(out *AutoFollowPatternStatus) {
+	*out = *in
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoFollowPatternStatus.
+func (in *AutoFollowPatternStatus) DeepCopy() *AutoFollowPatternStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoFollowPatternStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentTemplate) DeepCopyInto(out *ComponentTemplate) {
 	*out = *in
@@ -121,6 +243,16 @@ func (in *ComponentTemplateSpec) DeepCopy() *ComponentTemplateSpec {
 func (in *ComponentTemplateStatus) DeepCopyInto(out *ComponentTemplateStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentTemplateStatus.
@@ -134,7 +266,7 @@ func (in *ComponentTemplateStatus) DeepCopy() *ComponentTemplateStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicy) DeepCopyInto(out *IndexLifecyclePolicy) {
+func (in *FollowerIndex) DeepCopyInto(out *FollowerIndex) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -142,18 +274,18 @@ func (in *IndexLifecyclePolicy) DeepCopyInto(out *IndexLifecyclePolicy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicy.
-func (in *IndexLifecyclePolicy) DeepCopy() *IndexLifecyclePolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FollowerIndex.
+func (in *FollowerIndex) DeepCopy() *FollowerIndex {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicy)
+	out := new(FollowerIndex)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexLifecyclePolicy) DeepCopyObject() runtime.Object {
+func (in *FollowerIndex) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -161,31 +293,31 @@ func (in *IndexLifecyclePolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicyList) DeepCopyInto(out *IndexLifecyclePolicyList) {
+func (in *FollowerIndexList) DeepCopyInto(out *FollowerIndexList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IndexLifecyclePolicy, len(*in))
+		*out = make([]FollowerIndex, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyList.
-func (in *IndexLifecyclePolicyList) DeepCopy() *IndexLifecyclePolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FollowerIndexList.
+func (in *FollowerIndexList) DeepCopy() *FollowerIndexList {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicyList)
+	out := new(FollowerIndexList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexLifecyclePolicyList) DeepCopyObject() runtime.Object {
+func (in *FollowerIndexList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -193,208 +325,265 @@ func (in *IndexLifecyclePolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicySpec) DeepCopyInto(out *IndexLifecyclePolicySpec) {
+func (in *FollowerIndexParameters) DeepCopyInto(out *FollowerIndexParameters) {
 	*out = *in
-	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
-	if in.RawPolicy != nil {
-		in, out := &in.RawPolicy, &out.RawPolicy
-		*out = new(string)
+	if in.MaxReadRequestOperationCount != nil {
+		in, out := &in.MaxReadRequestOperationCount, &out.MaxReadRequestOperationCount
+		*out = new(int64)
 		**out = **in
 	}
-	if in.Policy != nil {
-		in, out := &in.Policy, &out.Policy
-		*out = new(IndexLifecyclePolicySpecPolicy)
-		(*in).DeepCopyInto(*out)
+	if in.MaxOutstandingReadRequests != nil {
+		in, out := &in.MaxOutstandingReadRequests, &out.MaxOutstandingReadRequests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxWriteRequestOperationCount != nil {
+		in, out := &in.MaxWriteRequestOperationCount, &out.MaxWriteRequestOperationCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxOutstandingWriteRequests != nil {
+		in, out := &in.MaxOutstandingWriteRequests, &out.MaxOutstandingWriteRequests
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxWriteBufferCount != nil {
+		in, out := &in.MaxWriteBufferCount, &out.MaxWriteBufferCount
+		*out = new(int64)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpec.
-func (in *IndexLifecyclePolicySpec) DeepCopy() *IndexLifecyclePolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FollowerIndexParameters.
+func (in *FollowerIndexParameters) DeepCopy() *FollowerIndexParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicySpec)
+	out := new(FollowerIndexParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicySpecPolicy) DeepCopyInto(out *IndexLifecyclePolicySpecPolicy) {
+func (in *FollowerIndexSpec) DeepCopyInto(out *FollowerIndexSpec) {
 	*out = *in
-	if in.Meta != nil {
-		in, out := &in.Meta, &out.Meta
-		*out = (*in).DeepCopy()
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(FollowerIndexParameters)
+		(*in).DeepCopyInto(*out)
 	}
-	in.Phases.DeepCopyInto(&out.Phases)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpecPolicy.
-func (in *IndexLifecyclePolicySpecPolicy) DeepCopy() *IndexLifecyclePolicySpecPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FollowerIndexSpec.
+func (in *FollowerIndexSpec) DeepCopy() *FollowerIndexSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicySpecPolicy)
+	out := new(FollowerIndexSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicySpecPolicyPhases) DeepCopyInto(out *IndexLifecyclePolicySpecPolicyPhases) {
+func (in *FollowerIndexStatus) DeepCopyInto(out *FollowerIndexStatus) {
 	*out = *in
-	if in.Hot != nil {
-		in, out := &in.Hot, &out.Hot
-		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Warm != nil {
-		in, out := &in.Warm, &out.Warm
-		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
-		(*in).DeepCopyInto(*out)
+	if in.LastReadTime != nil {
+		in, out := &in.LastReadTime, &out.LastReadTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Cold != nil {
-		in, out := &in.Cold, &out.Cold
-		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
-		(*in).DeepCopyInto(*out)
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
 	}
-	if in.Frozen != nil {
-		in, out := &in.Frozen, &out.Frozen
-		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
-		(*in).DeepCopyInto(*out)
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
 	}
-	if in.Delete != nil {
-		in, out := &in.Delete, &out.Delete
-		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FollowerIndexStatus.
+func (in *FollowerIndexStatus) DeepCopy() *FollowerIndexStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(FollowerIndexStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpecPolicyPhases.
-func (in *IndexLifecyclePolicySpecPolicyPhases) DeepCopy() *IndexLifecyclePolicySpecPolicyPhases {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicy) DeepCopyInto(out *IndexLifecyclePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicy.
+func (in *IndexLifecyclePolicy) DeepCopy() *IndexLifecyclePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicySpecPolicyPhases)
+	out := new(IndexLifecyclePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexLifecyclePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicySpecPolicyPhasesPhase) DeepCopyInto(out *IndexLifecyclePolicySpecPolicyPhasesPhase) {
+func (in *IndexLifecyclePolicyAllocateAction) DeepCopyInto(out *IndexLifecyclePolicyAllocateAction) {
 	*out = *in
-	if in.MinAge != nil {
-		in, out := &in.MinAge, &out.MinAge
-		*out = new(string)
+	if in.NumberOfReplicas != nil {
+		in, out := &in.NumberOfReplicas, &out.NumberOfReplicas
+		*out = new(int64)
 		**out = **in
 	}
-	in.Actions.DeepCopyInto(&out.Actions)
+	if in.TotalShardsPerNode != nil {
+		in, out := &in.TotalShardsPerNode, &out.TotalShardsPerNode
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Require != nil {
+		in, out := &in.Require, &out.Require
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpecPolicyPhasesPhase.
-func (in *IndexLifecyclePolicySpecPolicyPhasesPhase) DeepCopy() *IndexLifecyclePolicySpecPolicyPhasesPhase {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyAllocateAction.
+func (in *IndexLifecyclePolicyAllocateAction) DeepCopy() *IndexLifecyclePolicyAllocateAction {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicySpecPolicyPhasesPhase)
+	out := new(IndexLifecyclePolicyAllocateAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicyStatus) DeepCopyInto(out *IndexLifecyclePolicyStatus) {
+func (in *IndexLifecyclePolicyDeleteAction) DeepCopyInto(out *IndexLifecyclePolicyDeleteAction) {
 	*out = *in
-	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.DeleteSearchableSnapshot != nil {
+		in, out := &in.DeleteSearchableSnapshot, &out.DeleteSearchableSnapshot
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyStatus.
-func (in *IndexLifecyclePolicyStatus) DeepCopy() *IndexLifecyclePolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyDeleteAction.
+func (in *IndexLifecyclePolicyDeleteAction) DeepCopy() *IndexLifecyclePolicyDeleteAction {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicyStatus)
+	out := new(IndexLifecyclePolicyDeleteAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexTemplate) DeepCopyInto(out *IndexTemplate) {
+func (in *IndexLifecyclePolicyFailedIndex) DeepCopyInto(out *IndexLifecyclePolicyFailedIndex) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplate.
-func (in *IndexTemplate) DeepCopy() *IndexTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyFailedIndex.
+func (in *IndexLifecyclePolicyFailedIndex) DeepCopy() *IndexLifecyclePolicyFailedIndex {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexTemplate)
+	out := new(IndexLifecyclePolicyFailedIndex)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexTemplate) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicyForcemergeAction) DeepCopyInto(out *IndexLifecyclePolicyForcemergeAction) {
+	*out = *in
+	if in.IndexCodec != nil {
+		in, out := &in.IndexCodec, &out.IndexCodec
+		*out = new(string)
+		**out = **in
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyForcemergeAction.
+func (in *IndexLifecyclePolicyForcemergeAction) DeepCopy() *IndexLifecyclePolicyForcemergeAction {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicyForcemergeAction)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexTemplateData) DeepCopyInto(out *IndexTemplateData) {
+func (in *IndexLifecyclePolicyFreezeAction) DeepCopyInto(out *IndexLifecyclePolicyFreezeAction) {
 	*out = *in
-	if in.Settings != nil {
-		in, out := &in.Settings, &out.Settings
-		*out = (*in).DeepCopy()
-	}
-	if in.Mappings != nil {
-		in, out := &in.Mappings, &out.Mappings
-		*out = (*in).DeepCopy()
-	}
-	if in.Aliases != nil {
-		in, out := &in.Aliases, &out.Aliases
-		*out = (*in).DeepCopy()
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateData.
-func (in *IndexTemplateData) DeepCopy() *IndexTemplateData {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyFreezeAction.
+func (in *IndexLifecyclePolicyFreezeAction) DeepCopy() *IndexLifecyclePolicyFreezeAction {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexTemplateData)
+	out := new(IndexLifecyclePolicyFreezeAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexTemplateList) DeepCopyInto(out *IndexTemplateList) {
+func (in *IndexLifecyclePolicyList) DeepCopyInto(out *IndexLifecyclePolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IndexTemplate, len(*in))
+		*out = make([]IndexLifecyclePolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateList.
-func (in *IndexTemplateList) DeepCopy() *IndexTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyList.
+func (in *IndexLifecyclePolicyList) DeepCopy() *IndexLifecyclePolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexTemplateList)
+	out := new(IndexLifecyclePolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexTemplateList) DeepCopyObject() runtime.Object {
+func (in *IndexLifecyclePolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -402,63 +591,1694 @@ func (in *IndexTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexTemplateSpec) DeepCopyInto(out *IndexTemplateSpec) {
+func (in *IndexLifecyclePolicyMigrateAction) DeepCopyInto(out *IndexLifecyclePolicyMigrateAction) {
 	*out = *in
-	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
-	if in.IndexPatterns != nil {
-		in, out := &in.IndexPatterns, &out.IndexPatterns
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
 	}
-	if in.ComposedOf != nil {
-		in, out := &in.ComposedOf, &out.ComposedOf
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyMigrateAction.
+func (in *IndexLifecyclePolicyMigrateAction) DeepCopy() *IndexLifecyclePolicyMigrateAction {
+	if in == nil {
+		return nil
 	}
-	if in.Template != nil {
-		in, out := &in.Template, &out.Template
-		*out = new(IndexTemplateData)
+	out := new(IndexLifecyclePolicyMigrateAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicyReadonlyAction) DeepCopyInto(out *IndexLifecyclePolicyReadonlyAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyReadonlyAction.
+func (in *IndexLifecyclePolicyReadonlyAction) DeepCopy() *IndexLifecyclePolicyReadonlyAction {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicyReadonlyAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicyRolloverAction) DeepCopyInto(out *IndexLifecyclePolicyRolloverAction) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxDocs != nil {
+		in, out := &in.MaxDocs, &out.MaxDocs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxPrimaryShardSize != nil {
+		in, out := &in.MaxPrimaryShardSize, &out.MaxPrimaryShardSize
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxPrimaryShardDocs != nil {
+		in, out := &in.MaxPrimaryShardDocs, &out.MaxPrimaryShardDocs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinAge != nil {
+		in, out := &in.MinAge, &out.MinAge
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinDocs != nil {
+		in, out := &in.MinDocs, &out.MinDocs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinPrimaryShardSize != nil {
+		in, out := &in.MinPrimaryShardSize, &out.MinPrimaryShardSize
+		*out = new(string)
+		**out = **in
+	}
+	if in.MinPrimaryShardDocs != nil {
+		in, out := &in.MinPrimaryShardDocs, &out.MinPrimaryShardDocs
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyRolloverAction.
+func (in *IndexLifecyclePolicyRolloverAction) DeepCopy() *IndexLifecyclePolicyRolloverAction {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicyRolloverAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicySearchableSnapshotAction) DeepCopyInto(out *IndexLifecyclePolicySearchableSnapshotAction) {
+	*out = *in
+	if in.ForceMergeIndex != nil {
+		in, out := &in.ForceMergeIndex, &out.ForceMergeIndex
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySearchableSnapshotAction.
+func (in *IndexLifecyclePolicySearchableSnapshotAction) DeepCopy() *IndexLifecyclePolicySearchableSnapshotAction {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicySearchableSnapshotAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicyShrinkAction) DeepCopyInto(out *IndexLifecyclePolicyShrinkAction) {
+	*out = *in
+	if in.NumberOfShards != nil {
+		in, out := &in.NumberOfShards, &out.NumberOfShards
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPrimaryShardSize != nil {
+		in, out := &in.MaxPrimaryShardSize, &out.MaxPrimaryShardSize
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowWriteAfterShrink != nil {
+		in, out := &in.AllowWriteAfterShrink, &out.AllowWriteAfterShrink
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyShrinkAction.
+func (in *IndexLifecyclePolicyShrinkAction) DeepCopy() *IndexLifecyclePolicyShrinkAction {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicyShrinkAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicySpec) DeepCopyInto(out *IndexLifecyclePolicySpec) {
+	*out = *in
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	if in.RawPolicy != nil {
+		in, out := &in.RawPolicy, &out.RawPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(IndexLifecyclePolicySpecPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpec.
+func (in *IndexLifecyclePolicySpec) DeepCopy() *IndexLifecyclePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicySpecPolicy) DeepCopyInto(out *IndexLifecyclePolicySpecPolicy) {
+	*out = *in
 	if in.Meta != nil {
 		in, out := &in.Meta, &out.Meta
 		*out = (*in).DeepCopy()
 	}
-	if in.RawTemplate != nil {
-		in, out := &in.RawTemplate, &out.RawTemplate
+	in.Phases.DeepCopyInto(&out.Phases)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpecPolicy.
+func (in *IndexLifecyclePolicySpecPolicy) DeepCopy() *IndexLifecyclePolicySpecPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicySpecPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicySpecPolicyPhases) DeepCopyInto(out *IndexLifecyclePolicySpecPolicyPhases) {
+	*out = *in
+	if in.Hot != nil {
+		in, out := &in.Hot, &out.Hot
+		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Warm != nil {
+		in, out := &in.Warm, &out.Warm
+		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cold != nil {
+		in, out := &in.Cold, &out.Cold
+		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Frozen != nil {
+		in, out := &in.Frozen, &out.Frozen
+		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(IndexLifecyclePolicySpecPolicyPhasesPhase)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpecPolicyPhases.
+func (in *IndexLifecyclePolicySpecPolicyPhases) DeepCopy() *IndexLifecyclePolicySpecPolicyPhases {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicySpecPolicyPhases)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicySpecPolicyPhasesPhase) DeepCopyInto(out *IndexLifecyclePolicySpecPolicyPhasesPhase) {
+	*out = *in
+	if in.MinAge != nil {
+		in, out := &in.MinAge, &out.MinAge
 		*out = new(string)
 		**out = **in
 	}
+	in.Actions.DeepCopyInto(&out.Actions)
+	if in.Rollover != nil {
+		in, out := &in.Rollover, &out.Rollover
+		*out = new(IndexLifecyclePolicyRolloverAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Shrink != nil {
+		in, out := &in.Shrink, &out.Shrink
+		*out = new(IndexLifecyclePolicyShrinkAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Forcemerge != nil {
+		in, out := &in.Forcemerge, &out.Forcemerge
+		*out = new(IndexLifecyclePolicyForcemergeAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SearchableSnapshot != nil {
+		in, out := &in.SearchableSnapshot, &out.SearchableSnapshot
+		*out = new(IndexLifecyclePolicySearchableSnapshotAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Allocate != nil {
+		in, out := &in.Allocate, &out.Allocate
+		*out = new(IndexLifecyclePolicyAllocateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migrate != nil {
+		in, out := &in.Migrate, &out.Migrate
+		*out = new(IndexLifecyclePolicyMigrateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Freeze != nil {
+		in, out := &in.Freeze, &out.Freeze
+		*out = new(IndexLifecyclePolicyFreezeAction)
+		**out = **in
+	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(IndexLifecyclePolicyReadonlyAction)
+		**out = **in
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(IndexLifecyclePolicyDeleteAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpecPolicyPhasesPhase.
+func (in *IndexLifecyclePolicySpecPolicyPhasesPhase) DeepCopy() *IndexLifecyclePolicySpecPolicyPhasesPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicySpecPolicyPhasesPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicyStatus) DeepCopyInto(out *IndexLifecyclePolicyStatus) {
+	*out = *in
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
+	if in.InUseBy != nil {
+		in, out := &in.InUseBy, &out.InUseBy
+		*out = new(IndexLifecyclePolicyUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailedIndices != nil {
+		in, out := &in.FailedIndices, &out.FailedIndices
+		*out = make([]IndexLifecyclePolicyFailedIndex, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRetryTime != nil {
+		in, out := &in.LastRetryTime, &out.LastRetryTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyStatus.
+func (in *IndexLifecyclePolicyStatus) DeepCopy() *IndexLifecyclePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicyUsage) DeepCopyInto(out *IndexLifecyclePolicyUsage) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DataStreams != nil {
+		in, out := &in.DataStreams, &out.DataStreams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComposableTemplates != nil {
+		in, out := &in.ComposableTemplates, &out.ComposableTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyUsage.
+func (in *IndexLifecyclePolicyUsage) DeepCopy() *IndexLifecyclePolicyUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicyUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplate) DeepCopyInto(out *IndexTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplate.
+func (in *IndexTemplate) DeepCopy() *IndexTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplateData) DeepCopyInto(out *IndexTemplateData) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = (*in).DeepCopy()
+	}
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = (*in).DeepCopy()
+	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateData.
+func (in *IndexTemplateData) DeepCopy() *IndexTemplateData {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplateData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplateList) DeepCopyInto(out *IndexTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IndexTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateList.
+func (in *IndexTemplateList) DeepCopy() *IndexTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplateOverlapping) DeepCopyInto(out *IndexTemplateOverlapping) {
+	*out = *in
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateOverlapping.
+func (in *IndexTemplateOverlapping) DeepCopy() *IndexTemplateOverlapping {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplateOverlapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplateSimulation) DeepCopyInto(out *IndexTemplateSimulation) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = (*in).DeepCopy()
+	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComposedOf != nil {
+		in, out := &in.ComposedOf, &out.ComposedOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Overlapping != nil {
+		in, out := &in.Overlapping, &out.Overlapping
+		*out = make([]IndexTemplateOverlapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateSimulation.
+func (in *IndexTemplateSimulation) DeepCopy() *IndexTemplateSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplateSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplateSpec) DeepCopyInto(out *IndexTemplateSpec) {
+	*out = *in
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ComposedOf != nil {
+		in, out := &in.ComposedOf, &out.ComposedOf
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(IndexTemplateData)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Meta != nil {
+		in, out := &in.Meta, &out.Meta
+		*out = (*in).DeepCopy()
+	}
+	if in.RawTemplate != nil {
+		in, out := &in.RawTemplate, &out.RawTemplate
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateSpec.
+func (in *IndexTemplateSpec) DeepCopy() *IndexTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplateStatus) DeepCopyInto(out *IndexTemplateStatus) {
+	*out = *in
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
+	if in.Simulation != nil {
+		in, out := &in.Simulation, &out.Simulation
+		*out = new(IndexTemplateSimulation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateStatus.
+func (in *IndexTemplateStatus) DeepCopy() *IndexTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *License) DeepCopyInto(out *License) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new License.
+func (in *License) DeepCopy() *License {
+	if in == nil {
+		return nil
+	}
+	out := new(License)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *License) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LicenseList) DeepCopyInto(out *LicenseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]License, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseList.
+func (in *LicenseList) DeepCopy() *LicenseList {
+	if in == nil {
+		return nil
+	}
+	out := new(LicenseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LicenseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LicenseSpec) DeepCopyInto(out *LicenseSpec) {
+	*out = *in
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Basic != nil {
+		in, out := &in.Basic, &out.Basic
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Trial != nil {
+		in, out := &in.Trial, &out.Trial
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExpirationWarningDays != nil {
+		in, out := &in.ExpirationWarningDays, &out.ExpirationWarningDays
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseSpec.
+func (in *LicenseSpec) DeepCopy() *LicenseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LicenseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LicenseStatus) DeepCopyInto(out *LicenseStatus) {
+	*out = *in
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseStatus.
+func (in *LicenseStatus) DeepCopy() *LicenseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LicenseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCluster) DeepCopyInto(out *RemoteCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCluster.
+func (in *RemoteCluster) DeepCopy() *RemoteCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterApiKeyAccess) DeepCopyInto(out *RemoteClusterApiKeyAccess) {
+	*out = *in
+	if in.Search != nil {
+		in, out := &in.Search, &out.Search
+		*out = make([]RemoteClusterApiKeyAccessIndices, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Replication != nil {
+		in, out := &in.Replication, &out.Replication
+		*out = make([]RemoteClusterApiKeyAccessIndices, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterApiKeyAccess.
+func (in *RemoteClusterApiKeyAccess) DeepCopy() *RemoteClusterApiKeyAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterApiKeyAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterApiKeyAccessIndices) DeepCopyInto(out *RemoteClusterApiKeyAccessIndices) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterApiKeyAccessIndices.
+func (in *RemoteClusterApiKeyAccessIndices) DeepCopy() *RemoteClusterApiKeyAccessIndices {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterApiKeyAccessIndices)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterList) DeepCopyInto(out *RemoteClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterList.
+func (in *RemoteClusterList) DeepCopy() *RemoteClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterSecurity) DeepCopyInto(out *RemoteClusterSecurity) {
+	*out = *in
+	if in.ApiKeySecretRef != nil {
+		in, out := &in.ApiKeySecretRef, &out.ApiKeySecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = new(RemoteClusterApiKeyAccess)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterSecurity.
+func (in *RemoteClusterSecurity) DeepCopy() *RemoteClusterSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterSpec) DeepCopyInto(out *RemoteClusterSpec) {
+	*out = *in
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	in.Remote.DeepCopyInto(&out.Remote)
+	if in.SkipUnavailable != nil {
+		in, out := &in.SkipUnavailable, &out.SkipUnavailable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeConnections != nil {
+		in, out := &in.NodeConnections, &out.NodeConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ProxySocketConnections != nil {
+		in, out := &in.ProxySocketConnections, &out.ProxySocketConnections
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Security != nil {
+		in, out := &in.Security, &out.Security
+		*out = new(RemoteClusterSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterSpec.
+func (in *RemoteClusterSpec) DeepCopy() *RemoteClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterStatus) DeepCopyInto(out *RemoteClusterStatus) {
+	*out = *in
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterStatus.
+func (in *RemoteClusterStatus) DeepCopy() *RemoteClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteClusterTarget) DeepCopyInto(out *RemoteClusterTarget) {
+	*out = *in
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(shared.ElasticsearchManagedRef)
+		**out = **in
+	}
+	if in.Seeds != nil {
+		in, out := &in.Seeds, &out.Seeds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteClusterTarget.
+func (in *RemoteClusterTarget) DeepCopy() *RemoteClusterTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteClusterTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSet) DeepCopyInto(out *ResourceSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSet.
+func (in *ResourceSet) DeepCopy() *ResourceSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSetClusterStatus) DeepCopyInto(out *ResourceSetClusterStatus) {
+	*out = *in
+	if in.ResourcesOnError != nil {
+		in, out := &in.ResourcesOnError, &out.ResourcesOnError
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSetClusterStatus.
+func (in *ResourceSetClusterStatus) DeepCopy() *ResourceSetClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSetClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSetList) DeepCopyInto(out *ResourceSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourceSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSetList.
+func (in *ResourceSetList) DeepCopy() *ResourceSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourceSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSetSpec) DeepCopyInto(out *ResourceSetSpec) {
+	*out = *in
+	in.ElasticsearchSelector.DeepCopyInto(&out.ElasticsearchSelector)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]ResourceSetTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSetSpec.
+func (in *ResourceSetSpec) DeepCopy() *ResourceSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSetStatus) DeepCopyInto(out *ResourceSetStatus) {
+	*out = *in
+	in.DefaultMultiPhaseObjectStatus.DeepCopyInto(&out.DefaultMultiPhaseObjectStatus)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ResourceSetClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSetStatus.
+func (in *ResourceSetStatus) DeepCopy() *ResourceSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSetTemplate) DeepCopyInto(out *ResourceSetTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSetTemplate.
+func (in *ResourceSetTemplate) DeepCopy() *ResourceSetTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Restore) DeepCopyInto(out *Restore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Restore.
+func (in *Restore) DeepCopy() *Restore {
+	if in == nil {
+		return nil
+	}
+	out := new(Restore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Restore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreList) DeepCopyInto(out *RestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Restore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreList.
+func (in *RestoreList) DeepCopy() *RestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreSpec) DeepCopyInto(out *RestoreSpec) {
+	*out = *in
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureStates != nil {
+		in, out := &in.FeatureStates, &out.FeatureStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeAliases != nil {
+		in, out := &in.IncludeAliases, &out.IncludeAliases
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IndexSettings != nil {
+		in, out := &in.IndexSettings, &out.IndexSettings
+		*out = (*in).DeepCopy()
+	}
+	if in.IgnoreIndexSettings != nil {
+		in, out := &in.IgnoreIndexSettings, &out.IgnoreIndexSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreSpec.
+func (in *RestoreSpec) DeepCopy() *RestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreStatus) DeepCopyInto(out *RestoreStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = new(SnapshotShardsStatus)
+		**out = **in
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreStatus.
+func (in *RestoreStatus) DeepCopy() *RestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Role) DeepCopyInto(out *Role) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
+func (in *Role) DeepCopy() *Role {
+	if in == nil {
+		return nil
+	}
+	out := new(Role)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Role) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleList) DeepCopyInto(out *RoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Role, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleList.
+func (in *RoleList) DeepCopy() *RoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMapping) DeepCopyInto(out *RoleMapping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMapping.
+func (in *RoleMapping) DeepCopy() *RoleMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleMapping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMappingList) DeepCopyInto(out *RoleMappingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RoleMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingList.
+func (in *RoleMappingList) DeepCopy() *RoleMappingList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMappingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleMappingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMappingRoleTemplate) DeepCopyInto(out *RoleMappingRoleTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingRoleTemplate.
+func (in *RoleMappingRoleTemplate) DeepCopy() *RoleMappingRoleTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMappingRoleTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMappingRule) DeepCopyInto(out *RoleMappingRule) {
+	*out = *in
+	if in.Any != nil {
+		in, out := &in.Any, &out.Any
+		*out = make([]RoleMappingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.All != nil {
+		in, out := &in.All, &out.All
+		*out = make([]RoleMappingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Field != nil {
+		in, out := &in.Field, &out.Field
+		*out = (*in).DeepCopy()
+	}
+	if in.Except != nil {
+		in, out := &in.Except, &out.Except
+		*out = new(RoleMappingRule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingRule.
+func (in *RoleMappingRule) DeepCopy() *RoleMappingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMappingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMappingSpec) DeepCopyInto(out *RoleMappingSpec) {
+	*out = *in
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoleTemplates != nil {
+		in, out := &in.RoleTemplates, &out.RoleTemplates
+		*out = make([]RoleMappingRoleTemplate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = new(RoleMappingRule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingSpec.
+func (in *RoleMappingSpec) DeepCopy() *RoleMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleMappingStatus) DeepCopyInto(out *RoleMappingStatus) {
+	*out = *in
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingStatus.
+func (in *RoleMappingStatus) DeepCopy() *RoleMappingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleMappingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
+	*out = *in
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]RoleSpecIndicesPermissions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]RoleSpecApplicationPrivileges, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RunAs != nil {
+		in, out := &in.RunAs, &out.RunAs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Global != nil {
+		in, out := &in.Global, &out.Global
+		*out = (*in).DeepCopy()
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = (*in).DeepCopy()
+	}
+	if in.TransientMetadata != nil {
+		in, out := &in.TransientMetadata, &out.TransientMetadata
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
+func (in *RoleSpec) DeepCopy() *RoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleSpecApplicationPrivileges) DeepCopyInto(out *RoleSpecApplicationPrivileges) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpecApplicationPrivileges.
+func (in *RoleSpecApplicationPrivileges) DeepCopy() *RoleSpecApplicationPrivileges {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSpecApplicationPrivileges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleSpecIndicesPermissions) DeepCopyInto(out *RoleSpecIndicesPermissions) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FieldSecurity != nil {
+		in, out := &in.FieldSecurity, &out.FieldSecurity
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpecIndicesPermissions.
+func (in *RoleSpecIndicesPermissions) DeepCopy() *RoleSpecIndicesPermissions {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSpecIndicesPermissions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
+	*out = *in
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
+func (in *RoleStatus) DeepCopy() *RoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLMConfig) DeepCopyInto(out *SLMConfig) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureStates != nil {
+		in, out := &in.FeatureStates, &out.FeatureStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLMConfig.
+func (in *SLMConfig) DeepCopy() *SLMConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SLMConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLMInvocation) DeepCopyInto(out *SLMInvocation) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLMInvocation.
+func (in *SLMInvocation) DeepCopy() *SLMInvocation {
+	if in == nil {
+		return nil
+	}
+	out := new(SLMInvocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLMRetention) DeepCopyInto(out *SLMRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLMRetention.
+func (in *SLMRetention) DeepCopy() *SLMRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(SLMRetention)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateSpec.
-func (in *IndexTemplateSpec) DeepCopy() *IndexTemplateSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLMStats) DeepCopyInto(out *SLMStats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLMStats.
+func (in *SLMStats) DeepCopy() *SLMStats {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexTemplateSpec)
+	out := new(SLMStats)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexTemplateStatus) DeepCopyInto(out *IndexTemplateStatus) {
+func (in *Snapshot) DeepCopyInto(out *Snapshot) {
 	*out = *in
-	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateStatus.
-func (in *IndexTemplateStatus) DeepCopy() *IndexTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Snapshot.
+func (in *Snapshot) DeepCopy() *Snapshot {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexTemplateStatus)
+	out := new(Snapshot)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Snapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *License) DeepCopyInto(out *License) {
+func (in *SnapshotLifecyclePolicy) DeepCopyInto(out *SnapshotLifecyclePolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -466,18 +2286,18 @@ func (in *License) DeepCopyInto(out *License) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new License.
-func (in *License) DeepCopy() *License {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicy.
+func (in *SnapshotLifecyclePolicy) DeepCopy() *SnapshotLifecyclePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(License)
+	out := new(SnapshotLifecyclePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *License) DeepCopyObject() runtime.Object {
+func (in *SnapshotLifecyclePolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -485,31 +2305,31 @@ func (in *License) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LicenseList) DeepCopyInto(out *LicenseList) {
+func (in *SnapshotLifecyclePolicyList) DeepCopyInto(out *SnapshotLifecyclePolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]License, len(*in))
+		*out = make([]SnapshotLifecyclePolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseList.
-func (in *LicenseList) DeepCopy() *LicenseList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicyList.
+func (in *SnapshotLifecyclePolicyList) DeepCopy() *SnapshotLifecyclePolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(LicenseList)
+	out := new(SnapshotLifecyclePolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LicenseList) DeepCopyObject() runtime.Object {
+func (in *SnapshotLifecyclePolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -517,100 +2337,103 @@ func (in *LicenseList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LicenseSpec) DeepCopyInto(out *LicenseSpec) {
+func (in *SnapshotLifecyclePolicySpec) DeepCopyInto(out *SnapshotLifecyclePolicySpec) {
 	*out = *in
 	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
-	if in.Basic != nil {
-		in, out := &in.Basic, &out.Basic
-		*out = new(bool)
+	in.Config.DeepCopyInto(&out.Config)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(SLMRetention)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseSpec.
-func (in *LicenseSpec) DeepCopy() *LicenseSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicySpec.
+func (in *SnapshotLifecyclePolicySpec) DeepCopy() *SnapshotLifecyclePolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LicenseSpec)
+	out := new(SnapshotLifecyclePolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LicenseStatus) DeepCopyInto(out *LicenseStatus) {
+func (in *SnapshotLifecyclePolicyStatus) DeepCopyInto(out *SnapshotLifecyclePolicyStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LicenseStatus.
-func (in *LicenseStatus) DeepCopy() *LicenseStatus {
-	if in == nil {
-		return nil
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
+	if in.LastSuccess != nil {
+		in, out := &in.LastSuccess, &out.LastSuccess
+		*out = new(SLMInvocation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = new(SLMInvocation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NextExecution != nil {
+		in, out := &in.NextExecution, &out.NextExecution
+		*out = (*in).DeepCopy()
+	}
+	if in.Stats != nil {
+		in, out := &in.Stats, &out.Stats
+		*out = new(SLMStats)
+		**out = **in
+	}
+	if in.LastExecution != nil {
+		in, out := &in.LastExecution, &out.LastExecution
+		*out = new(SLMInvocation)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(LicenseStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Role) DeepCopyInto(out *Role) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
-func (in *Role) DeepCopy() *Role {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicyStatus.
+func (in *SnapshotLifecyclePolicyStatus) DeepCopy() *SnapshotLifecyclePolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Role)
+	out := new(SnapshotLifecyclePolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Role) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleList) DeepCopyInto(out *RoleList) {
+func (in *SnapshotList) DeepCopyInto(out *SnapshotList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Role, len(*in))
+		*out = make([]Snapshot, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleList.
-func (in *RoleList) DeepCopy() *RoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotList.
+func (in *SnapshotList) DeepCopy() *SnapshotList {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleList)
+	out := new(SnapshotList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RoleList) DeepCopyObject() runtime.Object {
+func (in *SnapshotList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -618,7 +2441,7 @@ func (in *RoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleMapping) DeepCopyInto(out *RoleMapping) {
+func (in *SnapshotRepository) DeepCopyInto(out *SnapshotRepository) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -626,18 +2449,18 @@ func (in *RoleMapping) DeepCopyInto(out *RoleMapping) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMapping.
-func (in *RoleMapping) DeepCopy() *RoleMapping {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepository.
+func (in *SnapshotRepository) DeepCopy() *SnapshotRepository {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleMapping)
+	out := new(SnapshotRepository)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RoleMapping) DeepCopyObject() runtime.Object {
+func (in *SnapshotRepository) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -645,31 +2468,31 @@ func (in *RoleMapping) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleMappingList) DeepCopyInto(out *RoleMappingList) {
+func (in *SnapshotRepositoryList) DeepCopyInto(out *SnapshotRepositoryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]RoleMapping, len(*in))
+		*out = make([]SnapshotRepository, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingList.
-func (in *RoleMappingList) DeepCopy() *RoleMappingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositoryList.
+func (in *SnapshotRepositoryList) DeepCopy() *SnapshotRepositoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleMappingList)
+	out := new(SnapshotRepositoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RoleMappingList) DeepCopyObject() runtime.Object {
+func (in *SnapshotRepositoryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -677,180 +2500,134 @@ func (in *RoleMappingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleMappingSpec) DeepCopyInto(out *RoleMappingSpec) {
+func (in *SnapshotRepositorySpec) DeepCopyInto(out *SnapshotRepositorySpec) {
 	*out = *in
 	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
-	if in.Roles != nil {
-		in, out := &in.Roles, &out.Roles
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Rules != nil {
-		in, out := &in.Rules, &out.Rules
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
 		*out = (*in).DeepCopy()
 	}
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = (*in).DeepCopy()
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingSpec.
-func (in *RoleMappingSpec) DeepCopy() *RoleMappingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositorySpec.
+func (in *SnapshotRepositorySpec) DeepCopy() *SnapshotRepositorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleMappingSpec)
+	out := new(SnapshotRepositorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleMappingStatus) DeepCopyInto(out *RoleMappingStatus) {
+func (in *SnapshotRepositoryStatus) DeepCopyInto(out *SnapshotRepositoryStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleMappingStatus.
-func (in *RoleMappingStatus) DeepCopy() *RoleMappingStatus {
-	if in == nil {
-		return nil
-	}
-	out := new(RoleMappingStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
-	*out = *in
-	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
-	if in.Cluster != nil {
-		in, out := &in.Cluster, &out.Cluster
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
 	}
-	if in.Indices != nil {
-		in, out := &in.Indices, &out.Indices
-		*out = make([]RoleSpecIndicesPermissions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
 	}
-	if in.Applications != nil {
-		in, out := &in.Applications, &out.Applications
-		*out = make([]RoleSpecApplicationPrivileges, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(SnapshotRepositoryVerificationStatus)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.RunAs != nil {
-		in, out := &in.RunAs, &out.RunAs
+	if in.CredentialsKeys != nil {
+		in, out := &in.CredentialsKeys, &out.CredentialsKeys
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Global != nil {
-		in, out := &in.Global, &out.Global
-		*out = (*in).DeepCopy()
-	}
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
-		*out = (*in).DeepCopy()
-	}
-	if in.TransientMetadata != nil {
-		in, out := &in.TransientMetadata, &out.TransientMetadata
-		*out = (*in).DeepCopy()
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
-func (in *RoleSpec) DeepCopy() *RoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositoryStatus.
+func (in *SnapshotRepositoryStatus) DeepCopy() *SnapshotRepositoryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleSpec)
+	out := new(SnapshotRepositoryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleSpecApplicationPrivileges) DeepCopyInto(out *RoleSpecApplicationPrivileges) {
+func (in *SnapshotRepositoryVerificationStatus) DeepCopyInto(out *SnapshotRepositoryVerificationStatus) {
 	*out = *in
-	if in.Privileges != nil {
-		in, out := &in.Privileges, &out.Privileges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.LastVerificationTime != nil {
+		in, out := &in.LastVerificationTime, &out.LastVerificationTime
+		*out = (*in).DeepCopy()
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]string, len(*in))
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]SnapshotRepositoryVerifiedNode, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpecApplicationPrivileges.
-func (in *RoleSpecApplicationPrivileges) DeepCopy() *RoleSpecApplicationPrivileges {
-	if in == nil {
-		return nil
-	}
-	out := new(RoleSpecApplicationPrivileges)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleSpecIndicesPermissions) DeepCopyInto(out *RoleSpecIndicesPermissions) {
-	*out = *in
-	if in.Names != nil {
-		in, out := &in.Names, &out.Names
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Privileges != nil {
-		in, out := &in.Privileges, &out.Privileges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.FieldSecurity != nil {
-		in, out := &in.FieldSecurity, &out.FieldSecurity
-		*out = (*in).DeepCopy()
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositoryVerificationStatus.
+func (in *SnapshotRepositoryVerificationStatus) DeepCopy() *SnapshotRepositoryVerificationStatus {
+	if in == nil {
+		return nil
 	}
+	out := new(SnapshotRepositoryVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpecIndicesPermissions.
-func (in *RoleSpecIndicesPermissions) DeepCopy() *RoleSpecIndicesPermissions {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotRepositoryVerifiedNode) DeepCopyInto(out *SnapshotRepositoryVerifiedNode) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositoryVerifiedNode.
+func (in *SnapshotRepositoryVerifiedNode) DeepCopy() *SnapshotRepositoryVerifiedNode {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleSpecIndicesPermissions)
+	out := new(SnapshotRepositoryVerifiedNode)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
+func (in *SnapshotShardsStatus) DeepCopyInto(out *SnapshotShardsStatus) {
 	*out = *in
-	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
-func (in *RoleStatus) DeepCopy() *RoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotShardsStatus.
+func (in *SnapshotShardsStatus) DeepCopy() *SnapshotShardsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleStatus)
+	out := new(SnapshotShardsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SLMConfig) DeepCopyInto(out *SLMConfig) {
+func (in *SnapshotSpec) DeepCopyInto(out *SnapshotSpec) {
 	*out = *in
+	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
 	if in.Indices != nil {
 		in, out := &in.Indices, &out.Indices
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.IncludeGlobalState != nil {
+		in, out := &in.IncludeGlobalState, &out.IncludeGlobalState
+		*out = new(bool)
+		**out = **in
+	}
 	if in.FeatureStates != nil {
 		in, out := &in.FeatureStates, &out.FeatureStates
 		*out = make([]string, len(*in))
@@ -862,33 +2639,47 @@ func (in *SLMConfig) DeepCopyInto(out *SLMConfig) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLMConfig.
-func (in *SLMConfig) DeepCopy() *SLMConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSpec.
+func (in *SnapshotSpec) DeepCopy() *SnapshotSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SLMConfig)
+	out := new(SnapshotSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SLMRetention) DeepCopyInto(out *SLMRetention) {
+func (in *SnapshotStatus) DeepCopyInto(out *SnapshotStatus) {
 	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = new(SnapshotShardsStatus)
+		**out = **in
+	}
+	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLMRetention.
-func (in *SLMRetention) DeepCopy() *SLMRetention {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotStatus.
+func (in *SnapshotStatus) DeepCopy() *SnapshotStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SLMRetention)
+	out := new(SnapshotStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotLifecyclePolicy) DeepCopyInto(out *SnapshotLifecyclePolicy) {
+func (in *StoredScript) DeepCopyInto(out *StoredScript) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -896,18 +2687,18 @@ func (in *SnapshotLifecyclePolicy) DeepCopyInto(out *SnapshotLifecyclePolicy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicy.
-func (in *SnapshotLifecyclePolicy) DeepCopy() *SnapshotLifecyclePolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoredScript.
+func (in *StoredScript) DeepCopy() *StoredScript {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotLifecyclePolicy)
+	out := new(StoredScript)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SnapshotLifecyclePolicy) DeepCopyObject() runtime.Object {
+func (in *StoredScript) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -915,31 +2706,31 @@ func (in *SnapshotLifecyclePolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotLifecyclePolicyList) DeepCopyInto(out *SnapshotLifecyclePolicyList) {
+func (in *StoredScriptList) DeepCopyInto(out *StoredScriptList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SnapshotLifecyclePolicy, len(*in))
+		*out = make([]StoredScript, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicyList.
-func (in *SnapshotLifecyclePolicyList) DeepCopy() *SnapshotLifecyclePolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoredScriptList.
+func (in *StoredScriptList) DeepCopy() *StoredScriptList {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotLifecyclePolicyList)
+	out := new(StoredScriptList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SnapshotLifecyclePolicyList) DeepCopyObject() runtime.Object {
+func (in *StoredScriptList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -947,45 +2738,56 @@ func (in *SnapshotLifecyclePolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotLifecyclePolicySpec) DeepCopyInto(out *SnapshotLifecyclePolicySpec) {
+func (in *StoredScriptSpec) DeepCopyInto(out *StoredScriptSpec) {
 	*out = *in
 	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
-	in.Config.DeepCopyInto(&out.Config)
-	if in.Retention != nil {
-		in, out := &in.Retention, &out.Retention
-		*out = new(SLMRetention)
-		**out = **in
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicySpec.
-func (in *SnapshotLifecyclePolicySpec) DeepCopy() *SnapshotLifecyclePolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoredScriptSpec.
+func (in *StoredScriptSpec) DeepCopy() *StoredScriptSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotLifecyclePolicySpec)
+	out := new(StoredScriptSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotLifecyclePolicyStatus) DeepCopyInto(out *SnapshotLifecyclePolicyStatus) {
+func (in *StoredScriptStatus) DeepCopyInto(out *StoredScriptStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicyStatus.
-func (in *SnapshotLifecyclePolicyStatus) DeepCopy() *SnapshotLifecyclePolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StoredScriptStatus.
+func (in *StoredScriptStatus) DeepCopy() *StoredScriptStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotLifecyclePolicyStatus)
+	out := new(StoredScriptStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotRepository) DeepCopyInto(out *SnapshotRepository) {
+func (in *Transform) DeepCopyInto(out *Transform) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -993,18 +2795,18 @@ func (in *SnapshotRepository) DeepCopyInto(out *SnapshotRepository) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepository.
-func (in *SnapshotRepository) DeepCopy() *SnapshotRepository {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Transform.
+func (in *Transform) DeepCopy() *Transform {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotRepository)
+	out := new(Transform)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SnapshotRepository) DeepCopyObject() runtime.Object {
+func (in *Transform) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1012,31 +2814,66 @@ func (in *SnapshotRepository) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotRepositoryList) DeepCopyInto(out *SnapshotRepositoryList) {
+func (in *TransformDest) DeepCopyInto(out *TransformDest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformDest.
+func (in *TransformDest) DeepCopy() *TransformDest {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformDest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformLatest) DeepCopyInto(out *TransformLatest) {
+	*out = *in
+	if in.UniqueKey != nil {
+		in, out := &in.UniqueKey, &out.UniqueKey
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformLatest.
+func (in *TransformLatest) DeepCopy() *TransformLatest {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformLatest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformList) DeepCopyInto(out *TransformList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SnapshotRepository, len(*in))
+		*out = make([]Transform, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositoryList.
-func (in *SnapshotRepositoryList) DeepCopy() *SnapshotRepositoryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformList.
+func (in *TransformList) DeepCopy() *TransformList {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotRepositoryList)
+	out := new(TransformList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SnapshotRepositoryList) DeepCopyObject() runtime.Object {
+func (in *TransformList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1044,37 +2881,154 @@ func (in *SnapshotRepositoryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotRepositorySpec) DeepCopyInto(out *SnapshotRepositorySpec) {
+func (in *TransformPivot) DeepCopyInto(out *TransformPivot) {
+	*out = *in
+	if in.GroupBy != nil {
+		in, out := &in.GroupBy, &out.GroupBy
+		*out = (*in).DeepCopy()
+	}
+	if in.Aggregations != nil {
+		in, out := &in.Aggregations, &out.Aggregations
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformPivot.
+func (in *TransformPivot) DeepCopy() *TransformPivot {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformPivot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformRetentionPolicy) DeepCopyInto(out *TransformRetentionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformRetentionPolicy.
+func (in *TransformRetentionPolicy) DeepCopy() *TransformRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformSource) DeepCopyInto(out *TransformSource) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = (*in).DeepCopy()
+	}
+	if in.RuntimeMappings != nil {
+		in, out := &in.RuntimeMappings, &out.RuntimeMappings
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformSource.
+func (in *TransformSource) DeepCopy() *TransformSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformSpec) DeepCopyInto(out *TransformSpec) {
 	*out = *in
 	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	in.Source.DeepCopyInto(&out.Source)
+	out.Dest = in.Dest
+	if in.Pivot != nil {
+		in, out := &in.Pivot, &out.Pivot
+		*out = new(TransformPivot)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Latest != nil {
+		in, out := &in.Latest, &out.Latest
+		*out = new(TransformLatest)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(TransformSync)
+		**out = **in
+	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(TransformRetentionPolicy)
+		**out = **in
+	}
 	if in.Settings != nil {
 		in, out := &in.Settings, &out.Settings
 		*out = (*in).DeepCopy()
 	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositorySpec.
-func (in *SnapshotRepositorySpec) DeepCopy() *SnapshotRepositorySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformSpec.
+func (in *TransformSpec) DeepCopy() *TransformSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotRepositorySpec)
+	out := new(TransformSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotRepositoryStatus) DeepCopyInto(out *SnapshotRepositoryStatus) {
+func (in *TransformStatus) DeepCopyInto(out *TransformStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositoryStatus.
-func (in *SnapshotRepositoryStatus) DeepCopy() *SnapshotRepositoryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformStatus.
+func (in *TransformStatus) DeepCopy() *TransformStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotRepositoryStatus)
+	out := new(TransformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TransformSync) DeepCopyInto(out *TransformSync) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TransformSync.
+func (in *TransformSync) DeepCopy() *TransformSync {
+	if in == nil {
+		return nil
+	}
+	out := new(TransformSync)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1187,6 +3141,16 @@ func (in *UserSpec) DeepCopy() *UserSpec {
 func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserStatus.
@@ -1226,6 +3190,34 @@ func (in *Watch) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchActionStatus) DeepCopyInto(out *WatchActionStatus) {
+	*out = *in
+	if in.LastExecution != nil {
+		in, out := &in.LastExecution, &out.LastExecution
+		*out = (*in).DeepCopy()
+	}
+	if in.Successful != nil {
+		in, out := &in.Successful, &out.Successful
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastThrottle != nil {
+		in, out := &in.LastThrottle, &out.LastThrottle
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchActionStatus.
+func (in *WatchActionStatus) DeepCopy() *WatchActionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchActionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WatchList) DeepCopyInto(out *WatchList) {
 	*out = *in
@@ -1258,10 +3250,54 @@ func (in *WatchList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchSimulatedAction) DeepCopyInto(out *WatchSimulatedAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchSimulatedAction.
+func (in *WatchSimulatedAction) DeepCopy() *WatchSimulatedAction {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchSimulatedAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchSimulation) DeepCopyInto(out *WatchSimulation) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]WatchSimulatedAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchSimulation.
+func (in *WatchSimulation) DeepCopy() *WatchSimulation {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchSimulation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WatchSpec) DeepCopyInto(out *WatchSpec) {
 	*out = *in
 	in.ElasticsearchRef.DeepCopyInto(&out.ElasticsearchRef)
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Trigger != nil {
 		in, out := &in.Trigger, &out.Trigger
 		*out = (*in).DeepCopy()
@@ -1302,6 +3338,46 @@ func (in *WatchSpec) DeepCopy() *WatchSpec {
 func (in *WatchStatus) DeepCopyInto(out *WatchStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
+	if in.Active != nil {
+		in, out := &in.Active, &out.Active
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LastChecked != nil {
+		in, out := &in.LastChecked, &out.LastChecked
+		*out = (*in).DeepCopy()
+	}
+	if in.LastMetCondition != nil {
+		in, out := &in.LastMetCondition, &out.LastMetCondition
+		*out = (*in).DeepCopy()
+	}
+	if in.ConditionMet != nil {
+		in, out := &in.ConditionMet, &out.ConditionMet
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]WatchActionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSimulation != nil {
+		in, out := &in.LastSimulation, &out.LastSimulation
+		*out = new(WatchSimulation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchStatus.
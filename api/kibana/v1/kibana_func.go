@@ -1,7 +1,15 @@
 package v1
 
 import (
+	"context"
+
+	"emperror.dev/errors"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // GetStatus implement the object.MultiPhaseObject
@@ -17,3 +25,29 @@ func (h *Kibana) IsPdb() bool {
 
 	return false
 }
+
+// ValidateKibanaRef check that the reference policy of the managed Kibana allow the object to reference it
+// It's used by webhooks, so it not return error when Kibana not yet exist
+func ValidateKibanaRef(ctx context.Context, c client.Client, o client.Object, kbRef shared.KibanaRef) *field.Error {
+	if !kbRef.IsManaged() || kbRef.ManagedKibanaRef.Namespace == "" || kbRef.ManagedKibanaRef.Namespace == o.GetNamespace() {
+		return nil
+	}
+	path := field.NewPath("spec").Child("kibanaRef", "managed", "namespace")
+
+	kb := &Kibana{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: kbRef.ManagedKibanaRef.Namespace, Name: kbRef.ManagedKibanaRef.Name}, kb); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return field.InternalError(path, err)
+	}
+
+	if err := shared.CheckReferencePolicy(ctx, c, o, kb, kb.Spec.ReferencePolicy); err != nil {
+		if errors.Is(err, shared.ErrReferenceNotAllowed) {
+			return field.Forbidden(path, err.Error())
+		}
+		return field.InternalError(path, err)
+	}
+
+	return nil
+}
@@ -95,6 +95,12 @@ type KibanaSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec
 	// +optional
 	Monitoring shared.MonitoringSpec `json:"monitoring,omitempty"`
+
+	// ReferencePolicy permit to allow the resources on other namespaces to reference Kibana, like UserSpace or Role
+	// Default, only the resources on the same namespace can reference it
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	ReferencePolicy *shared.ReferencePolicy `json:"referencePolicy,omitempty"`
 }
 
 type KibanaDeploymentSpec struct {
@@ -147,6 +147,11 @@ func (in *KibanaSpec) DeepCopyInto(out *KibanaSpec) {
 	in.Tls.DeepCopyInto(&out.Tls)
 	in.Deployment.DeepCopyInto(&out.Deployment)
 	in.Monitoring.DeepCopyInto(&out.Monitoring)
+	if in.ReferencePolicy != nil {
+		in, out := &in.ReferencePolicy, &out.ReferencePolicy
+		*out = new(shared.ReferencePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpec.
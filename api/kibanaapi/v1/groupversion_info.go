@@ -34,3 +34,8 @@ var (
 	// AddToScheme adds the types in this group-version to the given scheme.
 	AddToScheme = SchemeBuilder.AddToScheme
 )
+
+const (
+	// KibanaApiAnnotationKey is the base annotation key used on kibanaapi resources
+	KibanaApiAnnotationKey = "kibanaapi.k8s.webcenter.fr"
+)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/sirupsen/logrus"
+	kibanacrd "github.com/webcenter-fr/elasticsearch-operator/api/kibana/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -93,6 +94,9 @@ func (r *logstashPipelineValidator) ValidateCreate(ctx context.Context, obj runt
 	if err := logstashPipelineObj.Spec.KibanaRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := kibanacrd.ValidateKibanaRef(ctx, r.client, logstashPipelineObj, logstashPipelineObj.Spec.KibanaRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(logstashPipelineObj); err != nil {
 		allErrs = append(allErrs, err)
@@ -121,6 +125,9 @@ func (r *logstashPipelineValidator) ValidateUpdate(ctx context.Context, oldObj r
 	if err := logstashPipelineObj.Spec.KibanaRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := kibanacrd.ValidateKibanaRef(ctx, r.client, logstashPipelineObj, logstashPipelineObj.Spec.KibanaRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := validateImmutableName(logstashPipelineObj, oldO); err != nil {
 		allErrs = append(allErrs, err)
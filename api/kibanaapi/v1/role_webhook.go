@@ -23,6 +23,7 @@ import (
 
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/sirupsen/logrus"
+	kibanacrd "github.com/webcenter-fr/elasticsearch-operator/api/kibana/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -93,6 +94,9 @@ func (r *roleValidator) ValidateCreate(ctx context.Context, obj runtime.Object)
 	if err := roleObj.Spec.KibanaRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := kibanacrd.ValidateKibanaRef(ctx, r.client, roleObj, roleObj.Spec.KibanaRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(roleObj); err != nil {
 		allErrs = append(allErrs, err)
@@ -121,6 +125,9 @@ func (r *roleValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Objec
 	if err := roleObj.Spec.KibanaRef.ValidateField(); err != nil {
 		allErrs = append(allErrs, err)
 	}
+	if err := kibanacrd.ValidateKibanaRef(ctx, r.client, roleObj, roleObj.Spec.KibanaRef); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if err := r.validateResourceUnicity(roleObj); err != nil {
 		allErrs = append(allErrs, err)
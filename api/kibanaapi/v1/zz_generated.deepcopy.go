@@ -21,6 +21,7 @@ limitations under the License.
 package v1
 
 import (
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -279,6 +280,16 @@ func (in *LogstashPipelineSpec) DeepCopy() *LogstashPipelineSpec {
 func (in *LogstashPipelineStatus) DeepCopyInto(out *LogstashPipelineStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogstashPipelineStatus.
@@ -391,6 +402,16 @@ func (in *RoleSpec) DeepCopy() *RoleSpec {
 func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
@@ -494,6 +515,16 @@ func (in *UserSpaceSpec) DeepCopy() *UserSpaceSpec {
 func (in *UserSpaceStatus) DeepCopyInto(out *UserSpaceStatus) {
 	*out = *in
 	in.DefaultRemoteObjectStatus.DeepCopyInto(&out.DefaultRemoteObjectStatus)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(shared.AdoptionStatus)
+		**out = **in
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(shared.DryRunStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSpaceStatus.
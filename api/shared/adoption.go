@@ -0,0 +1,42 @@
+package shared
+
+// AdoptionPolicy is the policy applied when the remote object already exist and is not yet managed by the operator
+// +kubebuilder:validation:Enum=Apply;Manual
+type AdoptionPolicy string
+
+const (
+	// AdoptionPolicyApply record the remote object and the diff on status, then apply the expected object
+	AdoptionPolicyApply AdoptionPolicy = "Apply"
+
+	// AdoptionPolicyManual record the remote object and the diff on status, then wait the adopt annotation before apply the expected object
+	AdoptionPolicyManual AdoptionPolicy = "Manual"
+)
+
+const (
+	// AdoptionPhasePending is set when the adoption wait the adopt annotation
+	AdoptionPhasePending = "Pending"
+
+	// AdoptionPhaseAdopted is set when the remote object has been adopted
+	AdoptionPhaseAdopted = "Adopted"
+)
+
+// IsManual return true if the adoption need the adopt annotation before apply the expected object
+// Default policy is Apply
+func (a AdoptionPolicy) IsManual() bool {
+	return a == AdoptionPolicyManual
+}
+
+// AdoptionStatus is the status of the adoption of an existing remote object
+type AdoptionStatus struct {
+	// Phase is the adoption phase (Pending or Adopted)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Phase string `json:"phase,omitempty"`
+
+	// RemoteObject is the remote object found before the operator take the control on it, on JSON format
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	RemoteObject string `json:"remoteObject,omitempty"`
+
+	// Diff is the diff between the remote object and the expected object
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Diff string `json:"diff,omitempty"`
+}
@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdoptionPolicyIsManual(t *testing.T) {
+	var a AdoptionPolicy
+
+	// When default
+	assert.False(t, a.IsManual())
+
+	// When apply
+	a = AdoptionPolicyApply
+	assert.False(t, a.IsManual())
+
+	// When manual
+	a = AdoptionPolicyManual
+	assert.True(t, a.IsManual())
+}
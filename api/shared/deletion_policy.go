@@ -0,0 +1,19 @@
+package shared
+
+// DeletionPolicy is the policy applied on the remote object when the custom resource is deleted
+// +kubebuilder:validation:Enum=Delete;Orphan
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete delete the remote object when the custom resource is deleted
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+
+	// DeletionPolicyOrphan keep the remote object when the custom resource is deleted
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
+// IsOrphan return true if the remote object must be keeped when the custom resource is deleted
+// Default policy is Delete
+func (d DeletionPolicy) IsOrphan() bool {
+	return d == DeletionPolicyOrphan
+}
@@ -0,0 +1,22 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletionPolicyIsOrphan(t *testing.T) {
+	var d DeletionPolicy
+
+	// When default
+	assert.False(t, d.IsOrphan())
+
+	// When delete
+	d = DeletionPolicyDelete
+	assert.False(t, d.IsOrphan())
+
+	// When orphan
+	d = DeletionPolicyOrphan
+	assert.True(t, d.IsOrphan())
+}
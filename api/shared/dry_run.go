@@ -0,0 +1,23 @@
+package shared
+
+const (
+	// DryRunOperationNone is set when the remote object is already up to date
+	DryRunOperationNone = "None"
+
+	// DryRunOperationCreate is set when the remote object will be created
+	DryRunOperationCreate = "Create"
+
+	// DryRunOperationUpdate is set when the remote object will be updated
+	DryRunOperationUpdate = "Update"
+)
+
+// DryRunStatus is the change that the operator will apply on the remote object when the dry-run annotation will be removed
+type DryRunStatus struct {
+	// Operation is the operation that will be applied on the remote object (None, Create or Update)
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Operation string `json:"operation,omitempty"`
+
+	// Diff is the diff between the remote object and the expected object
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Diff string `json:"diff,omitempty"`
+}
@@ -0,0 +1,126 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/thoas/go-funk"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// ErrReferenceNotAllowed is returned when the reference policy of the target not allow the reference
+var ErrReferenceNotAllowed = errors.New("Reference not allowed by the reference policy")
+
+// ReferencePolicy is the allow list of the resources on other namespaces that can reference the cluster, like Gateway API ReferenceGrant
+// The resources on the same namespace are always allowed
+type ReferencePolicy struct {
+	// From is the list of the resources allowed to reference the cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	From []ReferencePolicyFrom `json:"from,omitempty"`
+}
+
+// ReferencePolicyFrom select the resources allowed to reference the cluster
+type ReferencePolicyFrom struct {
+	// Namespace is the namespace allowed to reference the cluster
+	// Use `*` to allow all namespaces
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// NamespaceSelector is the label selector of the namespaces allowed to reference the cluster
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Kinds is the list of the resource kinds allowed to reference the cluster, like `Kibana` or `Role`
+	// Default, all kinds are allowed
+	// +operator-sdk:csv:customresourcedefinitions:type=spec
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// IsAllowed return true if the resource kind from the namespace is allowed to reference the cluster
+// The namespace labels are only needed when the policy use namespace selector
+func (h *ReferencePolicy) IsAllowed(kind string, namespace string, namespaceLabels map[string]string) bool {
+	if h == nil {
+		return false
+	}
+
+	for _, from := range h.From {
+		if len(from.Kinds) > 0 && !funk.ContainsString(from.Kinds, kind) {
+			continue
+		}
+
+		if from.Namespace == "*" || (from.Namespace != "" && from.Namespace == namespace) {
+			return true
+		}
+
+		if from.NamespaceSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(from.NamespaceSelector)
+			if err != nil {
+				continue
+			}
+			if selector.Matches(labels.Set(namespaceLabels)) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsNamespaceSelector return true if the policy use namespace selector
+func (h *ReferencePolicy) IsNamespaceSelector() bool {
+	if h == nil {
+		return false
+	}
+
+	for _, from := range h.From {
+		if from.NamespaceSelector != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckReferencePolicy return ErrReferenceNotAllowed if the object can't reference the target from its namespace
+// The references on the same namespace are always allowed
+func CheckReferencePolicy(ctx context.Context, c client.Client, o client.Object, target client.Object, policy *ReferencePolicy) (err error) {
+	if o.GetNamespace() == target.GetNamespace() {
+		return nil
+	}
+
+	gvk, err := apiutil.GVKForObject(o, c.Scheme())
+	if err != nil {
+		return errors.Wrapf(err, "Error when get kind of %s/%s", o.GetNamespace(), o.GetName())
+	}
+	if policy.IsAllowed(gvk.Kind, o.GetNamespace(), nil) {
+		return nil
+	}
+
+	// Read the namespace labels only when it's needed by selector
+	if policy.IsNamespaceSelector() {
+		ns := &corev1.Namespace{}
+		if err = c.Get(ctx, types.NamespacedName{Name: o.GetNamespace()}, ns); err != nil {
+			return errors.Wrapf(err, "Error when read namespace %s", o.GetNamespace())
+		}
+		if policy.IsAllowed(gvk.Kind, o.GetNamespace(), ns.Labels) {
+			return nil
+		}
+	}
+
+	targetGvk, err := apiutil.GVKForObject(target, c.Scheme())
+	if err != nil {
+		return errors.Wrapf(err, "Error when get kind of %s/%s", target.GetNamespace(), target.GetName())
+	}
+
+	return errors.WithMessage(ErrReferenceNotAllowed, fmt.Sprintf("%s %s/%s can't reference %s %s/%s, it need to be allowed on spec.referencePolicy", gvk.Kind, o.GetNamespace(), o.GetName(), targetGvk.Kind, target.GetNamespace(), target.GetName()))
+}
@@ -0,0 +1,66 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReferencePolicyIsAllowed(t *testing.T) {
+	var o *ReferencePolicy
+
+	// When no policy
+	assert.False(t, o.IsAllowed("Kibana", "team-a", nil))
+
+	// When namespace is allowed
+	o = &ReferencePolicy{
+		From: []ReferencePolicyFrom{
+			{
+				Namespace: "team-a",
+			},
+		},
+	}
+	assert.True(t, o.IsAllowed("Kibana", "team-a", nil))
+	assert.True(t, o.IsAllowed("Role", "team-a", nil))
+	assert.False(t, o.IsAllowed("Kibana", "team-b", nil))
+
+	// When all namespaces are allowed
+	o = &ReferencePolicy{
+		From: []ReferencePolicyFrom{
+			{
+				Namespace: "*",
+			},
+		},
+	}
+	assert.True(t, o.IsAllowed("Kibana", "team-b", nil))
+
+	// When kinds are restricted
+	o = &ReferencePolicy{
+		From: []ReferencePolicyFrom{
+			{
+				Namespace: "team-a",
+				Kinds:     []string{"Kibana", "Metricbeat"},
+			},
+		},
+	}
+	assert.True(t, o.IsAllowed("Kibana", "team-a", nil))
+	assert.False(t, o.IsAllowed("Role", "team-a", nil))
+
+	// When namespace selector
+	o = &ReferencePolicy{
+		From: []ReferencePolicyFrom{
+			{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"team": "ops",
+					},
+				},
+			},
+		},
+	}
+	assert.True(t, o.IsNamespaceSelector())
+	assert.True(t, o.IsAllowed("Role", "team-a", map[string]string{"team": "ops"}))
+	assert.False(t, o.IsAllowed("Role", "team-a", map[string]string{"team": "dev"}))
+	assert.False(t, o.IsAllowed("Role", "team-a", nil))
+}
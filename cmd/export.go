@@ -0,0 +1,919 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
+	kibana "github.com/disaster37/go-kibana-rest/v8"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	elastic "github.com/elastic/go-elasticsearch/v8"
+	"github.com/sirupsen/logrus"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	kibanaapicrd "github.com/webcenter-fr/elasticsearch-operator/api/kibanaapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// kibanaApplicationName is the application used by Kibana to store its privileges on Elasticsearch roles
+const kibanaApplicationName = "kibana-.kibana"
+
+var invalidResourceNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// exportOptions is the options of the export command
+type exportOptions struct {
+	esURL       string
+	esUsername  string
+	esPassword  string
+	esRef       string
+	esSecretRef string
+	kbURL       string
+	kbUsername  string
+	kbPassword  string
+	kbRef       string
+	kbSecretRef string
+	namespace   string
+	output      string
+	insecure    bool
+}
+
+// exportNamer compute unique resource names per kind
+type exportNamer map[string]map[string]bool
+
+// runExport read the objects from a live Elasticsearch / Kibana and write them as custom resources on YAML format
+func runExport(ctx context.Context, args []string, stdout io.Writer, log *logrus.Entry) (err error) {
+	opts := &exportOptions{}
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.StringVar(&opts.esURL, "es-url", "", "The Elasticsearch URL to export from")
+	fs.StringVar(&opts.esUsername, "es-username", os.Getenv("ES_USERNAME"), "The Elasticsearch username. Default to env ES_USERNAME")
+	fs.StringVar(&opts.esPassword, "es-password", os.Getenv("ES_PASSWORD"), "The Elasticsearch password. Default to env ES_PASSWORD")
+	fs.StringVar(&opts.esRef, "es-ref", "", "The managed Elasticsearch resource name to target on exported resources. If empty, it use es-url as external Elasticsearch")
+	fs.StringVar(&opts.esSecretRef, "es-secret-ref", "", "The secret that store the Elasticsearch credentials, used with external Elasticsearch")
+	fs.StringVar(&opts.kbURL, "kb-url", "", "The Kibana URL to export from")
+	fs.StringVar(&opts.kbUsername, "kb-username", os.Getenv("KB_USERNAME"), "The Kibana username. Default to env KB_USERNAME")
+	fs.StringVar(&opts.kbPassword, "kb-password", os.Getenv("KB_PASSWORD"), "The Kibana password. Default to env KB_PASSWORD")
+	fs.StringVar(&opts.kbRef, "kb-ref", "", "The managed Kibana resource name to target on exported resources. If empty, it use kb-url as external Kibana")
+	fs.StringVar(&opts.kbSecretRef, "kb-secret-ref", "", "The secret that store the Kibana credentials, used with external Kibana")
+	fs.StringVar(&opts.namespace, "namespace", "default", "The namespace of exported resources")
+	fs.StringVar(&opts.output, "output", "", "The file where to write the resources. Default to stdout")
+	fs.BoolVar(&opts.insecure, "insecure", false, "Skip the TLS certificate check")
+	if err = fs.Parse(args); err != nil {
+		return err
+	}
+
+	if opts.esURL == "" && opts.kbURL == "" {
+		return errors.New("You need to provide at least es-url or kb-url")
+	}
+
+	objects := make([]client.Object, 0)
+	skipped := make([]string, 0)
+	namer := exportNamer{}
+
+	if opts.esURL != "" {
+		esClient, err := elastic.NewClient(elastic.Config{
+			Addresses: []string{opts.esURL},
+			Username:  opts.esUsername,
+			Password:  opts.esPassword,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: opts.insecure}, // #nosec G402
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "Error when create Elasticsearch client")
+		}
+		esObjects, esSkipped, err := exportElasticsearch(ctx, esClient, opts, namer, log)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, esObjects...)
+		skipped = append(skipped, esSkipped...)
+	}
+
+	if opts.kbURL != "" {
+		kbClient, err := kibana.NewClient(kibana.Config{
+			Address:          opts.kbURL,
+			Username:         opts.kbUsername,
+			Password:         opts.kbPassword,
+			DisableVerifySSL: opts.insecure,
+		})
+		if err != nil {
+			return errors.Wrap(err, "Error when create Kibana client")
+		}
+		kbObjects, err := exportKibana(kbClient, opts, namer, log)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, kbObjects...)
+	}
+
+	w := stdout
+	if opts.output != "" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return errors.Wrapf(err, "Error when create file %s", opts.output)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	log.Infof("Export %d resources", len(objects))
+
+	return writeExportedObjects(w, objects, skipped)
+}
+
+// exportElasticsearch read all supported objects from Elasticsearch
+// The kinds not available on the cluster (license, disabled feature) are skipped with a warning
+func exportElasticsearch(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer, log *logrus.Entry) (objects []client.Object, skipped []string, err error) {
+	objects = make([]client.Object, 0)
+	exporters := []struct {
+		name   string
+		export func(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) ([]client.Object, error)
+	}{
+		{name: "roles", export: exportRoles},
+		{name: "role mappings", export: exportRoleMappings},
+		{name: "users", export: exportUsers},
+		{name: "index lifecycle policies", export: exportIndexLifecyclePolicies},
+		{name: "snapshot lifecycle policies", export: exportSnapshotLifecyclePolicies},
+		{name: "index templates", export: exportIndexTemplates},
+		{name: "component templates", export: exportComponentTemplates},
+		{name: "snapshot repositories", export: exportSnapshotRepositories},
+		{name: "watches", export: exportWatches},
+	}
+
+	for _, exporter := range exporters {
+		exported, err := exporter.export(ctx, esClient, opts, namer)
+		if err != nil {
+			if errors.Is(err, errExportNotAvailable) {
+				log.Warnf("Skip %s: %s", exporter.name, err.Error())
+				skipped = append(skipped, exporter.name)
+				continue
+			}
+			return nil, nil, errors.Wrapf(err, "Error when export %s", exporter.name)
+		}
+		log.Debugf("Found %d %s", len(exported), exporter.name)
+		objects = append(objects, exported...)
+	}
+
+	return objects, skipped, nil
+}
+
+// exportKibana read all supported objects from Kibana
+func exportKibana(kbClient *kibana.Client, opts *exportOptions, namer exportNamer, log *logrus.Entry) (objects []client.Object, err error) {
+	objects = make([]client.Object, 0)
+
+	spaces, err := kbClient.API.KibanaSpaces.List()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when export Kibana user spaces")
+	}
+	for _, space := range spaces {
+		if space.Reserved {
+			continue
+		}
+		objects = append(objects, &kibanaapicrd.UserSpace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: kibanaapicrd.GroupVersion.String(), Kind: "UserSpace"},
+			ObjectMeta: namer.objectMeta("UserSpace", space.ID, opts),
+			Spec: kibanaapicrd.UserSpaceSpec{
+				KibanaRef:        opts.kibanaRef(),
+				ID:               space.ID,
+				Name:             space.Name,
+				Description:      space.Description,
+				DisabledFeatures: space.DisabledFeatures,
+				Initials:         space.Initials,
+				Color:            space.Color,
+			},
+		})
+	}
+	log.Debugf("Found %d Kibana user spaces", len(objects))
+
+	roles, err := kbClient.API.KibanaRoleManagement.List()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when export Kibana roles")
+	}
+	for _, role := range roles {
+		// Only roles with Kibana privileges are managed with Kibana, the others are exported as Elasticsearch roles
+		if isReserved(role.Metadata) || len(role.Kibana) == 0 {
+			continue
+		}
+		r := &kibanaapicrd.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: kibanaapicrd.GroupVersion.String(), Kind: "Role"},
+			ObjectMeta: namer.objectMeta("KibanaRole", role.Name, opts),
+			Spec: kibanaapicrd.RoleSpec{
+				KibanaRef: opts.kibanaRef(),
+				Name:      role.Name,
+				Metadata:  toMapAny(role.Metadata),
+			},
+		}
+		if role.Elasticsearch != nil {
+			r.Spec.Elasticsearch = &kibanaapicrd.KibanaRoleElasticsearch{
+				Cluster: role.Elasticsearch.Cluster,
+				RunAs:   role.Elasticsearch.RunAs,
+			}
+			for _, indice := range role.Elasticsearch.Indices {
+				r.Spec.Elasticsearch.Indices = append(r.Spec.Elasticsearch.Indices, kibanaapicrd.KibanaRoleElasticsearchIndice{
+					Names:         indice.Names,
+					Privileges:    indice.Privileges,
+					FieldSecurity: toMapAny(indice.FieldSecurity),
+					Query:         toQueryString(indice.Query),
+				})
+			}
+		}
+		for _, kb := range role.Kibana {
+			r.Spec.Kibana = append(r.Spec.Kibana, kibanaapicrd.KibanaRoleKibana{
+				Base:    kb.Base,
+				Feature: kb.Feature,
+				Spaces:  kb.Spaces,
+			})
+		}
+		objects = append(objects, r)
+	}
+
+	return objects, nil
+}
+
+// errExportNotAvailable is returned when the API is not available on the cluster
+var errExportNotAvailable = errors.New("API not available")
+
+// exportNotAvailableReasons is the errors returned by Elasticsearch when the feature is not allowed by the license or is disabled
+var exportNotAvailableReasons = []string{
+	"current license is non-compliant",
+	"Security must be explicitly enabled",
+	"no handler found for uri",
+}
+
+// exportWatchesPageSize is the number of watches read per call
+var exportWatchesPageSize = 100
+
+// esGet call the Elasticsearch API and decode the JSON response
+func esGet(ctx context.Context, esClient *elastic.Client, method string, path string, result any) (err error) {
+	return esCall(ctx, esClient, method, path, nil, result)
+}
+
+// esCall call the Elasticsearch API with the JSON body and decode the JSON response
+// The API not available because of license or disabled feature is returned as errExportNotAvailable, other errors like forbidden access or bad request fail because the export will be incomplete
+func esCall(ctx context.Context, esClient *elastic.Client, method string, path string, body any, result any) (err error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrapf(err, "Error when encode body of %s", path)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := esClient.Perform(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		// No object exist, like when no policy is defined
+		return nil
+	case res.StatusCode >= 400 && isExportNotAvailable(b):
+		return errors.Wrap(errExportNotAvailable, string(b))
+	case res.StatusCode == http.StatusForbidden:
+		return errors.Errorf("Permission denied when call %s %s: %s", method, path, string(b))
+	case res.StatusCode >= 300:
+		return errors.Errorf("Error when call %s %s: %s", method, path, string(b))
+	}
+
+	if err = json.Unmarshal(b, result); err != nil {
+		return errors.Wrapf(err, "Error when decode response of %s", path)
+	}
+
+	return nil
+}
+
+// isExportNotAvailable return true when the error is returned because the feature is not allowed by the license or is disabled
+func isExportNotAvailable(body []byte) bool {
+	for _, reason := range exportNotAvailableReasons {
+		if strings.Contains(string(body), reason) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func exportRoles(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) (objects []client.Object, err error) {
+	roles := map[string]struct {
+		Cluster []string `json:"cluster"`
+		Indices []struct {
+			Names                  []string       `json:"names"`
+			Privileges             []string       `json:"privileges"`
+			FieldSecurity          map[string]any `json:"field_security"`
+			Query                  any            `json:"query"`
+			AllowRestrictedIndices bool           `json:"allow_restricted_indices"`
+		} `json:"indices"`
+		Applications []elasticsearchapicrd.RoleSpecApplicationPrivileges `json:"applications"`
+		RunAs        []string                                            `json:"run_as"`
+		Global       map[string]any                                      `json:"global"`
+		Metadata     map[string]any                                      `json:"metadata"`
+	}{}
+	if err = esGet(ctx, esClient, http.MethodGet, "/_security/role", &roles); err != nil {
+		return nil, err
+	}
+
+	objects = make([]client.Object, 0, len(roles))
+	for _, name := range sortedKeys(roles) {
+		role := roles[name]
+		if isReserved(role.Metadata) {
+			continue
+		}
+
+		// Roles with Kibana privileges are exported as Kibana roles
+		isKibanaRole := false
+		for _, application := range role.Applications {
+			if application.Application == kibanaApplicationName {
+				isKibanaRole = true
+			}
+		}
+		if isKibanaRole && opts.kbURL != "" {
+			continue
+		}
+
+		r := &elasticsearchapicrd.Role{
+			TypeMeta:   metav1.TypeMeta{APIVersion: elasticsearchapicrd.GroupVersion.String(), Kind: "Role"},
+			ObjectMeta: namer.objectMeta("Role", name, opts),
+			Spec: elasticsearchapicrd.RoleSpec{
+				ElasticsearchRef: opts.elasticsearchRef(),
+				Name:             name,
+				Cluster:          role.Cluster,
+				Applications:     role.Applications,
+				RunAs:            role.RunAs,
+				Global:           toMapAny(role.Global),
+				Metadata:         toMapAny(role.Metadata),
+			},
+		}
+		for _, indice := range role.Indices {
+			r.Spec.Indices = append(r.Spec.Indices, elasticsearchapicrd.RoleSpecIndicesPermissions{
+				Names:                  indice.Names,
+				Privileges:             indice.Privileges,
+				FieldSecurity:          toMapAny(indice.FieldSecurity),
+				Query:                  toQueryString(indice.Query),
+				AllowRestrictedIndices: indice.AllowRestrictedIndices,
+			})
+		}
+		objects = append(objects, r)
+	}
+
+	return objects, nil
+}
+
+func exportRoleMappings(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) (objects []client.Object, err error) {
+	roleMappings := map[string]struct {
+		Enabled       bool     `json:"enabled"`
+		Roles         []string `json:"roles"`
+		RoleTemplates []struct {
+			Template any    `json:"template"`
+			Format   string `json:"format"`
+		} `json:"role_templates"`
+		Rules    *elasticsearchapicrd.RoleMappingRule `json:"rules"`
+		Metadata map[string]any                       `json:"metadata"`
+	}{}
+	if err = esGet(ctx, esClient, http.MethodGet, "/_security/role_mapping", &roleMappings); err != nil {
+		return nil, err
+	}
+
+	objects = make([]client.Object, 0, len(roleMappings))
+	for _, name := range sortedKeys(roleMappings) {
+		roleMapping := roleMappings[name]
+		if isReserved(roleMapping.Metadata) {
+			continue
+		}
+
+		rm := &elasticsearchapicrd.RoleMapping{
+			TypeMeta:   metav1.TypeMeta{APIVersion: elasticsearchapicrd.GroupVersion.String(), Kind: "RoleMapping"},
+			ObjectMeta: namer.objectMeta("RoleMapping", name, opts),
+			Spec: elasticsearchapicrd.RoleMappingSpec{
+				ElasticsearchRef: opts.elasticsearchRef(),
+				Name:             name,
+				Enabled:          roleMapping.Enabled,
+				Roles:            roleMapping.Roles,
+				Rules:            roleMapping.Rules,
+				Metadata:         toMapAny(roleMapping.Metadata),
+			},
+		}
+		for _, roleTemplate := range roleMapping.RoleTemplates {
+			source, err := getRoleTemplateSource(roleTemplate.Template)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error when read role template of role mapping %s", name)
+			}
+			rm.Spec.RoleTemplates = append(rm.Spec.RoleTemplates, elasticsearchapicrd.RoleMappingRoleTemplate{
+				Source: source,
+				Format: roleTemplate.Format,
+			})
+		}
+		objects = append(objects, rm)
+	}
+
+	return objects, nil
+}
+
+func exportUsers(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) (objects []client.Object, err error) {
+	users := map[string]struct {
+		Roles    []string       `json:"roles"`
+		FullName string         `json:"full_name"`
+		Email    string         `json:"email"`
+		Metadata map[string]any `json:"metadata"`
+		Enabled  bool           `json:"enabled"`
+	}{}
+	if err = esGet(ctx, esClient, http.MethodGet, "/_security/user", &users); err != nil {
+		return nil, err
+	}
+
+	objects = make([]client.Object, 0, len(users))
+	for _, name := range sortedKeys(users) {
+		user := users[name]
+		if isReserved(user.Metadata) {
+			continue
+		}
+
+		// The password can't be read, so it reference a secret that need to be created
+		meta := namer.objectMeta("User", name, opts)
+		objects = append(objects, &elasticsearchapicrd.User{
+			TypeMeta:   metav1.TypeMeta{APIVersion: elasticsearchapicrd.GroupVersion.String(), Kind: "User"},
+			ObjectMeta: meta,
+			Spec: elasticsearchapicrd.UserSpec{
+				ElasticsearchRef: opts.elasticsearchRef(),
+				Enabled:          ptr.To(user.Enabled),
+				Username:         name,
+				Email:            user.Email,
+				FullName:         user.FullName,
+				Metadata:         toMapAny(user.Metadata),
+				Roles:            user.Roles,
+				SecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: fmt.Sprintf("%s-credentials", meta.Name),
+					},
+					Key: "password",
+				},
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+func exportIndexLifecyclePolicies(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) (objects []client.Object, err error) {
+	policies := map[string]struct {
+		Policy map[string]any `json:"policy"`
+	}{}
+	if err = esGet(ctx, esClient, http.MethodGet, "/_ilm/policy", &policies); err != nil {
+		return nil, err
+	}
+
+	objects = make([]client.Object, 0, len(policies))
+	for _, name := range sortedKeys(policies) {
+		policy := policies[name]
+		if meta, ok := policy.Policy["_meta"].(map[string]any); ok && isManaged(meta) {
+			continue
+		}
+
+		rawPolicy, err := json.Marshal(map[string]any{"policy": policy.Policy})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error when convert policy %s", name)
+		}
+		objects = append(objects, &elasticsearchapicrd.IndexLifecyclePolicy{
+			TypeMeta:   metav1.TypeMeta{APIVersion: elasticsearchapicrd.GroupVersion.String(), Kind: "IndexLifecyclePolicy"},
+			ObjectMeta: namer.objectMeta("IndexLifecyclePolicy", name, opts),
+			Spec: elasticsearchapicrd.IndexLifecyclePolicySpec{
+				ElasticsearchRef: opts.elasticsearchRef(),
+				Name:             name,
+				RawPolicy:        ptr.To(string(rawPolicy)),
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+func exportSnapshotLifecyclePolicies(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) (objects []client.Object, err error) {
+	policies := map[string]struct {
+		Policy struct {
+			Name       string `json:"name"`
+			Schedule   string `json:"schedule"`
+			Repository string `json:"repository"`
+			Config     struct {
+				ExpandWildcards    string         `json:"expand_wildcards"`
+				IgnoreUnavailable  bool           `json:"ignore_unavailable"`
+				IncludeGlobalState bool           `json:"include_global_state"`
+				Indices            []string       `json:"indices"`
+				FeatureStates      []string       `json:"feature_states"`
+				Metadata           map[string]any `json:"metadata"`
+				Partial            bool           `json:"partial"`
+			} `json:"config"`
+			Retention *struct {
+				ExpireAfter string `json:"expire_after"`
+				MaxCount    int64  `json:"max_count"`
+				MinCount    int64  `json:"min_count"`
+			} `json:"retention"`
+		} `json:"policy"`
+	}{}
+	if err = esGet(ctx, esClient, http.MethodGet, "/_slm/policy", &policies); err != nil {
+		return nil, err
+	}
+
+	objects = make([]client.Object, 0, len(policies))
+	for _, name := range sortedKeys(policies) {
+		policy := policies[name].Policy
+		slm := &elasticsearchapicrd.SnapshotLifecyclePolicy{
+			TypeMeta:   metav1.TypeMeta{APIVersion: elasticsearchapicrd.GroupVersion.String(), Kind: "SnapshotLifecyclePolicy"},
+			ObjectMeta: namer.objectMeta("SnapshotLifecyclePolicy", name, opts),
+			Spec: elasticsearchapicrd.SnapshotLifecyclePolicySpec{
+				ElasticsearchRef:            opts.elasticsearchRef(),
+				SnapshotLifecyclePolicyName: name,
+				Schedule:                    policy.Schedule,
+				Name:                        policy.Name,
+				Repository:                  policy.Repository,
+				Config: elasticsearchapicrd.SLMConfig{
+					ExpendWildcards:    policy.Config.ExpandWildcards,
+					IgnoreUnavailable:  policy.Config.IgnoreUnavailable,
+					IncludeGlobalState: policy.Config.IncludeGlobalState,
+					Indices:            policy.Config.Indices,
+					FeatureStates:      policy.Config.FeatureStates,
+					Metadata:           toMapAny(policy.Config.Metadata),
+					Partial:            policy.Config.Partial,
+				},
+			},
+		}
+		if policy.Retention != nil {
+			slm.Spec.Retention = &elasticsearchapicrd.SLMRetention{
+				ExpireAfter: policy.Retention.ExpireAfter,
+				MaxCount:    policy.Retention.MaxCount,
+				MinCount:    policy.Retention.MinCount,
+			}
+		}
+		objects = append(objects, slm)
+	}
+
+	return objects, nil
+}
+
+func exportIndexTemplates(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) (objects []client.Object, err error) {
+	templates := struct {
+		IndexTemplates []struct {
+			Name          string         `json:"name"`
+			IndexTemplate map[string]any `json:"index_template"`
+		} `json:"index_templates"`
+	}{}
+	if err = esGet(ctx, esClient, http.MethodGet, "/_index_template", &templates); err != nil {
+		return nil, err
+	}
+
+	objects = make([]client.Object, 0, len(templates.IndexTemplates))
+	sort.Slice(templates.IndexTemplates, func(i, j int) bool {
+		return templates.IndexTemplates[i].Name < templates.IndexTemplates[j].Name
+	})
+	for _, template := range templates.IndexTemplates {
+		if meta, ok := template.IndexTemplate["_meta"].(map[string]any); ok && isManaged(meta) {
+			continue
+		}
+
+		rawTemplate, err := json.Marshal(template.IndexTemplate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error when convert index template %s", template.Name)
+		}
+		objects = append(objects, &elasticsearchapicrd.IndexTemplate{
+			TypeMeta:   metav1.TypeMeta{APIVersion: elasticsearchapicrd.GroupVersion.String(), Kind: "IndexTemplate"},
+			ObjectMeta: namer.objectMeta("IndexTemplate", template.Name, opts),
+			Spec: elasticsearchapicrd.IndexTemplateSpec{
+				ElasticsearchRef: opts.elasticsearchRef(),
+				Name:             template.Name,
+				RawTemplate:      ptr.To(string(rawTemplate)),
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+func exportComponentTemplates(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) (objects []client.Object, err error) {
+	templates := struct {
+		ComponentTemplates []struct {
+			Name              string         `json:"name"`
+			ComponentTemplate map[string]any `json:"component_template"`
+		} `json:"component_templates"`
+	}{}
+	if err = esGet(ctx, esClient, http.MethodGet, "/_component_template", &templates); err != nil {
+		return nil, err
+	}
+
+	objects = make([]client.Object, 0, len(templates.ComponentTemplates))
+	sort.Slice(templates.ComponentTemplates, func(i, j int) bool {
+		return templates.ComponentTemplates[i].Name < templates.ComponentTemplates[j].Name
+	})
+	for _, template := range templates.ComponentTemplates {
+		if meta, ok := template.ComponentTemplate["_meta"].(map[string]any); ok && isManaged(meta) {
+			continue
+		}
+
+		rawTemplate, err := json.Marshal(template.ComponentTemplate)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error when convert component template %s", template.Name)
+		}
+		objects = append(objects, &elasticsearchapicrd.ComponentTemplate{
+			TypeMeta:   metav1.TypeMeta{APIVersion: elasticsearchapicrd.GroupVersion.String(), Kind: "ComponentTemplate"},
+			ObjectMeta: namer.objectMeta("ComponentTemplate", template.Name, opts),
+			Spec: elasticsearchapicrd.ComponentTemplateSpec{
+				ElasticsearchRef: opts.elasticsearchRef(),
+				Name:             template.Name,
+				RawTemplate:      ptr.To(string(rawTemplate)),
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+func exportSnapshotRepositories(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) (objects []client.Object, err error) {
+	repositories := map[string]struct {
+		Type     string         `json:"type"`
+		Settings map[string]any `json:"settings"`
+	}{}
+	if err = esGet(ctx, esClient, http.MethodGet, "/_snapshot", &repositories); err != nil {
+		return nil, err
+	}
+
+	objects = make([]client.Object, 0, len(repositories))
+	for _, name := range sortedKeys(repositories) {
+		repository := repositories[name]
+		objects = append(objects, &elasticsearchapicrd.SnapshotRepository{
+			TypeMeta:   metav1.TypeMeta{APIVersion: elasticsearchapicrd.GroupVersion.String(), Kind: "SnapshotRepository"},
+			ObjectMeta: namer.objectMeta("SnapshotRepository", name, opts),
+			Spec: elasticsearchapicrd.SnapshotRepositorySpec{
+				ElasticsearchRef: opts.elasticsearchRef(),
+				Name:             name,
+				Type:             repository.Type,
+				Settings:         toMapAny(repository.Settings),
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+func exportWatches(ctx context.Context, esClient *elastic.Client, opts *exportOptions, namer exportNamer) (objects []client.Object, err error) {
+	watches := struct {
+		Count   int `json:"count"`
+		Watches []struct {
+			ID    string `json:"_id"`
+			Watch struct {
+				Trigger        map[string]any `json:"trigger"`
+				Input          map[string]any `json:"input"`
+				Condition      map[string]any `json:"condition"`
+				Transform      map[string]any `json:"transform"`
+				ThrottlePeriod string         `json:"throttle_period"`
+				Actions        map[string]any `json:"actions"`
+				Metadata       map[string]any `json:"metadata"`
+			} `json:"watch"`
+		} `json:"watches"`
+	}{}
+
+	// The API return only 10 watches by default, so we read all pages
+	for {
+		page := watches
+		page.Watches = nil
+		if err = esCall(ctx, esClient, http.MethodPost, "/_watcher/_query/watches", map[string]any{"from": len(watches.Watches), "size": exportWatchesPageSize}, &page); err != nil {
+			return nil, err
+		}
+		watches.Count = page.Count
+		watches.Watches = append(watches.Watches, page.Watches...)
+		if len(page.Watches) == 0 || len(watches.Watches) >= watches.Count {
+			break
+		}
+	}
+
+	objects = make([]client.Object, 0, len(watches.Watches))
+	sort.Slice(watches.Watches, func(i, j int) bool {
+		return watches.Watches[i].ID < watches.Watches[j].ID
+	})
+	for _, watch := range watches.Watches {
+		// Skip the watches managed by Elasticsearch, like the cluster alerts
+		if _, ok := watch.Watch.Metadata["xpack"]; ok {
+			continue
+		}
+
+		objects = append(objects, &elasticsearchapicrd.Watch{
+			TypeMeta:   metav1.TypeMeta{APIVersion: elasticsearchapicrd.GroupVersion.String(), Kind: "Watch"},
+			ObjectMeta: namer.objectMeta("Watch", watch.ID, opts),
+			Spec: elasticsearchapicrd.WatchSpec{
+				ElasticsearchRef: opts.elasticsearchRef(),
+				Name:             watch.ID,
+				Trigger:          toMapAny(watch.Watch.Trigger),
+				Input:            toMapAny(watch.Watch.Input),
+				Condition:        toMapAny(watch.Watch.Condition),
+				Transform:        toMapAny(watch.Watch.Transform),
+				ThrottlePeriod:   watch.Watch.ThrottlePeriod,
+				Actions:          toMapAny(watch.Watch.Actions),
+				Metadata:         toMapAny(watch.Watch.Metadata),
+			},
+		})
+	}
+
+	return objects, nil
+}
+
+// writeExportedObjects write the resources on YAML format
+// The status and the empty creation timestamp are removed to produce ready-to-apply resources
+// The skipped kinds are written as comment, so the output not look like a full export
+func writeExportedObjects(w io.Writer, objects []client.Object, skipped []string) (err error) {
+	if len(skipped) > 0 {
+		if _, err = fmt.Fprintf(w, "# Not exported because the API is not available on the cluster: %s\n", strings.Join(skipped, ", ")); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range objects {
+		b, err := json.Marshal(o)
+		if err != nil {
+			return errors.Wrapf(err, "Error when convert %s", o.GetName())
+		}
+		data := map[string]any{}
+		if err = json.Unmarshal(b, &data); err != nil {
+			return errors.Wrapf(err, "Error when convert %s", o.GetName())
+		}
+		delete(data, "status")
+		if meta, ok := data["metadata"].(map[string]any); ok {
+			delete(meta, "creationTimestamp")
+		}
+
+		b, err = yaml.Marshal(data)
+		if err != nil {
+			return errors.Wrapf(err, "Error when convert %s to YAML", o.GetName())
+		}
+		if _, err = fmt.Fprintf(w, "---\n%s", b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// objectMeta return the metadata with a valid and unique resource name for the kind
+func (n exportNamer) objectMeta(kind string, name string, opts *exportOptions) metav1.ObjectMeta {
+	if n[kind] == nil {
+		n[kind] = map[string]bool{}
+	}
+
+	resourceName := toResourceName(name)
+	candidate := resourceName
+	for i := 2; n[kind][candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d", resourceName, i)
+	}
+	n[kind][candidate] = true
+
+	return metav1.ObjectMeta{
+		Name:      candidate,
+		Namespace: opts.namespace,
+	}
+}
+
+// elasticsearchRef return the Elasticsearch ref to set on exported resources
+func (o *exportOptions) elasticsearchRef() shared.ElasticsearchRef {
+	if o.esRef != "" {
+		return shared.ElasticsearchRef{
+			ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+				Name: o.esRef,
+			},
+		}
+	}
+
+	esRef := shared.ElasticsearchRef{
+		ExternalElasticsearchRef: &shared.ElasticsearchExternalRef{
+			Addresses: []string{o.esURL},
+		},
+	}
+	if o.esSecretRef != "" {
+		esRef.SecretRef = &corev1.LocalObjectReference{
+			Name: o.esSecretRef,
+		}
+	}
+
+	return esRef
+}
+
+// kibanaRef return the Kibana ref to set on exported resources
+func (o *exportOptions) kibanaRef() shared.KibanaRef {
+	if o.kbRef != "" {
+		return shared.KibanaRef{
+			ManagedKibanaRef: &shared.KibanaManagedRef{
+				Name: o.kbRef,
+			},
+		}
+	}
+
+	kbRef := shared.KibanaRef{
+		ExternalKibanaRef: &shared.KibanaExternalRef{
+			Address: o.kbURL,
+		},
+	}
+	if o.kbSecretRef != "" {
+		kbRef.KibanaCredentialSecretRef = &corev1.LocalObjectReference{
+			Name: o.kbSecretRef,
+		}
+	}
+
+	return kbRef
+}
+
+// toResourceName convert a remote object name to a valid resource name
+func toResourceName(name string) string {
+	resourceName := strings.Trim(invalidResourceNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+	if len(resourceName) > 253 {
+		resourceName = strings.Trim(resourceName[:253], "-.")
+	}
+	if resourceName == "" {
+		return "unnamed"
+	}
+
+	return resourceName
+}
+
+// getRoleTemplateSource return the mustache source of role template
+// Elasticsearch return the template as JSON string or as object
+func getRoleTemplateSource(template any) (source string, err error) {
+	t := struct {
+		Source string `json:"source"`
+	}{}
+
+	switch v := template.(type) {
+	case string:
+		if err = json.Unmarshal([]byte(v), &t); err != nil {
+			return "", err
+		}
+	case map[string]any:
+		t.Source, _ = v["source"].(string)
+	default:
+		return "", errors.Errorf("Unexpected role template type %T", template)
+	}
+
+	return t.Source, nil
+}
+
+func toMapAny(data map[string]any) *apis.MapAny {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return &apis.MapAny{Data: data}
+}
+
+// toQueryString return the role query as string
+func toQueryString(query any) string {
+	switch v := query.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+func isReserved(metadata map[string]any) bool {
+	reserved, _ := metadata["_reserved"].(bool)
+	return reserved
+}
+
+func isManaged(meta map[string]any) bool {
+	managed, _ := meta["managed"].(bool)
+	return managed
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	elastic "github.com/elastic/go-elasticsearch/v8"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	kibanaapicrd "github.com/webcenter-fr/elasticsearch-operator/api/kibanaapi/v1"
+	"sigs.k8s.io/yaml"
+)
+
+func newFakeExportServer(t *testing.T) *httptest.Server {
+	responses := map[string]string{
+		"/_security/role": `{
+			"superuser": {"cluster": ["all"], "metadata": {"_reserved": true}},
+			"my_role": {"cluster": ["monitor"], "indices": [{"names": ["logs-*"], "privileges": ["read"], "query": {"match_all": {}}}], "metadata": {"team": "ops"}},
+			"kibana_role": {"cluster": [], "applications": [{"application": "kibana-.kibana", "privileges": ["all"], "resources": ["*"]}]}
+		}`,
+		"/_security/role_mapping": `{
+			"my_mapping": {"enabled": true, "roles": ["my_role"], "rules": {"field": {"groups": "ops"}}, "metadata": {}},
+			"My_Template": {"enabled": true, "role_templates": [{"template": "{\"source\":\"{{metadata.role}}\"}", "format": "string"}], "rules": {"any": [{"field": {"realm.name": "ldap"}}]}}
+		}`,
+		"/_security/user": `{
+			"elastic": {"roles": ["superuser"], "enabled": true, "metadata": {"_reserved": true}},
+			"john": {"roles": ["my_role"], "full_name": "John Doe", "email": "john@acme.com", "enabled": true, "metadata": {}}
+		}`,
+		"/_ilm/policy": `{
+			"logs": {"policy": {"_meta": {"managed": true}, "phases": {}}},
+			"my_policy": {"version": 1, "policy": {"phases": {"delete": {"min_age": "30d", "actions": {"delete": {}}}}}}
+		}`,
+		"/_slm/policy": `{
+			"daily": {"policy": {"name": "<daily-{now/d}>", "schedule": "0 30 1 * * ?", "repository": "backup", "config": {"indices": ["*"]}, "retention": {"expire_after": "30d", "min_count": 5, "max_count": 50}}}
+		}`,
+		"/_index_template": `{"index_templates": [
+			{"name": "my_template", "index_template": {"index_patterns": ["my-*"], "priority": 100}},
+			{"name": "logs", "index_template": {"index_patterns": ["logs-*-*"], "_meta": {"managed": true}}}
+		]}`,
+		"/_component_template": `{"component_templates": [
+			{"name": "my_component", "component_template": {"template": {"settings": {"number_of_shards": 1}}}}
+		]}`,
+		"/_snapshot": `{
+			"backup": {"type": "fs", "settings": {"location": "/mnt/backup"}}
+		}`,
+		"/api/spaces/space": `[
+			{"id": "default", "name": "Default", "_reserved": true},
+			{"id": "ops", "name": "Ops", "description": "Ops space", "disabledFeatures": ["dev_tools"]}
+		]`,
+		"/api/security/role": `[
+			{"name": "kibana_admin", "metadata": {"_reserved": true}, "elasticsearch": {}, "kibana": [{"base": ["all"], "spaces": ["*"]}]},
+			{"name": "my_role", "metadata": {}, "elasticsearch": {"cluster": ["monitor"]}, "kibana": []},
+			{"name": "kibana_role", "metadata": {}, "elasticsearch": {"cluster": [], "indices": [{"names": ["logs-*"], "privileges": ["read"]}]}, "kibana": [{"base": [], "feature": {"discover": ["read"]}, "spaces": ["ops"]}]}
+		]`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+		// Watcher is not available with basic license
+		if r.URL.Path == "/_watcher/_query/watches" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": "current license is non-compliant for [watcher]"}`))
+			return
+		}
+
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("Unexpected call %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func TestExport(t *testing.T) {
+	server := newFakeExportServer(t)
+	defer server.Close()
+
+	log := logrus.NewEntry(logrus.New())
+
+	// When no target
+	err := runExport(context.Background(), []string{}, &bytes.Buffer{}, log)
+	assert.Error(t, err)
+
+	// Export Elasticsearch and Kibana
+	out := &bytes.Buffer{}
+	err = runExport(context.Background(), []string{"--es-url", server.URL, "--es-ref", "my-cluster", "--kb-url", server.URL, "--kb-ref", "my-kibana", "--namespace", "test"}, out, log)
+	assert.NoError(t, err)
+
+	// Watcher is skipped and listed on output
+	assert.True(t, strings.HasPrefix(out.String(), "# Not exported because the API is not available on the cluster: watches\n"))
+
+	documents := strings.Split(strings.SplitN(out.String(), "---\n", 2)[1], "---\n")
+	assert.Len(t, documents, 11)
+
+	resources := map[string]string{}
+	for _, document := range documents {
+		o := map[string]any{}
+		if err = yaml.Unmarshal([]byte(document), &o); err != nil {
+			t.Fatal(err)
+		}
+		assert.NotContains(t, o, "status")
+		meta := o["metadata"].(map[string]any)
+		assert.Equal(t, "test", meta["namespace"])
+		assert.NotContains(t, meta, "creationTimestamp")
+		kind := o["kind"].(string)
+		if strings.HasPrefix(o["apiVersion"].(string), kibanaapicrd.GroupVersion.Group) {
+			kind = "Kibana" + kind
+		}
+		resources[kind+"/"+meta["name"].(string)] = document
+	}
+	assert.Contains(t, resources, "Role/my-role")
+	assert.Contains(t, resources, "RoleMapping/my-mapping")
+	assert.Contains(t, resources, "RoleMapping/my-template")
+	assert.Contains(t, resources, "User/john")
+	assert.Contains(t, resources, "IndexLifecyclePolicy/my-policy")
+	assert.Contains(t, resources, "SnapshotLifecyclePolicy/daily")
+	assert.Contains(t, resources, "IndexTemplate/my-template")
+	assert.Contains(t, resources, "ComponentTemplate/my-component")
+	assert.Contains(t, resources, "SnapshotRepository/backup")
+	assert.Contains(t, resources, "KibanaUserSpace/ops")
+	assert.Contains(t, resources, "KibanaRole/kibana-role")
+
+	// Check Elasticsearch role
+	role := &elasticsearchapicrd.Role{}
+	if err = yaml.Unmarshal([]byte(resources["Role/my-role"]), role); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, elasticsearchapicrd.GroupVersion.String(), role.APIVersion)
+	assert.Equal(t, "my-cluster", role.Spec.ElasticsearchRef.ManagedElasticsearchRef.Name)
+	assert.Equal(t, "my_role", role.Spec.Name)
+	assert.Equal(t, []string{"monitor"}, role.Spec.Cluster)
+	assert.Equal(t, `{"match_all":{}}`, role.Spec.Indices[0].Query)
+	assert.Equal(t, map[string]any{"team": "ops"}, role.Spec.Metadata.Data)
+
+	// Check role mapping with role templates
+	roleMapping := &elasticsearchapicrd.RoleMapping{}
+	if err = yaml.Unmarshal([]byte(resources["RoleMapping/my-template"]), roleMapping); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "My_Template", roleMapping.Spec.Name)
+	assert.Equal(t, "{{metadata.role}}", roleMapping.Spec.RoleTemplates[0].Source)
+	assert.Len(t, roleMapping.Spec.Rules.Any, 1)
+	assert.Equal(t, map[string]any{"realm.name": "ldap"}, roleMapping.Spec.Rules.Any[0].Field.Data)
+
+	// Check user reference the password secret
+	user := &elasticsearchapicrd.User{}
+	if err = yaml.Unmarshal([]byte(resources["User/john"]), user); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "john", user.Spec.Username)
+	assert.Equal(t, "john-credentials", user.Spec.SecretRef.Name)
+	assert.Equal(t, "password", user.Spec.SecretRef.Key)
+
+	// Check ILM use raw policy
+	ilm := &elasticsearchapicrd.IndexLifecyclePolicy{}
+	if err = yaml.Unmarshal([]byte(resources["IndexLifecyclePolicy/my-policy"]), ilm); err != nil {
+		t.Fatal(err)
+	}
+	assert.JSONEq(t, `{"policy":{"phases":{"delete":{"min_age":"30d","actions":{"delete":{}}}}}}`, *ilm.Spec.RawPolicy)
+
+	// Check Kibana role
+	kbRole := &kibanaapicrd.Role{}
+	if err = yaml.Unmarshal([]byte(resources["KibanaRole/kibana-role"]), kbRole); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, kibanaapicrd.GroupVersion.String(), kbRole.APIVersion)
+	assert.Equal(t, "my-kibana", kbRole.Spec.KibanaRef.ManagedKibanaRef.Name)
+	assert.Equal(t, map[string][]string{"discover": {"read"}}, kbRole.Spec.Kibana[0].Feature)
+	assert.Equal(t, []string{"logs-*"}, kbRole.Spec.Elasticsearch.Indices[0].Names)
+
+	// Export with external Elasticsearch
+	out = &bytes.Buffer{}
+	err = runExport(context.Background(), []string{"--es-url", server.URL, "--es-secret-ref", "es-credentials"}, out, log)
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "kind: Role\n")
+	assert.Contains(t, out.String(), "name: es-credentials")
+	assert.Contains(t, out.String(), server.URL)
+	// Kibana role is exported as Elasticsearch role when Kibana is not exported
+	assert.Contains(t, out.String(), "name: kibana_role")
+}
+
+func TestToResourceName(t *testing.T) {
+	assert.Equal(t, "my-role", toResourceName("my_role"))
+	assert.Equal(t, "logs-app.v1", toResourceName("Logs@App.v1"))
+	assert.Equal(t, "unnamed", toResourceName("___"))
+	assert.Len(t, toResourceName(strings.Repeat("a", 300)), 253)
+
+	namer := exportNamer{}
+	opts := &exportOptions{namespace: "default"}
+	assert.Equal(t, "my-role", namer.objectMeta("Role", "my_role", opts).Name)
+	assert.Equal(t, "my-role-2", namer.objectMeta("Role", "My_Role", opts).Name)
+	assert.Equal(t, "my-role", namer.objectMeta("User", "my_role", opts).Name)
+}
+
+func TestExportWatches(t *testing.T) {
+	exportWatchesPageSize = 2
+	defer func() { exportWatchesPageSize = 100 }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+
+		body := map[string]int{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, body["size"])
+
+		switch body["from"] {
+		case 0:
+			_, _ = w.Write([]byte(`{"count": 3, "watches": [{"_id": "watch1", "watch": {}}, {"_id": "watch2", "watch": {}}]}`))
+		case 2:
+			_, _ = w.Write([]byte(`{"count": 3, "watches": [{"_id": "watch3", "watch": {}}]}`))
+		default:
+			t.Errorf("Unexpected page from %d", body["from"])
+		}
+	}))
+	defer server.Close()
+
+	esClient, err := elastic.NewClient(elastic.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objects, err := exportWatches(context.Background(), esClient, &exportOptions{namespace: "default"}, exportNamer{})
+	assert.NoError(t, err)
+	assert.Len(t, objects, 3)
+	assert.Equal(t, "watch3", objects[2].GetName())
+}
+
+func TestExportPermissionDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": {"type": "security_exception", "reason": "action [cluster:admin/xpack/security/role/get] is unauthorized for user [export]"}}`))
+	}))
+	defer server.Close()
+
+	// The export fail instead to skip the kinds the user can't read
+	err := runExport(context.Background(), []string{"--es-url", server.URL}, &bytes.Buffer{}, logrus.NewEntry(logrus.New()))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Permission denied")
+}
+
+func TestExportBadRequest(t *testing.T) {
+	var body string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	esClient, err := elastic.NewClient(elastic.Config{Addresses: []string{server.URL}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The malformed request fail
+	body = `{"error": {"type": "parsing_exception", "reason": "unknown key [sizes] for query"}, "status": 400}`
+	_, err = exportWatches(context.Background(), esClient, &exportOptions{namespace: "default"}, exportNamer{})
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errExportNotAvailable)
+
+	// The disabled feature is skipped
+	body = `{"error": "no handler found for uri [/_watcher/_query/watches] and method [POST]"}`
+	_, err = exportWatches(context.Background(), esClient, &exportOptions{namespace: "default"}, exportNamer{})
+	assert.ErrorIs(t, err, errExportNotAvailable)
+
+	// The security disabled is skipped
+	body = `{"error": {"type": "exception", "reason": "Security must be explicitly enabled when using a [basic] license"}, "status": 400}`
+	_, err = exportRoles(context.Background(), esClient, &exportOptions{namespace: "default"}, exportNamer{})
+	assert.ErrorIs(t, err, errExportNotAvailable)
+}
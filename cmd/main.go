@@ -91,6 +91,20 @@ func main() {
 	var secureMetrics bool
 	var probeAddr string
 	var tlsOpts []func(*tls.Config)
+
+	// Export the objects of live cluster as custom resources
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		log := logrus.New()
+		log.SetOutput(os.Stderr)
+		log.SetLevel(helper.GetLogrusLogLevelFromEnv())
+		log.SetFormatter(helper.GetLogrusFormatterFromEnv())
+		if err := runExport(context.Background(), os.Args[2:], os.Stdout, log.WithFields(logrus.Fields{"type": "Export"})); err != nil {
+			log.Errorf("Error when export: %s", err.Error())
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -231,10 +245,16 @@ func main() {
 		elasticsearchapicrd.SetupIndexLifecyclePolicyIndexer,
 		elasticsearchapicrd.SetupIndexTemplateIndexer,
 		elasticsearchapicrd.SetupLicenceIndexer,
+		elasticsearchapicrd.SetupRemoteClusterIndexer,
+		elasticsearchapicrd.SetupFollowerIndexIndexer,
+		elasticsearchapicrd.SetupAutoFollowPatternIndexer,
+		elasticsearchapicrd.SetupSnapshotIndexer,
 		elasticsearchapicrd.SetupRoleIndexer,
 		elasticsearchapicrd.SetupRoleMappingIndexer,
 		elasticsearchapicrd.SetupSnapshotLifecyclePolicyIndexer,
 		elasticsearchapicrd.SetupSnapshotRepositoryIndexer,
+		elasticsearchapicrd.SetupTransformIndexer,
+		elasticsearchapicrd.SetupStoredScriptIndexer,
 		elasticsearchapicrd.SetupUserIndexexer,
 		elasticsearchapicrd.SetupWatchIndexer,
 		kibanaapicrd.SetupLogstashPipelineIndexer,
@@ -253,16 +273,25 @@ func main() {
 			beatcrd.SetupFilebeatWebhookWithManager(logrus.NewEntry(log)),
 			beatcrd.SetupMetricbeatWebhookWithManager(logrus.NewEntry(log)),
 			cerebrocrd.SetupHostWebhookWithManager(logrus.NewEntry(log)),
+			elasticsearchcrd.SetupElasticsearchWebhookWithManager(logrus.NewEntry(log)),
 			kibanacrd.SetupKibanaWebhookWithManager(logrus.NewEntry(log)),
 			logstashcrd.SetupLogstashWebhookWithManager(logrus.NewEntry(log)),
 			elasticsearchapicrd.SetupComponentTemplateWebhookWithManager(logrus.NewEntry(log)),
 			elasticsearchapicrd.SetupIndexLifecyclePolicyWebhookWithManager(logrus.NewEntry(log)),
 			elasticsearchapicrd.SetupIndexTemplateWebhookWithManager(logrus.NewEntry(log)),
 			elasticsearchapicrd.SetupLicenseWebhookWithManager(logrus.NewEntry(log)),
+			elasticsearchapicrd.SetupRemoteClusterWebhookWithManager(logrus.NewEntry(log)),
+			elasticsearchapicrd.SetupFollowerIndexWebhookWithManager(logrus.NewEntry(log)),
+			elasticsearchapicrd.SetupAutoFollowPatternWebhookWithManager(logrus.NewEntry(log)),
+			elasticsearchapicrd.SetupSnapshotWebhookWithManager(logrus.NewEntry(log)),
+			elasticsearchapicrd.SetupRestoreWebhookWithManager(logrus.NewEntry(log)),
 			elasticsearchapicrd.SetupRoleWebhookWithManager(logrus.NewEntry(log)),
+			elasticsearchapicrd.SetupResourceSetWebhookWithManager(logrus.NewEntry(log)),
 			elasticsearchapicrd.SetupRoleMappingWebhookWithManager(logrus.NewEntry(log)),
 			elasticsearchapicrd.SetupSnapshotLifecyclePolicyWebhookWithManager(logrus.NewEntry(log)),
 			elasticsearchapicrd.SetupSnapshotRepositoryWebhookWithManager(logrus.NewEntry(log)),
+			elasticsearchapicrd.SetupTransformWebhookWithManager(logrus.NewEntry(log)),
+			elasticsearchapicrd.SetupStoredScriptWebhookWithManager(logrus.NewEntry(log), elasticsearchapicontrollers.NewStoredScriptCompiler(logrus.NewEntry(log))),
 			elasticsearchapicrd.SetupUserWebhookWithManager(logrus.NewEntry(log)),
 			elasticsearchapicrd.SetupWatchWebhookWithManager(logrus.NewEntry(log)),
 			kibanaapicrd.SetupLogstashPipelineWebhookWithManager(logrus.NewEntry(log)),
@@ -323,6 +352,12 @@ func main() {
 		os.Exit(1)
 	}
 
+	elasticsearchResourceSetController := elasticsearchapicontrollers.NewResourceSetReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-resourceset-controller"))
+	if err = elasticsearchResourceSetController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchResourceSet")
+		os.Exit(1)
+	}
+
 	elasticsearchRoleController := elasticsearchapicontrollers.NewRoleReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-role-controller"))
 	if err = elasticsearchRoleController.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchRole")
@@ -353,6 +388,48 @@ func main() {
 		os.Exit(1)
 	}
 
+	elasticsearchTransformController := elasticsearchapicontrollers.NewTransformReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-transform-controller"))
+	if err = elasticsearchTransformController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchTransform")
+		os.Exit(1)
+	}
+
+	elasticsearchStoredScriptController := elasticsearchapicontrollers.NewStoredScriptReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-storedscript-controller"))
+	if err = elasticsearchStoredScriptController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchStoredScript")
+		os.Exit(1)
+	}
+
+	elasticsearchRemoteClusterController := elasticsearchapicontrollers.NewRemoteClusterReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-remotecluster-controller"))
+	if err = elasticsearchRemoteClusterController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchRemoteCluster")
+		os.Exit(1)
+	}
+
+	elasticsearchFollowerIndexController := elasticsearchapicontrollers.NewFollowerIndexReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-followerindex-controller"))
+	if err = elasticsearchFollowerIndexController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchFollowerIndex")
+		os.Exit(1)
+	}
+
+	elasticsearchAutoFollowPatternController := elasticsearchapicontrollers.NewAutoFollowPatternReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-autofollowpattern-controller"))
+	if err = elasticsearchAutoFollowPatternController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchAutoFollowPattern")
+		os.Exit(1)
+	}
+
+	elasticsearchSnapshotController := elasticsearchapicontrollers.NewSnapshotReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-snapshot-controller"))
+	if err = elasticsearchSnapshotController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchSnapshot")
+		os.Exit(1)
+	}
+
+	elasticsearchRestoreController := elasticsearchapicontrollers.NewRestoreReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-restore-controller"))
+	if err = elasticsearchRestoreController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchRestore")
+		os.Exit(1)
+	}
+
 	elasticsearchComponentTemplateController := elasticsearchapicontrollers.NewComponentTemplateReconciler(mgr.GetClient(), logrus.NewEntry(log), mgr.GetEventRecorderFor("elasticsearch-componenttemplate-controller"))
 	if err = elasticsearchComponentTemplateController.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchComponentTemplate")
@@ -181,6 +181,7 @@ func (t *CerebroControllerTestSuite) SetupSuite() {
 		beatcrd.SetupFilebeatWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		beatcrd.SetupMetricbeatWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		cerebrocrd.SetupHostWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchcrd.SetupElasticsearchWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		kibanacrd.SetupKibanaWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		logstashcrd.SetupLogstashWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupComponentTemplateWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// resyncIntervalEnv is the env to set the interval to check the drift of the remote objects
+	// It can be set per kind with suffix `_<GROUP>_<KIND>`, like `RESYNC_INTERVAL_ELASTICSEARCHAPI_ROLE`
+	resyncIntervalEnv = "RESYNC_INTERVAL"
+
+	// driftAlertOnlyEnv is the env to only alert when drift is detected, instead to correct it
+	// It can be set per kind with suffix `_<GROUP>_<KIND>`, like `DRIFT_ALERT_ONLY_ELASTICSEARCHAPI_ROLE`
+	driftAlertOnlyEnv = "DRIFT_ALERT_ONLY"
+
+	// driftAlertRequeueInterval is the interval to check again the drift on alert only mode when no resync interval is set
+	driftAlertRequeueInterval = 5 * time.Minute
+)
+
+// driftConfig is the drift detection settings of a kind
+type driftConfig struct {
+	// name is the kind identifier, like `elasticsearchapi-role`
+	name string
+
+	// resyncInterval is the interval to reconcile again the resource after success
+	// 0 disable it, so the drift is only checked on the next reconcile, like on the manager cache resync
+	resyncInterval time.Duration
+
+	// alertOnly only notify the drift without correct it
+	alertOnly bool
+}
+
+// newDriftConfig read the drift detection settings from env
+// The kind settings take precedence over the global settings
+func newDriftConfig[k8sObject any](annotationKey string) driftConfig {
+	group, _, _ := strings.Cut(annotationKey, ".")
+	kind := reflect.TypeOf((*k8sObject)(nil)).Elem()
+	if kind.Kind() == reflect.Pointer {
+		kind = kind.Elem()
+	}
+	suffix := strings.ToUpper(fmt.Sprintf("%s_%s", group, kind.Name()))
+
+	config := driftConfig{
+		name: strings.ToLower(fmt.Sprintf("%s-%s", group, kind.Name())),
+	}
+
+	for _, env := range []string{resyncIntervalEnv, fmt.Sprintf("%s_%s", resyncIntervalEnv, suffix)} {
+		if value, ok := os.LookupEnv(env); ok {
+			interval, err := time.ParseDuration(value)
+			if err != nil {
+				logrus.Warnf("Invalid duration '%s' on env %s, it will be ignored: %s", value, env, err.Error())
+				continue
+			}
+			config.resyncInterval = interval
+		}
+	}
+
+	for _, env := range []string{driftAlertOnlyEnv, fmt.Sprintf("%s_%s", driftAlertOnlyEnv, suffix)} {
+		if value, ok := os.LookupEnv(env); ok {
+			alertOnly, err := strconv.ParseBool(value)
+			if err != nil {
+				logrus.Warnf("Invalid boolean '%s' on env %s, it will be ignored: %s", value, env, err.Error())
+				continue
+			}
+			config.alertOnly = alertOnly
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,36 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	kibanaapicrd "github.com/webcenter-fr/elasticsearch-operator/api/kibanaapi/v1"
+)
+
+func TestNewDriftConfig(t *testing.T) {
+	// When no env
+	config := newDriftConfig[*elasticsearchapicrd.Role](elasticsearchapicrd.ElasticsearchApiAnnotationKey)
+	assert.Equal(t, driftConfig{name: "elasticsearchapi-role"}, config)
+
+	// When global env
+	t.Setenv("RESYNC_INTERVAL", "10m")
+	t.Setenv("DRIFT_ALERT_ONLY", "true")
+	config = newDriftConfig[*elasticsearchapicrd.Role](elasticsearchapicrd.ElasticsearchApiAnnotationKey)
+	assert.Equal(t, driftConfig{name: "elasticsearchapi-role", resyncInterval: 10 * time.Minute, alertOnly: true}, config)
+
+	// When kind env
+	t.Setenv("RESYNC_INTERVAL_KIBANAAPI_ROLE", "1h")
+	t.Setenv("DRIFT_ALERT_ONLY_KIBANAAPI_ROLE", "false")
+	config = newDriftConfig[*kibanaapicrd.Role](kibanaapicrd.KibanaApiAnnotationKey)
+	assert.Equal(t, driftConfig{name: "kibanaapi-role", resyncInterval: time.Hour, alertOnly: false}, config)
+	config = newDriftConfig[*elasticsearchapicrd.Role](elasticsearchapicrd.ElasticsearchApiAnnotationKey)
+	assert.Equal(t, driftConfig{name: "elasticsearchapi-role", resyncInterval: 10 * time.Minute, alertOnly: true}, config)
+
+	// When invalid env
+	t.Setenv("RESYNC_INTERVAL_ELASTICSEARCHAPI_ROLE", "bad")
+	t.Setenv("DRIFT_ALERT_ONLY_ELASTICSEARCHAPI_ROLE", "bad")
+	config = newDriftConfig[*elasticsearchapicrd.Role](elasticsearchapicrd.ElasticsearchApiAnnotationKey)
+	assert.Equal(t, driftConfig{name: "elasticsearchapi-role", resyncInterval: 10 * time.Minute, alertOnly: true}, config)
+}
@@ -31,5 +31,34 @@ func GetElasticsearchFromRef(ctx context.Context, c client.Client, o client.Obje
 		return nil, errors.Wrapf(err, "Error when read elasticsearch %s/%s", target.Namespace, target.Name)
 	}
 
+	// Check the cluster allow the reference from the namespace of object
+	if err = shared.CheckReferencePolicy(ctx, c, o, es, es.Spec.ReferencePolicy); err != nil {
+		return nil, err
+	}
+
+	// Use the effective spec, with the class settings
+	if err = ApplyElasticsearchClass(ctx, c, es); err != nil {
+		return nil, err
+	}
+
 	return es, nil
 }
+
+// ApplyElasticsearchClass merge the ElasticsearchClass referenced by the cluster on its spec
+// The spec is only merged on memory, it's never saved on Kubernetes
+func ApplyElasticsearchClass(ctx context.Context, c client.Client, es *elasticsearchcrd.Elasticsearch) (err error) {
+	if es.Spec.ClassRef == nil || es.Spec.ClassRef.Name == "" {
+		return nil
+	}
+
+	class := &elasticsearchcrd.ElasticsearchClass{}
+	if err = c.Get(ctx, types.NamespacedName{Name: es.Spec.ClassRef.Name}, class); err != nil {
+		return errors.Wrapf(err, "Error when read ElasticsearchClass %s", es.Spec.ClassRef.Name)
+	}
+
+	if err = es.ApplyClass(class); err != nil {
+		return errors.Wrapf(err, "Error when apply ElasticsearchClass %s", es.Spec.ClassRef.Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetElasticsearchFromRef(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, elasticsearchcrd.AddToScheme(scheme))
+	assert.NoError(t, elasticsearchapicrd.AddToScheme(scheme))
+
+	es := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "es",
+			Namespace: "cluster",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			ReferencePolicy: &shared.ReferencePolicy{
+				From: []shared.ReferencePolicyFrom{
+					{
+						Namespace: "team-a",
+						Kinds:     []string{"Role"},
+					},
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"team": "ops",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			es,
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-c", Labels: map[string]string{"team": "ops"}}},
+		).
+		Build()
+
+	esRef := shared.ElasticsearchRef{
+		ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+			Name:      "es",
+			Namespace: "cluster",
+		},
+	}
+	newRole := func(namespace string) *elasticsearchapicrd.Role {
+		return &elasticsearchapicrd.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: namespace,
+			},
+		}
+	}
+
+	// When same namespace
+	res, err := GetElasticsearchFromRef(context.Background(), c, newRole("cluster"), esRef)
+	assert.NoError(t, err)
+	assert.Equal(t, "es", res.Name)
+
+	// When namespace and kind are allowed
+	res, err = GetElasticsearchFromRef(context.Background(), c, newRole("team-a"), esRef)
+	assert.NoError(t, err)
+	assert.Equal(t, "es", res.Name)
+
+	// When kind is not allowed
+	_, err = GetElasticsearchFromRef(context.Background(), c, &elasticsearchapicrd.User{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "team-a"}}, esRef)
+	assert.True(t, errors.Is(err, shared.ErrReferenceNotAllowed))
+
+	// When namespace is not allowed
+	_, err = GetElasticsearchFromRef(context.Background(), c, newRole("team-b"), esRef)
+	assert.True(t, errors.Is(err, shared.ErrReferenceNotAllowed))
+
+	// When namespace is allowed by selector
+	res, err = GetElasticsearchFromRef(context.Background(), c, newRole("team-c"), esRef)
+	assert.NoError(t, err)
+	assert.Equal(t, "es", res.Name)
+
+	// When Elasticsearch not exist
+	esRef.ManagedElasticsearchRef.Name = "not-found"
+	res, err = GetElasticsearchFromRef(context.Background(), c, newRole("team-b"), esRef)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestRemoveAnnotationWithElasticsearchClass(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, elasticsearchcrd.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&elasticsearchcrd.Elasticsearch{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "es",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"elasticsearch.k8s.webcenter.fr/renew-certificates": "true",
+					},
+				},
+				Spec: elasticsearchcrd.ElasticsearchSpec{
+					ClassRef: &elasticsearchcrd.ElasticsearchClassRef{
+						Name: "default",
+					},
+				},
+			},
+			&elasticsearchcrd.ElasticsearchClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "default",
+				},
+				Spec: elasticsearchcrd.ElasticsearchClassSpec{
+					Version:     "8.10.0",
+					PluginsList: []string{"repository-s3"},
+				},
+			},
+		).
+		Build()
+
+	es := &elasticsearchcrd.Elasticsearch{}
+	assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "es"}, es))
+	assert.NoError(t, ApplyElasticsearchClass(context.Background(), c, es))
+	assert.Equal(t, "8.10.0", es.Spec.Version)
+
+	// The stored cluster not contain the class settings
+	err := RemoveAnnotation(context.Background(), c, es, "elasticsearch.k8s.webcenter.fr/renew-certificates")
+	assert.NoError(t, err)
+	assert.NotContains(t, es.Annotations, "elasticsearch.k8s.webcenter.fr/renew-certificates")
+	assert.Equal(t, "8.10.0", es.Spec.Version)
+
+	stored := &elasticsearchcrd.Elasticsearch{}
+	assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "es"}, stored))
+	assert.NotContains(t, stored.Annotations, "elasticsearch.k8s.webcenter.fr/renew-certificates")
+	assert.Empty(t, stored.Spec.Version)
+	assert.Empty(t, stored.Spec.PluginsList)
+	assert.Equal(t, stored.ResourceVersion, es.ResourceVersion)
+
+	// When annotation not exist
+	err = RemoveAnnotation(context.Background(), c, es, "elasticsearch.k8s.webcenter.fr/renew-certificates")
+	assert.NoError(t, err)
+}
@@ -31,5 +31,10 @@ func GetKibanaFromRef(ctx context.Context, c client.Client, o client.Object, kbR
 		return nil, errors.Wrapf(err, "Error when read kibana %s/%s", target.Namespace, target.Name)
 	}
 
+	// Check Kibana allow the reference from the namespace of object
+	if err = shared.CheckReferencePolicy(ctx, c, o, kb, kb.Spec.ReferencePolicy); err != nil {
+		return nil, err
+	}
+
 	return kb, nil
 }
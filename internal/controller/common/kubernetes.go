@@ -1,7 +1,44 @@
 package common
 
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
 // KubernetesCapability descripte the capability of the current Kubernetes cluster
 type KubernetesCapability struct {
 	HasRoute      bool
 	HasPrometheus bool
 }
+
+// RemoveAnnotation remove the annotation on the resource stored on Kubernetes, like the annotations that trigger an action
+// The resource is read again to not overwrite the status computed on memory, then the annotation is removed on memory too
+func RemoveAnnotation(ctx context.Context, c client.Client, o client.Object, annotation string) (err error) {
+	current, ok := o.DeepCopyObject().(client.Object)
+	if !ok {
+		return errors.Errorf("Error when copy object %s/%s", o.GetNamespace(), o.GetName())
+	}
+	if err = c.Get(ctx, client.ObjectKeyFromObject(o), current); err != nil {
+		return errors.Wrapf(err, "Error when read object %s/%s", o.GetNamespace(), o.GetName())
+	}
+
+	annotations := current.GetAnnotations()
+	if _, ok := annotations[annotation]; ok {
+		patch := client.MergeFrom(current.DeepCopyObject().(client.Object))
+		delete(annotations, annotation)
+		current.SetAnnotations(annotations)
+		if err = c.Patch(ctx, current, patch); err != nil {
+			return errors.Wrapf(err, "Error when remove annotation %s on object %s/%s", annotation, o.GetNamespace(), o.GetName())
+		}
+	}
+
+	// Keep the resource version to update the status later
+	annotations = o.GetAnnotations()
+	delete(annotations, annotation)
+	o.SetAnnotations(annotations)
+	o.SetResourceVersion(current.GetResourceVersion())
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRemoveAnnotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, elasticsearchapicrd.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&elasticsearchapicrd.SnapshotLifecyclePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "slm",
+					Namespace: "default",
+					Annotations: map[string]string{
+						"elasticsearchapi.k8s.webcenter.fr/execute": "true",
+					},
+				},
+			},
+		).
+		WithStatusSubresource(&elasticsearchapicrd.SnapshotLifecyclePolicy{}).
+		Build()
+
+	o := &elasticsearchapicrd.SnapshotLifecyclePolicy{}
+	assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "slm"}, o))
+	o.Status.LastExecution = &elasticsearchapicrd.SLMInvocation{
+		SnapshotName: "snapshot",
+	}
+
+	// The status computed on memory is keeped
+	err := RemoveAnnotation(context.Background(), c, o, "elasticsearchapi.k8s.webcenter.fr/execute")
+	assert.NoError(t, err)
+	assert.NotContains(t, o.Annotations, "elasticsearchapi.k8s.webcenter.fr/execute")
+	assert.Equal(t, "snapshot", o.Status.LastExecution.SnapshotName)
+
+	stored := &elasticsearchapicrd.SnapshotLifecyclePolicy{}
+	assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "slm"}, stored))
+	assert.NotContains(t, stored.Annotations, "elasticsearchapi.k8s.webcenter.fr/execute")
+	assert.Equal(t, stored.ResourceVersion, o.ResourceVersion)
+
+	// The status can be updated after
+	assert.NoError(t, c.Status().Update(context.Background(), o))
+
+	// When annotation not exist
+	err = RemoveAnnotation(context.Background(), c, o, "elasticsearchapi.k8s.webcenter.fr/execute")
+	assert.NoError(t, err)
+}
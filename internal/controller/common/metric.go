@@ -20,9 +20,17 @@ var (
 		Name: "elasticsearch_operator_instances_controller",
 		Help: "Number of instance per controllers",
 	}, []string{"controller", "namespace", "name"})
+	DriftDetected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "elasticsearch_operator_drift_detected_total",
+		Help: "Number of drifts detected on remote objects per controllers",
+	}, []string{"controller", "namespace", "name"})
+	LicenseExpirySeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "elasticsearch_operator_license_expiry_seconds",
+		Help: "Number of seconds until the license expire, negative when the license is expired",
+	}, []string{"namespace", "name", "type"})
 )
 
 func init() {
 	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(TotalErrors, ControllerErrors, ControllerInstances)
+	metrics.Registry.MustRegister(TotalErrors, ControllerErrors, ControllerInstances, DriftDetected, LicenseExpirySeconds)
 }
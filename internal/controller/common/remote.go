@@ -0,0 +1,222 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/sirupsen/logrus"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// adoptionPendingRequeueInterval is the interval to refresh the adoption diff when it wait the adopt annotation
+	adoptionPendingRequeueInterval = 5 * time.Minute
+
+	// dryRunRequeueInterval is the interval to refresh the dry-run diff when the dry-run annotation is set
+	dryRunRequeueInterval = 5 * time.Minute
+)
+
+// RemoteObject is a remote object that support the deletion policy
+type RemoteObject interface {
+	object.RemoteObject
+	GetDeletionPolicy() shared.DeletionPolicy
+}
+
+// AdoptableRemoteObject is a remote object that support the adoption of existing remote object
+type AdoptableRemoteObject interface {
+	GetAdoptionPolicy() shared.AdoptionPolicy
+	GetAdoptionStatus() *shared.AdoptionStatus
+	SetAdoptionStatus(status *shared.AdoptionStatus)
+}
+
+// DryRunRemoteObject is a remote object that support the dry-run annotation
+type DryRunRemoteObject interface {
+	GetDryRunStatus() *shared.DryRunStatus
+	SetDryRunStatus(status *shared.DryRunStatus)
+}
+
+// remoteReconcilerAction wrap a remote reconciler action to add the common behaviors of remote resources
+type remoteReconcilerAction[k8sObject RemoteObject, apiObject comparable, apiClient any] struct {
+	remote.RemoteReconcilerAction[k8sObject, apiObject, apiClient]
+	annotationKey string
+	drift         driftConfig
+}
+
+// NewRemoteReconcilerAction return a remote reconciler action that honor the deletion policy, the adoption policy and the dry-run annotation
+// It also detect the drift of remote objects, with the resync interval read from env
+// The annotation key is the base key used to read the annotations, like `<annotationKey>/adopt` or `<annotationKey>/dry-run`
+func NewRemoteReconcilerAction[k8sObject RemoteObject, apiObject comparable, apiClient any](annotationKey string, reconciler remote.RemoteReconcilerAction[k8sObject, apiObject, apiClient]) remote.RemoteReconcilerAction[k8sObject, apiObject, apiClient] {
+	return &remoteReconcilerAction[k8sObject, apiObject, apiClient]{
+		RemoteReconcilerAction: reconciler,
+		annotationKey:          annotationKey,
+		drift:                  newDriftConfig[k8sObject](annotationKey),
+	}
+}
+
+// Diff record the remote object and the diff on status when the remote object already exist and is not yet managed by the operator
+// It skip the update until the adopt annotation is set when the adoption policy is Manual
+// It only publish the diff, without create or update the remote object, when the dry-run annotation is set
+// It notify the drift when the remote object has been changed outside the operator
+func (h *remoteReconcilerAction[k8sObject, apiObject, apiClient]) Diff(ctx context.Context, o k8sObject, read remote.RemoteRead[apiObject], data map[string]any, handler remote.RemoteExternalReconciler[k8sObject, apiObject, apiClient], logger *logrus.Entry, ignoreDiff ...patch.CalculateOption) (diff remote.RemoteDiff[apiObject], res reconcile.Result, err error) {
+	diff, res, err = h.RemoteReconcilerAction.Diff(ctx, o, read, data, handler, logger, ignoreDiff...)
+	if err != nil || res != (reconcile.Result{}) {
+		return diff, res, err
+	}
+
+	if h.isDrift(o, diff) {
+		logger.Warnf("Detect drift on object '%s', the remote object has been changed outside the operator: %s", o.GetName(), diff.Diff())
+		DriftDetected.WithLabelValues(h.drift.name, o.GetNamespace(), o.GetName()).Inc()
+		if h.drift.alertOnly {
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "DriftDetected", "Object '%s' has been changed on remote target, it will not be corrected: %s", o.GetName(), diff.Diff())
+			o.GetStatus().SetIsSync(false)
+			requeueAfter := h.drift.resyncInterval
+			if requeueAfter == 0 {
+				requeueAfter = driftAlertRequeueInterval
+			}
+			return diff, reconcile.Result{RequeueAfter: requeueAfter}, nil
+		}
+		if _, ok := any(o).(DryRunRemoteObject); ok && h.isDryRun(o) {
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "DriftDetected", "Object '%s' has been changed on remote target, it will not be corrected because of dry-run, %s object '%s' on remote target: %s", o.GetName(), shared.DryRunOperationUpdate, o.GetName(), diff.Diff())
+		} else {
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "DriftDetected", "Object '%s' has been changed on remote target, it will be corrected: %s", o.GetName(), diff.Diff())
+		}
+	}
+
+	if dryRunnable, ok := any(o).(DryRunRemoteObject); ok {
+		if h.isDryRun(o) {
+			return h.dryRun(o, dryRunnable, diff, logger)
+		}
+		dryRunnable.SetDryRunStatus(nil)
+	}
+
+	adoptable, ok := any(o).(AdoptableRemoteObject)
+	if !ok {
+		return diff, res, nil
+	}
+
+	// Adoption only concern the remote object that already exist and never applied by the operator
+	if !diff.NeedUpdate() || o.GetStatus().GetLastAppliedConfiguration() != "" {
+		return diff, res, nil
+	}
+
+	remoteObject, err := json.Marshal(read.GetCurrentObject())
+	if err != nil {
+		return diff, res, errors.Wrapf(err, "Error when convert remote object %s", o.GetName())
+	}
+	adoptionStatus := &shared.AdoptionStatus{
+		Phase:        shared.AdoptionPhaseAdopted,
+		RemoteObject: string(remoteObject),
+		Diff:         diff.Diff(),
+	}
+
+	if adoptable.GetAdoptionPolicy().IsManual() && o.GetAnnotations()[fmt.Sprintf("%s/adopt", h.annotationKey)] != "true" {
+		if adoptable.GetAdoptionStatus() == nil || adoptable.GetAdoptionStatus().Phase != shared.AdoptionPhasePending {
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "AdoptionPending", "Object '%s' already exist on remote target, set annotation '%s/adopt' to 'true' to apply it", o.GetName(), h.annotationKey)
+		}
+		adoptionStatus.Phase = shared.AdoptionPhasePending
+		adoptable.SetAdoptionStatus(adoptionStatus)
+		o.GetStatus().SetIsSync(false)
+		logger.Infof("Object '%s' already exist on remote target, wait the adopt annotation before apply it", o.GetName())
+
+		return diff, reconcile.Result{RequeueAfter: adoptionPendingRequeueInterval}, nil
+	}
+
+	adoptable.SetAdoptionStatus(adoptionStatus)
+	logger.Infof("Adopt object '%s' that already exist on remote target", o.GetName())
+	h.Recorder().Eventf(o, corev1.EventTypeNormal, "Adopted", "Object '%s' already exist on remote target, it will be overwrited", o.GetName())
+
+	return diff, res, nil
+}
+
+// OnSuccess requeue the resource after the resync interval to detect the drift
+func (h *remoteReconcilerAction[k8sObject, apiObject, apiClient]) OnSuccess(ctx context.Context, o k8sObject, data map[string]any, handler remote.RemoteExternalReconciler[k8sObject, apiObject, apiClient], diff remote.RemoteDiff[apiObject], logger *logrus.Entry) (res reconcile.Result, err error) {
+	res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	if err != nil || h.drift.resyncInterval == 0 || res.Requeue {
+		return res, err
+	}
+
+	if res.RequeueAfter == 0 || res.RequeueAfter > h.drift.resyncInterval {
+		res.RequeueAfter = h.drift.resyncInterval
+	}
+
+	return res, nil
+}
+
+// Delete skip the remote delete when the deletion policy is Orphan
+func (h *remoteReconcilerAction[k8sObject, apiObject, apiClient]) Delete(ctx context.Context, o k8sObject, data map[string]any, handler remote.RemoteExternalReconciler[k8sObject, apiObject, apiClient], logger *logrus.Entry) (err error) {
+	if o.GetDeletionPolicy().IsOrphan() {
+		logger.Infof("Deletion policy is %s, keep object '%s' on remote target", shared.DeletionPolicyOrphan, o.GetName())
+		h.Recorder().Eventf(o, corev1.EventTypeNormal, "DeleteSkipped", "Object '%s' keeped on remote target because of deletion policy %s", o.GetName(), shared.DeletionPolicyOrphan)
+		return nil
+	}
+
+	if _, ok := any(o).(DryRunRemoteObject); ok && h.isDryRun(o) {
+		logger.Infof("Dry-run is enabled, keep object '%s' on remote target", o.GetName())
+		h.Recorder().Eventf(o, corev1.EventTypeNormal, "DryRun", "Object '%s' keeped on remote target because of dry-run", o.GetName())
+		return nil
+	}
+
+	return h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger)
+}
+
+// isDrift return true if the remote object has been changed outside the operator
+// It's the case when the resource has already been applied and the spec not changed since, but the remote object differ
+func (h *remoteReconcilerAction[k8sObject, apiObject, apiClient]) isDrift(o k8sObject, diff remote.RemoteDiff[apiObject]) bool {
+	if !diff.NeedCreate() && !diff.NeedUpdate() {
+		return false
+	}
+
+	return o.GetStatus().GetLastAppliedConfiguration() != "" && o.GetStatus().GetObservedGeneration() == o.GetGeneration()
+}
+
+// isDryRun return true if the dry-run annotation is set
+func (h *remoteReconcilerAction[k8sObject, apiObject, apiClient]) isDryRun(o k8sObject) bool {
+	return o.GetAnnotations()[fmt.Sprintf("%s/dry-run", h.annotationKey)] == "true"
+}
+
+// dryRun publish the diff on status and as event, then skip the create or the update of the remote object
+func (h *remoteReconcilerAction[k8sObject, apiObject, apiClient]) dryRun(o k8sObject, dryRunnable DryRunRemoteObject, diff remote.RemoteDiff[apiObject], logger *logrus.Entry) (remote.RemoteDiff[apiObject], reconcile.Result, error) {
+	dryRunStatus := &shared.DryRunStatus{
+		Operation: shared.DryRunOperationNone,
+		Diff:      diff.Diff(),
+	}
+	if diff.NeedCreate() {
+		// Show the object that will be created instead of the generic create message
+		expectedObject, err := json.Marshal(diff.GetObjectToCreate())
+		if err != nil {
+			return diff, reconcile.Result{}, errors.Wrapf(err, "Error when convert expected object %s", o.GetName())
+		}
+		dryRunStatus.Operation = shared.DryRunOperationCreate
+		dryRunStatus.Diff = string(expectedObject)
+	} else if diff.NeedUpdate() {
+		dryRunStatus.Operation = shared.DryRunOperationUpdate
+	}
+
+	// Only notify when the plan change to not flood the events
+	if previous := dryRunnable.GetDryRunStatus(); previous == nil || *previous != *dryRunStatus {
+		if dryRunStatus.Operation == shared.DryRunOperationNone {
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "DryRun", "Object '%s' is up to date on remote target", o.GetName())
+		} else {
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "DryRun", "%s object '%s' on remote target: %s", dryRunStatus.Operation, o.GetName(), dryRunStatus.Diff)
+		}
+	}
+	dryRunnable.SetDryRunStatus(dryRunStatus)
+
+	// Nothing to apply, so it can continue as usual
+	if dryRunStatus.Operation == shared.DryRunOperationNone {
+		return diff, reconcile.Result{}, nil
+	}
+
+	logger.Infof("Dry-run is enabled, skip %s of object '%s' on remote target: %s", dryRunStatus.Operation, o.GetName(), dryRunStatus.Diff)
+	o.GetStatus().SetIsSync(false)
+
+	return diff, reconcile.Result{RequeueAfter: dryRunRequeueInterval}, nil
+}
@@ -0,0 +1,296 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type fakeRoleApiClient struct {
+	remote.RemoteExternalReconciler[*elasticsearchapicrd.Role, *eshandler.XPackSecurityRole, eshandler.ElasticsearchHandler]
+	isDeleted bool
+}
+
+func (h *fakeRoleApiClient) Delete(o *elasticsearchapicrd.Role) (err error) {
+	h.isDeleted = true
+	return nil
+}
+
+func (h *fakeRoleApiClient) Diff(currentOject *eshandler.XPackSecurityRole, expectedObject *eshandler.XPackSecurityRole, originalObject *eshandler.XPackSecurityRole, o *elasticsearchapicrd.Role, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	return patch.DefaultPatchMaker.Calculate(currentOject, expectedObject, originalObject, ignoresDiff...)
+}
+
+func newTestRemoteReconcilerAction() remote.RemoteReconcilerAction[*elasticsearchapicrd.Role, *eshandler.XPackSecurityRole, eshandler.ElasticsearchHandler] {
+	return NewRemoteReconcilerAction(
+		elasticsearchapicrd.ElasticsearchApiAnnotationKey,
+		remote.NewRemoteReconcilerAction[*elasticsearchapicrd.Role, *eshandler.XPackSecurityRole, eshandler.ElasticsearchHandler](
+			fake.NewClientBuilder().Build(),
+			record.NewFakeRecorder(10),
+		),
+	)
+}
+
+func TestRemoteReconcilerActionDelete(t *testing.T) {
+	reconciler := newTestRemoteReconcilerAction()
+	o := &elasticsearchapicrd.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+
+	// When default policy
+	handler := &fakeRoleApiClient{}
+	err := reconciler.Delete(context.Background(), o, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, handler.isDeleted)
+
+	// When delete policy
+	o.Spec.DeletionPolicy = shared.DeletionPolicyDelete
+	handler = &fakeRoleApiClient{}
+	err = reconciler.Delete(context.Background(), o, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, handler.isDeleted)
+
+	// When orphan policy
+	o.Spec.DeletionPolicy = shared.DeletionPolicyOrphan
+	handler = &fakeRoleApiClient{}
+	err = reconciler.Delete(context.Background(), o, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.False(t, handler.isDeleted)
+
+	// When dry-run
+	o.Spec.DeletionPolicy = shared.DeletionPolicyDelete
+	o.Annotations = map[string]string{
+		"elasticsearchapi.k8s.webcenter.fr/dry-run": "true",
+	}
+	handler = &fakeRoleApiClient{}
+	err = reconciler.Delete(context.Background(), o, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.False(t, handler.isDeleted)
+}
+
+func TestRemoteReconcilerActionDiff(t *testing.T) {
+	reconciler := newTestRemoteReconcilerAction()
+	handler := &fakeRoleApiClient{}
+	o := &elasticsearchapicrd.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+	read := remote.NewRemoteRead[*eshandler.XPackSecurityRole]()
+	read.SetCurrentObject(&eshandler.XPackSecurityRole{Cluster: []string{"monitor"}})
+	read.SetExpectedObject(&eshandler.XPackSecurityRole{Cluster: []string{"all"}})
+
+	// When remote object not exist
+	readCreate := remote.NewRemoteRead[*eshandler.XPackSecurityRole]()
+	readCreate.SetExpectedObject(&eshandler.XPackSecurityRole{Cluster: []string{"all"}})
+	diff, res, err := reconciler.Diff(context.Background(), o, readCreate, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedCreate())
+	assert.Equal(t, reconcile.Result{}, res)
+	assert.Nil(t, o.Status.Adoption)
+
+	// When remote object already exist with default policy
+	diff, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Equal(t, reconcile.Result{}, res)
+	assert.Equal(t, shared.AdoptionPhaseAdopted, o.Status.Adoption.Phase)
+	assert.JSONEq(t, `{"cluster":["monitor"]}`, o.Status.Adoption.RemoteObject)
+	assert.NotEmpty(t, o.Status.Adoption.Diff)
+
+	// When remote object already exist with manual policy
+	o.Status.Adoption = nil
+	o.Spec.AdoptionPolicy = shared.AdoptionPolicyManual
+	diff, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Equal(t, 5*time.Minute, res.RequeueAfter)
+	assert.Equal(t, shared.AdoptionPhasePending, o.Status.Adoption.Phase)
+	assert.False(t, o.Status.GetIsSync())
+
+	// When remote object already exist with manual policy and adopt annotation
+	o.Annotations = map[string]string{
+		"elasticsearchapi.k8s.webcenter.fr/adopt": "true",
+	}
+	diff, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Equal(t, reconcile.Result{}, res)
+	assert.Equal(t, shared.AdoptionPhaseAdopted, o.Status.Adoption.Phase)
+
+	// When remote object is already managed by operator
+	o.Status.Adoption = nil
+	o.Annotations = nil
+	o.Status.LastAppliedConfiguration, err = helper.ZipAndBase64Encode(&eshandler.XPackSecurityRole{Cluster: []string{"monitor"}})
+	assert.NoError(t, err)
+	diff, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Equal(t, reconcile.Result{}, res)
+	assert.Nil(t, o.Status.Adoption)
+}
+
+func TestRemoteReconcilerActionDryRun(t *testing.T) {
+	var err error
+	reconciler := newTestRemoteReconcilerAction()
+	handler := &fakeRoleApiClient{}
+	o := &elasticsearchapicrd.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"elasticsearchapi.k8s.webcenter.fr/dry-run": "true",
+			},
+		},
+	}
+
+	// When remote object not exist
+	read := remote.NewRemoteRead[*eshandler.XPackSecurityRole]()
+	read.SetExpectedObject(&eshandler.XPackSecurityRole{Cluster: []string{"all"}})
+	diff, res, err := reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedCreate())
+	assert.Equal(t, 5*time.Minute, res.RequeueAfter)
+	assert.Equal(t, shared.DryRunOperationCreate, o.Status.DryRun.Operation)
+	assert.JSONEq(t, `{"cluster":["all"]}`, o.Status.DryRun.Diff)
+	assert.False(t, o.Status.GetIsSync())
+
+	// When remote object need to be updated
+	o.Status.LastAppliedConfiguration, err = helper.ZipAndBase64Encode(&eshandler.XPackSecurityRole{Cluster: []string{"monitor"}})
+	assert.NoError(t, err)
+	read.SetCurrentObject(&eshandler.XPackSecurityRole{Cluster: []string{"monitor"}})
+	diff, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Equal(t, 5*time.Minute, res.RequeueAfter)
+	assert.Equal(t, shared.DryRunOperationUpdate, o.Status.DryRun.Operation)
+	assert.NotEmpty(t, o.Status.DryRun.Diff)
+	assert.Nil(t, o.Status.Adoption)
+
+	// When remote object is up to date
+	read.SetCurrentObject(&eshandler.XPackSecurityRole{Cluster: []string{"all"}})
+	o.Status.LastAppliedConfiguration, err = helper.ZipAndBase64Encode(&eshandler.XPackSecurityRole{Cluster: []string{"all"}})
+	assert.NoError(t, err)
+	diff, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.False(t, diff.NeedUpdate())
+	assert.Equal(t, reconcile.Result{}, res)
+	assert.Equal(t, shared.DryRunOperationNone, o.Status.DryRun.Operation)
+
+	// When dry-run annotation is removed
+	o.Annotations = nil
+	_, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{}, res)
+	assert.Nil(t, o.Status.DryRun)
+}
+
+func TestRemoteReconcilerActionDrift(t *testing.T) {
+	var err error
+	handler := &fakeRoleApiClient{}
+	o := &elasticsearchapicrd.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "drift",
+			Namespace:  "default",
+			Generation: 2,
+		},
+	}
+	o.Status.LastAppliedConfiguration, err = helper.ZipAndBase64Encode(&eshandler.XPackSecurityRole{Cluster: []string{"all"}})
+	assert.NoError(t, err)
+	read := remote.NewRemoteRead[*eshandler.XPackSecurityRole]()
+	read.SetCurrentObject(&eshandler.XPackSecurityRole{Cluster: []string{"monitor"}})
+	read.SetExpectedObject(&eshandler.XPackSecurityRole{Cluster: []string{"all"}})
+	counter := DriftDetected.WithLabelValues("elasticsearchapi-role", "default", "drift")
+
+	// When spec has been changed since last sync
+	o.Status.ObservedGeneration = 1
+	reconciler := newTestRemoteReconcilerAction()
+	diff, res, err := reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Equal(t, reconcile.Result{}, res)
+	assert.Equal(t, float64(0), testutil.ToFloat64(counter))
+
+	// When remote object has been changed outside the operator
+	o.Status.ObservedGeneration = 2
+	diff, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Equal(t, reconcile.Result{}, res)
+	assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+
+	// When alert only
+	t.Setenv("DRIFT_ALERT_ONLY_ELASTICSEARCHAPI_ROLE", "true")
+	reconciler = newTestRemoteReconcilerAction()
+	diff, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.True(t, diff.NeedUpdate())
+	assert.Equal(t, 5*time.Minute, res.RequeueAfter)
+	assert.False(t, o.Status.GetIsSync())
+	assert.Equal(t, float64(2), testutil.ToFloat64(counter))
+
+	// When resync interval is set
+	t.Setenv("RESYNC_INTERVAL_ELASTICSEARCHAPI_ROLE", "30m")
+	reconciler = newTestRemoteReconcilerAction()
+	_, res, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, res.RequeueAfter)
+	res, err = reconciler.OnSuccess(context.Background(), o, map[string]any{}, handler, remote.NewRemoteDiff[*eshandler.XPackSecurityRole](), logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, res.RequeueAfter)
+	assert.True(t, o.Status.GetIsSync())
+}
+
+func TestRemoteReconcilerActionDriftDryRun(t *testing.T) {
+	var err error
+	handler := &fakeRoleApiClient{}
+	o := &elasticsearchapicrd.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "drift-dry-run",
+			Namespace:  "default",
+			Generation: 2,
+			Annotations: map[string]string{
+				"elasticsearchapi.k8s.webcenter.fr/dry-run": "true",
+			},
+		},
+	}
+	o.Status.ObservedGeneration = 2
+	o.Status.LastAppliedConfiguration, err = helper.ZipAndBase64Encode(&eshandler.XPackSecurityRole{Cluster: []string{"all"}})
+	assert.NoError(t, err)
+	read := remote.NewRemoteRead[*eshandler.XPackSecurityRole]()
+	read.SetCurrentObject(&eshandler.XPackSecurityRole{Cluster: []string{"monitor"}})
+	read.SetExpectedObject(&eshandler.XPackSecurityRole{Cluster: []string{"all"}})
+	recorder := record.NewFakeRecorder(10)
+	reconciler := NewRemoteReconcilerAction(
+		elasticsearchapicrd.ElasticsearchApiAnnotationKey,
+		remote.NewRemoteReconcilerAction[*elasticsearchapicrd.Role, *eshandler.XPackSecurityRole, eshandler.ElasticsearchHandler](
+			fake.NewClientBuilder().Build(),
+			recorder,
+		),
+	)
+
+	// The drift event not announce a correction
+	_, _, err = reconciler.Diff(context.Background(), o, read, map[string]any{}, handler, logrus.NewEntry(logrus.New()))
+	assert.NoError(t, err)
+	event := <-recorder.Events
+	assert.Contains(t, event, "DriftDetected")
+	assert.Contains(t, event, "it will not be corrected because of dry-run, Update object 'drift-dry-run' on remote target")
+	assert.Equal(t, shared.DryRunOperationUpdate, o.Status.DryRun.Operation)
+}
@@ -13,7 +13,8 @@ import (
 )
 
 // BuildConfigMaps permit to generate config maps for each node Groups
-func buildConfigMaps(es *elasticsearchcrd.Elasticsearch) (configMaps []*corev1.ConfigMap, err error) {
+// The remote cluster secret is managed by RemoteCluster, it's used to enable the remote cluster server and client with API key security
+func buildConfigMaps(es *elasticsearchcrd.Elasticsearch, remoteClusterSecret *corev1.Secret) (configMaps []*corev1.ConfigMap, err error) {
 	var (
 		configMap      *corev1.ConfigMap
 		expectedConfig map[string]string
@@ -42,6 +43,26 @@ func buildConfigMaps(es *elasticsearchcrd.Elasticsearch) (configMaps []*corev1.C
 		elasticsearchConfig["xpack.security.http.ssl.enabled"] = false
 	}
 
+	// Compute remote cluster settings used by API key security
+	// The remote cluster server use the transport certificates, and the client trust the transport CA of the remote clusters
+	// The client not check the hostname because the nodes are reached by IP on sniff mode
+	if isRemoteClusterServerEnabled(remoteClusterSecret) {
+		elasticsearchConfig["remote_cluster_server.enabled"] = true
+		elasticsearchConfig["xpack.security.remote_cluster_server.ssl.enabled"] = true
+		elasticsearchConfig["xpack.security.remote_cluster_server.ssl.certificate"] = "/usr/share/elasticsearch/config/transport-cert/${POD_NAME}.crt"
+		elasticsearchConfig["xpack.security.remote_cluster_server.ssl.key"] = "/usr/share/elasticsearch/config/transport-cert/${POD_NAME}.key"
+	}
+	if isRemoteClusterClientEnabled(remoteClusterSecret) {
+		elasticsearchConfig["xpack.security.remote_cluster_client.ssl.enabled"] = true
+		elasticsearchConfig["xpack.security.remote_cluster_client.ssl.verification_mode"] = "certificate"
+		elasticsearchConfig["xpack.security.remote_cluster_client.ssl.certificate_authorities"] = "/usr/share/elasticsearch/config/transport-cert/ca.crt"
+	}
+
+	// Compute realms settings
+	for key, value := range computeRealmsConfig(es) {
+		elasticsearchConfig[key] = value
+	}
+
 	injectedConfigMap := map[string]string{
 		"elasticsearch.yml": helper.ToYamlOrDie(elasticsearchConfig),
 	}
@@ -135,6 +156,113 @@ func buildConfigMaps(es *elasticsearchcrd.Elasticsearch) (configMaps []*corev1.C
 	return configMaps, nil
 }
 
+// computeRealmsConfig create the settings of all realms defined on security spec
+func computeRealmsConfig(es *elasticsearchcrd.Elasticsearch) (config map[string]any) {
+	config = map[string]any{}
+	if es.Spec.Security == nil || es.Spec.Security.Realms == nil {
+		return config
+	}
+
+	setIfNotEmpty := func(key string, value any) {
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				return
+			}
+		case []string:
+			if len(v) == 0 {
+				return
+			}
+		}
+		config[key] = value
+	}
+
+	// Common realm settings
+	for _, realm := range es.GetRealms() {
+		prefix := getRealmSettingPrefix(realm.Type, realm.Name)
+		if realm.Settings != nil {
+			for key, value := range realm.Settings.Data {
+				config[fmt.Sprintf("%s.%s", prefix, key)] = value
+			}
+		}
+		config[fmt.Sprintf("%s.order", prefix)] = realm.Order
+		if realm.Enabled != nil {
+			config[fmt.Sprintf("%s.enabled", prefix)] = *realm.Enabled
+		}
+	}
+
+	for _, realm := range es.Spec.Security.Realms.Ldap {
+		prefix := getRealmSettingPrefix(elasticsearchcrd.RealmTypeLdap, realm.Name)
+		setIfNotEmpty(fmt.Sprintf("%s.url", prefix), realm.Urls)
+		setIfNotEmpty(fmt.Sprintf("%s.bind_dn", prefix), realm.BindDn)
+		setIfNotEmpty(fmt.Sprintf("%s.user_dn_templates", prefix), realm.UserDnTemplates)
+		if realm.UserSearch != nil {
+			setIfNotEmpty(fmt.Sprintf("%s.user_search.base_dn", prefix), realm.UserSearch.BaseDn)
+			setIfNotEmpty(fmt.Sprintf("%s.user_search.filter", prefix), realm.UserSearch.Filter)
+			setIfNotEmpty(fmt.Sprintf("%s.user_search.scope", prefix), realm.UserSearch.Scope)
+		}
+		if realm.GroupSearch != nil {
+			setIfNotEmpty(fmt.Sprintf("%s.group_search.base_dn", prefix), realm.GroupSearch.BaseDn)
+			setIfNotEmpty(fmt.Sprintf("%s.group_search.filter", prefix), realm.GroupSearch.Filter)
+			setIfNotEmpty(fmt.Sprintf("%s.group_search.scope", prefix), realm.GroupSearch.Scope)
+		}
+		if realm.UnmappedGroupsAsRoles {
+			config[fmt.Sprintf("%s.unmapped_groups_as_roles", prefix)] = true
+		}
+	}
+
+	for _, realm := range es.Spec.Security.Realms.Saml {
+		prefix := getRealmSettingPrefix(elasticsearchcrd.RealmTypeSaml, realm.Name)
+		setIfNotEmpty(fmt.Sprintf("%s.idp.entity_id", prefix), realm.IdpEntityId)
+		if realm.IdpMetadataSecretRef != nil {
+			config[fmt.Sprintf("%s.idp.metadata.path", prefix)] = fmt.Sprintf("%s/%s", realmsPath, getRealmIdpMetadataPath(realm.Name))
+		} else {
+			setIfNotEmpty(fmt.Sprintf("%s.idp.metadata.path", prefix), realm.IdpMetadataUrl)
+		}
+		setIfNotEmpty(fmt.Sprintf("%s.sp.entity_id", prefix), realm.SpEntityId)
+		setIfNotEmpty(fmt.Sprintf("%s.sp.acs", prefix), realm.SpAcs)
+		setIfNotEmpty(fmt.Sprintf("%s.sp.logout", prefix), realm.SpLogout)
+		setIfNotEmpty(fmt.Sprintf("%s.attributes.principal", prefix), realm.Attributes.Principal)
+		setIfNotEmpty(fmt.Sprintf("%s.attributes.groups", prefix), realm.Attributes.Groups)
+		setIfNotEmpty(fmt.Sprintf("%s.attributes.name", prefix), realm.Attributes.Name)
+		setIfNotEmpty(fmt.Sprintf("%s.attributes.mail", prefix), realm.Attributes.Mail)
+	}
+
+	for _, realm := range es.Spec.Security.Realms.Oidc {
+		prefix := getRealmSettingPrefix(elasticsearchcrd.RealmTypeOidc, realm.Name)
+		setIfNotEmpty(fmt.Sprintf("%s.rp.client_id", prefix), realm.RpClientId)
+		setIfNotEmpty(fmt.Sprintf("%s.rp.response_type", prefix), realm.RpResponseType)
+		setIfNotEmpty(fmt.Sprintf("%s.rp.redirect_uri", prefix), realm.RpRedirectUri)
+		setIfNotEmpty(fmt.Sprintf("%s.rp.post_logout_redirect_uri", prefix), realm.RpPostLogoutRedirectUri)
+		setIfNotEmpty(fmt.Sprintf("%s.rp.requested_scopes", prefix), realm.RpRequestedScopes)
+		setIfNotEmpty(fmt.Sprintf("%s.op.issuer", prefix), realm.OpIssuer)
+		setIfNotEmpty(fmt.Sprintf("%s.op.authorization_endpoint", prefix), realm.OpAuthorizationEndpoint)
+		setIfNotEmpty(fmt.Sprintf("%s.op.token_endpoint", prefix), realm.OpTokenEndpoint)
+		setIfNotEmpty(fmt.Sprintf("%s.op.userinfo_endpoint", prefix), realm.OpUserinfoEndpoint)
+		setIfNotEmpty(fmt.Sprintf("%s.op.endsession_endpoint", prefix), realm.OpEndSessionEndpoint)
+		setIfNotEmpty(fmt.Sprintf("%s.op.jwkset_path", prefix), realm.OpJwkSetPath)
+		setIfNotEmpty(fmt.Sprintf("%s.claims.principal", prefix), realm.Claims.Principal)
+		setIfNotEmpty(fmt.Sprintf("%s.claims.groups", prefix), realm.Claims.Groups)
+		setIfNotEmpty(fmt.Sprintf("%s.claims.name", prefix), realm.Claims.Name)
+		setIfNotEmpty(fmt.Sprintf("%s.claims.mail", prefix), realm.Claims.Mail)
+	}
+
+	for _, realm := range es.Spec.Security.Realms.Jwt {
+		prefix := getRealmSettingPrefix(elasticsearchcrd.RealmTypeJwt, realm.Name)
+		setIfNotEmpty(fmt.Sprintf("%s.allowed_issuer", prefix), realm.AllowedIssuer)
+		setIfNotEmpty(fmt.Sprintf("%s.allowed_audiences", prefix), realm.AllowedAudiences)
+		setIfNotEmpty(fmt.Sprintf("%s.allowed_signature_algorithms", prefix), realm.AllowedSignatureAlgorithms)
+		setIfNotEmpty(fmt.Sprintf("%s.pkc_jwkset_path", prefix), realm.PkcJwkSetPath)
+		setIfNotEmpty(fmt.Sprintf("%s.client_authentication.type", prefix), realm.ClientAuthenticationType)
+		setIfNotEmpty(fmt.Sprintf("%s.claims.principal", prefix), realm.Claims.Principal)
+		setIfNotEmpty(fmt.Sprintf("%s.claims.groups", prefix), realm.Claims.Groups)
+		setIfNotEmpty(fmt.Sprintf("%s.claims.name", prefix), realm.Claims.Name)
+		setIfNotEmpty(fmt.Sprintf("%s.claims.mail", prefix), realm.Claims.Mail)
+	}
+
+	return config
+}
+
 // computeInitialMasterNodes create the list of all master nodes
 func computeInitialMasterNodes(es *elasticsearchcrd.Elasticsearch) string {
 	masterNodes := make([]string, 0, 3)
@@ -5,6 +5,8 @@ import (
 
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/elastic/go-ucfg"
+	ucfgyaml "github.com/elastic/go-ucfg/yaml"
 	"github.com/stretchr/testify/assert"
 	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
@@ -64,7 +66,7 @@ node:
 		},
 	}
 
-	configMaps, err := buildConfigMaps(o)
+	configMaps, err := buildConfigMaps(o, nil)
 	assert.NoError(t, err)
 	test.EqualFromYamlFile[*corev1.ConfigMap](t, "testdata/configmap_default.yml", configMaps[0], scheme.Scheme)
 
@@ -108,7 +110,7 @@ node:
 		},
 	}
 
-	configMaps, err = buildConfigMaps(o)
+	configMaps, err = buildConfigMaps(o, nil)
 	assert.NoError(t, err)
 	test.EqualFromYamlFile[*corev1.ConfigMap](t, "testdata/configmap_api_tls_disabled.yml", configMaps[0], scheme.Scheme)
 
@@ -139,7 +141,7 @@ node:
 		},
 	}
 
-	configMaps, err = buildConfigMaps(o)
+	configMaps, err = buildConfigMaps(o, nil)
 	assert.NoError(t, err)
 	test.EqualFromYamlFile[*corev1.ConfigMap](t, "testdata/configmap_not_bootstrapping.yml", configMaps[1], scheme.Scheme)
 
@@ -173,7 +175,7 @@ node:
 		},
 	}
 
-	configMaps, err = buildConfigMaps(o)
+	configMaps, err = buildConfigMaps(o, nil)
 	assert.NoError(t, err)
 	test.EqualFromYamlFile[*corev1.ConfigMap](t, "testdata/configmap_bootstrapping.yml", configMaps[1], scheme.Scheme)
 
@@ -204,11 +206,88 @@ node:
 		},
 	}
 
-	configMaps, err = buildConfigMaps(o)
+	configMaps, err = buildConfigMaps(o, nil)
 	assert.NoError(t, err)
 	test.EqualFromYamlFile[*corev1.ConfigMap](t, "testdata/configmap_not_bootstrapping_single.yml", configMaps[1], scheme.Scheme)
 }
 
+func TestBuildConfigMapsWithRemoteCluster(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			NodeGroups: []elasticsearchcrd.ElasticsearchNodeGroupSpec{
+				{
+					Name: "all",
+					Deployment: shared.Deployment{
+						Replicas: 1,
+					},
+				},
+			},
+		},
+	}
+
+	readConfig := func(cm *corev1.ConfigMap) *ucfg.Config {
+		config, err := ucfgyaml.NewConfig([]byte(cm.Data["elasticsearch.yml"]), ucfg.PathSep("."))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return config
+	}
+
+	// Without remote cluster
+	configMaps, err := buildConfigMaps(o, &corev1.Secret{})
+	assert.NoError(t, err)
+	config := readConfig(configMaps[0])
+	assert.False(t, config.HasField("remote_cluster_server"))
+	isSet, err := config.Has("xpack.security.remote_cluster_client", -1, ucfg.PathSep("."))
+	assert.NoError(t, err)
+	assert.False(t, isSet)
+
+	// When cluster is used as remote with API key security
+	configMaps, err = buildConfigMaps(o, &corev1.Secret{
+		Data: map[string][]byte{
+			"default-remote.server": []byte("true"),
+		},
+	})
+	assert.NoError(t, err)
+	config = readConfig(configMaps[0])
+	enabled, err := config.Bool("remote_cluster_server.enabled", -1, ucfg.PathSep("."))
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	enabled, err = config.Bool("xpack.security.remote_cluster_server.ssl.enabled", -1, ucfg.PathSep("."))
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	value, err := config.String("xpack.security.remote_cluster_server.ssl.certificate", -1, ucfg.PathSep("."))
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/share/elasticsearch/config/transport-cert/${POD_NAME}.crt", value)
+	value, err = config.String("xpack.security.remote_cluster_server.ssl.key", -1, ucfg.PathSep("."))
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/share/elasticsearch/config/transport-cert/${POD_NAME}.key", value)
+	isSet, err = config.Has("xpack.security.remote_cluster_client", -1, ucfg.PathSep("."))
+	assert.NoError(t, err)
+	assert.False(t, isSet)
+
+	// When cluster connect on remote cluster with API key security
+	configMaps, err = buildConfigMaps(o, &corev1.Secret{
+		Data: map[string][]byte{
+			"cluster.remote.remote.credentials": []byte("api-key"),
+			"default-remote.crt":                []byte("ca"),
+		},
+	})
+	assert.NoError(t, err)
+	config = readConfig(configMaps[0])
+	assert.False(t, config.HasField("remote_cluster_server"))
+	enabled, err = config.Bool("xpack.security.remote_cluster_client.ssl.enabled", -1, ucfg.PathSep("."))
+	assert.NoError(t, err)
+	assert.True(t, enabled)
+	value, err = config.String("xpack.security.remote_cluster_client.ssl.certificate_authorities", -1, ucfg.PathSep("."))
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/share/elasticsearch/config/transport-cert/ca.crt", value)
+}
+
 func TestComputeInitialMasterNodes(t *testing.T) {
 	var o *elasticsearchcrd.Elasticsearch
 
@@ -334,3 +413,141 @@ func TestComputeDiscoverySeedHosts(t *testing.T) {
 
 	assert.Equal(t, "test-all-headless-es, test-master-headless-es", computeDiscoverySeedHosts(o))
 }
+
+func TestComputeRealmsConfig(t *testing.T) {
+	var o *elasticsearchcrd.Elasticsearch
+
+	// Without realms
+	o = &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{},
+	}
+	assert.Empty(t, computeRealmsConfig(o))
+
+	// With realms
+	o.Spec.Security = &elasticsearchcrd.ElasticsearchSecuritySpec{
+		Realms: &elasticsearchcrd.ElasticsearchRealmsSpec{
+			Ldap: []elasticsearchcrd.ElasticsearchLdapRealmSpec{
+				{
+					ElasticsearchRealmSpec: elasticsearchcrd.ElasticsearchRealmSpec{
+						Name:  "ldap1",
+						Order: 0,
+						Settings: &apis.MapAny{
+							Data: map[string]any{
+								"ssl.verification_mode": "certificate",
+							},
+						},
+					},
+					Urls:   []string{"ldaps://ldap.example.com:636"},
+					BindDn: "cn=admin,dc=example,dc=com",
+					BindPasswordSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "realms",
+						},
+						Key: "ldap",
+					},
+					UserSearch: &elasticsearchcrd.ElasticsearchLdapSearchSpec{
+						BaseDn: "ou=users,dc=example,dc=com",
+						Filter: "(uid={0})",
+					},
+					GroupSearch: &elasticsearchcrd.ElasticsearchLdapSearchSpec{
+						BaseDn: "ou=groups,dc=example,dc=com",
+					},
+				},
+			},
+			Saml: []elasticsearchcrd.ElasticsearchSamlRealmSpec{
+				{
+					ElasticsearchRealmSpec: elasticsearchcrd.ElasticsearchRealmSpec{
+						Name:    "saml1",
+						Order:   1,
+						Enabled: ptr.To(false),
+					},
+					IdpEntityId: "https://idp.example.com",
+					IdpMetadataSecretRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "realms",
+						},
+						Key: "saml",
+					},
+					SpEntityId: "https://kibana.example.com",
+					SpAcs:      "https://kibana.example.com/api/security/saml/callback",
+					Attributes: elasticsearchcrd.ElasticsearchRealmClaimsSpec{
+						Principal: "nameid",
+						Groups:    "groups",
+					},
+				},
+			},
+			Oidc: []elasticsearchcrd.ElasticsearchOidcRealmSpec{
+				{
+					ElasticsearchRealmSpec: elasticsearchcrd.ElasticsearchRealmSpec{
+						Name:  "oidc1",
+						Order: 2,
+					},
+					RpClientId:              "kibana",
+					RpResponseType:          "code",
+					RpRedirectUri:           "https://kibana.example.com/api/security/oidc/callback",
+					RpRequestedScopes:       []string{"openid", "email"},
+					OpIssuer:                "https://op.example.com",
+					OpAuthorizationEndpoint: "https://op.example.com/auth",
+					OpTokenEndpoint:         "https://op.example.com/token",
+					OpJwkSetPath:            "https://op.example.com/certs",
+					Claims: elasticsearchcrd.ElasticsearchRealmClaimsSpec{
+						Principal: "sub",
+					},
+				},
+			},
+			Jwt: []elasticsearchcrd.ElasticsearchJwtRealmSpec{
+				{
+					ElasticsearchRealmSpec: elasticsearchcrd.ElasticsearchRealmSpec{
+						Name:  "jwt1",
+						Order: 3,
+					},
+					AllowedIssuer:            "https://issuer.example.com",
+					AllowedAudiences:         []string{"elasticsearch"},
+					PkcJwkSetPath:            "https://issuer.example.com/jwks",
+					ClientAuthenticationType: "none",
+					Claims: elasticsearchcrd.ElasticsearchRealmClaimsSpec{
+						Principal: "sub",
+					},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]any{
+		"xpack.security.authc.realms.ldap.ldap1.order":                     int64(0),
+		"xpack.security.authc.realms.ldap.ldap1.ssl.verification_mode":     "certificate",
+		"xpack.security.authc.realms.ldap.ldap1.url":                       []string{"ldaps://ldap.example.com:636"},
+		"xpack.security.authc.realms.ldap.ldap1.bind_dn":                   "cn=admin,dc=example,dc=com",
+		"xpack.security.authc.realms.ldap.ldap1.user_search.base_dn":       "ou=users,dc=example,dc=com",
+		"xpack.security.authc.realms.ldap.ldap1.user_search.filter":        "(uid={0})",
+		"xpack.security.authc.realms.ldap.ldap1.group_search.base_dn":      "ou=groups,dc=example,dc=com",
+		"xpack.security.authc.realms.saml.saml1.order":                     int64(1),
+		"xpack.security.authc.realms.saml.saml1.enabled":                   false,
+		"xpack.security.authc.realms.saml.saml1.idp.entity_id":             "https://idp.example.com",
+		"xpack.security.authc.realms.saml.saml1.idp.metadata.path":         "/usr/share/elasticsearch/config/realms/saml/saml1/idp-metadata.xml",
+		"xpack.security.authc.realms.saml.saml1.sp.entity_id":              "https://kibana.example.com",
+		"xpack.security.authc.realms.saml.saml1.sp.acs":                    "https://kibana.example.com/api/security/saml/callback",
+		"xpack.security.authc.realms.saml.saml1.attributes.principal":      "nameid",
+		"xpack.security.authc.realms.saml.saml1.attributes.groups":         "groups",
+		"xpack.security.authc.realms.oidc.oidc1.order":                     int64(2),
+		"xpack.security.authc.realms.oidc.oidc1.rp.client_id":              "kibana",
+		"xpack.security.authc.realms.oidc.oidc1.rp.response_type":          "code",
+		"xpack.security.authc.realms.oidc.oidc1.rp.redirect_uri":           "https://kibana.example.com/api/security/oidc/callback",
+		"xpack.security.authc.realms.oidc.oidc1.rp.requested_scopes":       []string{"openid", "email"},
+		"xpack.security.authc.realms.oidc.oidc1.op.issuer":                 "https://op.example.com",
+		"xpack.security.authc.realms.oidc.oidc1.op.authorization_endpoint": "https://op.example.com/auth",
+		"xpack.security.authc.realms.oidc.oidc1.op.token_endpoint":         "https://op.example.com/token",
+		"xpack.security.authc.realms.oidc.oidc1.op.jwkset_path":            "https://op.example.com/certs",
+		"xpack.security.authc.realms.oidc.oidc1.claims.principal":          "sub",
+		"xpack.security.authc.realms.jwt.jwt1.order":                       int64(3),
+		"xpack.security.authc.realms.jwt.jwt1.allowed_issuer":              "https://issuer.example.com",
+		"xpack.security.authc.realms.jwt.jwt1.allowed_audiences":           []string{"elasticsearch"},
+		"xpack.security.authc.realms.jwt.jwt1.pkc_jwkset_path":             "https://issuer.example.com/jwks",
+		"xpack.security.authc.realms.jwt.jwt1.client_authentication.type":  "none",
+		"xpack.security.authc.realms.jwt.jwt1.claims.principal":            "sub",
+	}, computeRealmsConfig(o))
+}
@@ -11,7 +11,9 @@ import (
 	"github.com/sirupsen/logrus"
 	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -52,8 +54,19 @@ func (r *configMapReconciler) Read(ctx context.Context, o *elasticsearchcrd.Elas
 	}
 	read.SetCurrentObjects(helper.ToSlicePtr(cmList.Items))
 
+	// Read remote cluster secret if exist, it's managed by RemoteCluster
+	var remoteClusterSecret *corev1.Secret
+	rcs := &corev1.Secret{}
+	if err = r.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: GetSecretNameForRemoteCluster(o)}, rcs); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return read, res, errors.Wrapf(err, "Error when read secret %s", GetSecretNameForRemoteCluster(o))
+		}
+	} else {
+		remoteClusterSecret = rcs
+	}
+
 	// Generate expected node group configmaps
-	expectedCms, err := buildConfigMaps(o)
+	expectedCms, err := buildConfigMaps(o, remoteClusterSecret)
 	if err != nil {
 		return read, res, errors.Wrap(err, "Error when generate config maps")
 	}
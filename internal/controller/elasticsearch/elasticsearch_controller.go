@@ -103,8 +103,11 @@ func NewElasticsearchReconciler(c client.Client, logger *logrus.Entry, recorder
 			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *corev1.Service, client.Object](newServiceReconciler(c, recorder)),
 			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *policyv1.PodDisruptionBudget, client.Object](newPdbReconciler(c, recorder)),
 			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *networkingv1.NetworkPolicy, client.Object](newNetworkPolicyReconciler(c, recorder)),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.Snapshot, client.Object](newUpgradeSnapshotReconciler(c, recorder)),
 			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *appv1.StatefulSet, client.Object](newStatefulsetReconciler(c, recorder, kubeCapability.HasRoute)),
 			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.User, client.Object](newSystemUserReconciler(c, recorder)),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.SnapshotRepository, client.Object](newSnapshotRepositoryReconciler(c, recorder)),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.Restore, client.Object](newRestoreReconciler(c, recorder)),
 			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *networkingv1.Ingress, client.Object](newIngressReconciler(c, recorder)),
 			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *corev1.Service, client.Object](newLoadBalancerReconciler(c, recorder)),
 			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *beatcrd.Metricbeat, client.Object](newMetricbeatReconciler(c, recorder)),
@@ -128,6 +131,7 @@ func NewElasticsearchReconciler(c client.Client, logger *logrus.Entry, recorder
 //+kubebuilder:rbac:groups=elasticsearch.k8s.webcenter.fr,resources=elasticsearches,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=elasticsearch.k8s.webcenter.fr,resources=elasticsearches/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=elasticsearch.k8s.webcenter.fr,resources=elasticsearches/finalizers,verbs=update
+//+kubebuilder:rbac:groups=elasticsearch.k8s.webcenter.fr,resources=elasticsearchclasses,verbs=get;list;watch
 //+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
 //+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
@@ -141,6 +145,9 @@ func NewElasticsearchReconciler(c client.Client, logger *logrus.Entry, recorder
 //+kubebuilder:rbac:groups="monitoring.coreos.com",resources=podmonitors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="elasticsearchapi.k8s.webcenter.fr",resources=users,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="elasticsearchapi.k8s.webcenter.fr",resources=licenses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="elasticsearchapi.k8s.webcenter.fr",resources=snapshotrepositories,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="elasticsearchapi.k8s.webcenter.fr",resources=restores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="elasticsearchapi.k8s.webcenter.fr",resources=snapshots,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="beat.k8s.webcenter.fr",resources=metricbeats,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="route.openshift.io",resources=routes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="route.openshift.io",resources=routes/custom-host,verbs=get;list;watch;create;update;patch;delete
@@ -186,6 +193,9 @@ func (h *ElasticsearchReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appv1.Deployment{}).
 		Owns(&elasticsearchapicrd.User{}).
 		Owns(&elasticsearchapicrd.License{}).
+		Owns(&elasticsearchapicrd.SnapshotRepository{}).
+		Owns(&elasticsearchapicrd.Restore{}).
+		Owns(&elasticsearchapicrd.Snapshot{}).
 		Owns(&beatcrd.Metricbeat{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.RoleBinding{}).
@@ -193,6 +203,7 @@ func (h *ElasticsearchReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(watchConfigMap(h.Client()))).
 		Watches(&elasticsearchcrd.Elasticsearch{}, handler.EnqueueRequestsFromMapFunc(watchElasticsearchMonitoring(h.Client()))).
 		Watches(&cerebrocrd.Host{}, handler.EnqueueRequestsFromMapFunc(watchHost(h.Client()))).
+		Watches(&elasticsearchcrd.ElasticsearchClass{}, handler.EnqueueRequestsFromMapFunc(watchElasticsearchClass(h.Client()))).
 		WithOptions(k8scontroller.Options{
 			RateLimiter: controller.DefaultControllerRateLimiter[reconcile.Request](),
 		})
@@ -229,6 +240,15 @@ func (h *ElasticsearchReconciler) Configure(ctx context.Context, req reconcile.R
 		o.Status.IsBootstrapping = ptr.To[bool](false)
 	}
 
+	// Merge the class settings before compute the expected resources
+	// Not needed on delete, it avoid to save the merged spec when remove finalizer
+	// The merged spec must never be saved, so the changes on cluster metadata need to patch a fresh read object, like with common.RemoveAnnotation
+	if o.DeletionTimestamp.IsZero() {
+		if err = common.ApplyElasticsearchClass(ctx, h.Client(), o); err != nil {
+			return res, err
+		}
+	}
+
 	// Get Elasticsearch health
 	// Not blocking way, cluster can be unreachable
 	esHandler, err := h.getElasticsearchHandler(ctx, o, logger)
@@ -322,18 +342,40 @@ loopStatefulset:
 	}
 
 	if isReady {
-		if !condition.IsStatusConditionPresentAndEqual(o.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue) {
+		if !o.IsBoostrapping() {
+			o.Status.IsBootstrapping = ptr.To[bool](true)
+		}
+
+		// The cluster is ready only when the snapshot is restored
+		isRestored := true
+		if o.IsRestoreFromPending() {
+			if isRestored, err = h.checkRestoreFrom(ctx, o); err != nil {
+				return res, err
+			}
+		}
+
+		if isRestored {
+			if !condition.IsStatusConditionPresentAndEqual(o.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue) {
+				condition.SetStatusCondition(&o.Status.Conditions, metav1.Condition{
+					Type:   controller.ReadyCondition.String(),
+					Status: metav1.ConditionTrue,
+					Reason: "Ready",
+				})
+			}
+
+			o.Status.PhaseName = controller.RunningPhase
+		} else {
 			condition.SetStatusCondition(&o.Status.Conditions, metav1.Condition{
-				Type:   controller.ReadyCondition.String(),
-				Status: metav1.ConditionTrue,
-				Reason: "Ready",
+				Type:    controller.ReadyCondition.String(),
+				Status:  metav1.ConditionFalse,
+				Reason:  "Restoring",
+				Message: "Wait the snapshot restore completion",
 			})
-		}
 
-		o.Status.PhaseName = controller.RunningPhase
+			o.Status.PhaseName = controller.StartingPhase
 
-		if !o.IsBoostrapping() {
-			o.Status.IsBootstrapping = ptr.To[bool](true)
+			// Requeued to check if the restore is completed
+			res.RequeueAfter = time.Second * 10
 		}
 
 	} else {
@@ -365,6 +407,31 @@ loopStatefulset:
 	return res, nil
 }
 
+// checkRestoreFrom permit to check if the snapshot restore is completed when bootstrap cluster
+// It record the restored snapshot on status when it's completed, and return an error when it failed
+func (h *ElasticsearchReconciler) checkRestoreFrom(ctx context.Context, es *elasticsearchcrd.Elasticsearch) (isRestored bool, err error) {
+	restore := &elasticsearchapicrd.Restore{}
+	if err = h.Client().Get(ctx, types.NamespacedName{Namespace: es.Namespace, Name: GetRestoreFromName(es)}, restore); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "Error when read restore")
+	}
+
+	switch restore.Status.State {
+	case "SUCCESS":
+	case "FAILED":
+		return false, errors.Errorf("Restore of snapshot %s from repository %s failed, see the events of restore %s", restore.GetSnapshotName(), restore.Spec.Repository, restore.Name)
+	default:
+		return false, nil
+	}
+
+	es.Status.RestoredSnapshot = restore.GetSnapshotName()
+	h.Recorder().Eventf(es, corev1.EventTypeNormal, "SnapshotRestored", "Snapshot %s from repository %s successfully restored", es.Status.RestoredSnapshot, restore.Spec.Repository)
+
+	return true, nil
+}
+
 // computeElasticsearchUrl permit to get the public Elasticsearch url to put it on status
 func (h *ElasticsearchReconciler) computeElasticsearchUrl(ctx context.Context, es *elasticsearchcrd.Elasticsearch) (target string, err error) {
 	var (
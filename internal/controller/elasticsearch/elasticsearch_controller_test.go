@@ -1549,3 +1549,196 @@ func doDeleteElasticsearchStep() test.TestStep[*elasticsearchcrd.Elasticsearch]
 		},
 	}
 }
+
+func (t *ElasticsearchControllerTestSuite) TestElasticsearchControllerWithClass() {
+	key := types.NamespacedName{
+		Name:      "t-es-class-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase[*elasticsearchcrd.Elasticsearch](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchcrd.Elasticsearch]{
+		doCreateElasticsearchWithClassStep(),
+		doUpdateElasticsearchClassStep(),
+		doRenewCertificatesElasticsearchWithClassStep(),
+		doDeleteElasticsearchStep(),
+	}
+
+	testCase.Run()
+}
+
+func doCreateElasticsearchWithClassStep() test.TestStep[*elasticsearchcrd.Elasticsearch] {
+	return test.TestStep[*elasticsearchcrd.Elasticsearch]{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchcrd.Elasticsearch, data map[string]any) (err error) {
+			logrus.Infof("=== Add new Elasticsearch cluster with class %s/%s ===\n\n", key.Namespace, key.Name)
+
+			class := &elasticsearchcrd.ElasticsearchClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: key.Name,
+				},
+				Spec: elasticsearchcrd.ElasticsearchClassSpec{
+					ImageSpec: shared.ImageSpec{
+						Image: "registry.acme.com/elasticsearch",
+					},
+					PluginsList: []string{"repository-s3"},
+				},
+			}
+			if err = c.Create(context.Background(), class); err != nil {
+				return err
+			}
+
+			es := &elasticsearchcrd.Elasticsearch{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchcrd.ElasticsearchSpec{
+					Version: "8.7.0",
+					ClassRef: &elasticsearchcrd.ElasticsearchClassRef{
+						Name: key.Name,
+					},
+					NodeGroups: []elasticsearchcrd.ElasticsearchNodeGroupSpec{
+						{
+							Name: "all",
+							Roles: []string{
+								"master",
+								"data",
+								"ingest",
+							},
+							Deployment: shared.Deployment{
+								Replicas: 1,
+							},
+						},
+					},
+				},
+			}
+			if err = c.Create(context.Background(), es); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchcrd.Elasticsearch, data map[string]any) (err error) {
+			es := &elasticsearchcrd.Elasticsearch{}
+			sts := &appv1.StatefulSet{}
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, es); err != nil {
+					t.Fatal("Elasticsearch not found")
+				}
+				if es.GetStatus().GetObservedGeneration() == 0 {
+					return errors.New("Not yet created")
+				}
+				if err = c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: GetNodeGroupName(es, "all")}, sts); err != nil {
+					return err
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("All Elasticsearch step provisionning not finished: %s", err.Error())
+			}
+
+			// The class is used to build the children
+			assert.Equal(t, "registry.acme.com/elasticsearch:8.7.0", sts.Spec.Template.Spec.Containers[0].Image)
+
+			// The class is never merged on stored cluster
+			assert.Empty(t, es.Spec.Image)
+			assert.Empty(t, es.Spec.PluginsList)
+
+			return nil
+		},
+	}
+}
+
+func doUpdateElasticsearchClassStep() test.TestStep[*elasticsearchcrd.Elasticsearch] {
+	return test.TestStep[*elasticsearchcrd.Elasticsearch]{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchcrd.Elasticsearch, data map[string]any) (err error) {
+			logrus.Infof("=== Update class of Elasticsearch cluster %s/%s ===\n\n", key.Namespace, key.Name)
+
+			class := &elasticsearchcrd.ElasticsearchClass{}
+			if err = c.Get(context.Background(), types.NamespacedName{Name: key.Name}, class); err != nil {
+				return err
+			}
+			class.Spec.Image = "registry2.acme.com/elasticsearch"
+			if err = c.Update(context.Background(), class); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchcrd.Elasticsearch, data map[string]any) (err error) {
+			es := &elasticsearchcrd.Elasticsearch{}
+			sts := &appv1.StatefulSet{}
+
+			// The class change reconcile the cluster, without change on it
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, es); err != nil {
+					t.Fatal("Elasticsearch not found")
+				}
+				if err = c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: GetNodeGroupName(es, "all")}, sts); err != nil {
+					t.Fatal(err)
+				}
+				if sts.Spec.Template.Spec.Containers[0].Image != "registry2.acme.com/elasticsearch:8.7.0" {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Class change not applied: %s", err.Error())
+			}
+
+			assert.Empty(t, es.Spec.Image)
+			assert.Empty(t, es.Spec.PluginsList)
+
+			return nil
+		},
+	}
+}
+
+func doRenewCertificatesElasticsearchWithClassStep() test.TestStep[*elasticsearchcrd.Elasticsearch] {
+	return test.TestStep[*elasticsearchcrd.Elasticsearch]{
+		Name: "renew",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchcrd.Elasticsearch, data map[string]any) (err error) {
+			logrus.Infof("=== Renew certificates of Elasticsearch cluster %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Elasticsearch is null")
+			}
+
+			if o.Annotations == nil {
+				o.Annotations = map[string]string{}
+			}
+			o.Annotations[fmt.Sprintf("%s/renew-certificates", elasticsearchcrd.ElasticsearchAnnotationKey)] = "true"
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchcrd.Elasticsearch, data map[string]any) (err error) {
+			es := &elasticsearchcrd.Elasticsearch{}
+
+			// The operator remove the annotation without save the class settings
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, es); err != nil {
+					t.Fatal("Elasticsearch not found")
+				}
+				if _, ok := es.Annotations[fmt.Sprintf("%s/renew-certificates", elasticsearchcrd.ElasticsearchAnnotationKey)]; ok {
+					return errors.New("Not yet renewed")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Certificates not renewed: %s", err.Error())
+			}
+
+			assert.Empty(t, es.Spec.Image)
+			assert.Empty(t, es.Spec.PluginsList)
+
+			return nil
+		},
+	}
+}
@@ -116,6 +116,17 @@ func watchSecret(c client.Client) handler.MapFunc {
 			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: e.Name, Namespace: e.Namespace}})
 		}
 
+		// Realm secrets
+		listElasticsearch = &elasticsearchcrd.ElasticsearchList{}
+		fs = fields.ParseSelectorOrDie(fmt.Sprintf("spec.security.realms.secretRef.name=%s", a.GetName()))
+		// Get all elasticsearch linked with secret
+		if err := c.List(context.Background(), listElasticsearch, &client.ListOptions{Namespace: a.GetNamespace(), FieldSelector: fs}); err != nil {
+			panic(err)
+		}
+		for _, e := range listElasticsearch.Items {
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: e.Name, Namespace: e.Namespace}})
+		}
+
 		// cacerts secret
 		listElasticsearch = &elasticsearchcrd.ElasticsearchList{}
 		fs = fields.ParseSelectorOrDie(fmt.Sprintf("spec.globalNodeGroup.cacertsSecretRef.name=%s", a.GetName()))
@@ -209,3 +220,26 @@ func watchHost(c client.Client) handler.MapFunc {
 		return reconcileRequests
 	}
 }
+
+// watchElasticsearchClass permit to update Elasticsearch when the class change
+func watchElasticsearchClass(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		var (
+			listElasticsearch *elasticsearchcrd.ElasticsearchList
+			fs                fields.Selector
+		)
+
+		reconcileRequests := make([]reconcile.Request, 0)
+
+		listElasticsearch = &elasticsearchcrd.ElasticsearchList{}
+		fs = fields.ParseSelectorOrDie(fmt.Sprintf("spec.classRef.name=%s", a.GetName()))
+		if err := c.List(context.Background(), listElasticsearch, &client.ListOptions{FieldSelector: fs}); err != nil {
+			panic(err)
+		}
+		for _, k := range listElasticsearch.Items {
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: k.Name, Namespace: k.Namespace}})
+		}
+
+		return reconcileRequests
+	}
+}
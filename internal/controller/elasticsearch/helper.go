@@ -3,17 +3,32 @@ package elasticsearch
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/thoas/go-funk"
 	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const (
 	defaultImage         = "docker.elastic.co/elasticsearch/elasticsearch"
 	defaultExporterImage = "quay.io/prometheuscommunity/elasticsearch-exporter"
+	realmsPath           = "/usr/share/elasticsearch/config/realms"
 )
 
+// reloadableSecureSettings is the keystore settings that Elasticsearch reload with the API _nodes/reload_secure_settings
+var reloadableSecureSettings = []*regexp.Regexp{
+	regexp.MustCompile(`^s3\.client\.[^.]+\.`),
+	regexp.MustCompile(`^gcs\.client\.[^.]+\.`),
+	regexp.MustCompile(`^azure\.client\.[^.]+\.`),
+	regexp.MustCompile(`^discovery\.ec2\.`),
+	regexp.MustCompile(`^xpack\.notification\.`),
+	regexp.MustCompile(`^xpack\.monitoring\.exporters\.[^.]+\.auth\.secure_password$`),
+	regexp.MustCompile(`^cluster\.remote\.[^.]+\.credentials$`),
+}
+
 // GetNodeGroupName permit to get the node group name
 func GetNodeGroupName(elasticsearch *elasticsearchcrd.Elasticsearch, nodeGroupName string) (name string) {
 	return fmt.Sprintf("%s-%s-es", elasticsearch.Name, nodeGroupName)
@@ -80,6 +95,16 @@ func GetSecretNameForPkiTransport(elasticsearch *elasticsearchcrd.Elasticsearch)
 	return fmt.Sprintf("%s-pki-transport-es", elasticsearch.Name)
 }
 
+// RemoteClusterServerKeySuffix is the suffix of the keys set on the remote cluster secret by the RemoteCluster with API key security
+// The remote cluster server is enabled when the secret has one of them
+const RemoteClusterServerKeySuffix = ".server"
+
+// GetSecretNameForRemoteCluster permit to get the secret name that store the transport CA of the remote clusters and the cross-cluster API keys
+// It's managed by the RemoteCluster controller
+func GetSecretNameForRemoteCluster(elasticsearch *elasticsearchcrd.Elasticsearch) (secretName string) {
+	return fmt.Sprintf("%s-remote-cluster-es", elasticsearch.Name)
+}
+
 // GetSecretNameForTlsApi permit to get the secret name that store all certificates for Api layout (Http endpoint)
 // It return the secret name as string
 func GetSecretNameForTlsApi(elasticsearch *elasticsearchcrd.Elasticsearch) (secretName string) {
@@ -112,6 +137,204 @@ func GetSecretNameForKeystore(elasticsearch *elasticsearchcrd.Elasticsearch) (se
 	return ""
 }
 
+// getRealmSettingPrefix permit to get the setting prefix of realm
+func getRealmSettingPrefix(realmType string, realmName string) string {
+	return fmt.Sprintf("xpack.security.authc.realms.%s.%s", realmType, realmName)
+}
+
+// getRealmIdpMetadataPath permit to get the path of SAML IdP metadata file relative to realms directory
+func getRealmIdpMetadataPath(realmName string) string {
+	return fmt.Sprintf("saml/%s/idp-metadata.xml", realmName)
+}
+
+// getRealmKeystoreSecretRefs permit to get the realm secrets to inject on keystore
+// The key is the keystore setting name
+func getRealmKeystoreSecretRefs(elasticsearch *elasticsearchcrd.Elasticsearch) (secretRefs map[string]corev1.SecretKeySelector) {
+	secretRefs = map[string]corev1.SecretKeySelector{}
+	if elasticsearch.Spec.Security == nil || elasticsearch.Spec.Security.Realms == nil {
+		return secretRefs
+	}
+
+	for _, realm := range elasticsearch.Spec.Security.Realms.Ldap {
+		if realm.BindPasswordSecretRef != nil {
+			secretRefs[fmt.Sprintf("%s.secure_bind_password", getRealmSettingPrefix(elasticsearchcrd.RealmTypeLdap, realm.Name))] = *realm.BindPasswordSecretRef
+		}
+	}
+	for _, realm := range elasticsearch.Spec.Security.Realms.Oidc {
+		if realm.RpClientSecretRef != nil {
+			secretRefs[fmt.Sprintf("%s.rp.client_secret", getRealmSettingPrefix(elasticsearchcrd.RealmTypeOidc, realm.Name))] = *realm.RpClientSecretRef
+		}
+	}
+	for _, realm := range elasticsearch.Spec.Security.Realms.Jwt {
+		if realm.HmacKeySecretRef != nil {
+			secretRefs[fmt.Sprintf("%s.hmac_key", getRealmSettingPrefix(elasticsearchcrd.RealmTypeJwt, realm.Name))] = *realm.HmacKeySecretRef
+		}
+		if realm.ClientAuthenticationSharedSecretRef != nil {
+			secretRefs[fmt.Sprintf("%s.client_authentication.shared_secret", getRealmSettingPrefix(elasticsearchcrd.RealmTypeJwt, realm.Name))] = *realm.ClientAuthenticationSharedSecretRef
+		}
+	}
+
+	return secretRefs
+}
+
+// getRealmFileSecretRefs permit to get the realm secrets to mount as file on realms directory
+// The key is the file path relative to realms directory
+func getRealmFileSecretRefs(elasticsearch *elasticsearchcrd.Elasticsearch) (secretRefs map[string]corev1.SecretKeySelector) {
+	secretRefs = map[string]corev1.SecretKeySelector{}
+	if elasticsearch.Spec.Security == nil || elasticsearch.Spec.Security.Realms == nil {
+		return secretRefs
+	}
+
+	for _, realm := range elasticsearch.Spec.Security.Realms.Saml {
+		if realm.IdpMetadataSecretRef != nil {
+			secretRefs[getRealmIdpMetadataPath(realm.Name)] = *realm.IdpMetadataSecretRef
+		}
+	}
+
+	return secretRefs
+}
+
+// GetSecretNameForSnapshotRepository permit to get the secret name that store the client credentials of snapshot repositories
+// It's managed by the SnapshotRepository controller
+func GetSecretNameForSnapshotRepository(elasticsearch *elasticsearchcrd.Elasticsearch) (secretName string) {
+	return fmt.Sprintf("%s-snapshot-repository-es", elasticsearch.Name)
+}
+
+// getSnapshotRepositorySecret permit to get the snapshot repository secret from the secrets read by the statefulset reconciler
+// It return nil if the secret not exist
+func getSnapshotRepositorySecret(elasticsearch *elasticsearchcrd.Elasticsearch, secrets []*corev1.Secret) *corev1.Secret {
+	for _, s := range secrets {
+		if s.Name == GetSecretNameForSnapshotRepository(elasticsearch) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// isReloadableSecureSetting return true if Elasticsearch can reload the keystore setting without restart
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/secure-settings.html#reloadable-secure-settings
+func isReloadableSecureSetting(key string) bool {
+	for _, r := range reloadableSecureSettings {
+		if r.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getNotReloadableSecureSettings permit to get the keystore settings that need a restart to be loaded
+func getNotReloadableSecureSettings(data map[string][]byte) (notReloadableData map[string][]byte) {
+	notReloadableData = map[string][]byte{}
+	for key, value := range data {
+		if !isReloadableSecureSetting(key) {
+			notReloadableData[key] = value
+		}
+	}
+
+	return notReloadableData
+}
+
+// getRemoteClusterSecret permit to get the remote cluster secret from the secrets read by the statefulset reconciler
+// It return nil if the secret not exist
+func getRemoteClusterSecret(elasticsearch *elasticsearchcrd.Elasticsearch, secrets []*corev1.Secret) *corev1.Secret {
+	for _, s := range secrets {
+		if s.Name == GetSecretNameForRemoteCluster(elasticsearch) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// getRemoteClusterKeystoreSecretRefs permit to get the cross-cluster API keys to inject on keystore
+// The key is the keystore setting name
+func getRemoteClusterKeystoreSecretRefs(secret *corev1.Secret) (secretRefs map[string]corev1.SecretKeySelector) {
+	secretRefs = map[string]corev1.SecretKeySelector{}
+	if secret == nil {
+		return secretRefs
+	}
+
+	for key := range secret.Data {
+		if strings.HasPrefix(key, "cluster.remote.") {
+			secretRefs[key] = corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secret.Name,
+				},
+				Key: key,
+			}
+		}
+	}
+
+	return secretRefs
+}
+
+// isRemoteClusterServerEnabled return true when a RemoteCluster with API key security use the cluster as remote
+func isRemoteClusterServerEnabled(secret *corev1.Secret) bool {
+	if secret == nil {
+		return false
+	}
+
+	for key := range secret.Data {
+		if strings.HasSuffix(key, RemoteClusterServerKeySuffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRemoteClusterClientEnabled return true when the cluster connect on a managed remote cluster with API key security
+// The transport CA of the remote cluster is needed to trust its remote cluster server
+func isRemoteClusterClientEnabled(secret *corev1.Secret) bool {
+	return len(getRemoteClusterKeystoreSecretRefs(secret)) > 0 && len(getRemoteClusterCaKeys(secret)) > 0
+}
+
+// getRemoteClusterCaKeys permit to get the keys of the remote cluster secret that store the transport CA of remote clusters
+func getRemoteClusterCaKeys(secret *corev1.Secret) (keys []string) {
+	keys = make([]string, 0)
+	if secret == nil {
+		return keys
+	}
+
+	for key := range secret.Data {
+		if strings.HasSuffix(key, ".crt") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// secretKeySelectorsToProjections permit to convert secret key selectors on projected volume sources
+// The key is the file path on volume
+func secretKeySelectorsToProjections(secretRefs map[string]corev1.SecretKeySelector) (projections []corev1.VolumeProjection) {
+	paths := make([]string, 0, len(secretRefs))
+	for path := range secretRefs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	projections = make([]corev1.VolumeProjection, 0, len(paths))
+	for _, path := range paths {
+		projections = append(projections, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: secretRefs[path].LocalObjectReference,
+				Items: []corev1.KeyToPath{
+					{
+						Key:  secretRefs[path].Key,
+						Path: path,
+					},
+				},
+			},
+		})
+	}
+
+	return projections
+}
+
 // GetSecretNameForCacerts permit to get the secret name that store the custom ca to ibject on Java cacerts
 // It will inject each certificate file on cacerts
 // It return empty string if not secret provided
@@ -190,6 +413,35 @@ func GetLicenseName(es *elasticsearchcrd.Elasticsearch) string {
 	return fmt.Sprintf("%s-es", es.Name)
 }
 
+// GetRestoreFromName return the name for the snapshot repository and the restore used to restore snapshot when bootstrap cluster
+func GetRestoreFromName(es *elasticsearchcrd.Elasticsearch) string {
+	return fmt.Sprintf("%s-restore-from-es", es.Name)
+}
+
+// GetUpgradeSnapshotName return the name for the snapshot taken before upgrade the cluster version
+// The name is unique per upgrade attempt with the generation, it's recorded on status when the upgrade start
+func GetUpgradeSnapshotName(es *elasticsearchcrd.Elasticsearch) string {
+	if es.Status.UpgradeSnapshot != nil && es.Status.UpgradeSnapshot.Version == es.Spec.Version && es.Status.UpgradeSnapshot.Name != "" {
+		return es.Status.UpgradeSnapshot.Name
+	}
+
+	return fmt.Sprintf("%s-upgrade-%s-%d", es.Name, es.Spec.Version, es.Generation)
+}
+
+// isVersionUpgradePending return true if some statefulsets not yet run the expected Elasticsearch image
+func isVersionUpgradePending(es *elasticsearchcrd.Elasticsearch, statefulsets []*appv1.StatefulSet) bool {
+	expectedImage := GetContainerImage(es)
+	for _, sts := range statefulsets {
+		for _, container := range sts.Spec.Template.Spec.Containers {
+			if container.Name == "elasticsearch" && container.Image != expectedImage {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 // GetElasticsearchNameFromSecretApiTlsName return the Elasticsearch name from secret name that store TLS API
 func GetElasticsearchNameFromSecretApiTlsName(secretApiTlsName string) (elasticsearchName string) {
 	r := regexp.MustCompile(`^(.+)-tls-api-es`)
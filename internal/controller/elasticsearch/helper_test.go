@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	appv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -539,6 +540,94 @@ func TestGetLicenseName(t *testing.T) {
 	assert.Equal(t, "test-es", GetLicenseName(o))
 }
 
+func TestGetRestoreFromName(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{},
+	}
+
+	assert.Equal(t, "test-restore-from-es", GetRestoreFromName(o))
+}
+
+func TestGetSecretNameForSnapshotRepository(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{},
+	}
+
+	assert.Equal(t, "test-snapshot-repository-es", GetSecretNameForSnapshotRepository(o))
+}
+
+func TestGetUpgradeSnapshotName(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			Version: "8.15.0",
+		},
+	}
+
+	// When the upgrade is not yet started
+	o.Generation = 2
+	assert.Equal(t, "test-upgrade-8.15.0-2", GetUpgradeSnapshotName(o))
+
+	// When the upgrade is started, the name not change with the generation
+	o.Status.UpgradeSnapshot = &elasticsearchcrd.ElasticsearchUpgradeSnapshotStatus{
+		Name:    "test-upgrade-8.15.0-2",
+		Version: "8.15.0",
+	}
+	o.Generation = 3
+	assert.Equal(t, "test-upgrade-8.15.0-2", GetUpgradeSnapshotName(o))
+
+	// When upgrade again to other version
+	o.Spec.Version = "8.16.0"
+	assert.Equal(t, "test-upgrade-8.16.0-3", GetUpgradeSnapshotName(o))
+}
+
+func TestIsVersionUpgradePending(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			Version: "8.15.0",
+		},
+	}
+	sts := &appv1.StatefulSet{
+		Spec: appv1.StatefulSetSpec{
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  "elasticsearch",
+							Image: "docker.elastic.co/elasticsearch/elasticsearch:8.15.0",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// When no statefulset
+	assert.False(t, isVersionUpgradePending(o, nil))
+
+	// When statefulset run the expected version
+	assert.False(t, isVersionUpgradePending(o, []*appv1.StatefulSet{sts}))
+
+	// When version change
+	o.Spec.Version = "8.16.0"
+	assert.True(t, isVersionUpgradePending(o, []*appv1.StatefulSet{sts}))
+}
+
 func TestGetElasticsearchNameFromSecretApiTlsName(t *testing.T) {
 	assert.Equal(t, "test", GetElasticsearchNameFromSecretApiTlsName("test-tls-api-es"))
 }
@@ -793,3 +882,118 @@ func TestGetServiceAccountName(t *testing.T) {
 
 	assert.Equal(t, "test-es", GetServiceAccountName(o))
 }
+
+func TestGetRealmSecretRefs(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{},
+	}
+
+	// Without realms
+	assert.Empty(t, getRealmKeystoreSecretRefs(o))
+	assert.Empty(t, getRealmFileSecretRefs(o))
+
+	// With realms
+	o.Spec.Security = &elasticsearchcrd.ElasticsearchSecuritySpec{
+		Realms: &elasticsearchcrd.ElasticsearchRealmsSpec{
+			Ldap: []elasticsearchcrd.ElasticsearchLdapRealmSpec{
+				{
+					ElasticsearchRealmSpec: elasticsearchcrd.ElasticsearchRealmSpec{
+						Name: "ldap1",
+					},
+					BindPasswordSecretRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "realms",
+						},
+						Key: "ldap",
+					},
+				},
+			},
+			Saml: []elasticsearchcrd.ElasticsearchSamlRealmSpec{
+				{
+					ElasticsearchRealmSpec: elasticsearchcrd.ElasticsearchRealmSpec{
+						Name: "saml1",
+					},
+					IdpMetadataSecretRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "realms",
+						},
+						Key: "saml",
+					},
+				},
+			},
+			Oidc: []elasticsearchcrd.ElasticsearchOidcRealmSpec{
+				{
+					ElasticsearchRealmSpec: elasticsearchcrd.ElasticsearchRealmSpec{
+						Name: "oidc1",
+					},
+					RpClientSecretRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: "realms",
+						},
+						Key: "oidc",
+					},
+				},
+			},
+		},
+	}
+
+	keystoreSecretRefs := getRealmKeystoreSecretRefs(o)
+	assert.Equal(t, map[string]v1.SecretKeySelector{
+		"xpack.security.authc.realms.ldap.ldap1.secure_bind_password": *o.Spec.Security.Realms.Ldap[0].BindPasswordSecretRef,
+		"xpack.security.authc.realms.oidc.oidc1.rp.client_secret":     *o.Spec.Security.Realms.Oidc[0].RpClientSecretRef,
+	}, keystoreSecretRefs)
+	assert.Equal(t, map[string]v1.SecretKeySelector{
+		"saml/saml1/idp-metadata.xml": *o.Spec.Security.Realms.Saml[0].IdpMetadataSecretRef,
+	}, getRealmFileSecretRefs(o))
+
+	// Projections are sorted by path
+	assert.Equal(t, []v1.VolumeProjection{
+		{
+			Secret: &v1.SecretProjection{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "realms",
+				},
+				Items: []v1.KeyToPath{
+					{
+						Key:  "ldap",
+						Path: "xpack.security.authc.realms.ldap.ldap1.secure_bind_password",
+					},
+				},
+			},
+		},
+		{
+			Secret: &v1.SecretProjection{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "realms",
+				},
+				Items: []v1.KeyToPath{
+					{
+						Key:  "oidc",
+						Path: "xpack.security.authc.realms.oidc.oidc1.rp.client_secret",
+					},
+				},
+			},
+		},
+	}, secretKeySelectorsToProjections(keystoreSecretRefs))
+}
+
+func TestIsReloadableSecureSetting(t *testing.T) {
+	assert.True(t, isReloadableSecureSetting("s3.client.default.access_key"))
+	assert.True(t, isReloadableSecureSetting("gcs.client.default.credentials_file"))
+	assert.True(t, isReloadableSecureSetting("azure.client.default.key"))
+	assert.True(t, isReloadableSecureSetting("xpack.notification.email.account.gmail.smtp.secure_password"))
+	assert.True(t, isReloadableSecureSetting("cluster.remote.my-remote.credentials"))
+	assert.False(t, isReloadableSecureSetting("xpack.security.authc.realms.oidc.oidc1.rp.client_secret"))
+	assert.False(t, isReloadableSecureSetting("bootstrap.password"))
+
+	assert.Equal(t, map[string][]byte{
+		"xpack.security.authc.realms.oidc.oidc1.rp.client_secret": []byte("secret"),
+	}, getNotReloadableSecureSettings(map[string][]byte{
+		"s3.client.default.access_key":                            []byte("access"),
+		"xpack.security.authc.realms.oidc.oidc1.rp.client_secret": []byte("secret"),
+	}))
+}
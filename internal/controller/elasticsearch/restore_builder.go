@@ -0,0 +1,49 @@
+package elasticsearch
+
+import (
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// buildRestores permit to generate the restore of snapshot when bootstrap cluster
+// The restore is only expected until it's completed
+func buildRestores(es *elasticsearchcrd.Elasticsearch) (restores []*elasticsearchapicrd.Restore, err error) {
+	if !es.IsRestoreFromPending() || !es.IsBoostrapping() {
+		return nil, nil
+	}
+
+	snapshot := es.Spec.RestoreFrom.Snapshot
+	if snapshot == "" {
+		snapshot = elasticsearchapicrd.RestoreLatestSnapshot
+	}
+
+	restores = []*elasticsearchapicrd.Restore{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   es.Namespace,
+				Name:        GetRestoreFromName(es),
+				Labels:      getLabels(es),
+				Annotations: getAnnotations(es),
+			},
+			Spec: elasticsearchapicrd.RestoreSpec{
+				ElasticsearchRef: shared.ElasticsearchRef{
+					ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+						Name: es.Name,
+					},
+				},
+				Repository:         es.Spec.RestoreFrom.Repository.Name,
+				Snapshot:           snapshot,
+				Indices:            es.Spec.RestoreFrom.Indices,
+				IgnoreUnavailable:  es.Spec.RestoreFrom.IgnoreUnavailable,
+				IncludeGlobalState: es.Spec.RestoreFrom.IncludeGlobalState,
+				FeatureStates:      es.Spec.RestoreFrom.FeatureStates,
+				IncludeAliases:     es.Spec.RestoreFrom.IncludeAliases,
+				Partial:            es.Spec.RestoreFrom.Partial,
+			},
+		},
+	}
+
+	return restores, nil
+}
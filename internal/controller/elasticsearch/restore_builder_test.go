@@ -0,0 +1,75 @@
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/stretchr/testify/assert"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildRestore(t *testing.T) {
+	var (
+		o        *elasticsearchcrd.Elasticsearch
+		restores []*elasticsearchapicrd.Restore
+	)
+	sch := scheme.Scheme
+	if err := elasticsearchapicrd.AddToScheme(sch); err != nil {
+		panic(err)
+	}
+
+	// When no restore is expected
+	o = &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{},
+		Status: elasticsearchcrd.ElasticsearchStatus{
+			IsBootstrapping: ptr.To[bool](true),
+		},
+	}
+
+	restores, err := buildRestores(o)
+	assert.NoError(t, err)
+	assert.Empty(t, restores)
+
+	// When cluster is not yet bootstrapped
+	o = &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			RestoreFrom: &elasticsearchcrd.ElasticsearchRestoreFromSpec{
+				Repository: elasticsearchcrd.ElasticsearchRestoreFromRepositorySpec{
+					Name: "backup",
+					Type: "s3",
+				},
+				Indices:            []string{"logs-*"},
+				IncludeGlobalState: true,
+				FeatureStates:      []string{"security"},
+			},
+		},
+	}
+
+	restores, err = buildRestores(o)
+	assert.NoError(t, err)
+	assert.Empty(t, restores)
+
+	// When cluster is bootstrapped
+	o.Status.IsBootstrapping = ptr.To[bool](true)
+	restores, err = buildRestores(o)
+	assert.NoError(t, err)
+	test.EqualFromYamlFile[*elasticsearchapicrd.Restore](t, "testdata/restore.yml", restores[0], sch)
+
+	// When restore is already completed
+	o.Status.RestoredSnapshot = "snapshot"
+	restores, err = buildRestores(o)
+	assert.NoError(t, err)
+	assert.Empty(t, restores)
+}
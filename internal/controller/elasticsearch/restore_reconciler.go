@@ -0,0 +1,63 @@
+package elasticsearch
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/shared"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/multiphase"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	RestoreCondition shared.ConditionName = "RestoreReady"
+	RestorePhase     shared.PhaseName     = "Restore"
+)
+
+type restoreReconciler struct {
+	multiphase.MultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.Restore]
+}
+
+func newRestoreReconciler(client client.Client, recorder record.EventRecorder) (multiPhaseStepReconcilerAction multiphase.MultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.Restore]) {
+	return &restoreReconciler{
+		MultiPhaseStepReconcilerAction: multiphase.NewMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.Restore](
+			client,
+			RestorePhase,
+			RestoreCondition,
+			recorder,
+		),
+	}
+}
+
+// Read existing restore
+func (r *restoreReconciler) Read(ctx context.Context, o *elasticsearchcrd.Elasticsearch, data map[string]any, logger *logrus.Entry) (read multiphase.MultiPhaseRead[*elasticsearchapicrd.Restore], res reconcile.Result, err error) {
+	restore := &elasticsearchapicrd.Restore{}
+	read = multiphase.NewMultiPhaseRead[*elasticsearchapicrd.Restore]()
+
+	// Read current restore
+	if err = r.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: GetRestoreFromName(o)}, restore); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return read, res, errors.Wrapf(err, "Error when read restore")
+		}
+		restore = nil
+	}
+	if restore != nil {
+		read.AddCurrentObject(restore)
+	}
+
+	// Generate expected restore
+	expectedRestores, err := buildRestores(o)
+	if err != nil {
+		return read, res, errors.Wrap(err, "Error when generate restore")
+	}
+	read.SetExpectedObjects(expectedRestores)
+
+	return read, res, nil
+}
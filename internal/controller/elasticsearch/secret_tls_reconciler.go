@@ -17,6 +17,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/thoas/go-funk"
 	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 	localhelper "github.com/webcenter-fr/elasticsearch-operator/pkg/helper"
 	"github.com/webcenter-fr/elasticsearch-operator/pkg/pki"
 	appv1 "k8s.io/api/apps/v1"
@@ -704,8 +705,8 @@ func (r *tlsReconciler) OnSuccess(ctx context.Context, o *elasticsearchcrd.Elast
 	case TlsPhaseUpdatePki:
 		// Remove force renew certificate
 		if o.Annotations[fmt.Sprintf("%s/renew-certificates", elasticsearchcrd.ElasticsearchAnnotationKey)] == "true" {
-			delete(o.Annotations, fmt.Sprintf("%s/renew-certificates", elasticsearchcrd.ElasticsearchAnnotationKey))
-			if err = r.Client().Update(ctx, o); err != nil {
+			// The spec is merged with the class, so we not update the whole object
+			if err = common.RemoveAnnotation(ctx, r.Client(), o, fmt.Sprintf("%s/renew-certificates", elasticsearchcrd.ElasticsearchAnnotationKey)); err != nil {
 				return res, err
 			}
 		}
@@ -0,0 +1,52 @@
+package elasticsearch
+
+import (
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// buildSnapshotRepositories permit to generate the snapshot repository used to restore snapshot when bootstrap cluster
+// The repository is registered as readonly if not specified, to not corrupt it when it's used by another cluster
+func buildSnapshotRepositories(es *elasticsearchcrd.Elasticsearch) (snapshotRepositories []*elasticsearchapicrd.SnapshotRepository, err error) {
+	if es.Spec.RestoreFrom == nil || !es.IsBoostrapping() {
+		return nil, nil
+	}
+
+	settings := map[string]any{}
+	if es.Spec.RestoreFrom.Repository.Settings != nil {
+		for key, value := range es.Spec.RestoreFrom.Repository.Settings.Data {
+			settings[key] = value
+		}
+	}
+	if _, ok := settings["readonly"]; !ok {
+		settings["readonly"] = true
+	}
+
+	snapshotRepositories = []*elasticsearchapicrd.SnapshotRepository{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   es.Namespace,
+				Name:        GetRestoreFromName(es),
+				Labels:      getLabels(es),
+				Annotations: getAnnotations(es),
+			},
+			Spec: elasticsearchapicrd.SnapshotRepositorySpec{
+				ElasticsearchRef: shared.ElasticsearchRef{
+					ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+						Name: es.Name,
+					},
+				},
+				Name: es.Spec.RestoreFrom.Repository.Name,
+				Type: es.Spec.RestoreFrom.Repository.Type,
+				Settings: &apis.MapAny{
+					Data: settings,
+				},
+			},
+		},
+	}
+
+	return snapshotRepositories, nil
+}
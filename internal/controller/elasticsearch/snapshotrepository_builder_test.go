@@ -0,0 +1,78 @@
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/stretchr/testify/assert"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildSnapshotRepository(t *testing.T) {
+	var (
+		o                    *elasticsearchcrd.Elasticsearch
+		snapshotRepositories []*elasticsearchapicrd.SnapshotRepository
+	)
+	sch := scheme.Scheme
+	if err := elasticsearchapicrd.AddToScheme(sch); err != nil {
+		panic(err)
+	}
+
+	// When no restore is expected
+	o = &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{},
+		Status: elasticsearchcrd.ElasticsearchStatus{
+			IsBootstrapping: ptr.To[bool](true),
+		},
+	}
+
+	snapshotRepositories, err := buildSnapshotRepositories(o)
+	assert.NoError(t, err)
+	assert.Empty(t, snapshotRepositories)
+
+	// When cluster is not yet bootstrapped
+	o = &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			RestoreFrom: &elasticsearchcrd.ElasticsearchRestoreFromSpec{
+				Repository: elasticsearchcrd.ElasticsearchRestoreFromRepositorySpec{
+					Name: "backup",
+					Type: "s3",
+					Settings: &apis.MapAny{
+						Data: map[string]any{
+							"bucket": "backup",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	snapshotRepositories, err = buildSnapshotRepositories(o)
+	assert.NoError(t, err)
+	assert.Empty(t, snapshotRepositories)
+
+	// When cluster is bootstrapped
+	o.Status.IsBootstrapping = ptr.To[bool](true)
+	snapshotRepositories, err = buildSnapshotRepositories(o)
+	assert.NoError(t, err)
+	test.EqualFromYamlFile[*elasticsearchapicrd.SnapshotRepository](t, "testdata/snapshotrepository.yml", snapshotRepositories[0], sch)
+
+	// When readonly is explicitly set
+	o.Spec.RestoreFrom.Repository.Settings.Data["readonly"] = false
+	snapshotRepositories, err = buildSnapshotRepositories(o)
+	assert.NoError(t, err)
+	assert.Equal(t, false, snapshotRepositories[0].Spec.Settings.Data["readonly"])
+}
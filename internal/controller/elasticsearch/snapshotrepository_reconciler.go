@@ -0,0 +1,63 @@
+package elasticsearch
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/shared"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/multiphase"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	SnapshotRepositoryCondition shared.ConditionName = "SnapshotRepositoryReady"
+	SnapshotRepositoryPhase     shared.PhaseName     = "SnapshotRepository"
+)
+
+type snapshotRepositoryReconciler struct {
+	multiphase.MultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.SnapshotRepository]
+}
+
+func newSnapshotRepositoryReconciler(client client.Client, recorder record.EventRecorder) (multiPhaseStepReconcilerAction multiphase.MultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.SnapshotRepository]) {
+	return &snapshotRepositoryReconciler{
+		MultiPhaseStepReconcilerAction: multiphase.NewMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.SnapshotRepository](
+			client,
+			SnapshotRepositoryPhase,
+			SnapshotRepositoryCondition,
+			recorder,
+		),
+	}
+}
+
+// Read existing snapshot repository
+func (r *snapshotRepositoryReconciler) Read(ctx context.Context, o *elasticsearchcrd.Elasticsearch, data map[string]any, logger *logrus.Entry) (read multiphase.MultiPhaseRead[*elasticsearchapicrd.SnapshotRepository], res reconcile.Result, err error) {
+	snapshotRepository := &elasticsearchapicrd.SnapshotRepository{}
+	read = multiphase.NewMultiPhaseRead[*elasticsearchapicrd.SnapshotRepository]()
+
+	// Read current snapshot repository
+	if err = r.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: GetRestoreFromName(o)}, snapshotRepository); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return read, res, errors.Wrapf(err, "Error when read snapshot repository")
+		}
+		snapshotRepository = nil
+	}
+	if snapshotRepository != nil {
+		read.AddCurrentObject(snapshotRepository)
+	}
+
+	// Generate expected snapshot repository
+	expectedSnapshotRepositories, err := buildSnapshotRepositories(o)
+	if err != nil {
+		return read, res, errors.Wrap(err, "Error when generate snapshot repository")
+	}
+	read.SetExpectedObjects(expectedSnapshotRepositories)
+
+	return read, res, nil
+}
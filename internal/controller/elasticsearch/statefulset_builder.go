@@ -46,7 +46,12 @@ func buildStatefulsets(es *elasticsearchcrd.Elasticsearch, secretsChecksum []*co
 
 		sum := s.Annotations[fmt.Sprintf("%s/sequence", elasticsearchcrd.ElasticsearchAnnotationKey)]
 		if sum == "" {
-			j, err := json.Marshal(s.Data)
+			data := s.Data
+			// The keystore-reloader sidecar load the reloadable settings without restart
+			if s.Name == GetSecretNameForKeystore(es) || s.Name == GetSecretNameForSnapshotRepository(es) {
+				data = getNotReloadableSecureSettings(s.Data)
+			}
+			j, err := json.Marshal(data)
 			if err != nil {
 				return nil, errors.Wrapf(err, "Error when convert data of secret %s on json string", s.Name)
 			}
@@ -59,6 +64,22 @@ func buildStatefulsets(es *elasticsearchcrd.Elasticsearch, secretsChecksum []*co
 		checksumAnnotations[fmt.Sprintf("%s/secret-%s", elasticsearchcrd.ElasticsearchAnnotationKey, s.Name)] = sum
 	}
 
+	// Compute realm secrets to inject on keystore and on realms directory
+	keystoreSecretRefs := getRealmKeystoreSecretRefs(es)
+	realmFileSecretRefs := getRealmFileSecretRefs(es)
+
+	// Compute the transport CA and the cross-cluster API keys provided by RemoteCluster
+	remoteClusterSecret := getRemoteClusterSecret(es, secretsChecksum)
+	remoteClusterCaKeys := getRemoteClusterCaKeys(remoteClusterSecret)
+	for key, secretRef := range getRemoteClusterKeystoreSecretRefs(remoteClusterSecret) {
+		keystoreSecretRefs[key] = secretRef
+	}
+
+	// Compute the client credentials provided by SnapshotRepository
+	// The whole secret is injected on keystore, so adding credentials not change the pod template
+	snapshotRepositorySecret := getSnapshotRepositorySecret(es, secretsChecksum)
+	isKeystore := es.Spec.GlobalNodeGroup.KeystoreSecretRef != nil || len(keystoreSecretRefs) > 0 || snapshotRepositorySecret != nil
+
 	// Compute cluster name
 	clusterName := es.Name
 	if es.Spec.ClusterName != "" {
@@ -256,6 +277,15 @@ func buildStatefulsets(es *elasticsearchcrd.Elasticsearch, secretsChecksum []*co
 				Protocol:      corev1.ProtocolTCP,
 			},
 		}, k8sbuilder.Merge)
+		if isRemoteClusterServerEnabled(remoteClusterSecret) {
+			cb.WithPort([]corev1.ContainerPort{
+				{
+					Name:          "remote-cluster",
+					ContainerPort: 9443,
+					Protocol:      corev1.ProtocolTCP,
+				},
+			}, k8sbuilder.Merge)
+		}
 
 		// Compute resources
 		cb.WithResource(nodeGroup.Resources, k8sbuilder.Merge)
@@ -313,6 +343,16 @@ func buildStatefulsets(es *elasticsearchcrd.Elasticsearch, secretsChecksum []*co
 			}, k8sbuilder.Merge)
 		}
 
+		if len(realmFileSecretRefs) > 0 {
+			cb.WithVolumeMount([]corev1.VolumeMount{
+				{
+					Name:      "elasticsearch-realms",
+					MountPath: realmsPath,
+					ReadOnly:  true,
+				},
+			}, k8sbuilder.Merge)
+		}
+
 		if es.Spec.GlobalNodeGroup.CacertsSecretRef != nil {
 			cb.WithVolumeMount([]corev1.VolumeMount{
 				{
@@ -445,6 +485,105 @@ fi
 
 		// Compute containers
 		ptb.WithContainers([]corev1.Container{*cb.Container()}, k8sbuilder.Merge)
+		if isKeystore {
+			// Update the keystore in place and reload the secure settings when the keystore secrets change
+			// The settings that can't be reloaded are on the pod checksum, so they are loaded by the rolling restart
+			scheme := "http"
+			if es.Spec.Tls.IsTlsEnabled() {
+				scheme = "https"
+			}
+			rcb := k8sbuilder.NewContainerBuilder().WithContainer(&corev1.Container{
+				Name:            "keystore-reloader",
+				Image:           GetContainerImage(es),
+				ImagePullPolicy: es.Spec.ImagePullPolicy,
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{
+						Drop: []corev1.Capability{
+							"ALL",
+						},
+					},
+					AllowPrivilegeEscalation: ptr.To(false),
+					Privileged:               ptr.To(false),
+					RunAsNonRoot:             ptr.To(true),
+					RunAsUser:                ptr.To[int64](1000),
+					RunAsGroup:               ptr.To[int64](1000),
+				},
+				Env: []corev1.EnvVar{
+					{
+						Name: "ELASTIC_PASSWORD",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: GetSecretNameForCredentials(es),
+								},
+								Key: "elastic",
+							},
+						},
+					},
+					{
+						Name:  "PROBE_SCHEME",
+						Value: scheme,
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{
+						Name:      "config",
+						MountPath: "/usr/share/elasticsearch/config",
+					},
+					{
+						Name:      "elasticsearch-keystore",
+						MountPath: "/mnt/keystoreSecrets",
+						ReadOnly:  true,
+					},
+				},
+				Command: []string{
+					"/bin/bash",
+					"-c",
+					`#!/usr/bin/env bash
+set -uo pipefail
+
+checksum() {
+  for i in /mnt/keystoreSecrets/*; do
+    echo "$i"
+    cat "$i"
+  done | sha256sum
+}
+
+loaded=$(checksum)
+while true; do
+  sleep 30
+  expected=$(checksum)
+  if [ "$expected" == "$loaded" ]; then
+    continue
+  fi
+
+  echo "Keystore secrets changed, update the keystore"
+  for key in $(elasticsearch-keystore list); do
+    if [ "$key" != "keystore.seed" ] && [ "$key" != "bootstrap.password" ] && [ ! -f "/mnt/keystoreSecrets/$key" ]; then
+      echo "Removing keystore key $key"
+      elasticsearch-keystore remove "$key"
+    fi
+  done
+  for i in /mnt/keystoreSecrets/*; do
+    key=$(basename $i)
+    elasticsearch-keystore add-file -f "$key" "$i"
+  done
+
+  echo "Reload secure settings"
+  response=$(curl -k -s --fail -XPOST -u elastic:${ELASTIC_PASSWORD} ${PROBE_SCHEME}://127.0.0.1:9200/_nodes/_local/reload_secure_settings)
+  if [ $? -ne 0 ] || echo "$response" | grep -q reload_exception; then
+    echo "Error when reload secure settings, try again later: $response"
+    continue
+  fi
+  loaded=$expected
+done
+`,
+				},
+			})
+			rcb.WithResource(es.Spec.GlobalNodeGroup.InitContainerResources)
+
+			ptb.WithContainers([]corev1.Container{*rcb.Container()}, k8sbuilder.Merge)
+		}
 
 		// Compute init containers
 		if es.Spec.SetVMMaxMapCount == nil || *es.Spec.SetVMMaxMapCount {
@@ -467,7 +606,7 @@ fi
 
 			ptb.WithInitContainers([]corev1.Container{*icb.Container()}, k8sbuilder.Merge)
 		}
-		if es.Spec.GlobalNodeGroup.KeystoreSecretRef != nil {
+		if isKeystore {
 			kcb := k8sbuilder.NewContainerBuilder().WithContainer(&corev1.Container{
 				Name:            "init-keystore",
 				Image:           GetContainerImage(es),
@@ -676,7 +815,23 @@ if [ -f /mnt/keystore/elasticsearch.keystore ]; then
   cp /mnt/keystore/elasticsearch.keystore /mnt/config
 fi
 
-# Set right
+`)
+		if len(remoteClusterCaKeys) > 0 {
+			command.WriteString(`# Trust the transport CA of remote clusters
+echo "Add remote clusters CA"
+for i in /mnt/certs/remote/*.crt; do
+  cat "$i" >> /mnt/config/transport-cert/ca.crt
+done
+
+`)
+			ccb.WithVolumeMount([]corev1.VolumeMount{
+				{
+					Name:      "remote-cluster-tls",
+					MountPath: "/mnt/certs/remote",
+				},
+			}, k8sbuilder.Merge)
+		}
+		command.WriteString(`# Set right
 echo "Set right"
 chown -R elasticsearch:elasticsearch /mnt/config
 chown elasticsearch:elasticsearch /mnt/data
@@ -770,7 +925,40 @@ fi
 			})
 		}
 		ptb.WithVolumes(additionalVolume, k8sbuilder.Merge)
-		if GetSecretNameForKeystore(es) != "" {
+		if len(keystoreSecretRefs) > 0 || snapshotRepositorySecret != nil {
+			// Merge the keystore secret, the realm secrets, the cross-cluster API keys and the repository credentials on the same volume
+			sources := make([]corev1.VolumeProjection, 0, len(keystoreSecretRefs)+2)
+			if GetSecretNameForKeystore(es) != "" {
+				sources = append(sources, corev1.VolumeProjection{
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: GetSecretNameForKeystore(es),
+						},
+					},
+				})
+			}
+			if snapshotRepositorySecret != nil {
+				sources = append(sources, corev1.VolumeProjection{
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: snapshotRepositorySecret.Name,
+						},
+						Optional: ptr.To(true),
+					},
+				})
+			}
+			sources = append(sources, secretKeySelectorsToProjections(keystoreSecretRefs)...)
+			ptb.WithVolumes([]corev1.Volume{
+				{
+					Name: "elasticsearch-keystore",
+					VolumeSource: corev1.VolumeSource{
+						Projected: &corev1.ProjectedVolumeSource{
+							Sources: sources,
+						},
+					},
+				},
+			}, k8sbuilder.Merge)
+		} else if GetSecretNameForKeystore(es) != "" {
 			ptb.WithVolumes([]corev1.Volume{
 				{
 					Name: "elasticsearch-keystore",
@@ -782,6 +970,38 @@ fi
 				},
 			}, k8sbuilder.Merge)
 		}
+		if len(remoteClusterCaKeys) > 0 {
+			items := make([]corev1.KeyToPath, 0, len(remoteClusterCaKeys))
+			for _, key := range remoteClusterCaKeys {
+				items = append(items, corev1.KeyToPath{
+					Key:  key,
+					Path: key,
+				})
+			}
+			ptb.WithVolumes([]corev1.Volume{
+				{
+					Name: "remote-cluster-tls",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName: remoteClusterSecret.Name,
+							Items:      items,
+						},
+					},
+				},
+			}, k8sbuilder.Merge)
+		}
+		if len(realmFileSecretRefs) > 0 {
+			ptb.WithVolumes([]corev1.Volume{
+				{
+					Name: "elasticsearch-realms",
+					VolumeSource: corev1.VolumeSource{
+						Projected: &corev1.ProjectedVolumeSource{
+							Sources: secretKeySelectorsToProjections(realmFileSecretRefs),
+						},
+					},
+				},
+			}, k8sbuilder.Merge)
+		}
 		if GetSecretNameForCacerts(es) != "" {
 			ptb.WithVolumes([]corev1.Volume{
 				{
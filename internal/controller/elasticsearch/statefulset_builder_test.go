@@ -259,7 +259,7 @@ func TestBuildStatefulset(t *testing.T) {
 	}
 
 	// Keep only configmap of type config
-	extraConfigMapsTmp, err := buildConfigMaps(o)
+	extraConfigMapsTmp, err := buildConfigMaps(o, nil)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -334,7 +334,7 @@ func TestBuildStatefulset(t *testing.T) {
 	}
 
 	// Keep only configmap of type config
-	extraConfigMapsTmp, err = buildConfigMaps(o)
+	extraConfigMapsTmp, err = buildConfigMaps(o, nil)
 	if err != nil {
 		t.Fatal(err.Error())
 	}
@@ -350,6 +350,328 @@ func TestBuildStatefulset(t *testing.T) {
 	test.EqualFromYamlFile[*appv1.StatefulSet](t, "testdata/statefullset-all-external-tls.yml", sts[0], scheme.Scheme)
 }
 
+func TestBuildStatefulsetWithRealms(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			NodeGroups: []elasticsearchcrd.ElasticsearchNodeGroupSpec{
+				{
+					Name: "all",
+					Roles: []string{
+						"master",
+						"data",
+						"ingest",
+					},
+					Deployment: shared.Deployment{
+						Replicas: 1,
+					},
+				},
+			},
+			GlobalNodeGroup: elasticsearchcrd.ElasticsearchGlobalNodeGroupSpec{
+				KeystoreSecretRef: &corev1.LocalObjectReference{
+					Name: "keystore",
+				},
+			},
+			Security: &elasticsearchcrd.ElasticsearchSecuritySpec{
+				Realms: &elasticsearchcrd.ElasticsearchRealmsSpec{
+					Ldap: []elasticsearchcrd.ElasticsearchLdapRealmSpec{
+						{
+							ElasticsearchRealmSpec: elasticsearchcrd.ElasticsearchRealmSpec{
+								Name: "ldap1",
+							},
+							BindPasswordSecretRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "realms",
+								},
+								Key: "ldap",
+							},
+						},
+					},
+					Saml: []elasticsearchcrd.ElasticsearchSamlRealmSpec{
+						{
+							ElasticsearchRealmSpec: elasticsearchcrd.ElasticsearchRealmSpec{
+								Name:  "saml1",
+								Order: 1,
+							},
+							IdpMetadataSecretRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: "realms",
+								},
+								Key: "saml",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	sts, err := buildStatefulsets(o, nil, nil, false)
+	assert.NoError(t, err)
+	podSpec := sts[0].Spec.Template.Spec
+
+	// Keystore secret and realm secrets are merged on keystore volume
+	var keystoreVolume, realmsVolume *corev1.Volume
+	for i, volume := range podSpec.Volumes {
+		switch volume.Name {
+		case "elasticsearch-keystore":
+			keystoreVolume = &podSpec.Volumes[i]
+		case "elasticsearch-realms":
+			realmsVolume = &podSpec.Volumes[i]
+		}
+	}
+	assert.NotNil(t, keystoreVolume)
+	assert.NotNil(t, keystoreVolume.Projected)
+	assert.Equal(t, []corev1.VolumeProjection{
+		{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "keystore",
+				},
+			},
+		},
+		{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "realms",
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  "ldap",
+						Path: "xpack.security.authc.realms.ldap.ldap1.secure_bind_password",
+					},
+				},
+			},
+		},
+	}, keystoreVolume.Projected.Sources)
+
+	// SAML metadata is mounted on realms directory
+	assert.NotNil(t, realmsVolume)
+	assert.NotNil(t, realmsVolume.Projected)
+	assert.Equal(t, "saml/saml1/idp-metadata.xml", realmsVolume.Projected.Sources[0].Secret.Items[0].Path)
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      "elasticsearch-realms",
+		MountPath: "/usr/share/elasticsearch/config/realms",
+		ReadOnly:  true,
+	})
+
+	// Keystore is initialized even without keystore secret
+	o.Spec.GlobalNodeGroup.KeystoreSecretRef = nil
+	sts, err = buildStatefulsets(o, nil, nil, false)
+	assert.NoError(t, err)
+	isInitKeystore := false
+	for _, container := range sts[0].Spec.Template.Spec.InitContainers {
+		if container.Name == "init-keystore" {
+			isInitKeystore = true
+		}
+	}
+	assert.True(t, isInitKeystore)
+}
+
+func TestBuildStatefulsetWithRemoteCluster(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			NodeGroups: []elasticsearchcrd.ElasticsearchNodeGroupSpec{
+				{
+					Name: "all",
+					Roles: []string{
+						"master",
+						"data",
+						"ingest",
+					},
+					Deployment: shared.Deployment{
+						Replicas: 1,
+					},
+				},
+			},
+		},
+	}
+	remoteClusterSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-remote-cluster-es",
+		},
+		Data: map[string][]byte{
+			"other-remote-ca.crt":               []byte("ca"),
+			"cluster.remote.remote.credentials": []byte("apikey"),
+		},
+	}
+
+	sts, err := buildStatefulsets(o, []*corev1.Secret{remoteClusterSecret}, nil, false)
+	assert.NoError(t, err)
+	podSpec := sts[0].Spec.Template.Spec
+
+	// Remote CA are mounted and the API key is added on keystore
+	var keystoreVolume, remoteClusterVolume *corev1.Volume
+	for i, volume := range podSpec.Volumes {
+		switch volume.Name {
+		case "elasticsearch-keystore":
+			keystoreVolume = &podSpec.Volumes[i]
+		case "remote-cluster-tls":
+			remoteClusterVolume = &podSpec.Volumes[i]
+		}
+	}
+	assert.NotNil(t, remoteClusterVolume)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "other-remote-ca.crt", Path: "other-remote-ca.crt"}}, remoteClusterVolume.Secret.Items)
+	assert.NotNil(t, keystoreVolume)
+	assert.Equal(t, []corev1.VolumeProjection{
+		{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "test-remote-cluster-es",
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  "cluster.remote.remote.credentials",
+						Path: "cluster.remote.remote.credentials",
+					},
+				},
+			},
+		},
+	}, keystoreVolume.Projected.Sources)
+
+	isInitKeystore := false
+	for _, container := range podSpec.InitContainers {
+		switch container.Name {
+		case "init-keystore":
+			isInitKeystore = true
+		case "init-filesystem":
+			assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+				Name:      "remote-cluster-tls",
+				MountPath: "/mnt/certs/remote",
+			})
+			assert.Contains(t, container.Command[2], "/mnt/certs/remote/*.crt")
+		}
+	}
+	assert.True(t, isInitKeystore)
+	assert.NotEmpty(t, sts[0].Spec.Template.Annotations[fmt.Sprintf("%s/secret-test-remote-cluster-es", elasticsearchcrd.ElasticsearchAnnotationKey)])
+}
+
+func TestBuildStatefulsetWithSnapshotRepository(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			NodeGroups: []elasticsearchcrd.ElasticsearchNodeGroupSpec{
+				{
+					Name: "all",
+					Roles: []string{
+						"master",
+						"data",
+						"ingest",
+					},
+					Deployment: shared.Deployment{
+						Replicas: 1,
+					},
+				},
+			},
+		},
+	}
+	snapshotRepositorySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-snapshot-repository-es",
+		},
+		Data: map[string][]byte{
+			"s3.client.default.access_key": []byte("access"),
+		},
+	}
+
+	sts, err := buildStatefulsets(o, []*corev1.Secret{snapshotRepositorySecret}, nil, false)
+	assert.NoError(t, err)
+	podSpec := sts[0].Spec.Template.Spec
+
+	// The credentials are added on keystore
+	var keystoreVolume *corev1.Volume
+	for i, volume := range podSpec.Volumes {
+		if volume.Name == "elasticsearch-keystore" {
+			keystoreVolume = &podSpec.Volumes[i]
+		}
+	}
+	assert.NotNil(t, keystoreVolume)
+	assert.Equal(t, []corev1.VolumeProjection{
+		{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: "test-snapshot-repository-es",
+				},
+				Optional: ptr.To(true),
+			},
+		},
+	}, keystoreVolume.Projected.Sources)
+
+	// The keystore is reloaded by sidecar
+	assert.Len(t, podSpec.Containers, 2)
+	assert.Equal(t, "keystore-reloader", podSpec.Containers[1].Name)
+}
+
+func TestBuildStatefulsetWithReloadableKeystore(t *testing.T) {
+	o := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			GlobalNodeGroup: elasticsearchcrd.ElasticsearchGlobalNodeGroupSpec{
+				KeystoreSecretRef: &corev1.LocalObjectReference{
+					Name: "keystore",
+				},
+			},
+			NodeGroups: []elasticsearchcrd.ElasticsearchNodeGroupSpec{
+				{
+					Name: "all",
+					Roles: []string{
+						"master",
+						"data",
+						"ingest",
+					},
+					Deployment: shared.Deployment{
+						Replicas: 1,
+					},
+				},
+			},
+		},
+	}
+	keystoreSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "keystore",
+		},
+		Data: map[string][]byte{
+			"s3.client.default.access_key":                            []byte("access"),
+			"xpack.security.authc.realms.oidc.oidc1.rp.client_secret": []byte("secret"),
+		},
+	}
+	checksumAnnotation := "elasticsearch.k8s.webcenter.fr/secret-keystore"
+
+	sts, err := buildStatefulsets(o, []*corev1.Secret{keystoreSecret}, nil, false)
+	assert.NoError(t, err)
+	checksum := sts[0].Spec.Template.Annotations[checksumAnnotation]
+	assert.NotEmpty(t, checksum)
+
+	// Change reloadable setting not change the pod template
+	keystoreSecret.Data["s3.client.default.access_key"] = []byte("access2")
+	keystoreSecret.Data["s3.client.backup.access_key"] = []byte("access")
+	sts, err = buildStatefulsets(o, []*corev1.Secret{keystoreSecret}, nil, false)
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, sts[0].Spec.Template.Annotations[checksumAnnotation])
+
+	// Change setting that can't be reloaded need a rolling restart
+	keystoreSecret.Data["xpack.security.authc.realms.oidc.oidc1.rp.client_secret"] = []byte("secret2")
+	sts, err = buildStatefulsets(o, []*corev1.Secret{keystoreSecret}, nil, false)
+	assert.NoError(t, err)
+	assert.NotEqual(t, checksum, sts[0].Spec.Template.Annotations[checksumAnnotation])
+}
+
 func TestComputeJavaOpts(t *testing.T) {
 	var o *elasticsearchcrd.Elasticsearch
 
@@ -32,6 +32,7 @@ const (
 	StatefulsetCondition            shared.ConditionName = "StatefulsetReady"
 	StatefulsetConditionUpgrade     shared.ConditionName = "StatefulsetUpgrade"
 	StatefulsetPhase                shared.PhaseName     = "Statefullset"
+	StatefulsetPhaseUpgradeSnapshot shared.PhaseName     = "statefulsetUpgradeSnapshot"
 	StatefulsetPhaseUpgradeStarted  shared.PhaseName     = "statefulsetUpgradeStarted"
 	StatefulsetPhaseUpgrade         shared.PhaseName     = "statefulsetUpgrade"
 	StatefulsetPhaseUpgradeFinished shared.PhaseName     = "statefulsetUpgradeFinished"
@@ -76,8 +77,10 @@ func (r *statefulsetReconciler) Read(ctx context.Context, o *elasticsearchcrd.El
 	read.SetCurrentObjects(helper.ToSlicePtr(stsList.Items))
 
 	// Read keystore secret if needed
+	// Use its own object because the builder need its data to compute the checksum of settings that can't be reloaded
 	if o.Spec.GlobalNodeGroup.KeystoreSecretRef != nil && o.Spec.GlobalNodeGroup.KeystoreSecretRef.Name != "" {
-		if err = r.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Spec.GlobalNodeGroup.KeystoreSecretRef.Name}, s); err != nil {
+		ks := &corev1.Secret{}
+		if err = r.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Spec.GlobalNodeGroup.KeystoreSecretRef.Name}, ks); err != nil {
 			if !k8serrors.IsNotFound(err) {
 				return read, res, errors.Wrapf(err, "Error when read secret %s", o.Spec.GlobalNodeGroup.KeystoreSecretRef.Name)
 			}
@@ -85,7 +88,21 @@ func (r *statefulsetReconciler) Read(ctx context.Context, o *elasticsearchcrd.El
 			return read, reconcile.Result{RequeueAfter: 30 * time.Second}, nil
 		}
 
-		secretsChecksum = append(secretsChecksum, s)
+		secretsChecksum = append(secretsChecksum, ks)
+	}
+
+	// Read realm secrets if needed
+	for _, secretName := range o.GetRealmSecretNames() {
+		rs := &corev1.Secret{}
+		if err = r.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: secretName}, rs); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return read, res, errors.Wrapf(err, "Error when read secret %s", secretName)
+			}
+			logger.Warnf("Secret %s not yet exist, try again later", secretName)
+			return read, reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+
+		secretsChecksum = append(secretsChecksum, rs)
 	}
 
 	// Read cacerts secret if needed
@@ -136,6 +153,26 @@ func (r *statefulsetReconciler) Read(ctx context.Context, o *elasticsearchcrd.El
 	}
 	secretsChecksum = append(secretsChecksum, s)
 
+	// Read remote cluster secret if exist, it's managed by RemoteCluster
+	rcs := &corev1.Secret{}
+	if err = r.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: GetSecretNameForRemoteCluster(o)}, rcs); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return read, res, errors.Wrapf(err, "Error when read secret %s", GetSecretNameForRemoteCluster(o))
+		}
+	} else {
+		secretsChecksum = append(secretsChecksum, rcs)
+	}
+
+	// Read snapshot repository secret if exist, it's managed by SnapshotRepository
+	srs := &corev1.Secret{}
+	if err = r.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: GetSecretNameForSnapshotRepository(o)}, srs); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return read, res, errors.Wrapf(err, "Error when read secret %s", GetSecretNameForSnapshotRepository(o))
+		}
+	} else {
+		secretsChecksum = append(secretsChecksum, srs)
+	}
+
 	// Read configMaps to generate checksum
 	// Keep only configmap of type config
 	labelSelectors, err = labels.Parse(fmt.Sprintf("cluster=%s,%s=true", o.Name, elasticsearchcrd.ElasticsearchAnnotationKey))
@@ -402,6 +439,13 @@ func (r *statefulsetReconciler) Diff(ctx context.Context, o *elasticsearchcrd.El
 			// Start upgrade phase
 			activeStateFulsetAlreadyUpgraded := false
 
+			// Wait the snapshot is completed before upgrade the cluster version
+			if len(stsToExpectedUpdated) > 0 && o.Spec.UpgradeSnapshot != nil && isVersionUpgradePending(o, currentStatefulsets) && !o.IsUpgradeSnapshotCompleted() {
+				logger.Infof("Wait snapshot %s before upgrade to version %s", GetUpgradeSnapshotName(o), o.Spec.Version)
+				data["phase"] = StatefulsetPhaseUpgradeSnapshot
+				stsToExpectedUpdated = nil
+			}
+
 			for _, sts := range stsToExpectedUpdated {
 				if *sts.Spec.Replicas == 0 {
 					diff.AddObjectToUpdate(sts)
@@ -484,6 +528,16 @@ func (r *statefulsetReconciler) OnSuccess(ctx context.Context, o *elasticsearchc
 
 		return reconcile.Result{RequeueAfter: time.Second * 30}, nil
 
+	case StatefulsetPhaseUpgradeSnapshot:
+		condition.SetStatusCondition(&o.Status.Conditions, metav1.Condition{
+			Type:    StatefulsetConditionUpgrade.String(),
+			Reason:  "WaitSnapshot",
+			Status:  metav1.ConditionFalse,
+			Message: fmt.Sprintf("Wait snapshot %s before upgrade", GetUpgradeSnapshotName(o)),
+		})
+
+		return reconcile.Result{RequeueAfter: time.Second * 10}, nil
+
 	case StatefulsetPhaseUpgrade:
 		return reconcile.Result{RequeueAfter: time.Second * 30}, nil
 
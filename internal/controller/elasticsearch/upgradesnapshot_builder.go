@@ -0,0 +1,47 @@
+package elasticsearch
+
+import (
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// buildUpgradeSnapshots permit to generate the snapshot taken before upgrade the cluster version
+// The snapshot is only expected while the upgrade is pending, and it's keeped on repository when the resource is removed to allow rollback
+func buildUpgradeSnapshots(es *elasticsearchcrd.Elasticsearch, isUpgradePending bool) (snapshots []*elasticsearchapicrd.Snapshot, err error) {
+	if es.Spec.UpgradeSnapshot == nil || !isUpgradePending {
+		return nil, nil
+	}
+
+	includeGlobalState := es.Spec.UpgradeSnapshot.IncludeGlobalState
+	if includeGlobalState == nil {
+		includeGlobalState = ptr.To[bool](true)
+	}
+
+	snapshots = []*elasticsearchapicrd.Snapshot{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   es.Namespace,
+				Name:        GetUpgradeSnapshotName(es),
+				Labels:      getLabels(es),
+				Annotations: getAnnotations(es),
+			},
+			Spec: elasticsearchapicrd.SnapshotSpec{
+				ElasticsearchRef: shared.ElasticsearchRef{
+					ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+						Name: es.Name,
+					},
+				},
+				DeletionPolicy:     shared.DeletionPolicyOrphan,
+				Repository:         es.Spec.UpgradeSnapshot.Repository,
+				Indices:            es.Spec.UpgradeSnapshot.Indices,
+				IncludeGlobalState: includeGlobalState,
+				WaitForCompletion:  true,
+			},
+		},
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,60 @@
+package elasticsearch
+
+import (
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/stretchr/testify/assert"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildUpgradeSnapshot(t *testing.T) {
+	var (
+		o         *elasticsearchcrd.Elasticsearch
+		snapshots []*elasticsearchapicrd.Snapshot
+	)
+	sch := scheme.Scheme
+	if err := elasticsearchapicrd.AddToScheme(sch); err != nil {
+		panic(err)
+	}
+
+	// When no upgrade snapshot is expected
+	o = &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "test",
+			Generation: 2,
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			Version: "8.15.0",
+		},
+	}
+
+	snapshots, err := buildUpgradeSnapshots(o, true)
+	assert.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	// When no upgrade is pending
+	o.Spec.UpgradeSnapshot = &elasticsearchcrd.ElasticsearchUpgradeSnapshotSpec{
+		Repository: "backup",
+	}
+
+	snapshots, err = buildUpgradeSnapshots(o, false)
+	assert.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	// When upgrade is pending
+	snapshots, err = buildUpgradeSnapshots(o, true)
+	assert.NoError(t, err)
+	test.EqualFromYamlFile[*elasticsearchapicrd.Snapshot](t, "testdata/upgradesnapshot.yml", snapshots[0], sch)
+
+	// When global state is excluded
+	o.Spec.UpgradeSnapshot.IncludeGlobalState = ptr.To[bool](false)
+	snapshots, err = buildUpgradeSnapshots(o, true)
+	assert.NoError(t, err)
+	assert.False(t, *snapshots[0].Spec.IncludeGlobalState)
+}
@@ -0,0 +1,129 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/shared"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/multiphase"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	appv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	UpgradeSnapshotCondition shared.ConditionName = "UpgradeSnapshotReady"
+	UpgradeSnapshotPhase     shared.PhaseName     = "UpgradeSnapshot"
+)
+
+type upgradeSnapshotReconciler struct {
+	multiphase.MultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.Snapshot]
+}
+
+func newUpgradeSnapshotReconciler(client client.Client, recorder record.EventRecorder) (multiPhaseStepReconcilerAction multiphase.MultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.Snapshot]) {
+	return &upgradeSnapshotReconciler{
+		MultiPhaseStepReconcilerAction: multiphase.NewMultiPhaseStepReconcilerAction[*elasticsearchcrd.Elasticsearch, *elasticsearchapicrd.Snapshot](
+			client,
+			UpgradeSnapshotPhase,
+			UpgradeSnapshotCondition,
+			recorder,
+		),
+	}
+}
+
+// Read existing upgrade snapshot
+func (r *upgradeSnapshotReconciler) Read(ctx context.Context, o *elasticsearchcrd.Elasticsearch, data map[string]any, logger *logrus.Entry) (read multiphase.MultiPhaseRead[*elasticsearchapicrd.Snapshot], res reconcile.Result, err error) {
+	snapshotList := &elasticsearchapicrd.SnapshotList{}
+	stsList := &appv1.StatefulSetList{}
+	read = multiphase.NewMultiPhaseRead[*elasticsearchapicrd.Snapshot]()
+
+	// Read current upgrade snapshots
+	// The snapshot name depend of the version, so we need to read all snapshots managed by the cluster
+	labelSelectors, err := labels.Parse(fmt.Sprintf("cluster=%s,%s=true", o.Name, elasticsearchcrd.ElasticsearchAnnotationKey))
+	if err != nil {
+		return read, res, errors.Wrap(err, "Error when generate label selector")
+	}
+	if err = r.Client().List(ctx, snapshotList, &client.ListOptions{Namespace: o.Namespace, LabelSelector: labelSelectors}); err != nil {
+		return read, res, errors.Wrapf(err, "Error when read snapshots")
+	}
+	for i := range snapshotList.Items {
+		read.AddCurrentObject(&snapshotList.Items[i])
+	}
+
+	// Check if the cluster version need to be upgraded
+	if err = r.Client().List(ctx, stsList, &client.ListOptions{Namespace: o.Namespace, LabelSelector: labelSelectors}); err != nil {
+		return read, res, errors.Wrapf(err, "Error when read statefulset")
+	}
+
+	// Record the upgrade attempt on status, so the snapshot name not change until the upgrade is completed
+	isUpgradePending := isVersionUpgradePending(o, helper.ToSlicePtr(stsList.Items))
+	if isUpgradePending && o.Spec.UpgradeSnapshot != nil && (o.Status.UpgradeSnapshot == nil || o.Status.UpgradeSnapshot.Version != o.Spec.Version) {
+		o.Status.UpgradeSnapshot = &elasticsearchcrd.ElasticsearchUpgradeSnapshotStatus{
+			Name:       GetUpgradeSnapshotName(o),
+			Repository: o.Spec.UpgradeSnapshot.Repository,
+			Version:    o.Spec.Version,
+		}
+	}
+
+	// Generate expected upgrade snapshot
+	expectedSnapshots, err := buildUpgradeSnapshots(o, isUpgradePending)
+	if err != nil {
+		return read, res, errors.Wrap(err, "Error when generate upgrade snapshot")
+	}
+	read.SetExpectedObjects(expectedSnapshots)
+
+	return read, res, nil
+}
+
+// OnSuccess record the upgrade snapshot state on status
+// The statefulset reconciler wait this snapshot is successfully completed before start the upgrade
+func (r *upgradeSnapshotReconciler) OnSuccess(ctx context.Context, o *elasticsearchcrd.Elasticsearch, data map[string]any, diff multiphase.MultiPhaseDiff[*elasticsearchapicrd.Snapshot], logger *logrus.Entry) (res reconcile.Result, err error) {
+	if res, err = r.MultiPhaseStepReconcilerAction.OnSuccess(ctx, o, data, diff, logger); err != nil {
+		return res, err
+	}
+
+	if o.Spec.UpgradeSnapshot == nil || o.IsUpgradeSnapshotCompleted() {
+		return res, nil
+	}
+
+	snapshot := &elasticsearchapicrd.Snapshot{}
+	if err = r.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: GetUpgradeSnapshotName(o)}, snapshot); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return res, nil
+		}
+		return res, errors.Wrap(err, "Error when read upgrade snapshot")
+	}
+
+	previousState := ""
+	if o.Status.UpgradeSnapshot != nil && o.Status.UpgradeSnapshot.Version == o.Spec.Version {
+		previousState = o.Status.UpgradeSnapshot.State
+	}
+
+	o.Status.UpgradeSnapshot = &elasticsearchcrd.ElasticsearchUpgradeSnapshotStatus{
+		Name:       snapshot.GetExternalName(),
+		Repository: snapshot.Spec.Repository,
+		Version:    o.Spec.Version,
+		State:      snapshot.Status.State,
+	}
+
+	if snapshot.Status.State != previousState {
+		switch snapshot.Status.State {
+		case "SUCCESS":
+			r.Recorder().Eventf(o, corev1.EventTypeNormal, "UpgradeSnapshotCompleted", "Snapshot %s successfully completed, start upgrade to version %s", snapshot.GetExternalName(), o.Spec.Version)
+		case "PARTIAL", "FAILED":
+			r.Recorder().Eventf(o, corev1.EventTypeWarning, "UpgradeSnapshotFailed", "Snapshot %s is %s, the upgrade to version %s is blocked", snapshot.GetExternalName(), snapshot.Status.State, o.Spec.Version)
+		}
+	}
+
+	return res, nil
+}
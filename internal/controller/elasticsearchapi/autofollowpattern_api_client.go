@@ -0,0 +1,236 @@
+package elasticsearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+)
+
+// autoFollowPattern is the auto-follow pattern object stored on Elasticsearch
+type autoFollowPattern struct {
+	RemoteCluster                string         `json:"remote_cluster"`
+	LeaderIndexPatterns          []string       `json:"leader_index_patterns"`
+	LeaderIndexExclusionPatterns []string       `json:"leader_index_exclusion_patterns,omitempty"`
+	FollowIndexPattern           string         `json:"follow_index_pattern,omitempty"`
+	Settings                     map[string]any `json:"settings,omitempty"`
+	followerIndexParameters
+}
+
+// autoFollowPatternInfo is the auto-follow pattern with its state
+type autoFollowPatternInfo struct {
+	autoFollowPattern
+	Active bool `json:"active"`
+}
+
+type autoFollowPatternGetResponse struct {
+	Patterns []struct {
+		Name    string                `json:"name"`
+		Pattern autoFollowPatternInfo `json:"pattern"`
+	} `json:"patterns"`
+}
+
+type autoFollowPatternApiClient struct {
+	remote.RemoteExternalReconciler[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler]
+}
+
+func newAutoFollowPatternApiClient(client eshandler.ElasticsearchHandler) remote.RemoteExternalReconciler[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler] {
+	return &autoFollowPatternApiClient{
+		RemoteExternalReconciler: remote.NewRemoteExternalReconciler[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler](client),
+	}
+}
+
+func (h *autoFollowPatternApiClient) Build(o *elasticsearchapicrd.AutoFollowPattern) (pattern *autoFollowPattern, err error) {
+	pattern = &autoFollowPattern{
+		RemoteCluster:                o.Spec.RemoteCluster,
+		LeaderIndexPatterns:          o.Spec.LeaderIndexPatterns,
+		LeaderIndexExclusionPatterns: o.Spec.LeaderIndexExclusionPatterns,
+		FollowIndexPattern:           o.Spec.FollowIndexPattern,
+	}
+
+	if o.Spec.Settings != nil {
+		pattern.Settings = o.Spec.Settings.Data
+	}
+
+	if parameters := buildFollowerIndexParameters(o.Spec.Parameters); parameters != nil {
+		pattern.followerIndexParameters = *parameters
+	}
+
+	return pattern, nil
+}
+
+func (h *autoFollowPatternApiClient) Get(o *elasticsearchapicrd.AutoFollowPattern) (object *autoFollowPattern, err error) {
+	info, err := autoFollowPatternGet(h.Client(), o.GetExternalName())
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, nil
+	}
+
+	return &info.autoFollowPattern, nil
+}
+
+func (h *autoFollowPatternApiClient) Create(object *autoFollowPattern, o *elasticsearchapicrd.AutoFollowPattern) (err error) {
+	return autoFollowPatternUpdate(h.Client(), o.GetExternalName(), object)
+}
+
+func (h *autoFollowPatternApiClient) Update(object *autoFollowPattern, o *elasticsearchapicrd.AutoFollowPattern) (err error) {
+	return autoFollowPatternUpdate(h.Client(), o.GetExternalName(), object)
+}
+
+// Delete remove the auto-follow pattern
+// The follower indices already created are keeped
+func (h *autoFollowPatternApiClient) Delete(o *elasticsearchapicrd.AutoFollowPattern) (err error) {
+	return autoFollowPatternDelete(h.Client(), o.GetExternalName())
+}
+
+func (h *autoFollowPatternApiClient) Diff(currentOject *autoFollowPattern, expectedObject *autoFollowPattern, originalObject *autoFollowPattern, o *elasticsearchapicrd.AutoFollowPattern, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	// If not yet exist
+	if currentOject == nil {
+		expected, err := json.Marshal(expectedObject)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert expected object to byte sequence")
+		}
+
+		return &patch.PatchResult{
+			Patch:    expected,
+			Current:  expected,
+			Modified: expected,
+			Original: nil,
+			Patched:  expectedObject,
+		}, nil
+	}
+
+	return patch.DefaultPatchMaker.Calculate(currentOject, expectedObject, originalObject, ignoresDiff...)
+}
+
+// autoFollowPatternGet permit to get auto-follow pattern
+// It return nil if auto-follow pattern not exist
+func autoFollowPatternGet(client eshandler.ElasticsearchHandler, name string) (pattern *autoFollowPatternInfo, err error) {
+	api := client.Client().API
+	res, err := api.CCR.GetAutoFollowPattern(
+		api.CCR.GetAutoFollowPattern.WithContext(context.Background()),
+		api.CCR.GetAutoFollowPattern.WithName(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get auto-follow pattern %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &autoFollowPatternGetResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode auto-follow pattern %s", name)
+	}
+
+	for _, p := range resp.Patterns {
+		if p.Name == name {
+			return &p.Pattern, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// autoFollowPatternUpdate permit to create or update auto-follow pattern
+func autoFollowPatternUpdate(client eshandler.ElasticsearchHandler, name string, pattern *autoFollowPattern) (err error) {
+	data, err := json.Marshal(pattern)
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.CCR.PutAutoFollowPattern(
+		name,
+		bytes.NewReader(data),
+		api.CCR.PutAutoFollowPattern.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when add auto-follow pattern %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// autoFollowPatternDelete permit to delete auto-follow pattern
+func autoFollowPatternDelete(client eshandler.ElasticsearchHandler, name string) (err error) {
+	api := client.Client().API
+	res, err := api.CCR.DeleteAutoFollowPattern(
+		name,
+		api.CCR.DeleteAutoFollowPattern.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete auto-follow pattern %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// autoFollowPatternPause permit to pause auto-follow pattern
+// The existing follower indices are not paused
+func autoFollowPatternPause(client eshandler.ElasticsearchHandler, name string) (err error) {
+	api := client.Client().API
+	res, err := api.CCR.PauseAutoFollowPattern(
+		name,
+		api.CCR.PauseAutoFollowPattern.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when pause auto-follow pattern %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// autoFollowPatternResume permit to resume auto-follow pattern
+func autoFollowPatternResume(client eshandler.ElasticsearchHandler, name string) (err error) {
+	api := client.Client().API
+	res, err := api.CCR.ResumeAutoFollowPattern(
+		name,
+		api.CCR.ResumeAutoFollowPattern.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when resume auto-follow pattern %s: %s", name, res.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package elasticsearchapi
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestAutoFollowPatternBuild(t *testing.T) {
+	var (
+		o               *elasticsearchapicrd.AutoFollowPattern
+		pattern         *autoFollowPattern
+		expectedPattern *autoFollowPattern
+		err             error
+		client          *autoFollowPatternApiClient
+	)
+
+	client = &autoFollowPatternApiClient{}
+
+	// With minimal spec
+	o = &elasticsearchapicrd.AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.AutoFollowPatternSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			RemoteCluster:       "remote",
+			LeaderIndexPatterns: []string{"logs-*"},
+		},
+	}
+
+	expectedPattern = &autoFollowPattern{
+		RemoteCluster:       "remote",
+		LeaderIndexPatterns: []string{"logs-*"},
+	}
+
+	pattern, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPattern, pattern)
+
+	// With all fields
+	o = &elasticsearchapicrd.AutoFollowPattern{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.AutoFollowPatternSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			RemoteCluster:                "remote",
+			LeaderIndexPatterns:          []string{"logs-*"},
+			LeaderIndexExclusionPatterns: []string{"logs-debug-*"},
+			FollowIndexPattern:           "{{leader_index}}-copy",
+			Settings: &apis.MapAny{
+				Data: map[string]any{
+					"index.number_of_replicas": 0,
+				},
+			},
+			Parameters: &elasticsearchapicrd.FollowerIndexParameters{
+				MaxOutstandingReadRequests: ptr.To[int64](16),
+			},
+		},
+	}
+
+	expectedPattern = &autoFollowPattern{
+		RemoteCluster:                "remote",
+		LeaderIndexPatterns:          []string{"logs-*"},
+		LeaderIndexExclusionPatterns: []string{"logs-debug-*"},
+		FollowIndexPattern:           "{{leader_index}}-copy",
+		Settings: map[string]any{
+			"index.number_of_replicas": 0,
+		},
+		followerIndexParameters: followerIndexParameters{
+			MaxOutstandingReadRequests: ptr.To[int64](16),
+		},
+	}
+
+	pattern, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPattern, pattern)
+}
+
+func TestAutoFollowPatternApi(t *testing.T) {
+	var (
+		method string
+		path   string
+		body   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		switch {
+		case r.URL.Path == "/_ccr/auto_follow/test" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"patterns":[{"name":"test","pattern":{"active":false,"remote_cluster":"remote","leader_index_patterns":["logs-*"],"leader_index_exclusion_patterns":[],"follow_index_pattern":"{{leader_index}}","max_outstanding_read_requests":16}}]}`))
+			return
+		case r.URL.Path == "/_ccr/auto_follow/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"type":"resource_not_found_exception"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+
+	// Get
+	info, err := autoFollowPatternGet(mockES, "test")
+	assert.NoError(t, err)
+	assert.False(t, info.Active)
+	assert.Equal(t, autoFollowPattern{
+		RemoteCluster:                "remote",
+		LeaderIndexPatterns:          []string{"logs-*"},
+		LeaderIndexExclusionPatterns: []string{},
+		FollowIndexPattern:           "{{leader_index}}",
+		followerIndexParameters: followerIndexParameters{
+			MaxOutstandingReadRequests: ptr.To[int64](16),
+		},
+	}, info.autoFollowPattern)
+
+	// Get when not exist
+	info, err = autoFollowPatternGet(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	// Update
+	err = autoFollowPatternUpdate(mockES, "test", &autoFollowPattern{
+		RemoteCluster:       "remote",
+		LeaderIndexPatterns: []string{"logs-*"},
+		followerIndexParameters: followerIndexParameters{
+			ReadPollTimeout: "30s",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_ccr/auto_follow/test", path)
+	assert.JSONEq(t, `{"remote_cluster":"remote","leader_index_patterns":["logs-*"],"read_poll_timeout":"30s"}`, body)
+
+	// Pause
+	err = autoFollowPatternPause(mockES, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "/_ccr/auto_follow/test/pause", path)
+
+	// Resume
+	err = autoFollowPatternResume(mockES, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "/_ccr/auto_follow/test/resume", path)
+
+	// Delete
+	err = autoFollowPatternDelete(mockES, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, method)
+
+	// Delete when not exist
+	err = autoFollowPatternDelete(mockES, "missing")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,248 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (t *ElasticsearchapiControllerTestSuite) TestAutoFollowPatternReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-autofollowpattern-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase[*elasticsearchapicrd.AutoFollowPattern](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchapicrd.AutoFollowPattern]{
+		doCreateAutoFollowPatternStep(),
+		doUpdateAutoFollowPatternStep(),
+		doDeleteAutoFollowPatternStep(),
+	}
+	testCase.PreTest = doMockAutoFollowPattern(t.fakeElasticsearchMux, key.Name)
+
+	testCase.Run()
+}
+
+func doMockAutoFollowPattern(mux *http.ServeMux, name string) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		var mutex sync.Mutex
+		var pattern map[string]any
+
+		mux.HandleFunc("/_ccr/auto_follow/"+name, func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			switch r.Method {
+			case http.MethodGet:
+				if pattern == nil {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"error":{"type":"resource_not_found_exception"}}`))
+					return
+				}
+				b, _ := json.Marshal(map[string]any{
+					"patterns": []any{
+						map[string]any{
+							"name":    name,
+							"pattern": pattern,
+						},
+					},
+				})
+				_, _ = w.Write(b)
+				return
+			case http.MethodPut:
+				body, _ := io.ReadAll(r.Body)
+				pattern = map[string]any{}
+				_ = json.Unmarshal(body, &pattern)
+				pattern["active"] = true
+				switch *stepName {
+				case "create":
+					data["isCreated"] = true
+				case "update":
+					data["isUpdated"] = true
+				}
+			case http.MethodDelete:
+				pattern = nil
+				data["isDeleted"] = true
+			}
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		})
+
+		mux.HandleFunc("/_ccr/auto_follow/"+name+"/", func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if pattern != nil {
+				pattern["active"] = strings.HasSuffix(r.URL.Path, "/resume")
+			}
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		})
+
+		return nil
+	}
+}
+
+func doCreateAutoFollowPatternStep() test.TestStep[*elasticsearchapicrd.AutoFollowPattern] {
+	return test.TestStep[*elasticsearchapicrd.AutoFollowPattern]{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.AutoFollowPattern, data map[string]any) (err error) {
+			logrus.Infof("=== Add new auto-follow pattern %s/%s ===\n\n", key.Namespace, key.Name)
+
+			autoFollowPattern := &elasticsearchapicrd.AutoFollowPattern{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchapicrd.AutoFollowPatternSpec{
+					ElasticsearchRef: shared.ElasticsearchRef{
+						ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+							Name: "test",
+						},
+					},
+					RemoteCluster:       "remote",
+					LeaderIndexPatterns: []string{"logs-*"},
+				},
+			}
+			if err = c.Create(context.Background(), autoFollowPattern); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.AutoFollowPattern, data map[string]any) (err error) {
+			autoFollowPattern := &elasticsearchapicrd.AutoFollowPattern{}
+			isCreated := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, autoFollowPattern); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated || autoFollowPattern.GetStatus().GetObservedGeneration() == 0 {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Auto-follow pattern: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(autoFollowPattern.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *autoFollowPattern.Status.IsSync)
+			assert.Equal(t, "active", autoFollowPattern.Status.State)
+
+			return nil
+		},
+	}
+}
+
+func doUpdateAutoFollowPatternStep() test.TestStep[*elasticsearchapicrd.AutoFollowPattern] {
+	return test.TestStep[*elasticsearchapicrd.AutoFollowPattern]{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.AutoFollowPattern, data map[string]any) (err error) {
+			logrus.Infof("=== Update auto-follow pattern %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Auto-follow pattern is null")
+			}
+
+			data["lastGeneration"] = o.GetStatus().GetObservedGeneration()
+			o.Spec.LeaderIndexPatterns = []string{"logs-*", "metrics-*"}
+			o.Spec.State = elasticsearchapicrd.FollowerIndexStatePaused
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.AutoFollowPattern, data map[string]any) (err error) {
+			autoFollowPattern := &elasticsearchapicrd.AutoFollowPattern{}
+			isUpdated := false
+
+			lastGeneration := data["lastGeneration"].(int64)
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, autoFollowPattern); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated || lastGeneration == autoFollowPattern.GetStatus().GetObservedGeneration() {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Auto-follow pattern: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(autoFollowPattern.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *autoFollowPattern.Status.IsSync)
+			assert.Equal(t, "paused", autoFollowPattern.Status.State)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteAutoFollowPatternStep() test.TestStep[*elasticsearchapicrd.AutoFollowPattern] {
+	return test.TestStep[*elasticsearchapicrd.AutoFollowPattern]{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.AutoFollowPattern, data map[string]any) (err error) {
+			logrus.Infof("=== Delete auto-follow pattern %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Auto-follow pattern is null")
+			}
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), o, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.AutoFollowPattern, data map[string]any) (err error) {
+			autoFollowPattern := &elasticsearchapicrd.AutoFollowPattern{}
+			isDeleted := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, autoFollowPattern); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Auto-follow pattern stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+			assert.Equal(t, true, data["isDeleted"])
+			return nil
+		},
+	}
+}
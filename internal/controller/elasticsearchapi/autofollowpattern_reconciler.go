@@ -0,0 +1,99 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/sirupsen/logrus"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type autoFollowPatternReconciler struct {
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler]
+	name string
+}
+
+func newAutoFollowPatternReconciler(name string, client client.Client, recorder record.EventRecorder) remote.RemoteReconcilerAction[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler] {
+	return &autoFollowPatternReconciler{
+		RemoteReconcilerAction: remote.NewRemoteReconcilerAction[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler](
+			client,
+			recorder,
+		),
+		name: name,
+	}
+}
+
+func (h *autoFollowPatternReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.AutoFollowPattern, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+	esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, h.Client(), logger)
+	if err != nil && o.DeletionTimestamp.IsZero() {
+		return nil, res, err
+	}
+
+	// Elastic not ready
+	if esClient == nil {
+		if o.DeletionTimestamp.IsZero() {
+			return nil, reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+		}
+
+		return nil, res, nil
+	}
+
+	handler = newAutoFollowPatternApiClient(esClient)
+
+	return handler, res, nil
+}
+
+// OnSuccess pause or resume the auto-follow pattern
+func (h *autoFollowPatternReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.AutoFollowPattern, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*autoFollowPattern], logger *logrus.Entry) (res reconcile.Result, err error) {
+	info, err := autoFollowPatternGet(handler.Client(), o.GetExternalName())
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get auto-follow pattern")
+	}
+
+	// The auto-follow pattern not exist when the dry-run annotation is set before create it
+	if info == nil {
+		if o.GetDryRunStatus() != nil {
+			return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+		}
+		return res, errors.Errorf("Auto-follow pattern %s not found", o.GetExternalName())
+	}
+
+	isActive := info.Active
+	if o.GetDryRunStatus() == nil {
+		switch o.GetExpectedState() {
+		case elasticsearchapicrd.FollowerIndexStateActive:
+			if !info.Active {
+				if err = autoFollowPatternResume(handler.Client(), o.GetExternalName()); err != nil {
+					return res, errors.Wrap(err, "Error when resume auto-follow pattern")
+				}
+				isActive = true
+				logger.Infof("Auto-follow pattern %s successfully resumed", o.GetExternalName())
+				h.Recorder().Eventf(o, corev1.EventTypeNormal, "ResumeCompleted", "Auto-follow pattern %s successfully resumed", o.GetExternalName())
+			}
+		case elasticsearchapicrd.FollowerIndexStatePaused:
+			if info.Active {
+				if err = autoFollowPatternPause(handler.Client(), o.GetExternalName()); err != nil {
+					return res, errors.Wrap(err, "Error when pause auto-follow pattern")
+				}
+				isActive = false
+				logger.Infof("Auto-follow pattern %s successfully paused", o.GetExternalName())
+				h.Recorder().Eventf(o, corev1.EventTypeNormal, "PauseCompleted", "Auto-follow pattern %s successfully paused", o.GetExternalName())
+			}
+		}
+	}
+
+	if isActive {
+		o.Status.State = followerIndexStatusActive
+	} else {
+		o.Status.State = followerIndexStatusPaused
+	}
+
+	return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+}
@@ -0,0 +1,420 @@
+package elasticsearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+)
+
+// followerIndexParameters is the replication settings of follower index and auto-follow pattern
+type followerIndexParameters struct {
+	MaxReadRequestOperationCount  *int64 `json:"max_read_request_operation_count,omitempty"`
+	MaxOutstandingReadRequests    *int64 `json:"max_outstanding_read_requests,omitempty"`
+	MaxReadRequestSize            string `json:"max_read_request_size,omitempty"`
+	MaxWriteRequestOperationCount *int64 `json:"max_write_request_operation_count,omitempty"`
+	MaxWriteRequestSize           string `json:"max_write_request_size,omitempty"`
+	MaxOutstandingWriteRequests   *int64 `json:"max_outstanding_write_requests,omitempty"`
+	MaxWriteBufferCount           *int64 `json:"max_write_buffer_count,omitempty"`
+	MaxWriteBufferSize            string `json:"max_write_buffer_size,omitempty"`
+	MaxRetryDelay                 string `json:"max_retry_delay,omitempty"`
+	ReadPollTimeout               string `json:"read_poll_timeout,omitempty"`
+}
+
+// followerIndex is the follower index object read from follow info API
+type followerIndex struct {
+	RemoteCluster string                   `json:"remote_cluster"`
+	LeaderIndex   string                   `json:"leader_index"`
+	Parameters    *followerIndexParameters `json:"parameters,omitempty"`
+}
+
+// followerIndexFollowRequest is the body accepted by the follow API
+type followerIndexFollowRequest struct {
+	RemoteCluster string `json:"remote_cluster"`
+	LeaderIndex   string `json:"leader_index"`
+	followerIndexParameters
+}
+
+// followerIndexInfo is the follower index with its replication status
+type followerIndexInfo struct {
+	followerIndex
+	FollowerIndex string `json:"follower_index"`
+	Status        string `json:"status"`
+}
+
+type followerIndexInfoResponse struct {
+	FollowerIndices []followerIndexInfo `json:"follower_indices"`
+}
+
+// followerIndexStats is the replication lag computed from all shards of follower index
+type followerIndexStats struct {
+	OperationsBehind        int64
+	TimeSinceLastReadMillis int64
+	FatalError              string
+}
+
+type followerIndexStatsResponse struct {
+	Indices []struct {
+		Index  string `json:"index"`
+		Shards []struct {
+			LeaderGlobalCheckpoint   int64 `json:"leader_global_checkpoint"`
+			FollowerGlobalCheckpoint int64 `json:"follower_global_checkpoint"`
+			TimeSinceLastReadMillis  int64 `json:"time_since_last_read_millis"`
+			FatalException           *struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"fatal_exception,omitempty"`
+		} `json:"shards"`
+	} `json:"indices"`
+}
+
+type followerIndexApiClient struct {
+	remote.RemoteExternalReconciler[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler]
+}
+
+func newFollowerIndexApiClient(client eshandler.ElasticsearchHandler) remote.RemoteExternalReconciler[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler] {
+	return &followerIndexApiClient{
+		RemoteExternalReconciler: remote.NewRemoteExternalReconciler[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler](client),
+	}
+}
+
+// Build compute the expected follower index
+// Elasticsearch not return the parameters when the replication is paused, so they are only expected when the follower index is active
+func (h *followerIndexApiClient) Build(o *elasticsearchapicrd.FollowerIndex) (follower *followerIndex, err error) {
+	follower = &followerIndex{
+		RemoteCluster: o.Spec.RemoteCluster,
+		LeaderIndex:   o.Spec.LeaderIndex,
+	}
+
+	if o.GetExpectedState() == elasticsearchapicrd.FollowerIndexStateActive {
+		follower.Parameters = buildFollowerIndexParameters(o.Spec.Parameters)
+	}
+
+	return follower, nil
+}
+
+func (h *followerIndexApiClient) Get(o *elasticsearchapicrd.FollowerIndex) (object *followerIndex, err error) {
+	info, err := followerIndexGetInfo(h.Client(), o.GetExternalName())
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, nil
+	}
+
+	return &info.followerIndex, nil
+}
+
+func (h *followerIndexApiClient) Create(object *followerIndex, o *elasticsearchapicrd.FollowerIndex) (err error) {
+	return followerIndexFollow(h.Client(), o.GetExternalName(), object)
+}
+
+// Update apply the new parameters
+// Elasticsearch only accept them when resume the replication, so the follower index is paused before
+func (h *followerIndexApiClient) Update(object *followerIndex, o *elasticsearchapicrd.FollowerIndex) (err error) {
+	info, err := followerIndexGetInfo(h.Client(), o.GetExternalName())
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return errors.Errorf("Follower index %s not found", o.GetExternalName())
+	}
+
+	if info.RemoteCluster != object.RemoteCluster || info.LeaderIndex != object.LeaderIndex {
+		return errors.Errorf("Follower index %s already follow %s:%s, you need to unfollow it before", o.GetExternalName(), info.RemoteCluster, info.LeaderIndex)
+	}
+
+	if o.GetExpectedState() == elasticsearchapicrd.FollowerIndexStatePaused {
+		return nil
+	}
+
+	if info.Status == followerIndexStatusActive {
+		if err = followerIndexPause(h.Client(), o.GetExternalName()); err != nil {
+			return err
+		}
+	}
+
+	return followerIndexResume(h.Client(), o.GetExternalName(), object.Parameters)
+}
+
+// Delete convert the follower index to regular index
+// The data are keeped
+func (h *followerIndexApiClient) Delete(o *elasticsearchapicrd.FollowerIndex) (err error) {
+	return followerIndexUnfollow(h.Client(), o.GetExternalName())
+}
+
+func (h *followerIndexApiClient) Diff(currentOject *followerIndex, expectedObject *followerIndex, originalObject *followerIndex, o *elasticsearchapicrd.FollowerIndex, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	// If not yet exist
+	if currentOject == nil {
+		expected, err := json.Marshal(expectedObject)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert expected object to byte sequence")
+		}
+
+		return &patch.PatchResult{
+			Patch:    expected,
+			Current:  expected,
+			Modified: expected,
+			Original: nil,
+			Patched:  expectedObject,
+		}, nil
+	}
+
+	return patch.DefaultPatchMaker.Calculate(currentOject, expectedObject, originalObject, ignoresDiff...)
+}
+
+// buildFollowerIndexParameters convert the replication parameters to the Elasticsearch format
+func buildFollowerIndexParameters(parameters *elasticsearchapicrd.FollowerIndexParameters) *followerIndexParameters {
+	if parameters == nil {
+		return nil
+	}
+
+	return &followerIndexParameters{
+		MaxReadRequestOperationCount:  parameters.MaxReadRequestOperationCount,
+		MaxOutstandingReadRequests:    parameters.MaxOutstandingReadRequests,
+		MaxReadRequestSize:            parameters.MaxReadRequestSize,
+		MaxWriteRequestOperationCount: parameters.MaxWriteRequestOperationCount,
+		MaxWriteRequestSize:           parameters.MaxWriteRequestSize,
+		MaxOutstandingWriteRequests:   parameters.MaxOutstandingWriteRequests,
+		MaxWriteBufferCount:           parameters.MaxWriteBufferCount,
+		MaxWriteBufferSize:            parameters.MaxWriteBufferSize,
+		MaxRetryDelay:                 parameters.MaxRetryDelay,
+		ReadPollTimeout:               parameters.ReadPollTimeout,
+	}
+}
+
+// followerIndexGetInfo permit to get the follower index and its replication status
+// It return nil if index not exist or if it's not a follower index
+func followerIndexGetInfo(client eshandler.ElasticsearchHandler, name string) (info *followerIndexInfo, err error) {
+	api := client.Client().API
+	res, err := api.CCR.FollowInfo(
+		[]string{name},
+		api.CCR.FollowInfo.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get follower index %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &followerIndexInfoResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode follower index %s", name)
+	}
+
+	for _, follower := range resp.FollowerIndices {
+		if follower.FollowerIndex == name {
+			return &follower, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// followerIndexFollow permit to create the follower index
+func followerIndexFollow(client eshandler.ElasticsearchHandler, name string, follower *followerIndex) (err error) {
+	request := &followerIndexFollowRequest{
+		RemoteCluster: follower.RemoteCluster,
+		LeaderIndex:   follower.LeaderIndex,
+	}
+	if follower.Parameters != nil {
+		request.followerIndexParameters = *follower.Parameters
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.CCR.Follow(
+		name,
+		bytes.NewReader(data),
+		api.CCR.Follow.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when follow index %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// followerIndexPause permit to pause the replication
+func followerIndexPause(client eshandler.ElasticsearchHandler, name string) (err error) {
+	api := client.Client().API
+	res, err := api.CCR.PauseFollow(
+		name,
+		api.CCR.PauseFollow.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when pause follower index %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// followerIndexResume permit to resume the replication with the given parameters
+func followerIndexResume(client eshandler.ElasticsearchHandler, name string, parameters *followerIndexParameters) (err error) {
+	api := client.Client().API
+	opts := []func(*esapi.CCRResumeFollowRequest){
+		api.CCR.ResumeFollow.WithContext(context.Background()),
+	}
+	if parameters != nil {
+		data, err := json.Marshal(parameters)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, api.CCR.ResumeFollow.WithBody(bytes.NewReader(data)))
+	}
+	res, err := api.CCR.ResumeFollow(
+		name,
+		opts...,
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when resume follower index %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// followerIndexUnfollow permit to convert the follower index to regular index
+// The replication is paused and the index is closed during the operation
+func followerIndexUnfollow(client eshandler.ElasticsearchHandler, name string) (err error) {
+	info, err := followerIndexGetInfo(client, name)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return nil
+	}
+
+	if info.Status == followerIndexStatusActive {
+		if err = followerIndexPause(client, name); err != nil {
+			return err
+		}
+	}
+
+	api := client.Client().API
+	res, err := api.Indices.Close(
+		[]string{name},
+		api.Indices.Close.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return errors.Errorf("Error when close follower index %s: %s", name, res.String())
+	}
+
+	res, err = api.CCR.Unfollow(
+		name,
+		api.CCR.Unfollow.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return errors.Errorf("Error when unfollow index %s: %s", name, res.String())
+	}
+
+	res, err = api.Indices.Open(
+		[]string{name},
+		api.Indices.Open.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return errors.Errorf("Error when open index %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// followerIndexGetStats permit to get the replication lag of follower index
+// It return nil if the replication is not active
+func followerIndexGetStats(client eshandler.ElasticsearchHandler, name string) (stats *followerIndexStats, err error) {
+	api := client.Client().API
+	res, err := api.CCR.FollowStats(
+		[]string{name},
+		api.CCR.FollowStats.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get stats of follower index %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &followerIndexStatsResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode stats of follower index %s", name)
+	}
+
+	for _, index := range resp.Indices {
+		if index.Index != name || len(index.Shards) == 0 {
+			continue
+		}
+
+		// Keep the worst shard to not hide a stuck replication
+		stats = &followerIndexStats{}
+		for _, shard := range index.Shards {
+			if shard.LeaderGlobalCheckpoint > shard.FollowerGlobalCheckpoint {
+				stats.OperationsBehind += shard.LeaderGlobalCheckpoint - shard.FollowerGlobalCheckpoint
+			}
+			if shard.TimeSinceLastReadMillis > stats.TimeSinceLastReadMillis {
+				stats.TimeSinceLastReadMillis = shard.TimeSinceLastReadMillis
+			}
+			if shard.FatalException != nil && stats.FatalError == "" {
+				stats.FatalError = shard.FatalException.Reason
+			}
+		}
+
+		return stats, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,173 @@
+package elasticsearchapi
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestFollowerIndexBuild(t *testing.T) {
+	var (
+		o                *elasticsearchapicrd.FollowerIndex
+		follower         *followerIndex
+		expectedFollower *followerIndex
+		err              error
+		client           *followerIndexApiClient
+	)
+
+	client = &followerIndexApiClient{}
+
+	// With parameters
+	o = &elasticsearchapicrd.FollowerIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.FollowerIndexSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			RemoteCluster: "remote",
+			LeaderIndex:   "logs",
+			Parameters: &elasticsearchapicrd.FollowerIndexParameters{
+				MaxReadRequestOperationCount: ptr.To[int64](1024),
+				ReadPollTimeout:              "30s",
+			},
+		},
+	}
+
+	expectedFollower = &followerIndex{
+		RemoteCluster: "remote",
+		LeaderIndex:   "logs",
+		Parameters: &followerIndexParameters{
+			MaxReadRequestOperationCount: ptr.To[int64](1024),
+			ReadPollTimeout:              "30s",
+		},
+	}
+
+	follower, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedFollower, follower)
+
+	// When paused, the parameters are not returned by Elasticsearch
+	o.Spec.State = elasticsearchapicrd.FollowerIndexStatePaused
+	expectedFollower = &followerIndex{
+		RemoteCluster: "remote",
+		LeaderIndex:   "logs",
+	}
+
+	follower, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedFollower, follower)
+}
+
+func TestFollowerIndexApi(t *testing.T) {
+	var (
+		status string
+		calls  []string
+		body   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		switch r.URL.Path {
+		case "/follower/_ccr/info":
+			_, _ = w.Write([]byte(`{"follower_indices":[{"follower_index":"follower","remote_cluster":"remote","leader_index":"logs","status":"` + status + `","parameters":{"max_read_request_operation_count":5120,"read_poll_timeout":"1m"}}]}`))
+			return
+		case "/follower/_ccr/stats":
+			_, _ = w.Write([]byte(`{"indices":[{"index":"follower","shards":[{"shard_id":0,"leader_global_checkpoint":100,"follower_global_checkpoint":90,"time_since_last_read_millis":200},{"shard_id":1,"leader_global_checkpoint":50,"follower_global_checkpoint":50,"time_since_last_read_millis":1000}]}]}`))
+			return
+		case "/missing/_ccr/info":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"type":"index_not_found_exception"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+
+	// Get info
+	status = "active"
+	info, err := followerIndexGetInfo(mockES, "follower")
+	assert.NoError(t, err)
+	assert.Equal(t, "active", info.Status)
+	assert.Equal(t, followerIndex{
+		RemoteCluster: "remote",
+		LeaderIndex:   "logs",
+		Parameters: &followerIndexParameters{
+			MaxReadRequestOperationCount: ptr.To[int64](5120),
+			ReadPollTimeout:              "1m",
+		},
+	}, info.followerIndex)
+
+	// Get info when not exist
+	info, err = followerIndexGetInfo(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	// Follow
+	err = followerIndexFollow(mockES, "follower", &followerIndex{
+		RemoteCluster: "remote",
+		LeaderIndex:   "logs",
+		Parameters: &followerIndexParameters{
+			ReadPollTimeout: "30s",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "PUT /follower/_ccr/follow", calls[len(calls)-1])
+	assert.JSONEq(t, `{"remote_cluster":"remote","leader_index":"logs","read_poll_timeout":"30s"}`, body)
+
+	// Resume with parameters
+	err = followerIndexResume(mockES, "follower", &followerIndexParameters{ReadPollTimeout: "30s"})
+	assert.NoError(t, err)
+	assert.Equal(t, "POST /follower/_ccr/resume_follow", calls[len(calls)-1])
+	assert.JSONEq(t, `{"read_poll_timeout":"30s"}`, body)
+
+	// Unfollow pause, close, unfollow then open the index
+	calls = nil
+	err = followerIndexUnfollow(mockES, "follower")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"GET /follower/_ccr/info",
+		"POST /follower/_ccr/pause_follow",
+		"POST /follower/_close",
+		"POST /follower/_ccr/unfollow",
+		"POST /follower/_open",
+	}, calls)
+
+	// Unfollow when already paused
+	status = "paused"
+	calls = nil
+	err = followerIndexUnfollow(mockES, "follower")
+	assert.NoError(t, err)
+	assert.NotContains(t, calls, "POST /follower/_ccr/pause_follow")
+
+	// Unfollow when not exist
+	calls = nil
+	err = followerIndexUnfollow(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET /missing/_ccr/info"}, calls)
+
+	// Get stats
+	stats, err := followerIndexGetStats(mockES, "follower")
+	assert.NoError(t, err)
+	assert.Equal(t, &followerIndexStats{
+		OperationsBehind:        10,
+		TimeSinceLastReadMillis: 1000,
+	}, stats)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2022.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearchapi
+
+import (
+	"context"
+
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/sirupsen/logrus"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	followerIndexName string = "followerIndex"
+)
+
+// FollowerIndexReconciler reconciles a FollowerIndex object
+type FollowerIndexReconciler struct {
+	controller.Controller
+	remote.RemoteReconciler[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler]
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler]
+	name string
+}
+
+func NewFollowerIndexReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder) controller.Controller {
+	return &FollowerIndexReconciler{
+		Controller: controller.NewController(),
+		RemoteReconciler: remote.NewRemoteReconciler[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler](
+			client,
+			followerIndexName,
+			"followerindex.elasticsearchapi.k8s.webcenter.fr/finalizer",
+			logger,
+			recorder,
+		),
+		RemoteReconcilerAction: common.NewRemoteReconcilerAction(
+			elasticsearchapicrd.ElasticsearchApiAnnotationKey,
+			newFollowerIndexReconciler(
+				followerIndexName,
+				client,
+				recorder,
+			),
+		),
+		name: followerIndexName,
+	}
+}
+
+//+kubebuilder:rbac:groups=elasticsearchapi.k8s.webcenter.fr,resources=followerindices,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elasticsearchapi.k8s.webcenter.fr,resources=followerindices/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elasticsearchapi.k8s.webcenter.fr,resources=followerindices/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
+//+kubebuilder:rbac:groups="elasticsearch.k8s.webcenter.fr",resources=elasticsearches,verbs=get
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// TODO(user): Modify the Reconcile function to compare the state specified by
+// the License object against the actual cluster state, and then
+// perform operations to make the cluster state reflect the state specified by
+// the user.
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *FollowerIndexReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	sr := &elasticsearchapicrd.FollowerIndex{}
+	data := map[string]any{}
+
+	return r.RemoteReconciler.Reconcile(
+		ctx,
+		req,
+		sr,
+		data,
+		r,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *FollowerIndexReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&elasticsearchapicrd.FollowerIndex{}).
+		WithOptions(k8scontroller.Options{
+			RateLimiter: controller.DefaultControllerRateLimiter[reconcile.Request](),
+		}).
+		Complete(r)
+}
+
+func (h *FollowerIndexReconciler) Client() client.Client {
+	return h.RemoteReconcilerAction.Client()
+}
+
+func (h *FollowerIndexReconciler) Recorder() record.EventRecorder {
+	return h.RemoteReconcilerAction.Recorder()
+}
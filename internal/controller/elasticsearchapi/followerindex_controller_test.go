@@ -0,0 +1,304 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (t *ElasticsearchapiControllerTestSuite) TestFollowerIndexReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-followerindex-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase[*elasticsearchapicrd.FollowerIndex](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchapicrd.FollowerIndex]{
+		doCreateFollowerIndexStep(),
+		doUpdateFollowerIndexStep(),
+		doPauseFollowerIndexStep(),
+		doDeleteFollowerIndexStep(),
+	}
+	testCase.PreTest = doMockFollowerIndex(t.fakeElasticsearchMux, key.Name)
+
+	testCase.Run()
+}
+
+func doMockFollowerIndex(mux *http.ServeMux, name string) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		var mutex sync.Mutex
+		isExist := false
+		status := "active"
+		parameters := map[string]any{}
+
+		mux.HandleFunc("/"+name+"/", func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/_ccr/info"):
+				if !isExist {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"error":{"type":"index_not_found_exception"}}`))
+					return
+				}
+				info := map[string]any{
+					"follower_index": name,
+					"remote_cluster": "remote",
+					"leader_index":   "logs",
+					"status":         status,
+				}
+				if status == "active" {
+					info["parameters"] = parameters
+				}
+				b, _ := json.Marshal(map[string]any{"follower_indices": []any{info}})
+				_, _ = w.Write(b)
+				return
+			case strings.HasSuffix(r.URL.Path, "/_ccr/stats"):
+				_, _ = w.Write([]byte(`{"indices":[{"index":"` + name + `","shards":[{"shard_id":0,"leader_global_checkpoint":100,"follower_global_checkpoint":90,"time_since_last_read_millis":200}]}]}`))
+				return
+			case strings.HasSuffix(r.URL.Path, "/_ccr/follow"):
+				body, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(body, &parameters)
+				delete(parameters, "remote_cluster")
+				delete(parameters, "leader_index")
+				isExist = true
+				status = "active"
+				data["isCreated"] = true
+			case strings.HasSuffix(r.URL.Path, "/_ccr/pause_follow"):
+				status = "paused"
+				if *stepName == "pause" {
+					data["isPaused"] = true
+				}
+			case strings.HasSuffix(r.URL.Path, "/_ccr/resume_follow"):
+				body, _ := io.ReadAll(r.Body)
+				parameters = map[string]any{}
+				_ = json.Unmarshal(body, &parameters)
+				status = "active"
+				if *stepName == "update" {
+					data["isUpdated"] = true
+				}
+			case strings.HasSuffix(r.URL.Path, "/_ccr/unfollow"):
+				isExist = false
+				data["isDeleted"] = true
+			}
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		})
+
+		return nil
+	}
+}
+
+func doCreateFollowerIndexStep() test.TestStep[*elasticsearchapicrd.FollowerIndex] {
+	return test.TestStep[*elasticsearchapicrd.FollowerIndex]{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.FollowerIndex, data map[string]any) (err error) {
+			logrus.Infof("=== Add new follower index %s/%s ===\n\n", key.Namespace, key.Name)
+
+			followerIndex := &elasticsearchapicrd.FollowerIndex{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchapicrd.FollowerIndexSpec{
+					ElasticsearchRef: shared.ElasticsearchRef{
+						ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+							Name: "test",
+						},
+					},
+					RemoteCluster: "remote",
+					LeaderIndex:   "logs",
+				},
+			}
+			if err = c.Create(context.Background(), followerIndex); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.FollowerIndex, data map[string]any) (err error) {
+			followerIndex := &elasticsearchapicrd.FollowerIndex{}
+			isCreated := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, followerIndex); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated || followerIndex.GetStatus().GetObservedGeneration() == 0 {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Follower index: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(followerIndex.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *followerIndex.Status.IsSync)
+			assert.Equal(t, "active", followerIndex.Status.State)
+			assert.Equal(t, int64(10), followerIndex.Status.OperationsBehind)
+			assert.NotNil(t, followerIndex.Status.LastReadTime)
+
+			return nil
+		},
+	}
+}
+
+func doUpdateFollowerIndexStep() test.TestStep[*elasticsearchapicrd.FollowerIndex] {
+	return test.TestStep[*elasticsearchapicrd.FollowerIndex]{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.FollowerIndex, data map[string]any) (err error) {
+			logrus.Infof("=== Update follower index %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Follower index is null")
+			}
+
+			data["lastGeneration"] = o.GetStatus().GetObservedGeneration()
+			o.Spec.Parameters = &elasticsearchapicrd.FollowerIndexParameters{
+				MaxReadRequestOperationCount: ptr.To[int64](1024),
+			}
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.FollowerIndex, data map[string]any) (err error) {
+			followerIndex := &elasticsearchapicrd.FollowerIndex{}
+			isUpdated := false
+
+			lastGeneration := data["lastGeneration"].(int64)
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, followerIndex); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated || lastGeneration == followerIndex.GetStatus().GetObservedGeneration() {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Follower index: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(followerIndex.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *followerIndex.Status.IsSync)
+			assert.Equal(t, "active", followerIndex.Status.State)
+
+			return nil
+		},
+	}
+}
+
+func doPauseFollowerIndexStep() test.TestStep[*elasticsearchapicrd.FollowerIndex] {
+	return test.TestStep[*elasticsearchapicrd.FollowerIndex]{
+		Name: "pause",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.FollowerIndex, data map[string]any) (err error) {
+			logrus.Infof("=== Pause follower index %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Follower index is null")
+			}
+
+			o.Spec.State = elasticsearchapicrd.FollowerIndexStatePaused
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.FollowerIndex, data map[string]any) (err error) {
+			followerIndex := &elasticsearchapicrd.FollowerIndex{}
+			isPaused := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, followerIndex); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isPaused"]; ok {
+					isPaused = b.(bool)
+				}
+				if !isPaused || followerIndex.Status.State != "paused" {
+					return errors.New("Not yet paused")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Follower index: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(followerIndex.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *followerIndex.Status.IsSync)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteFollowerIndexStep() test.TestStep[*elasticsearchapicrd.FollowerIndex] {
+	return test.TestStep[*elasticsearchapicrd.FollowerIndex]{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.FollowerIndex, data map[string]any) (err error) {
+			logrus.Infof("=== Delete follower index %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Follower index is null")
+			}
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), o, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.FollowerIndex, data map[string]any) (err error) {
+			followerIndex := &elasticsearchapicrd.FollowerIndex{}
+			isDeleted := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, followerIndex); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Follower index stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+			assert.Equal(t, true, data["isDeleted"])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/sirupsen/logrus"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	followerIndexStatusActive = "active"
+	followerIndexStatusPaused = "paused"
+
+	followerIndexStatsRefreshInterval = 1 * time.Minute
+)
+
+type followerIndexReconciler struct {
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler]
+	name string
+}
+
+func newFollowerIndexReconciler(name string, client client.Client, recorder record.EventRecorder) remote.RemoteReconcilerAction[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler] {
+	return &followerIndexReconciler{
+		RemoteReconcilerAction: remote.NewRemoteReconcilerAction[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler](
+			client,
+			recorder,
+		),
+		name: name,
+	}
+}
+
+func (h *followerIndexReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.FollowerIndex, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+	esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, h.Client(), logger)
+	if err != nil && o.DeletionTimestamp.IsZero() {
+		return nil, res, err
+	}
+
+	// Elastic not ready
+	if esClient == nil {
+		if o.DeletionTimestamp.IsZero() {
+			return nil, reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+		}
+
+		return nil, res, nil
+	}
+
+	handler = newFollowerIndexApiClient(esClient)
+
+	return handler, res, nil
+}
+
+// OnSuccess pause or resume the replication, then read the replication lag
+func (h *followerIndexReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.FollowerIndex, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*followerIndex], logger *logrus.Entry) (res reconcile.Result, err error) {
+	info, err := followerIndexGetInfo(handler.Client(), o.GetExternalName())
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get follower index")
+	}
+
+	// The follower index not exist when the dry-run annotation is set before create it
+	if info == nil {
+		if o.GetDryRunStatus() != nil {
+			return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+		}
+		return res, errors.Errorf("Follower index %s not found", o.GetExternalName())
+	}
+
+	// Manage the replication lifecycle
+	if o.GetDryRunStatus() == nil {
+		isStateChanged := false
+		switch o.GetExpectedState() {
+		case elasticsearchapicrd.FollowerIndexStateActive:
+			if info.Status == followerIndexStatusPaused {
+				expected, err := handler.Build(o)
+				if err != nil {
+					return res, errors.Wrap(err, "Error when build follower index")
+				}
+				if err = followerIndexResume(handler.Client(), o.GetExternalName(), expected.Parameters); err != nil {
+					return res, errors.Wrap(err, "Error when resume follower index")
+				}
+				isStateChanged = true
+				logger.Infof("Follower index %s successfully resumed", o.GetExternalName())
+				h.Recorder().Eventf(o, corev1.EventTypeNormal, "ResumeCompleted", "Follower index %s successfully resumed", o.GetExternalName())
+			}
+		case elasticsearchapicrd.FollowerIndexStatePaused:
+			if info.Status == followerIndexStatusActive {
+				if err = followerIndexPause(handler.Client(), o.GetExternalName()); err != nil {
+					return res, errors.Wrap(err, "Error when pause follower index")
+				}
+				isStateChanged = true
+				logger.Infof("Follower index %s successfully paused", o.GetExternalName())
+				h.Recorder().Eventf(o, corev1.EventTypeNormal, "PauseCompleted", "Follower index %s successfully paused", o.GetExternalName())
+			}
+		}
+
+		if isStateChanged {
+			if info, err = followerIndexGetInfo(handler.Client(), o.GetExternalName()); err != nil {
+				return res, errors.Wrap(err, "Error when get follower index")
+			}
+			if info == nil {
+				return res, errors.Errorf("Follower index %s not found", o.GetExternalName())
+			}
+		}
+	}
+	o.Status.State = info.Status
+
+	// The stats are only available when the replication is active
+	if info.Status == followerIndexStatusActive {
+		stats, err := followerIndexGetStats(handler.Client(), o.GetExternalName())
+		if err != nil {
+			return res, errors.Wrap(err, "Error when get follower index stats")
+		}
+		if stats != nil {
+			if stats.FatalError != "" && stats.FatalError != o.Status.FatalError {
+				h.Recorder().Eventf(o, corev1.EventTypeWarning, "ReplicationFailed", "Replication of follower index %s failed: %s", o.GetExternalName(), stats.FatalError)
+			}
+			o.Status.OperationsBehind = stats.OperationsBehind
+			o.Status.LastReadTime = &metav1.Time{Time: time.Now().Add(-time.Duration(stats.TimeSinceLastReadMillis) * time.Millisecond)}
+			o.Status.FatalError = stats.FatalError
+		}
+	}
+
+	if res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger); err != nil {
+		return res, err
+	}
+
+	// Refresh periodically the lag when the replication run
+	if info.Status == followerIndexStatusActive && (res.RequeueAfter == 0 || res.RequeueAfter > followerIndexStatsRefreshInterval) {
+		res.RequeueAfter = followerIndexStatsRefreshInterval
+	}
+
+	return res, nil
+}
@@ -13,13 +13,16 @@ import (
 	"github.com/elastic/elastic-transport-go/v8/elastictransport"
 	elastic "github.com/elastic/go-elasticsearch/v8"
 	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
 	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 	elasticsearchcontrollers "github.com/webcenter-fr/elasticsearch-operator/internal/controller/elasticsearch"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -132,3 +135,66 @@ func GetElasticsearchHandler(ctx context.Context, o client.Object, esRef shared.
 func GetUserSecretWhenAutoGeneratePassword(user *elasticsearchapicrd.User) string {
 	return fmt.Sprintf("%s-credential-es", user.Name)
 }
+
+// GetRemoteClusterApiKeySecretName return the secret name that store the cross-cluster API key created by operator
+func GetRemoteClusterApiKeySecretName(o *elasticsearchapicrd.RemoteCluster) string {
+	return fmt.Sprintf("%s-api-key-es", o.Name)
+}
+
+// updateElasticsearchSecret set the keys on a secret owned by the managed Elasticsearch, like the keys to inject on its keystore
+// The key is removed when the value is nil
+// It return true if the secret has been changed
+func updateElasticsearchSecret(ctx context.Context, c client.Client, es *elasticsearchcrd.Elasticsearch, secretName string, expectedData map[string][]byte) (isUpdated bool, err error) {
+	secret := &core.Secret{}
+	isNew := false
+	if err = c.Get(ctx, types.NamespacedName{Namespace: es.Namespace, Name: secretName}, secret); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, "Error when get secret %s", secretName)
+		}
+		isNew = true
+		secret = &core.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: es.Namespace,
+			},
+		}
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	for key, value := range expectedData {
+		if value == nil {
+			if _, ok := secret.Data[key]; ok {
+				delete(secret.Data, key)
+				isUpdated = true
+			}
+			continue
+		}
+		if string(secret.Data[key]) != string(value) {
+			secret.Data[key] = value
+			isUpdated = true
+		}
+	}
+
+	if !isUpdated {
+		return false, nil
+	}
+
+	if isNew {
+		// Elasticsearch own the secret, so it's reconciled when the secret change
+		if err = ctrl.SetControllerReference(es, secret, c.Scheme()); err != nil {
+			return false, errors.Wrapf(err, "Error when set owner reference on object '%s'", secret.GetName())
+		}
+		if err = c.Create(ctx, secret); err != nil {
+			return false, errors.Wrapf(err, "Error when create secret %s", secret.Name)
+		}
+		return true, nil
+	}
+
+	if err = c.Update(ctx, secret); err != nil {
+		return false, errors.Wrapf(err, "Error when update secret %s", secret.Name)
+	}
+
+	return true, nil
+}
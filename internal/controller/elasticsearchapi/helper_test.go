@@ -1,8 +1,12 @@
 package elasticsearchapi
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	elastic "github.com/elastic/go-elasticsearch/v8"
+
 	"github.com/stretchr/testify/assert"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,3 +23,22 @@ func TestGetUserSecretWhenAutoGeneratePassword(t *testing.T) {
 
 	assert.Equal(t, "test-credential-es", GetUserSecretWhenAutoGeneratePassword(u))
 }
+
+// newFakeElasticsearchClient start an HTTP stand-in of Elasticsearch and return a client that use it
+func newFakeElasticsearchClient(t *testing.T, handler http.HandlerFunc) *elastic.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := elastic.NewClient(elastic.Config{
+		Addresses: []string{server.URL},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client
+}
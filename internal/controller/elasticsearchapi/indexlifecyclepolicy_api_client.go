@@ -1,7 +1,12 @@
 package elasticsearchapi
 
 import (
+	"context"
 	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
 
 	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
@@ -12,6 +17,36 @@ import (
 	"sigs.k8s.io/yaml"
 )
 
+const (
+	// ilmExplainTarget is the indices explained to find the indices on error, with the hidden backing indices of data streams
+	ilmExplainTarget = "*,.ds-*"
+)
+
+// ilmPolicyUsageResponse is the objects that use the policy returned by _ilm/policy
+type ilmPolicyUsageResponse struct {
+	InUseBy struct {
+		Indices             []string `json:"indices"`
+		DataStreams         []string `json:"data_streams"`
+		ComposableTemplates []string `json:"composable_templates"`
+	} `json:"in_use_by"`
+}
+
+// ilmExplainResponse is the response of _ilm/explain
+type ilmExplainResponse struct {
+	Indices map[string]struct {
+		Index      string `json:"index"`
+		Policy     string `json:"policy"`
+		Phase      string `json:"phase"`
+		Action     string `json:"action"`
+		Step       string `json:"step"`
+		FailedStep string `json:"failed_step"`
+		StepInfo   *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"step_info,omitempty"`
+	} `json:"indices"`
+}
+
 type indexLifecyclePolicyApiClient struct {
 	remote.RemoteExternalReconciler[*elasticsearchapicrd.IndexLifecyclePolicy, *olivere.XPackIlmGetLifecycleResponse, eshandler.ElasticsearchHandler]
 }
@@ -29,8 +64,29 @@ func (h *indexLifecyclePolicyApiClient) Build(o *elasticsearchapicrd.IndexLifecy
 			return nil, errors.Wrap(err, "Unable to convert expected policy to object")
 		}
 	} else {
+		// The typed actions are merged with the raw actions on the actions of each phase
+		var expectedPolicy map[string]any
+		if o.Spec.Policy != nil {
+			phases := map[string]any{}
+			for name, phase := range o.Spec.Policy.Phases.GetPhases() {
+				expectedPhase := map[string]any{
+					"actions": phase.GetActions(),
+				}
+				if phase.MinAge != nil {
+					expectedPhase["min_age"] = *phase.MinAge
+				}
+				phases[name] = expectedPhase
+			}
+			expectedPolicy = map[string]any{
+				"phases": phases,
+			}
+			if o.Spec.Policy.Meta != nil {
+				expectedPolicy["_meta"] = o.Spec.Policy.Meta
+			}
+		}
+
 		policy := map[string]any{}
-		b, err := yaml.Marshal(o.Spec.Policy)
+		b, err := yaml.Marshal(expectedPolicy)
 		if err != nil {
 			return nil, errors.Wrap(err, "Error when marshal policy")
 		}
@@ -64,3 +120,122 @@ func (h *indexLifecyclePolicyApiClient) Delete(o *elasticsearchapicrd.IndexLifec
 func (h *indexLifecyclePolicyApiClient) Diff(currentOject *olivere.XPackIlmGetLifecycleResponse, expectedObject *olivere.XPackIlmGetLifecycleResponse, originalObject *olivere.XPackIlmGetLifecycleResponse, o *elasticsearchapicrd.IndexLifecyclePolicy, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
 	return h.Client().ILMDiff(currentOject, expectedObject, originalObject)
 }
+
+// ilmGetUsage permit to get the index templates, the data streams and the indices that use the policy
+// It return nil if the policy not exist
+func ilmGetUsage(client eshandler.ElasticsearchHandler, name string) (usage *ilmPolicyUsageResponse, err error) {
+	api := client.Client().API
+	res, err := api.ILM.GetLifecycle(
+		api.ILM.GetLifecycle.WithContext(context.Background()),
+		api.ILM.GetLifecycle.WithPolicy(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, errors.Errorf("Error when get usage of ILM policy %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := map[string]*ilmPolicyUsageResponse{}
+	if err = json.Unmarshal(b, &resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode usage of ILM policy %s", name)
+	}
+
+	return resp[name], nil
+}
+
+// ilmGetFailedIndices permit to get the indices managed by the policy that are stuck on ERROR step
+func ilmGetFailedIndices(client eshandler.ElasticsearchHandler, name string) (failedIndices []elasticsearchapicrd.IndexLifecyclePolicyFailedIndex, err error) {
+	api := client.Client().API
+	res, err := api.ILM.ExplainLifecycle(
+		ilmExplainTarget,
+		api.ILM.ExplainLifecycle.WithContext(context.Background()),
+		api.ILM.ExplainLifecycle.WithOnlyErrors(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when explain indices of ILM policy %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &ilmExplainResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode explain of ILM policy %s", name)
+	}
+
+	failedIndices = make([]elasticsearchapicrd.IndexLifecyclePolicyFailedIndex, 0)
+	for indexName, index := range resp.Indices {
+		if index.Policy != name || index.Step != "ERROR" {
+			continue
+		}
+		failedIndex := elasticsearchapicrd.IndexLifecyclePolicyFailedIndex{
+			Index:      indexName,
+			Phase:      index.Phase,
+			Action:     index.Action,
+			FailedStep: index.FailedStep,
+		}
+		if index.StepInfo != nil {
+			failedIndex.Reason = index.StepInfo.Reason
+		}
+		failedIndices = append(failedIndices, failedIndex)
+	}
+	sort.Slice(failedIndices, func(i, j int) bool {
+		return failedIndices[i].Index < failedIndices[j].Index
+	})
+
+	return failedIndices, nil
+}
+
+// ilmRetry permit to retry the failed step of indices
+func ilmRetry(client eshandler.ElasticsearchHandler, indices []string) (err error) {
+	api := client.Client().API
+	res, err := api.ILM.Retry(
+		strings.Join(indices, ","),
+		api.ILM.Retry.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when retry ILM on indices %s: %s", strings.Join(indices, ","), res.String())
+	}
+
+	return nil
+}
+
+// ilmRemovePolicy permit to remove the policy from indices, so it can be deleted
+func ilmRemovePolicy(client eshandler.ElasticsearchHandler, indices []string) (err error) {
+	api := client.Client().API
+	res, err := api.ILM.RemovePolicy(
+		strings.Join(indices, ","),
+		api.ILM.RemovePolicy.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when remove ILM policy from indices %s: %s", strings.Join(indices, ","), res.String())
+	}
+
+	return nil
+}
@@ -1,13 +1,16 @@
 package elasticsearchapi
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/disaster37/es-handler/v8/mocks"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/assert"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
@@ -88,4 +91,148 @@ func TestIndexLifecyclePolicyBuild(t *testing.T) {
 	ilm, err = client.Build(o)
 	assert.NoError(t, err)
 	assert.Equal(t, expectedIlm, ilm)
+
+	// With typed actions
+	o = &elasticsearchapicrd.IndexLifecyclePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.IndexLifecyclePolicySpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Policy: &elasticsearchapicrd.IndexLifecyclePolicySpecPolicy{
+				Phases: elasticsearchapicrd.IndexLifecyclePolicySpecPolicyPhases{
+					Hot: &elasticsearchapicrd.IndexLifecyclePolicySpecPolicyPhasesPhase{
+						Rollover: &elasticsearchapicrd.IndexLifecyclePolicyRolloverAction{
+							MaxAge:              ptr.To("1d"),
+							MaxPrimaryShardSize: ptr.To("50gb"),
+						},
+						Actions: apis.MapAny{
+							Data: map[string]any{
+								"set_priority": map[string]any{
+									"priority": 100,
+								},
+							},
+						},
+					},
+					Delete: &elasticsearchapicrd.IndexLifecyclePolicySpecPolicyPhasesPhase{
+						MinAge: ptr.To("30d"),
+						Delete: &elasticsearchapicrd.IndexLifecyclePolicyDeleteAction{},
+					},
+				},
+			},
+		},
+	}
+
+	expectedIlm = &olivere.XPackIlmGetLifecycleResponse{
+		Policy: map[string]any{
+			"phases": map[string]any{
+				"hot": map[string]any{
+					"actions": map[string]any{
+						"rollover": map[string]any{
+							"max_age":                "1d",
+							"max_primary_shard_size": "50gb",
+						},
+						"set_priority": map[string]any{
+							"priority": float64(100),
+						},
+					},
+				},
+				"delete": map[string]any{
+					"min_age": "30d",
+					"actions": map[string]any{
+						"delete": map[string]any{},
+					},
+				},
+			},
+		},
+	}
+
+	ilm, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedIlm, ilm)
+}
+
+func TestIndexLifecyclePolicyApi(t *testing.T) {
+	var (
+		method string
+		path   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+
+		switch r.URL.Path {
+		case "/_ilm/policy/logs":
+			_, _ = w.Write([]byte(`{"logs": {"version": 1, "policy": {"phases": {}}, "in_use_by": {"indices": ["logs-000002", "logs-000001"], "data_streams": ["logs-app"], "composable_templates": ["logs"]}}}`))
+		case "/*,.ds-*/_ilm/explain":
+			_, _ = w.Write([]byte(`{"indices": {"logs-000002": {"index": "logs-000002", "managed": true, "policy": "logs", "phase": "warm", "action": "shrink", "step": "ERROR", "failed_step": "shrink", "step_info": {"type": "illegal_argument_exception", "reason": "not enough nodes"}}, "logs-000001": {"index": "logs-000001", "managed": true, "policy": "logs", "phase": "hot", "action": "rollover", "step": "ERROR", "failed_step": "check-rollover-ready"}, "other-000001": {"index": "other-000001", "managed": true, "policy": "other", "phase": "hot", "action": "rollover", "step": "ERROR"}}}`))
+		case "/logs-000001,logs-000002/_ilm/retry":
+			_, _ = w.Write([]byte(`{"acknowledged": true}`))
+		case "/logs-000001,logs-000002/_ilm/remove":
+			_, _ = w.Write([]byte(`{"has_failures": false, "failed_indexes": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"type": "resource_not_found_exception", "reason": "Lifecycle policy not found: missing"}, "status": 404}`))
+		}
+	}))
+
+	// Get usage
+	usage, err := ilmGetUsage(mockES, "logs")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, method)
+	assert.Equal(t, "/_ilm/policy/logs", path)
+	assert.Equal(t, []string{"logs-000002", "logs-000001"}, usage.InUseBy.Indices)
+	assert.Equal(t, []string{"logs-app"}, usage.InUseBy.DataStreams)
+	assert.Equal(t, []string{"logs"}, usage.InUseBy.ComposableTemplates)
+
+	// Get usage when policy not exist
+	usage, err = ilmGetUsage(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, usage)
+
+	// Get failed indices
+	failedIndices, err := ilmGetFailedIndices(mockES, "logs")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, method)
+	assert.Equal(t, "/*,.ds-*/_ilm/explain", path)
+	assert.Equal(t, []elasticsearchapicrd.IndexLifecyclePolicyFailedIndex{
+		{
+			Index:      "logs-000001",
+			Phase:      "hot",
+			Action:     "rollover",
+			FailedStep: "check-rollover-ready",
+		},
+		{
+			Index:      "logs-000002",
+			Phase:      "warm",
+			Action:     "shrink",
+			FailedStep: "shrink",
+			Reason:     "not enough nodes",
+		},
+	}, failedIndices)
+
+	// Retry
+	err = ilmRetry(mockES, []string{"logs-000001", "logs-000002"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/logs-000001,logs-000002/_ilm/retry", path)
+
+	// Retry when index not exist
+	err = ilmRetry(mockES, []string{"missing"})
+	assert.Error(t, err)
+
+	// Remove policy
+	err = ilmRemovePolicy(mockES, []string{"logs-000001", "logs-000002"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/logs-000001,logs-000002/_ilm/remove", path)
 }
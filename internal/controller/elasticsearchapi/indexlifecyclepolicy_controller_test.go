@@ -3,6 +3,7 @@ package elasticsearchapi
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
@@ -38,18 +39,34 @@ func (t *ElasticsearchapiControllerTestSuite) TestIndexLifecyclePolicyReconciler
 	testCase.Steps = []test.TestStep[*elasticsearchapicrd.IndexLifecyclePolicy]{
 		doCreateILMStep(),
 		doUpdateILMStep(),
+		doRetryILMStep(),
 		doDeleteILMStep(),
 	}
-	testCase.PreTest = doMockILM(t.mockElasticsearchHandler)
+	testCase.PreTest = doMockILM(t.mockElasticsearchHandler, t.fakeElasticsearchMux, key.Name)
 
 	testCase.Run()
 }
 
-func doMockILM(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+func doMockILM(mockES *mocks.MockElasticsearchHandler, mux *http.ServeMux, name string) func(stepName *string, data map[string]any) error {
 	return func(stepName *string, data map[string]any) (err error) {
 		isCreated := false
 		isUpdated := false
 
+		mux.HandleFunc("/_ilm/policy/"+name, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"` + name + `": {"version": 1, "in_use_by": {"indices": ["logs-000001"], "data_streams": [], "composable_templates": ["logs"]}}}`))
+		})
+		mux.HandleFunc("/*,.ds-*/_ilm/explain", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"indices": {"logs-000001": {"index": "logs-000001", "managed": true, "policy": "` + name + `", "phase": "warm", "action": "forcemerge", "step": "ERROR", "failed_step": "forcemerge", "step_info": {"type": "illegal_argument_exception", "reason": "forcemerge failed"}}}}`))
+		})
+		mux.HandleFunc("/logs-000001/_ilm/remove", func(w http.ResponseWriter, r *http.Request) {
+			data["isRemoved"] = true
+			_, _ = w.Write([]byte(`{"has_failures": false, "failed_indexes": []}`))
+		})
+		mux.HandleFunc("/logs-000001/_ilm/retry", func(w http.ResponseWriter, r *http.Request) {
+			data["isRetried"] = true
+			_, _ = w.Write([]byte(`{"acknowledged": true}`))
+		})
+
 		mockES.EXPECT().ILMGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*olivere.XPackIlmGetLifecycleResponse, error) {
 			switch *stepName {
 			case "create":
@@ -267,6 +284,20 @@ func doCreateILMStep() test.TestStep[*elasticsearchapicrd.IndexLifecyclePolicy]
 			}
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(ilm.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
 			assert.True(t, *ilm.Status.IsSync)
+			assert.Equal(t, &elasticsearchapicrd.IndexLifecyclePolicyUsage{
+				IndicesCount:        1,
+				Indices:             []string{"logs-000001"},
+				ComposableTemplates: []string{"logs"},
+			}, ilm.Status.InUseBy)
+			assert.Equal(t, []elasticsearchapicrd.IndexLifecyclePolicyFailedIndex{
+				{
+					Index:      "logs-000001",
+					Phase:      "warm",
+					Action:     "forcemerge",
+					FailedStep: "forcemerge",
+					Reason:     "forcemerge failed",
+				},
+			}, ilm.Status.FailedIndices)
 
 			return nil
 		},
@@ -344,6 +375,50 @@ func doUpdateILMStep() test.TestStep[*elasticsearchapicrd.IndexLifecyclePolicy]
 	}
 }
 
+func doRetryILMStep() test.TestStep[*elasticsearchapicrd.IndexLifecyclePolicy] {
+	return test.TestStep[*elasticsearchapicrd.IndexLifecyclePolicy]{
+		Name: "retry",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.IndexLifecyclePolicy, data map[string]any) (err error) {
+			logrus.Infof("=== Retry failed indices of ILM policy %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("ILM is null")
+			}
+
+			if o.Annotations == nil {
+				o.Annotations = map[string]string{}
+			}
+			o.Annotations[ilmRetryAnnotation] = "true"
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.IndexLifecyclePolicy, data map[string]any) error {
+			ilm := &elasticsearchapicrd.IndexLifecyclePolicy{}
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, ilm); err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := data["isRetried"]; !ok || ilm.Status.LastRetryTime == nil {
+					return errors.New("Not yet retried")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+
+			if err != nil || isTimeout {
+				return errors.Wrapf(err, "Failed to retry ILM")
+			}
+			_, ok := ilm.Annotations[ilmRetryAnnotation]
+			assert.False(t, ok)
+
+			return nil
+		},
+	}
+}
+
 func doDeleteILMStep() test.TestStep[*elasticsearchapicrd.IndexLifecyclePolicy] {
 	return test.TestStep[*elasticsearchapicrd.IndexLifecyclePolicy]{
 		Name: "delete",
@@ -365,6 +440,22 @@ func doDeleteILMStep() test.TestStep[*elasticsearchapicrd.IndexLifecyclePolicy]
 			ilm := &elasticsearchapicrd.IndexLifecyclePolicy{}
 			isDeleted := false
 
+			// The policy is in use, so it's not deleted without the force-delete annotation
+			time.Sleep(5 * time.Second)
+			if err = c.Get(context.Background(), key, ilm); err != nil {
+				t.Fatal(err)
+			}
+			assert.False(t, ilm.DeletionTimestamp.IsZero())
+			assert.NotContains(t, data, "isDeleted")
+
+			patch := client.MergeFrom(ilm.DeepCopy())
+			ilm.Annotations = map[string]string{
+				elasticsearchapicrd.IndexLifecyclePolicyForceDeleteAnnotation: "true",
+			}
+			if err = c.Patch(context.Background(), ilm, patch); err != nil {
+				t.Fatal(err)
+			}
+
 			isTimeout, err := test.RunWithTimeout(func() error {
 				if err = c.Get(context.Background(), key, ilm); err != nil {
 					if k8serrors.IsNotFound(err) {
@@ -381,6 +472,7 @@ func doDeleteILMStep() test.TestStep[*elasticsearchapicrd.IndexLifecyclePolicy]
 				return errors.Wrapf(err, "ILM not deleted")
 			}
 			assert.True(t, isDeleted)
+			assert.True(t, data["isRemoved"].(bool))
 
 			return nil
 		},
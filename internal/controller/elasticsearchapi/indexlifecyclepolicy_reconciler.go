@@ -2,18 +2,37 @@ package elasticsearchapi
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
+	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// ilmStatusRefreshInterval is the interval to refresh the usage and the failed indices of the policy
+	ilmStatusRefreshInterval = 5 * time.Minute
+
+	// ilmMaxIndicesOnStatus is the maximum number of indices recorded on status
+	ilmMaxIndicesOnStatus = 100
+)
+
+var (
+	// ilmRetryAnnotation is the annotation that retry the failed step of indices stuck on ERROR step
+	ilmRetryAnnotation = fmt.Sprintf("%s/retry", elasticsearchapicrd.ElasticsearchApiAnnotationKey)
+)
+
 type indexLifecyclePolicyReconciler struct {
 	remote.RemoteReconcilerAction[*elasticsearchapicrd.IndexLifecyclePolicy, *olivere.XPackIlmGetLifecycleResponse, eshandler.ElasticsearchHandler]
 	name string
@@ -48,3 +67,114 @@ func (h *indexLifecyclePolicyReconciler) GetRemoteHandler(ctx context.Context, r
 
 	return handler, res, nil
 }
+
+// OnSuccess retry the failed indices when the retry annotation is set, then read the usage and the failed indices of the policy
+func (h *indexLifecyclePolicyReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.IndexLifecyclePolicy, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.IndexLifecyclePolicy, *olivere.XPackIlmGetLifecycleResponse, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*olivere.XPackIlmGetLifecycleResponse], logger *logrus.Entry) (res reconcile.Result, err error) {
+	// Not retry when the dry-run annotation is set, the policy can be not yet applied
+	if o.GetDryRunStatus() == nil && o.GetAnnotations()[ilmRetryAnnotation] == "true" {
+		if len(o.Status.FailedIndices) > 0 {
+			indices := make([]string, 0, len(o.Status.FailedIndices))
+			for _, failedIndex := range o.Status.FailedIndices {
+				indices = append(indices, failedIndex.Index)
+			}
+			if err = ilmRetry(handler.Client(), indices); err != nil {
+				return res, errors.Wrapf(err, "Error when retry failed indices of ILM policy %s", o.GetExternalName())
+			}
+			o.Status.LastRetryTime = &metav1.Time{Time: time.Now()}
+			logger.Infof("Retry %d failed indices of ILM policy %s", len(indices), o.GetExternalName())
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "Retried", "Retry %d failed indices of ILM policy %s", len(indices), o.GetExternalName())
+		}
+
+		if err = common.RemoveAnnotation(ctx, h.Client(), o, ilmRetryAnnotation); err != nil {
+			return res, err
+		}
+	}
+
+	usage, err := ilmGetUsage(handler.Client(), o.GetExternalName())
+	if err != nil {
+		return res, errors.Wrapf(err, "Error when get usage of ILM policy %s", o.GetExternalName())
+	}
+	setIndexLifecyclePolicyUsage(o, usage)
+
+	failedIndices, err := ilmGetFailedIndices(handler.Client(), o.GetExternalName())
+	if err != nil {
+		return res, errors.Wrapf(err, "Error when get failed indices of ILM policy %s", o.GetExternalName())
+	}
+	h.setFailedIndices(o, failedIndices)
+
+	res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	if err != nil {
+		return res, err
+	}
+
+	// Refresh the usage and the failed indices
+	if !res.Requeue && (res.RequeueAfter == 0 || res.RequeueAfter > ilmStatusRefreshInterval) {
+		res.RequeueAfter = ilmStatusRefreshInterval
+	}
+
+	return res, nil
+}
+
+// Delete refuse to delete the policy while it's in use, so the finalizer is keeped until the policy is no more used or the force-delete annotation is set
+// With the annotation, it remove the policy from the indices that use it, then delete the policy. Without it, Elasticsearch refuse to delete a policy in use
+func (h *indexLifecyclePolicyReconciler) Delete(ctx context.Context, o *elasticsearchapicrd.IndexLifecyclePolicy, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.IndexLifecyclePolicy, *olivere.XPackIlmGetLifecycleResponse, eshandler.ElasticsearchHandler], logger *logrus.Entry) (err error) {
+	if !o.GetDeletionPolicy().IsOrphan() {
+		usage, err := ilmGetUsage(handler.Client(), o.GetExternalName())
+		if err != nil {
+			return errors.Wrapf(err, "Error when get usage of ILM policy %s before delete it", o.GetExternalName())
+		}
+		if usage != nil && !o.IsForceDelete() && (len(usage.InUseBy.Indices) > 0 || len(usage.InUseBy.DataStreams) > 0 || len(usage.InUseBy.ComposableTemplates) > 0) {
+			return errors.Errorf("ILM policy %s is in use by %d indices, %d data streams and %d index templates, set the annotation '%s' to 'true' to remove it from the indices and delete it", o.GetExternalName(), len(usage.InUseBy.Indices), len(usage.InUseBy.DataStreams), len(usage.InUseBy.ComposableTemplates), elasticsearchapicrd.IndexLifecyclePolicyForceDeleteAnnotation)
+		}
+		if usage != nil && len(usage.InUseBy.Indices) > 0 {
+			if err = ilmRemovePolicy(handler.Client(), usage.InUseBy.Indices); err != nil {
+				return errors.Wrapf(err, "Error when remove ILM policy %s from indices before delete it", o.GetExternalName())
+			}
+			logger.Infof("ILM policy %s removed from %d indices before delete it", o.GetExternalName(), len(usage.InUseBy.Indices))
+		}
+	}
+
+	return h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger)
+}
+
+// setFailedIndices set the indices stuck on ERROR step on status
+// It notify when new indices failed
+func (h *indexLifecyclePolicyReconciler) setFailedIndices(o *elasticsearchapicrd.IndexLifecyclePolicy, failedIndices []elasticsearchapicrd.IndexLifecyclePolicyFailedIndex) {
+	knownFailedIndices := make(map[string]struct{}, len(o.Status.FailedIndices))
+	for _, failedIndex := range o.Status.FailedIndices {
+		knownFailedIndices[failedIndex.Index] = struct{}{}
+	}
+	for _, failedIndex := range failedIndices {
+		if _, ok := knownFailedIndices[failedIndex.Index]; !ok {
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "IndexFailed", "Index %s failed on step %s of ILM policy %s: %s", failedIndex.Index, failedIndex.FailedStep, o.GetExternalName(), failedIndex.Reason)
+		}
+	}
+
+	if len(failedIndices) == 0 {
+		o.Status.FailedIndices = nil
+	} else {
+		o.Status.FailedIndices = failedIndices
+	}
+}
+
+// setIndexLifecyclePolicyUsage set the objects that use the policy on status
+func setIndexLifecyclePolicyUsage(o *elasticsearchapicrd.IndexLifecyclePolicy, usage *ilmPolicyUsageResponse) {
+	if usage == nil {
+		o.Status.InUseBy = nil
+		return
+	}
+
+	indices := append([]string(nil), usage.InUseBy.Indices...)
+	sort.Strings(indices)
+	o.Status.InUseBy = &elasticsearchapicrd.IndexLifecyclePolicyUsage{
+		IndicesCount:        len(indices),
+		DataStreams:         usage.InUseBy.DataStreams,
+		ComposableTemplates: usage.InUseBy.ComposableTemplates,
+	}
+	if len(indices) > ilmMaxIndicesOnStatus {
+		indices = indices[:ilmMaxIndicesOnStatus]
+	}
+	if len(indices) > 0 {
+		o.Status.InUseBy.Indices = indices
+	}
+}
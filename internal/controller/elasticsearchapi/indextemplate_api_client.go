@@ -1,8 +1,11 @@
 package elasticsearchapi
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 
+	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/generic-objectmatcher/patch"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
@@ -10,6 +13,19 @@ import (
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 )
 
+// indexTemplateSimulateResponse is the response of _index_template/_simulate
+type indexTemplateSimulateResponse struct {
+	Template struct {
+		Settings map[string]any `json:"settings,omitempty"`
+		Mappings map[string]any `json:"mappings,omitempty"`
+		Aliases  map[string]any `json:"aliases,omitempty"`
+	} `json:"template"`
+	Overlapping []struct {
+		Name          string   `json:"name"`
+		IndexPatterns []string `json:"index_patterns"`
+	} `json:"overlapping,omitempty"`
+}
+
 type indexTemplateApiClient struct {
 	remote.RemoteExternalReconciler[*elasticsearchapicrd.IndexTemplate, *olivere.IndicesGetIndexTemplate, eshandler.ElasticsearchHandler]
 }
@@ -80,3 +96,31 @@ func (h *indexTemplateApiClient) Delete(o *elasticsearchapicrd.IndexTemplate) (e
 func (h *indexTemplateApiClient) Diff(currentOject *olivere.IndicesGetIndexTemplate, expectedObject *olivere.IndicesGetIndexTemplate, originalObject *olivere.IndicesGetIndexTemplate, o *elasticsearchapicrd.IndexTemplate, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
 	return h.Client().IndexTemplateDiff(currentOject, expectedObject, originalObject)
 }
+
+// indexTemplateSimulate permit to get the index template resolved by Elasticsearch with its component templates
+func indexTemplateSimulate(client eshandler.ElasticsearchHandler, name string) (simulation *indexTemplateSimulateResponse, err error) {
+	api := client.Client().API
+	res, err := api.Indices.SimulateTemplate(
+		api.Indices.SimulateTemplate.WithContext(context.Background()),
+		api.Indices.SimulateTemplate.WithName(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when simulate index template %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	simulation = &indexTemplateSimulateResponse{}
+	if err = json.Unmarshal(b, simulation); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode simulation of index template %s", name)
+	}
+
+	return simulation, nil
+}
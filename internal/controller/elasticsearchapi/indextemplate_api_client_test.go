@@ -1,13 +1,16 @@
 package elasticsearchapi
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/disaster37/es-handler/v8/mocks"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/assert"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -117,3 +120,52 @@ func TestIndexTemplateBuild(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedIt, it)
 }
+
+func TestIndexTemplateApi(t *testing.T) {
+	var (
+		method string
+		path   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+
+		switch r.URL.Path {
+		case "/_index_template/_simulate/logs":
+			_, _ = w.Write([]byte(`{"template": {"settings": {"index": {"number_of_shards": "1"}}, "mappings": {"properties": {"message": {"type": "text"}}}, "aliases": {}}, "overlapping": [{"name": "default", "index_patterns": ["*"]}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"type": "resource_not_found_exception", "reason": "index template matching [missing] not found"}, "status": 404}`))
+		}
+	}))
+
+	// Simulate
+	simulation, err := indexTemplateSimulate(mockES, "logs")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/_index_template/_simulate/logs", path)
+	assert.Equal(t, map[string]any{
+		"index": map[string]any{
+			"number_of_shards": "1",
+		},
+	}, simulation.Template.Settings)
+	assert.Equal(t, map[string]any{
+		"properties": map[string]any{
+			"message": map[string]any{
+				"type": "text",
+			},
+		},
+	}, simulation.Template.Mappings)
+	assert.Empty(t, simulation.Template.Aliases)
+	assert.Len(t, simulation.Overlapping, 1)
+	assert.Equal(t, "default", simulation.Overlapping[0].Name)
+	assert.Equal(t, []string{"*"}, simulation.Overlapping[0].IndexPatterns)
+
+	// Simulate when template not exist
+	_, err = indexTemplateSimulate(mockES, "missing")
+	assert.Error(t, err)
+}
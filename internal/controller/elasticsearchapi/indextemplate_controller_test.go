@@ -2,6 +2,7 @@ package elasticsearchapi
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
@@ -38,16 +39,25 @@ func (t *ElasticsearchapiControllerTestSuite) TestIndexTemplateReconciler() {
 		doUpdateIndexTemplateStep(),
 		doDeleteIndexTemplateStep(),
 	}
-	testCase.PreTest = doMockIndexTemplate(t.mockElasticsearchHandler)
+	testCase.PreTest = doMockIndexTemplate(t.mockElasticsearchHandler, t.fakeElasticsearchMux, key.Name)
 
 	testCase.Run()
 }
 
-func doMockIndexTemplate(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+func doMockIndexTemplate(mockES *mocks.MockElasticsearchHandler, mux *http.ServeMux, name string) func(stepName *string, data map[string]any) error {
 	return func(stepName *string, data map[string]any) (err error) {
 		isCreated := false
 		isUpdated := false
 
+		mux.HandleFunc("/_index_template/_simulate/"+name, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"template": {"settings": {"index": {"number_of_shards": "1"}}, "mappings": {"properties": {"message": {"type": "text"}}}, "aliases": {"logs": {}}}, "overlapping": [{"name": "logs", "index_patterns": ["*"]}]}`))
+		})
+
+		mockES.EXPECT().ComponentTemplateGet(name + "-component").AnyTimes().DoAndReturn(func(name string) (*olivere.IndicesGetComponentTemplate, error) {
+			data["isComponentTemplateChecked"] = true
+			return &olivere.IndicesGetComponentTemplate{}, nil
+		})
+
 		mockES.EXPECT().IndexTemplateGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*olivere.IndicesGetIndexTemplate, error) {
 			switch *stepName {
 			case "create":
@@ -172,6 +182,17 @@ func doCreateIndexTemplateStep() test.TestStep[*elasticsearchapicrd.IndexTemplat
 			}
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(template.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
 			assert.True(t, *template.Status.IsSync)
+			assert.NotNil(t, template.Status.Simulation)
+			assert.Equal(t, map[string]any{"number_of_shards": "1"}, template.Status.Simulation.Settings.Data["index"])
+			assert.Equal(t, []string{"logs"}, template.Status.Simulation.Aliases)
+			assert.Empty(t, template.Status.Simulation.ComposedOf)
+			assert.NotEmpty(t, template.Status.Simulation.TemplateHash)
+			assert.Equal(t, []elasticsearchapicrd.IndexTemplateOverlapping{
+				{
+					Name:          "logs",
+					IndexPatterns: []string{"*"},
+				},
+			}, template.Status.Simulation.Overlapping)
 
 			return nil
 		},
@@ -190,6 +211,7 @@ func doUpdateIndexTemplateStep() test.TestStep[*elasticsearchapicrd.IndexTemplat
 
 			data["lastGeneration"] = o.GetStatus().GetObservedGeneration()
 			o.Spec.IndexPatterns = []string{"test2"}
+			o.Spec.RawTemplate = ptr.To(`{"composed_of": ["` + key.Name + `-component"]}`)
 			if err = c.Update(context.Background(), o); err != nil {
 				return err
 			}
@@ -219,6 +241,8 @@ func doUpdateIndexTemplateStep() test.TestStep[*elasticsearchapicrd.IndexTemplat
 			}
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(template.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
 			assert.True(t, *template.Status.IsSync)
+			_, ok := data["isComponentTemplateChecked"]
+			assert.True(t, ok)
 
 			return nil
 		},
@@ -1,10 +1,16 @@
 package elasticsearchapi
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"sort"
 	"time"
 
+	"emperror.dev/errors"
+	"github.com/codingsince1985/checksum"
 	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
@@ -48,3 +54,79 @@ func (h *indexTemplateReconciler) GetRemoteHandler(ctx context.Context, req reco
 
 	return handler, res, nil
 }
+
+// Create check that the component templates exist before create the index template
+func (h *indexTemplateReconciler) Create(ctx context.Context, o *elasticsearchapicrd.IndexTemplate, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.IndexTemplate, *olivere.IndicesGetIndexTemplate, eshandler.ElasticsearchHandler], object *olivere.IndicesGetIndexTemplate, logger *logrus.Entry) (res reconcile.Result, err error) {
+	if err = checkComponentTemplatesExist(handler.Client(), object.ComposedOf); err != nil {
+		return res, err
+	}
+
+	return h.RemoteReconcilerAction.Create(ctx, o, data, handler, object, logger)
+}
+
+// Update check that the component templates exist before update the index template
+func (h *indexTemplateReconciler) Update(ctx context.Context, o *elasticsearchapicrd.IndexTemplate, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.IndexTemplate, *olivere.IndicesGetIndexTemplate, eshandler.ElasticsearchHandler], object *olivere.IndicesGetIndexTemplate, logger *logrus.Entry) (res reconcile.Result, err error) {
+	if err = checkComponentTemplatesExist(handler.Client(), object.ComposedOf); err != nil {
+		return res, err
+	}
+
+	return h.RemoteReconcilerAction.Update(ctx, o, data, handler, object, logger)
+}
+
+// OnSuccess record the summary of the index template resolved by Elasticsearch with its component templates
+func (h *indexTemplateReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.IndexTemplate, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.IndexTemplate, *olivere.IndicesGetIndexTemplate, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*olivere.IndicesGetIndexTemplate], logger *logrus.Entry) (res reconcile.Result, err error) {
+	// Not simulate when the dry-run annotation is set, the template can be not yet applied
+	if o.GetDryRunStatus() == nil {
+		simulation, err := indexTemplateSimulate(handler.Client(), o.GetExternalName())
+		if err != nil {
+			return res, errors.Wrapf(err, "Error when simulate index template %s", o.GetExternalName())
+		}
+		indexTemplate, err := handler.Build(o)
+		if err != nil {
+			return res, errors.Wrapf(err, "Error when build index template %s", o.GetExternalName())
+		}
+		j, err := json.Marshal(simulation.Template)
+		if err != nil {
+			return res, errors.Wrap(err, "Error when convert resolved template to JSON")
+		}
+		hash, err := checksum.SHA256sumReader(bytes.NewReader(j))
+		if err != nil {
+			return res, errors.Wrap(err, "Error when compute resolved template hash")
+		}
+
+		o.Status.Simulation = &elasticsearchapicrd.IndexTemplateSimulation{
+			ComposedOf:   indexTemplate.ComposedOf,
+			TemplateHash: hash,
+		}
+		if len(simulation.Template.Settings) > 0 {
+			o.Status.Simulation.Settings = &apis.MapAny{Data: simulation.Template.Settings}
+		}
+		for alias := range simulation.Template.Aliases {
+			o.Status.Simulation.Aliases = append(o.Status.Simulation.Aliases, alias)
+		}
+		sort.Strings(o.Status.Simulation.Aliases)
+		for _, overlapping := range simulation.Overlapping {
+			o.Status.Simulation.Overlapping = append(o.Status.Simulation.Overlapping, elasticsearchapicrd.IndexTemplateOverlapping{
+				Name:          overlapping.Name,
+				IndexPatterns: overlapping.IndexPatterns,
+			})
+		}
+	}
+
+	return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+}
+
+// checkComponentTemplatesExist return error if some component templates not yet exist on Elasticsearch
+func checkComponentTemplatesExist(esClient eshandler.ElasticsearchHandler, componentTemplateNames []string) (err error) {
+	for _, componentTemplateName := range componentTemplateNames {
+		componentTemplate, err := esClient.ComponentTemplateGet(componentTemplateName)
+		if err != nil {
+			return errors.Wrapf(err, "Error when get component template %s to check if exist before apply index template", componentTemplateName)
+		}
+		if componentTemplate == nil {
+			return errors.Errorf("Component template %s not yet exist, skip it", componentTemplateName)
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,10 @@
 package elasticsearchapi
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+
 	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/generic-objectmatcher/patch"
@@ -9,6 +13,17 @@ import (
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 )
 
+// licenseTrialStatusResponse is the response of _license/trial_status
+type licenseTrialStatusResponse struct {
+	EligibleToStartTrial bool `json:"eligible_to_start_trial"`
+}
+
+// licenseStartTrialResponse is the response of _license/start_trial
+type licenseStartTrialResponse struct {
+	TrialWasStarted bool   `json:"trial_was_started"`
+	ErrorMessage    string `json:"error_message,omitempty"`
+}
+
 type licenseApiClient struct {
 	remote.RemoteExternalReconciler[*elasticsearchapicrd.License, *olivere.XPackInfoLicense, eshandler.ElasticsearchHandler]
 }
@@ -48,3 +63,59 @@ func (h *licenseApiClient) Delete(o *elasticsearchapicrd.License) (err error) {
 func (h *licenseApiClient) Diff(currentOject *olivere.XPackInfoLicense, expectedObject *olivere.XPackInfoLicense, originalObject *olivere.XPackInfoLicense, o *elasticsearchapicrd.License, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
 	return nil, nil
 }
+
+// licenseStartTrial permit to start the trial license
+// The trial license can be started only one time per major version of Elasticsearch
+func licenseStartTrial(client eshandler.ElasticsearchHandler) (err error) {
+	api := client.Client().API
+	res, err := api.License.GetTrialStatus(
+		api.License.GetTrialStatus.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when check if trial license can be started: %s", res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	trialStatus := &licenseTrialStatusResponse{}
+	if err = json.Unmarshal(b, trialStatus); err != nil {
+		return errors.Wrap(err, "Error when decode trial license status")
+	}
+	if !trialStatus.EligibleToStartTrial {
+		return errors.New("The trial license was already started on this cluster, you need to provide a license or switch to basic license")
+	}
+
+	res, err = api.License.PostStartTrial(
+		api.License.PostStartTrial.WithContext(context.Background()),
+		api.License.PostStartTrial.WithAcknowledge(true),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when start trial license: %s", res.String())
+	}
+
+	b, err = io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	startTrial := &licenseStartTrialResponse{}
+	if err = json.Unmarshal(b, startTrial); err != nil {
+		return errors.Wrap(err, "Error when decode trial license start")
+	}
+	if !startTrial.TrialWasStarted {
+		return errors.Errorf("Error when start trial license: %s", startTrial.ErrorMessage)
+	}
+
+	return nil
+}
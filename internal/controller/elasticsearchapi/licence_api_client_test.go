@@ -0,0 +1,68 @@
+package elasticsearchapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLicenseApi(t *testing.T) {
+	var (
+		isEligible   bool
+		isStarted    bool
+		errorMessage string
+		method       string
+		path         string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_license/trial_status":
+			if isEligible {
+				_, _ = w.Write([]byte(`{"eligible_to_start_trial": true}`))
+			} else {
+				_, _ = w.Write([]byte(`{"eligible_to_start_trial": false}`))
+			}
+		case "/_license/start_trial":
+			method = r.Method
+			path = r.URL.Path
+			if errorMessage == "" {
+				isStarted = true
+				_, _ = w.Write([]byte(`{"acknowledged": true, "trial_was_started": true, "type": "trial"}`))
+			} else {
+				_, _ = w.Write([]byte(`{"acknowledged": true, "trial_was_started": false, "error_message": "` + errorMessage + `"}`))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"type": "resource_not_found_exception"}, "status": 404}`))
+		}
+	}))
+
+	// Start trial
+	isEligible = true
+	err := licenseStartTrial(mockES)
+	assert.NoError(t, err)
+	assert.True(t, isStarted)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/_license/start_trial", path)
+
+	// Start trial when the trial was already started
+	isEligible = false
+	isStarted = false
+	err = licenseStartTrial(mockES)
+	assert.Error(t, err)
+	assert.False(t, isStarted)
+
+	// Start trial when Elasticsearch refuse it
+	isEligible = true
+	errorMessage = "Operation failed: Trial was already activated."
+	err = licenseStartTrial(mockES)
+	assert.Error(t, err)
+	assert.False(t, isStarted)
+}
@@ -16,6 +16,7 @@ package elasticsearchapi
 import (
 	"context"
 	"fmt"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
@@ -56,10 +57,13 @@ func NewLicenseReconciler(client client.Client, logger *logrus.Entry, recorder r
 			logger,
 			recorder,
 		),
-		RemoteReconcilerAction: newLicenseReconciler(
-			licenseName,
-			client,
-			recorder,
+		RemoteReconcilerAction: common.NewRemoteReconcilerAction(
+			elasticsearchapicrd.ElasticsearchApiAnnotationKey,
+			newLicenseReconciler(
+				licenseName,
+				client,
+				recorder,
+			),
 		),
 		name: licenseName,
 	}
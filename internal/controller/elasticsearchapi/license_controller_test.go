@@ -2,6 +2,8 @@ package elasticsearchapi
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -11,10 +13,12 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
 	olivere "github.com/olivere/elastic/v7"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 	"go.uber.org/mock/gomock"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -38,18 +42,31 @@ func (t *ElasticsearchapiControllerTestSuite) TestLicenseReconciler() {
 		doDeleteBasicLicenseStep(),
 		doUpdateToEnterpriseLicenseStep(),
 		doUpdateEnterpriseLicenseStep(),
+		doDowngradeEnterpriseLicenseStep(),
 		doDeleteEnterpriseLicenseStep(),
+		doStartTrialLicenseStep(),
+		doDeleteTrialLicenseStep(),
 	}
-	testCase.PreTest = doMockLicense(t.mockElasticsearchHandler)
+	testCase.PreTest = doMockLicense(t.mockElasticsearchHandler, t.fakeElasticsearchMux)
 
 	testCase.Run()
 }
 
-func doMockLicense(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+func doMockLicense(mockES *mocks.MockElasticsearchHandler, mux *http.ServeMux) func(stepName *string, data map[string]any) error {
 	return func(stepName *string, data map[string]any) (err error) {
 		isCreatedBasicLicense := false
 		isUpdatedToEnterpriseLicense := false
 		isUpdatedEnterpriseLicense := false
+		isStartedTrialLicense := false
+
+		mux.HandleFunc("/_license/trial_status", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"eligible_to_start_trial": %t}`, !isStartedTrialLicense)))
+		})
+		mux.HandleFunc("/_license/start_trial", func(w http.ResponseWriter, r *http.Request) {
+			isStartedTrialLicense = true
+			data["isStartedTrialLicense"] = true
+			_, _ = w.Write([]byte(`{"acknowledged": true, "trial_was_started": true, "type": "trial"}`))
+		})
 
 		mockES.EXPECT().LicenseGet().AnyTimes().DoAndReturn(func() (*olivere.XPackInfoLicense, error) {
 			switch *stepName {
@@ -70,8 +87,9 @@ func doMockLicense(mockES *mocks.MockElasticsearchHandler) func(stepName *string
 					}, nil
 				} else {
 					return &olivere.XPackInfoLicense{
-						UID:  "test",
-						Type: "gold",
+						UID:         "test",
+						Type:        "gold",
+						ExpiryMilis: int(time.Now().Add(10 * 24 * time.Hour).UnixMilli()),
 					}, nil
 				}
 			case "update_enterprise_license":
@@ -82,8 +100,27 @@ func doMockLicense(mockES *mocks.MockElasticsearchHandler) func(stepName *string
 					}, nil
 				} else {
 					return &olivere.XPackInfoLicense{
-						UID:  "test2",
-						Type: "gold",
+						UID:         "test2",
+						Type:        "gold",
+						ExpiryMilis: int(time.Now().Add(365 * 24 * time.Hour).UnixMilli()),
+					}, nil
+				}
+			case "downgrade_enterprise_license":
+				return &olivere.XPackInfoLicense{
+					UID:  "test2",
+					Type: "basic",
+				}, nil
+			case "start_trial_license":
+				if !isStartedTrialLicense {
+					return &olivere.XPackInfoLicense{
+						UID:  "test",
+						Type: "basic",
+					}, nil
+				} else {
+					return &olivere.XPackInfoLicense{
+						UID:         "test3",
+						Type:        "trial",
+						ExpiryMilis: int(time.Now().Add(30 * 24 * time.Hour).UnixMilli()),
 					}, nil
 				}
 			}
@@ -120,6 +157,7 @@ func doMockLicense(mockES *mocks.MockElasticsearchHandler) func(stepName *string
 			switch *stepName {
 			case "create_basic_license":
 				if !isCreatedBasicLicense {
+					isCreatedBasicLicense = true
 					data["isCreatedBasicLicense"] = true
 					return nil
 				} else {
@@ -136,9 +174,11 @@ func doMockLicense(mockES *mocks.MockElasticsearchHandler) func(stepName *string
 		mockES.EXPECT().LicenseUpdate(gomock.Any()).AnyTimes().DoAndReturn(func(license string) error {
 			switch *stepName {
 			case "update_to_enterprise_license":
+				isUpdatedToEnterpriseLicense = true
 				data["isUpdatedToEnterpriseLicense"] = true
 				return nil
 			case "update_enterprise_license":
+				isUpdatedEnterpriseLicense = true
 				data["isUpdatedEnterpriseLicense"] = true
 				return nil
 			}
@@ -195,6 +235,7 @@ func doEnableBasicLicenseStep() test.TestStep[*elasticsearchapicrd.License] {
 			assert.Empty(t, license.Status.ExpireAt)
 			assert.Equal(t, "basic", license.Status.LicenseType)
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(license.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(license.Status.Conditions, licenseDegradedCondition.String(), metav1.ConditionFalse))
 			assert.True(t, *license.Status.IsSync)
 
 			return nil
@@ -320,6 +361,14 @@ func doUpdateToEnterpriseLicenseStep() test.TestStep[*elasticsearchapicrd.Licens
 			assert.Equal(t, "gold", license.Status.LicenseType)
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(license.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
 			assert.True(t, *license.Status.IsSync)
+			// The license expire in 10 days
+			degradedCondition := condition.FindStatusCondition(license.Status.Conditions, licenseDegradedCondition.String())
+			assert.NotNil(t, degradedCondition)
+			assert.Equal(t, metav1.ConditionTrue, degradedCondition.Status)
+			assert.Equal(t, "LicenseExpireSoon", degradedCondition.Reason)
+			expireIn := testutil.ToFloat64(common.LicenseExpirySeconds.WithLabelValues(key.Namespace, key.Name, "gold"))
+			assert.Greater(t, expireIn, float64(9*24*3600))
+			assert.LessOrEqual(t, expireIn, float64(10*24*3600))
 
 			return nil
 		},
@@ -377,6 +426,9 @@ func doUpdateEnterpriseLicenseStep() test.TestStep[*elasticsearchapicrd.License]
 				if !isUpdated {
 					return errors.New("Not yet updated")
 				}
+				if !condition.IsStatusConditionPresentAndEqual(license.Status.Conditions, licenseDegradedCondition.String(), metav1.ConditionFalse) {
+					return errors.New("Not yet valid")
+				}
 				return nil
 			}, time.Second*30, time.Second*1)
 			if err != nil || isTimeout {
@@ -392,6 +444,51 @@ func doUpdateEnterpriseLicenseStep() test.TestStep[*elasticsearchapicrd.License]
 	}
 }
 
+func doDowngradeEnterpriseLicenseStep() test.TestStep[*elasticsearchapicrd.License] {
+	return test.TestStep[*elasticsearchapicrd.License]{
+		Name: "downgrade_enterprise_license",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.License, data map[string]any) (err error) {
+			logrus.Infof("=== Downgrade enterprise license %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("License is null")
+			}
+
+			// Force reconcile to read the basic license applied on cluster
+			if o.Annotations == nil {
+				o.Annotations = map[string]string{}
+			}
+			o.Annotations["test/downgrade"] = "true"
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.License, data map[string]any) (err error) {
+			license := &elasticsearchapicrd.License{}
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, license); err != nil {
+					t.Fatal(err)
+				}
+				degradedCondition := condition.FindStatusCondition(license.Status.Conditions, licenseDegradedCondition.String())
+				if degradedCondition == nil || degradedCondition.Status != metav1.ConditionTrue || degradedCondition.Reason != "LicenseDowngraded" {
+					return errors.New("Not yet downgraded")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get License: %s", err.Error())
+			}
+			assert.Empty(t, license.Status.ExpireAt)
+			assert.Equal(t, "basic", license.Status.LicenseType)
+
+			return nil
+		},
+	}
+}
+
 func doDeleteEnterpriseLicenseStep() test.TestStep[*elasticsearchapicrd.License] {
 	return test.TestStep[*elasticsearchapicrd.License]{
 		Name: "delete_enterprise_license",
@@ -432,3 +529,100 @@ func doDeleteEnterpriseLicenseStep() test.TestStep[*elasticsearchapicrd.License]
 		},
 	}
 }
+
+func doStartTrialLicenseStep() test.TestStep[*elasticsearchapicrd.License] {
+	return test.TestStep[*elasticsearchapicrd.License]{
+		Name: "start_trial_license",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.License, data map[string]any) (err error) {
+			logrus.Infof("=== Start trial license %s/%s ===\n\n", key.Namespace, key.Name)
+
+			license := &elasticsearchapicrd.License{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchapicrd.LicenseSpec{
+					ElasticsearchRef: shared.ElasticsearchRef{
+						ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+							Name: "test",
+						},
+					},
+					Trial:                 ptr.To(true),
+					ExpirationWarningDays: ptr.To(7),
+				},
+			}
+
+			if err = c.Create(context.Background(), license); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.License, data map[string]any) (err error) {
+			license := &elasticsearchapicrd.License{}
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, license); err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := data["isStartedTrialLicense"]; !ok || license.GetStatus().GetObservedGeneration() == 0 {
+					return errors.New("Not yet started")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get License: %s", err.Error())
+			}
+			assert.NotEmpty(t, license.Status.ExpireAt)
+			assert.Equal(t, "trial", license.Status.LicenseType)
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(license.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			// The license expire in 30 days, after the warning period of 7 days
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(license.Status.Conditions, licenseDegradedCondition.String(), metav1.ConditionFalse))
+			assert.True(t, *license.Status.IsSync)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteTrialLicenseStep() test.TestStep[*elasticsearchapicrd.License] {
+	return test.TestStep[*elasticsearchapicrd.License]{
+		Name: "delete_trial_license",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.License, data map[string]any) (err error) {
+			logrus.Infof("=== Delete trial license %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("License is null")
+			}
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), o, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.License, data map[string]any) (err error) {
+			license := &elasticsearchapicrd.License{}
+			isDeleted := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, license); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("License stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+			assert.Equal(t, 0, testutil.CollectAndCount(common.LicenseExpirySeconds))
+			return nil
+		},
+	}
+}
@@ -3,24 +3,41 @@ package elasticsearchapi
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/shared"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
 	olivere "github.com/olivere/elastic/v7"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// licenseDegradedCondition is the condition set when the license expire soon or is expired
+	licenseDegradedCondition shared.ConditionName = "Degraded"
+
+	// licenseStatusRefreshInterval is the interval to refresh the expiration of the license
+	licenseStatusRefreshInterval = 1 * time.Hour
+
+	licenseTypeBasic = "basic"
+	licenseTypeTrial = "trial"
+)
+
 type licenseReconciler struct {
 	remote.RemoteReconcilerAction[*elasticsearchapicrd.License, *olivere.XPackInfoLicense, eshandler.ElasticsearchHandler]
 	name string
@@ -37,6 +54,11 @@ func newLicenseReconciler(name string, client client.Client, recorder record.Eve
 }
 
 func (h *licenseReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.License, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.License, *olivere.XPackInfoLicense, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+	// Remove the expiration metric, even if the license is orphaned or Elasticsearch is not ready
+	if !o.DeletionTimestamp.IsZero() {
+		common.LicenseExpirySeconds.DeletePartialMatch(prometheus.Labels{"namespace": o.GetNamespace(), "name": o.GetName()})
+	}
+
 	esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, h.Client(), logger)
 	if err != nil && o.DeletionTimestamp.IsZero() {
 		return nil, res, err
@@ -62,6 +84,14 @@ func (h *licenseReconciler) Read(ctx context.Context, o *elasticsearchapicrd.Lic
 		return nil, res, err
 	}
 
+	// The trial license is generated by Elasticsearch
+	if o.IsTrialLicense() {
+		read.SetExpectedObject(&olivere.XPackInfoLicense{
+			Type: licenseTypeTrial,
+		})
+		return read, res, nil
+	}
+
 	// If not basic license, the license is stored on secret
 	if !o.IsBasicLicense() {
 		if o.Spec.SecretRef == nil {
@@ -93,7 +123,7 @@ func (h *licenseReconciler) Read(ctx context.Context, o *elasticsearchapicrd.Lic
 		data["license"] = &expectedLicense.License
 	} else {
 		read.SetExpectedObject(&olivere.XPackInfoLicense{
-			Type: "basic",
+			Type: licenseTypeBasic,
 		})
 	}
 
@@ -101,7 +131,14 @@ func (h *licenseReconciler) Read(ctx context.Context, o *elasticsearchapicrd.Lic
 }
 
 func (h *licenseReconciler) Create(ctx context.Context, o *elasticsearchapicrd.License, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.License, *olivere.XPackInfoLicense, eshandler.ElasticsearchHandler], object *olivere.XPackInfoLicense, logger *logrus.Entry) (res reconcile.Result, err error) {
-	if o.IsBasicLicense() {
+	if o.IsTrialLicense() {
+		if err = licenseStartTrial(handler.Client()); err != nil {
+			return res, errors.Wrap(err, "Error when start trial license")
+		}
+
+		logger.Info("Successfully start trial license")
+		h.Recorder().Event(o, core.EventTypeNormal, "Completed", "Start trial license")
+	} else if o.IsBasicLicense() {
 
 		if err = handler.Client().LicenseEnableBasic(); err != nil {
 			return res, errors.Wrap(err, "Error when activate basic license")
@@ -142,6 +179,15 @@ func (h *licenseReconciler) Diff(ctx context.Context, o *elasticsearchapicrd.Lic
 		return diff, res, nil
 	}
 
+	// The trial license has not UID to compare with
+	if o.IsTrialLicense() {
+		if read.GetCurrentObject().Type != licenseTypeTrial {
+			diff.AddDiff("Start the trial license")
+			diff.SetObjectToUpdate(read.GetExpectedObject())
+		}
+		return diff, res, nil
+	}
+
 	if handler.Client().LicenseDiff(read.GetCurrentObject(), read.GetExpectedObject()) {
 		diff.AddDiff("Update the current license")
 		diff.SetObjectToUpdate(read.GetExpectedObject())
@@ -150,19 +196,99 @@ func (h *licenseReconciler) Diff(ctx context.Context, o *elasticsearchapicrd.Lic
 	return diff, res, nil
 }
 
+// OnSuccess read the license applied on cluster, then check its expiration
 func (h *licenseReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.License, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.License, *olivere.XPackInfoLicense, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*olivere.XPackInfoLicense], logger *logrus.Entry) (res reconcile.Result, err error) {
+	// The license applied on cluster can differ from the expected license, for instance when the cluster fall back to basic license
+	license, err := handler.Client().LicenseGet()
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get license")
+	}
+	h.setLicenseStatus(o, license, h.getExpectedLicenseType(o, data))
+
+	res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	if err != nil {
+		return res, err
+	}
+
+	// Refresh the expiration of the license
+	if !res.Requeue && (res.RequeueAfter == 0 || res.RequeueAfter > licenseStatusRefreshInterval) {
+		res.RequeueAfter = licenseStatusRefreshInterval
+	}
+
+	return res, nil
+}
+
+// getExpectedLicenseType return the license type expected on cluster
+func (h *licenseReconciler) getExpectedLicenseType(o *elasticsearchapicrd.License, data map[string]any) string {
+	if o.IsTrialLicense() {
+		return licenseTypeTrial
+	}
 	if o.IsBasicLicense() {
-		o.Status.LicenseType = "basic"
+		return licenseTypeBasic
+	}
+	if expectedLicense, ok := data["license"].(*olivere.XPackInfoLicense); ok && expectedLicense != nil {
+		return expectedLicense.Type
+	}
+
+	return ""
+}
+
+// setLicenseStatus set the license type and the expiration date on status
+// It set the degraded condition and the expiration metric when the license expire or when the cluster fall back to basic license
+func (h *licenseReconciler) setLicenseStatus(o *elasticsearchapicrd.License, license *olivere.XPackInfoLicense, expectedType string) {
+	common.LicenseExpirySeconds.DeletePartialMatch(prometheus.Labels{"namespace": o.GetNamespace(), "name": o.GetName()})
+
+	if license == nil {
+		o.Status.LicenseType = ""
 		o.Status.ExpireAt = ""
-	} else {
-		d, err := helper.Get(data, "license")
-		if err != nil {
-			return res, err
-		}
-		l := d.(*olivere.XPackInfoLicense)
-		o.Status.ExpireAt = time.UnixMilli(int64(l.ExpiryMilis)).Format(time.RFC3339)
-		o.Status.LicenseType = l.Type
+		h.setDegradedCondition(o, metav1.ConditionFalse, "NoLicense", "There are no license on cluster")
+		return
+	}
+
+	if license.Type == licenseTypeBasic && expectedType != "" && expectedType != licenseTypeBasic {
+		o.Status.LicenseType = license.Type
+		o.Status.ExpireAt = ""
+		h.setDegradedCondition(o, metav1.ConditionTrue, "LicenseDowngraded", fmt.Sprintf("The cluster use the basic license instead of the expected %s license", expectedType))
+		return
+	}
+
+	if license.Type == licenseTypeBasic || license.ExpiryMilis <= 0 {
+		o.Status.LicenseType = license.Type
+		o.Status.ExpireAt = ""
+		h.setDegradedCondition(o, metav1.ConditionFalse, "NoExpiration", fmt.Sprintf("The %s license never expire", license.Type))
+		return
+	}
+
+	expireAt := time.UnixMilli(int64(license.ExpiryMilis))
+	expireIn := time.Until(expireAt)
+	o.Status.LicenseType = license.Type
+	o.Status.ExpireAt = expireAt.Format(time.RFC3339)
+	common.LicenseExpirySeconds.WithLabelValues(o.GetNamespace(), o.GetName(), license.Type).Set(expireIn.Seconds())
+
+	switch {
+	case expireIn <= 0:
+		h.setDegradedCondition(o, metav1.ConditionTrue, "LicenseExpired", fmt.Sprintf("The %s license expired at %s", license.Type, o.Status.ExpireAt))
+	case expireIn <= time.Duration(o.GetExpirationWarningDays())*24*time.Hour:
+		h.setDegradedCondition(o, metav1.ConditionTrue, "LicenseExpireSoon", fmt.Sprintf("The %s license expire in %d days, at %s", license.Type, int(expireIn.Hours()/24), o.Status.ExpireAt))
+	default:
+		h.setDegradedCondition(o, metav1.ConditionFalse, "LicenseValid", fmt.Sprintf("The %s license expire at %s", license.Type, o.Status.ExpireAt))
+	}
+}
+
+// setDegradedCondition set the degraded condition
+// It notify when the license become degraded
+func (h *licenseReconciler) setDegradedCondition(o *elasticsearchapicrd.License, status metav1.ConditionStatus, reason string, message string) {
+	conditions := o.GetStatus().GetConditions()
+	currentCondition := condition.FindStatusCondition(conditions, licenseDegradedCondition.String())
+	if status == metav1.ConditionTrue && (currentCondition == nil || currentCondition.Reason != reason) {
+		h.Recorder().Event(o, core.EventTypeWarning, reason, message)
 	}
 
-	return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	condition.SetStatusCondition(&conditions, metav1.Condition{
+		Type:    licenseDegradedCondition.String(),
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	o.GetStatus().SetConditions(conditions)
 }
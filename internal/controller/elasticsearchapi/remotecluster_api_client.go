@@ -0,0 +1,388 @@
+package elasticsearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+)
+
+// remoteClusterSettings is the remote cluster settings stored on `cluster.remote.<alias>`
+type remoteClusterSettings struct {
+	Mode                   string   `json:"mode,omitempty"`
+	Seeds                  []string `json:"seeds,omitempty"`
+	ProxyAddress           string   `json:"proxy_address,omitempty"`
+	ServerName             string   `json:"server_name,omitempty"`
+	SkipUnavailable        *bool    `json:"skip_unavailable,omitempty"`
+	NodeConnections        *int64   `json:"node_connections,omitempty"`
+	ProxySocketConnections *int64   `json:"proxy_socket_connections,omitempty"`
+}
+
+// remoteClusterInfo is the connection status returned by `_remote/info`
+type remoteClusterInfo struct {
+	Connected                bool   `json:"connected"`
+	Mode                     string `json:"mode"`
+	NumNodesConnected        int64  `json:"num_nodes_connected"`
+	NumProxySocketsConnected int64  `json:"num_proxy_sockets_connected"`
+}
+
+type remoteClusterApiClient struct {
+	remote.RemoteExternalReconciler[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler]
+}
+
+func newRemoteClusterApiClient(client eshandler.ElasticsearchHandler) remote.RemoteExternalReconciler[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler] {
+	return &remoteClusterApiClient{
+		RemoteExternalReconciler: remote.NewRemoteExternalReconciler[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler](client),
+	}
+}
+
+// Build compute the remote cluster settings
+// The addresses of managed remote cluster are computed by the reconciler
+func (h *remoteClusterApiClient) Build(o *elasticsearchapicrd.RemoteCluster) (settings *remoteClusterSettings, err error) {
+	settings = &remoteClusterSettings{
+		Mode:            string(o.GetMode()),
+		SkipUnavailable: o.Spec.SkipUnavailable,
+	}
+
+	switch o.GetMode() {
+	case elasticsearchapicrd.RemoteClusterModeSniff:
+		settings.Seeds = o.Spec.Remote.Seeds
+		settings.NodeConnections = o.Spec.NodeConnections
+	case elasticsearchapicrd.RemoteClusterModeProxy:
+		settings.ProxyAddress = o.Spec.Remote.ProxyAddress
+		settings.ServerName = o.Spec.Remote.ServerName
+		settings.ProxySocketConnections = o.Spec.ProxySocketConnections
+	}
+
+	return settings, nil
+}
+
+func (h *remoteClusterApiClient) Get(o *elasticsearchapicrd.RemoteCluster) (object *remoteClusterSettings, err error) {
+	return remoteClusterGet(h.Client(), o.GetExternalName())
+}
+
+func (h *remoteClusterApiClient) Create(object *remoteClusterSettings, o *elasticsearchapicrd.RemoteCluster) (err error) {
+	return remoteClusterUpdate(h.Client(), o.GetExternalName(), object)
+}
+
+func (h *remoteClusterApiClient) Update(object *remoteClusterSettings, o *elasticsearchapicrd.RemoteCluster) (err error) {
+	return remoteClusterUpdate(h.Client(), o.GetExternalName(), object)
+}
+
+func (h *remoteClusterApiClient) Delete(o *elasticsearchapicrd.RemoteCluster) (err error) {
+	return remoteClusterDelete(h.Client(), o.GetExternalName())
+}
+
+func (h *remoteClusterApiClient) Diff(currentOject *remoteClusterSettings, expectedObject *remoteClusterSettings, originalObject *remoteClusterSettings, o *elasticsearchapicrd.RemoteCluster, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	// If not yet exist
+	if currentOject == nil {
+		expected, err := json.Marshal(expectedObject)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert expected object to byte sequence")
+		}
+
+		return &patch.PatchResult{
+			Patch:    expected,
+			Current:  expected,
+			Modified: expected,
+			Original: nil,
+			Patched:  expectedObject,
+		}, nil
+	}
+
+	return patch.DefaultPatchMaker.Calculate(currentOject, expectedObject, originalObject, ignoresDiff...)
+}
+
+// remoteClusterSettingKey return the persistent setting key of remote cluster
+func remoteClusterSettingKey(alias string, setting string) string {
+	return fmt.Sprintf("cluster.remote.%s.%s", alias, setting)
+}
+
+// remoteClusterGet permit to get the remote cluster settings from persistent cluster settings
+// It return nil if remote cluster not exist
+func remoteClusterGet(client eshandler.ElasticsearchHandler, alias string) (settings *remoteClusterSettings, err error) {
+	api := client.Client().API
+	res, err := api.Cluster.GetSettings(
+		api.Cluster.GetSettings.WithContext(context.Background()),
+		api.Cluster.GetSettings.WithFlatSettings(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when get remote cluster %s: %s", alias, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &struct {
+		Persistent map[string]any `json:"persistent"`
+	}{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode remote cluster %s", alias)
+	}
+
+	prefix := fmt.Sprintf("cluster.remote.%s.", alias)
+	isFound := false
+	settings = &remoteClusterSettings{}
+	for key, value := range resp.Persistent {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+
+		// Flat settings return array for list, and string for all other values
+		switch strings.TrimPrefix(key, prefix) {
+		case "mode":
+			settings.Mode = fmt.Sprint(value)
+		case "seeds":
+			if values, ok := value.([]any); ok {
+				for _, v := range values {
+					settings.Seeds = append(settings.Seeds, fmt.Sprint(v))
+				}
+			}
+		case "proxy_address":
+			settings.ProxyAddress = fmt.Sprint(value)
+		case "server_name":
+			settings.ServerName = fmt.Sprint(value)
+		case "skip_unavailable":
+			skipUnavailable, err := strconv.ParseBool(fmt.Sprint(value))
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error when decode setting %s", key)
+			}
+			settings.SkipUnavailable = &skipUnavailable
+		case "node_connections":
+			nodeConnections, err := strconv.ParseInt(fmt.Sprint(value), 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error when decode setting %s", key)
+			}
+			settings.NodeConnections = &nodeConnections
+		case "proxy_socket_connections":
+			proxySocketConnections, err := strconv.ParseInt(fmt.Sprint(value), 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error when decode setting %s", key)
+			}
+			settings.ProxySocketConnections = &proxySocketConnections
+		default:
+			continue
+		}
+		isFound = true
+	}
+
+	if !isFound {
+		return nil, nil
+	}
+
+	// Elasticsearch use sniff mode when mode is not set
+	if settings.Mode == "" {
+		settings.Mode = string(elasticsearchapicrd.RemoteClusterModeSniff)
+	}
+
+	return settings, nil
+}
+
+// remoteClusterUpdate permit to create or update remote cluster settings
+// The settings not used by the connection mode are removed
+func remoteClusterUpdate(client eshandler.ElasticsearchHandler, alias string, settings *remoteClusterSettings) (err error) {
+	persistent := map[string]any{
+		remoteClusterSettingKey(alias, "mode"):                     settings.Mode,
+		remoteClusterSettingKey(alias, "seeds"):                    nil,
+		remoteClusterSettingKey(alias, "proxy_address"):            nil,
+		remoteClusterSettingKey(alias, "server_name"):              nil,
+		remoteClusterSettingKey(alias, "skip_unavailable"):         nil,
+		remoteClusterSettingKey(alias, "node_connections"):         nil,
+		remoteClusterSettingKey(alias, "proxy_socket_connections"): nil,
+	}
+	if len(settings.Seeds) > 0 {
+		persistent[remoteClusterSettingKey(alias, "seeds")] = settings.Seeds
+	}
+	if settings.ProxyAddress != "" {
+		persistent[remoteClusterSettingKey(alias, "proxy_address")] = settings.ProxyAddress
+	}
+	if settings.ServerName != "" {
+		persistent[remoteClusterSettingKey(alias, "server_name")] = settings.ServerName
+	}
+	if settings.SkipUnavailable != nil {
+		persistent[remoteClusterSettingKey(alias, "skip_unavailable")] = *settings.SkipUnavailable
+	}
+	if settings.NodeConnections != nil {
+		persistent[remoteClusterSettingKey(alias, "node_connections")] = *settings.NodeConnections
+	}
+	if settings.ProxySocketConnections != nil {
+		persistent[remoteClusterSettingKey(alias, "proxy_socket_connections")] = *settings.ProxySocketConnections
+	}
+
+	return remoteClusterPutSettings(client, alias, persistent)
+}
+
+// remoteClusterDelete permit to remove remote cluster settings
+func remoteClusterDelete(client eshandler.ElasticsearchHandler, alias string) (err error) {
+	persistent := map[string]any{}
+	for _, setting := range []string{"mode", "seeds", "proxy_address", "server_name", "skip_unavailable", "node_connections", "proxy_socket_connections"} {
+		persistent[remoteClusterSettingKey(alias, setting)] = nil
+	}
+
+	return remoteClusterPutSettings(client, alias, persistent)
+}
+
+// remoteClusterPutSettings permit to put persistent cluster settings
+func remoteClusterPutSettings(client eshandler.ElasticsearchHandler, alias string, persistent map[string]any) (err error) {
+	data, err := json.Marshal(map[string]any{
+		"persistent": persistent,
+	})
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.Cluster.PutSettings(
+		bytes.NewReader(data),
+		api.Cluster.PutSettings.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when update remote cluster %s: %s", alias, res.String())
+	}
+
+	return nil
+}
+
+// remoteClusterGetInfo permit to get the connection status of remote cluster
+// It return nil if remote cluster not exist
+func remoteClusterGetInfo(client eshandler.ElasticsearchHandler, alias string) (info *remoteClusterInfo, err error) {
+	api := client.Client().API
+	res, err := api.Cluster.RemoteInfo(
+		api.Cluster.RemoteInfo.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when get info of remote cluster %s: %s", alias, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := map[string]*remoteClusterInfo{}
+	if err = json.Unmarshal(b, &resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode info of remote cluster %s", alias)
+	}
+
+	return resp[alias], nil
+}
+
+// remoteClusterApiKeyCreate permit to create cross-cluster API key
+// It return the API key ID and the encoded API key
+func remoteClusterApiKeyCreate(client eshandler.ElasticsearchHandler, name string, access *elasticsearchapicrd.RemoteClusterApiKeyAccess) (id string, encoded string, err error) {
+	data, err := json.Marshal(map[string]any{
+		"name":   name,
+		"access": access,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	api := client.Client().API
+	res, err := api.Security.CreateCrossClusterAPIKey(
+		bytes.NewReader(data),
+		api.Security.CreateCrossClusterAPIKey.WithContext(context.Background()),
+	)
+	if err != nil {
+		return "", "", err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", "", errors.Errorf("Error when create cross-cluster API key %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", "", err
+	}
+	resp := &struct {
+		ID      string `json:"id"`
+		Encoded string `json:"encoded"`
+	}{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return "", "", errors.Wrapf(err, "Error when decode cross-cluster API key %s", name)
+	}
+
+	return resp.ID, resp.Encoded, nil
+}
+
+// remoteClusterApiKeyUpdate permit to update the access of cross-cluster API key
+func remoteClusterApiKeyUpdate(client eshandler.ElasticsearchHandler, id string, access *elasticsearchapicrd.RemoteClusterApiKeyAccess) (err error) {
+	data, err := json.Marshal(map[string]any{
+		"access": access,
+	})
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.Security.UpdateCrossClusterAPIKey(
+		id,
+		bytes.NewReader(data),
+		api.Security.UpdateCrossClusterAPIKey.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when update cross-cluster API key %s: %s", id, res.String())
+	}
+
+	return nil
+}
+
+// remoteClusterApiKeyInvalidate permit to invalidate cross-cluster API key
+func remoteClusterApiKeyInvalidate(client eshandler.ElasticsearchHandler, id string) (err error) {
+	data, err := json.Marshal(map[string]any{
+		"ids": []string{id},
+	})
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.Security.InvalidateAPIKey(
+		bytes.NewReader(data),
+		api.Security.InvalidateAPIKey.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when invalidate cross-cluster API key %s: %s", id, res.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,263 @@
+package elasticsearchapi
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/stretchr/testify/assert"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRemoteClusterBuild(t *testing.T) {
+	var (
+		o                *elasticsearchapicrd.RemoteCluster
+		settings         *remoteClusterSettings
+		expectedSettings *remoteClusterSettings
+		err              error
+		client           *remoteClusterApiClient
+	)
+
+	client = &remoteClusterApiClient{}
+
+	// With sniff mode
+	o = &elasticsearchapicrd.RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.RemoteClusterSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Remote: elasticsearchapicrd.RemoteClusterTarget{
+				Seeds: []string{"es.remote.local:9300"},
+			},
+			NodeConnections: ptr.To[int64](5),
+		},
+	}
+
+	expectedSettings = &remoteClusterSettings{
+		Mode:            "sniff",
+		Seeds:           []string{"es.remote.local:9300"},
+		NodeConnections: ptr.To[int64](5),
+	}
+
+	settings, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSettings, settings)
+
+	// With proxy mode
+	o = &elasticsearchapicrd.RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.RemoteClusterSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Mode: elasticsearchapicrd.RemoteClusterModeProxy,
+			Remote: elasticsearchapicrd.RemoteClusterTarget{
+				ProxyAddress: "lb.remote.local:9300",
+				ServerName:   "es.remote.local",
+			},
+			SkipUnavailable:        ptr.To(true),
+			ProxySocketConnections: ptr.To[int64](18),
+		},
+	}
+
+	expectedSettings = &remoteClusterSettings{
+		Mode:                   "proxy",
+		ProxyAddress:           "lb.remote.local:9300",
+		ServerName:             "es.remote.local",
+		SkipUnavailable:        ptr.To(true),
+		ProxySocketConnections: ptr.To[int64](18),
+	}
+
+	settings, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSettings, settings)
+}
+
+func TestRemoteClusterApi(t *testing.T) {
+	var (
+		method   string
+		path     string
+		body     string
+		response string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		switch r.URL.Path {
+		case "/_cluster/settings":
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`{"persistent":{"cluster.remote.remote.seeds":["es.remote.local:9300"],"cluster.remote.remote.skip_unavailable":"true","cluster.remote.remote.node_connections":"5","cluster.remote.other.mode":"proxy"},"transient":{}}`))
+				return
+			}
+		case "/_remote/info":
+			_, _ = w.Write([]byte(`{"remote":{"connected":true,"mode":"sniff","seeds":["es.remote.local:9300"],"num_nodes_connected":3}}`))
+			return
+		case "/_security/cross_cluster/api_key":
+			_, _ = w.Write([]byte(`{"id":"VuaCfGcBCdbkQm-e5aOx","name":"default-test","encoded":"VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw=="}`))
+			return
+		}
+		if response != "" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(response))
+			return
+		}
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+
+	// Get
+	settings, err := remoteClusterGet(mockES, "remote")
+	assert.NoError(t, err)
+	assert.Equal(t, &remoteClusterSettings{
+		Mode:            "sniff",
+		Seeds:           []string{"es.remote.local:9300"},
+		SkipUnavailable: ptr.To(true),
+		NodeConnections: ptr.To[int64](5),
+	}, settings)
+
+	// Get when not exist
+	settings, err = remoteClusterGet(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, settings)
+
+	// Update remove the settings of other mode
+	err = remoteClusterUpdate(mockES, "remote", &remoteClusterSettings{Mode: "proxy", ProxyAddress: "lb.remote.local:9300"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_cluster/settings", path)
+	assert.JSONEq(t, `{"persistent":{"cluster.remote.remote.mode":"proxy","cluster.remote.remote.proxy_address":"lb.remote.local:9300","cluster.remote.remote.seeds":null,"cluster.remote.remote.server_name":null,"cluster.remote.remote.skip_unavailable":null,"cluster.remote.remote.node_connections":null,"cluster.remote.remote.proxy_socket_connections":null}}`, body)
+
+	// Delete
+	err = remoteClusterDelete(mockES, "remote")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.JSONEq(t, `{"persistent":{"cluster.remote.remote.mode":null,"cluster.remote.remote.proxy_address":null,"cluster.remote.remote.seeds":null,"cluster.remote.remote.server_name":null,"cluster.remote.remote.skip_unavailable":null,"cluster.remote.remote.node_connections":null,"cluster.remote.remote.proxy_socket_connections":null}}`, body)
+
+	// Get info
+	info, err := remoteClusterGetInfo(mockES, "remote")
+	assert.NoError(t, err)
+	assert.Equal(t, &remoteClusterInfo{Connected: true, Mode: "sniff", NumNodesConnected: 3}, info)
+
+	// Get info when not exist
+	info, err = remoteClusterGetInfo(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	// Create API key
+	id, encoded, err := remoteClusterApiKeyCreate(mockES, "default-test", &elasticsearchapicrd.RemoteClusterApiKeyAccess{
+		Search: []elasticsearchapicrd.RemoteClusterApiKeyAccessIndices{
+			{
+				Names: []string{"logs-*"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "VuaCfGcBCdbkQm-e5aOx", id)
+	assert.NotEmpty(t, encoded)
+	assert.JSONEq(t, `{"name":"default-test","access":{"search":[{"names":["logs-*"]}]}}`, body)
+
+	// Update API key
+	err = remoteClusterApiKeyUpdate(mockES, "VuaCfGcBCdbkQm-e5aOx", &elasticsearchapicrd.RemoteClusterApiKeyAccess{
+		Replication: []elasticsearchapicrd.RemoteClusterApiKeyAccessIndices{
+			{
+				Names: []string{"logs-*"},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/_security/cross_cluster/api_key/VuaCfGcBCdbkQm-e5aOx", path)
+	assert.JSONEq(t, `{"access":{"replication":[{"names":["logs-*"]}]}}`, body)
+
+	// Invalidate API key
+	err = remoteClusterApiKeyInvalidate(mockES, "VuaCfGcBCdbkQm-e5aOx")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/_security/api_key", path)
+
+	// Invalidate API key when not exist
+	response = `{"error":"not found"}`
+	err = remoteClusterApiKeyInvalidate(mockES, "missing")
+	assert.NoError(t, err)
+}
+
+func TestComputeRemoteClusterAddresses(t *testing.T) {
+	remoteEs := &elasticsearchcrd.Elasticsearch{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "remote",
+			Namespace: "other",
+		},
+		Spec: elasticsearchcrd.ElasticsearchSpec{
+			NodeGroups: []elasticsearchcrd.ElasticsearchNodeGroupSpec{
+				{
+					Name: "master",
+				},
+				{
+					Name: "data",
+				},
+			},
+		},
+	}
+	o := &elasticsearchapicrd.RemoteCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.RemoteClusterSpec{
+			Remote: elasticsearchapicrd.RemoteClusterTarget{
+				Managed: &shared.ElasticsearchManagedRef{
+					Name:      "remote",
+					Namespace: "other",
+				},
+			},
+		},
+	}
+
+	// Sniff mode with certificate
+	seeds, proxyAddress, serverName := computeRemoteClusterAddresses(o, remoteEs)
+	assert.Equal(t, []string{"remote-es.other.svc:9300"}, seeds)
+	assert.Empty(t, proxyAddress)
+	assert.Empty(t, serverName)
+
+	// Proxy mode with certificate
+	o.Spec.Mode = elasticsearchapicrd.RemoteClusterModeProxy
+	seeds, proxyAddress, serverName = computeRemoteClusterAddresses(o, remoteEs)
+	assert.Empty(t, seeds)
+	assert.Equal(t, "remote-es.other.svc:9300", proxyAddress)
+	assert.Equal(t, "remote-es.other.svc", serverName)
+
+	// Sniff mode with API key
+	o.Spec.Mode = elasticsearchapicrd.RemoteClusterModeSniff
+	o.Spec.Security = &elasticsearchapicrd.RemoteClusterSecurity{
+		Mode: elasticsearchapicrd.RemoteClusterSecurityModeApiKey,
+	}
+	seeds, _, _ = computeRemoteClusterAddresses(o, remoteEs)
+	assert.Equal(t, []string{"remote-master-headless-es.other.svc:9443", "remote-data-headless-es.other.svc:9443"}, seeds)
+
+	// Sniff mode with API key on target node group
+	o.Spec.Remote.Managed.TargetNodeGroup = "data"
+	seeds, _, _ = computeRemoteClusterAddresses(o, remoteEs)
+	assert.Equal(t, []string{"remote-data-headless-es.other.svc:9443"}, seeds)
+}
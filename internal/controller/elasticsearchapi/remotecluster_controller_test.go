@@ -0,0 +1,240 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (t *ElasticsearchapiControllerTestSuite) TestRemoteClusterReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-remotecluster-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase[*elasticsearchapicrd.RemoteCluster](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchapicrd.RemoteCluster]{
+		doCreateRemoteClusterStep(),
+		doUpdateRemoteClusterStep(),
+		doDeleteRemoteClusterStep(),
+	}
+	testCase.PreTest = doMockRemoteCluster(t.fakeElasticsearchMux)
+
+	testCase.Run()
+}
+
+func doMockRemoteCluster(mux *http.ServeMux) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		var mutex sync.Mutex
+		persistent := map[string]any{}
+
+		mux.HandleFunc("/_cluster/settings", func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if r.Method == http.MethodPut {
+				body, _ := io.ReadAll(r.Body)
+				settings := &struct {
+					Persistent map[string]any `json:"persistent"`
+				}{}
+				_ = json.Unmarshal(body, settings)
+				isDeleted := true
+				for key, value := range settings.Persistent {
+					if value == nil {
+						delete(persistent, key)
+						continue
+					}
+					persistent[key] = value
+					isDeleted = false
+				}
+				switch {
+				case isDeleted:
+					data["isDeleted"] = true
+				case *stepName == "create":
+					data["isCreated"] = true
+				case *stepName == "update":
+					data["isUpdated"] = true
+				}
+			}
+
+			b, _ := json.Marshal(map[string]any{
+				"persistent": persistent,
+				"transient":  map[string]any{},
+			})
+			_, _ = w.Write(b)
+		})
+
+		mux.HandleFunc("/_remote/info", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"remote":{"connected":true,"mode":"sniff","seeds":["es.remote.local:9300"],"num_nodes_connected":3,"max_connections_per_cluster":3,"initial_connect_timeout":"30s","skip_unavailable":true}}`))
+		})
+
+		return nil
+	}
+}
+
+func doCreateRemoteClusterStep() test.TestStep[*elasticsearchapicrd.RemoteCluster] {
+	return test.TestStep[*elasticsearchapicrd.RemoteCluster]{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.RemoteCluster, data map[string]any) (err error) {
+			logrus.Infof("=== Add new remote cluster %s/%s ===\n\n", key.Namespace, key.Name)
+
+			remoteCluster := &elasticsearchapicrd.RemoteCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchapicrd.RemoteClusterSpec{
+					ElasticsearchRef: shared.ElasticsearchRef{
+						ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+							Name: "test",
+						},
+					},
+					Name: "remote",
+					Remote: elasticsearchapicrd.RemoteClusterTarget{
+						Seeds: []string{"es.remote.local:9300"},
+					},
+				},
+			}
+			if err = c.Create(context.Background(), remoteCluster); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.RemoteCluster, data map[string]any) (err error) {
+			remoteCluster := &elasticsearchapicrd.RemoteCluster{}
+			isCreated := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, remoteCluster); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated || remoteCluster.GetStatus().GetObservedGeneration() == 0 {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Remote cluster: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(remoteCluster.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *remoteCluster.Status.IsSync)
+			assert.True(t, remoteCluster.Status.Connected)
+			assert.Equal(t, int64(3), remoteCluster.Status.NumNodesConnected)
+
+			return nil
+		},
+	}
+}
+
+func doUpdateRemoteClusterStep() test.TestStep[*elasticsearchapicrd.RemoteCluster] {
+	return test.TestStep[*elasticsearchapicrd.RemoteCluster]{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.RemoteCluster, data map[string]any) (err error) {
+			logrus.Infof("=== Update remote cluster %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Remote cluster is null")
+			}
+
+			data["lastGeneration"] = o.GetStatus().GetObservedGeneration()
+			o.Spec.SkipUnavailable = ptr.To(true)
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.RemoteCluster, data map[string]any) (err error) {
+			remoteCluster := &elasticsearchapicrd.RemoteCluster{}
+			isUpdated := false
+
+			lastGeneration := data["lastGeneration"].(int64)
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, remoteCluster); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated || lastGeneration == remoteCluster.GetStatus().GetObservedGeneration() {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Remote cluster: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(remoteCluster.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *remoteCluster.Status.IsSync)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteRemoteClusterStep() test.TestStep[*elasticsearchapicrd.RemoteCluster] {
+	return test.TestStep[*elasticsearchapicrd.RemoteCluster]{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.RemoteCluster, data map[string]any) (err error) {
+			logrus.Infof("=== Delete remote cluster %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Remote cluster is null")
+			}
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), o, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.RemoteCluster, data map[string]any) (err error) {
+			remoteCluster := &elasticsearchapicrd.RemoteCluster{}
+			isDeleted := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, remoteCluster); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Remote cluster stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+			assert.Equal(t, true, data["isDeleted"])
+			return nil
+		},
+	}
+}
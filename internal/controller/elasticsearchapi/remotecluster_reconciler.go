@@ -0,0 +1,434 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
+	elasticsearchcontrollers "github.com/webcenter-fr/elasticsearch-operator/internal/controller/elasticsearch"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// remoteClusterServerPort is the port of the remote cluster server used by API key security
+	remoteClusterServerPort = 9443
+
+	// remoteClusterTransportPort is the transport port used by certificate security
+	remoteClusterTransportPort = 9300
+)
+
+type remoteClusterReconciler struct {
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler]
+	name string
+}
+
+func newRemoteClusterReconciler(name string, client client.Client, recorder record.EventRecorder) remote.RemoteReconcilerAction[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler] {
+	return &remoteClusterReconciler{
+		RemoteReconcilerAction: remote.NewRemoteReconcilerAction[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler](
+			client,
+			recorder,
+		),
+		name: name,
+	}
+}
+
+func (h *remoteClusterReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.RemoteCluster, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+	esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, h.Client(), logger)
+	if err != nil && o.DeletionTimestamp.IsZero() {
+		return nil, res, err
+	}
+
+	// Elastic not ready
+	if esClient == nil {
+		if o.DeletionTimestamp.IsZero() {
+			return nil, reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+		}
+
+		return nil, res, nil
+	}
+
+	handler = newRemoteClusterApiClient(esClient)
+
+	return handler, res, nil
+}
+
+// Read compute the addresses of the managed remote cluster
+func (h *remoteClusterReconciler) Read(ctx context.Context, o *elasticsearchapicrd.RemoteCluster, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler], logger *logrus.Entry) (read remote.RemoteRead[*remoteClusterSettings], res reconcile.Result, err error) {
+	read, res, err = h.RemoteReconcilerAction.Read(ctx, o, data, handler, logger)
+	if err != nil {
+		return nil, res, err
+	}
+
+	if !o.IsManagedRemote() {
+		return read, res, nil
+	}
+
+	remoteEs, err := common.GetElasticsearchFromRef(ctx, h.Client(), o, o.GetRemoteElasticsearchRef())
+	if err != nil {
+		return nil, res, errors.Wrap(err, "Error when get remote Elasticsearch")
+	}
+	if remoteEs == nil {
+		logger.Warnf("Remote Elasticsearch %s not yet exist, try later", o.Spec.Remote.Managed.Name)
+		h.Recorder().Eventf(o, corev1.EventTypeWarning, "Failed", "Remote Elasticsearch %s not yet exist", o.Spec.Remote.Managed.Name)
+		return nil, reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+	}
+	data["remoteElasticsearch"] = remoteEs
+
+	seeds, proxyAddress, serverName := computeRemoteClusterAddresses(o, remoteEs)
+	read.GetExpectedObject().Seeds = seeds
+	read.GetExpectedObject().ProxyAddress = proxyAddress
+	read.GetExpectedObject().ServerName = serverName
+
+	return read, res, nil
+}
+
+// OnSuccess exchange the transport CA and the API key between the clusters, then read the connection status
+func (h *remoteClusterReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.RemoteCluster, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*remoteClusterSettings], logger *logrus.Entry) (res reconcile.Result, err error) {
+	// Not touch the clusters when the dry-run annotation is set
+	if o.GetDryRunStatus() == nil {
+		if res, err = h.syncRemoteClusterSecrets(ctx, o, data, logger); err != nil || res != (reconcile.Result{}) {
+			return res, err
+		}
+	}
+
+	info, err := remoteClusterGetInfo(handler.Client(), o.GetExternalName())
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get remote cluster info")
+	}
+	if info == nil {
+		info = &remoteClusterInfo{}
+	}
+	if o.Status.Connected != info.Connected {
+		if info.Connected {
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "Connected", "Remote cluster %s is connected", o.GetExternalName())
+		} else {
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "Disconnected", "Remote cluster %s is not connected", o.GetExternalName())
+		}
+	}
+	o.Status.Connected = info.Connected
+	o.Status.NumNodesConnected = info.NumNodesConnected
+	o.Status.NumProxySocketsConnected = info.NumProxySocketsConnected
+
+	res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	if err != nil {
+		return res, err
+	}
+
+	// The connection can take time, after the rolling restart needed by the CA or the API key
+	if !o.Status.Connected && (res.RequeueAfter == 0 || res.RequeueAfter > 60*time.Second) {
+		res.RequeueAfter = 60 * time.Second
+	}
+
+	return res, nil
+}
+
+// Delete remove the transport CA and the API key from the clusters, then the remote cluster settings
+func (h *remoteClusterReconciler) Delete(ctx context.Context, o *elasticsearchapicrd.RemoteCluster, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler], logger *logrus.Entry) (err error) {
+	if err = h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger); err != nil {
+		return err
+	}
+
+	localEs, err := common.GetElasticsearchFromRef(ctx, h.Client(), o, o.Spec.ElasticsearchRef)
+	if err != nil {
+		return errors.Wrap(err, "Error when get Elasticsearch")
+	}
+	if localEs != nil {
+		if err = h.updateRemoteClusterSecret(ctx, localEs, map[string][]byte{getRemoteClusterCaKey(o): nil, getRemoteClusterCredentialsKey(o): nil}); err != nil {
+			return err
+		}
+	}
+
+	if o.IsManagedRemote() {
+		remoteEs, err := common.GetElasticsearchFromRef(ctx, h.Client(), o, o.GetRemoteElasticsearchRef())
+		if err != nil {
+			return errors.Wrap(err, "Error when get remote Elasticsearch")
+		}
+		if remoteEs != nil {
+			if err = h.updateRemoteClusterSecret(ctx, remoteEs, map[string][]byte{getRemoteClusterCaKey(o): nil, getRemoteClusterServerKey(o): nil}); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Invalidate the API key created by operator
+	apiKeySecret := &corev1.Secret{}
+	if err = h.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: GetRemoteClusterApiKeySecretName(o)}, apiKeySecret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "Error when get secret %s", GetRemoteClusterApiKeySecretName(o))
+	}
+	if len(apiKeySecret.Data["id"]) > 0 && o.IsManagedRemote() {
+		remoteClient, err := GetElasticsearchHandler(ctx, o, o.GetRemoteElasticsearchRef(), h.Client(), logger)
+		if err != nil || remoteClient == nil {
+			logger.Warnf("Remote Elasticsearch not reachable, skip API key invalidation: %v", err)
+			return nil
+		}
+		if err = remoteClusterApiKeyInvalidate(remoteClient, string(apiKeySecret.Data["id"])); err != nil {
+			return errors.Wrap(err, "Error when invalidate cross-cluster API key")
+		}
+	}
+
+	return nil
+}
+
+// syncRemoteClusterSecrets put the transport CA and the API key on the secrets used by the managed clusters
+// Elasticsearch controllers mount them, so the clusters trust each other
+func (h *remoteClusterReconciler) syncRemoteClusterSecrets(ctx context.Context, o *elasticsearchapicrd.RemoteCluster, data map[string]any, logger *logrus.Entry) (res reconcile.Result, err error) {
+	localEs, err := common.GetElasticsearchFromRef(ctx, h.Client(), o, o.Spec.ElasticsearchRef)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get Elasticsearch")
+	}
+	var remoteEs *elasticsearchcrd.Elasticsearch
+	if d, ok := data["remoteElasticsearch"]; ok {
+		remoteEs = d.(*elasticsearchcrd.Elasticsearch)
+	}
+
+	// The local cluster need to trust the CA of remote cluster, and to store the API key on its keystore
+	if localEs != nil {
+		expectedData := map[string][]byte{
+			getRemoteClusterCaKey(o):          nil,
+			getRemoteClusterCredentialsKey(o): nil,
+		}
+		if remoteEs != nil {
+			ca, err := h.getTransportCa(ctx, remoteEs)
+			if err != nil {
+				return res, err
+			}
+			if ca == nil {
+				logger.Warnf("Transport CA of Elasticsearch %s/%s not yet exist, try later", remoteEs.Namespace, remoteEs.Name)
+				return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+			expectedData[getRemoteClusterCaKey(o)] = ca
+		}
+		if o.GetSecurityMode() == elasticsearchapicrd.RemoteClusterSecurityModeApiKey {
+			apiKey, err := h.getApiKey(ctx, o, logger)
+			if err != nil {
+				return res, err
+			}
+			if apiKey == nil {
+				return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+			expectedData[getRemoteClusterCredentialsKey(o)] = apiKey
+		}
+
+		if err = h.updateRemoteClusterSecret(ctx, localEs, expectedData); err != nil {
+			return res, err
+		}
+	} else if o.GetSecurityMode() == elasticsearchapicrd.RemoteClusterSecurityModeApiKey {
+		logger.Infof("Elasticsearch is not managed by operator, you need to add the key %s on its keystore", getRemoteClusterCredentialsKey(o))
+	}
+
+	// The remote cluster need to trust the CA of local cluster with certificate security
+	// With API key security, it need to enable the remote cluster server
+	if remoteEs != nil {
+		expectedData := map[string][]byte{
+			getRemoteClusterCaKey(o):     nil,
+			getRemoteClusterServerKey(o): nil,
+		}
+		if o.GetSecurityMode() == elasticsearchapicrd.RemoteClusterSecurityModeApiKey {
+			expectedData[getRemoteClusterServerKey(o)] = []byte("true")
+		}
+		if localEs != nil && o.GetSecurityMode() == elasticsearchapicrd.RemoteClusterSecurityModeCertificate {
+			ca, err := h.getTransportCa(ctx, localEs)
+			if err != nil {
+				return res, err
+			}
+			if ca == nil {
+				logger.Warnf("Transport CA of Elasticsearch %s/%s not yet exist, try later", localEs.Namespace, localEs.Name)
+				return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+			expectedData[getRemoteClusterCaKey(o)] = ca
+		}
+
+		if err = h.updateRemoteClusterSecret(ctx, remoteEs, expectedData); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// getTransportCa return the CA of transport layer of managed cluster
+// It return nil if not yet exist
+func (h *remoteClusterReconciler) getTransportCa(ctx context.Context, es *elasticsearchcrd.Elasticsearch) (ca []byte, err error) {
+	secret := &corev1.Secret{}
+	if err = h.Client().Get(ctx, types.NamespacedName{Namespace: es.Namespace, Name: elasticsearchcontrollers.GetSecretNameForTlsTransport(es)}, secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "Error when get secret %s", elasticsearchcontrollers.GetSecretNameForTlsTransport(es))
+	}
+
+	return secret.Data["ca.crt"], nil
+}
+
+// getApiKey return the encoded cross-cluster API key
+// It create the API key on remote cluster when it's managed by operator
+// It return nil if the API key is not yet available
+func (h *remoteClusterReconciler) getApiKey(ctx context.Context, o *elasticsearchapicrd.RemoteCluster, logger *logrus.Entry) (apiKey []byte, err error) {
+	secret := &corev1.Secret{}
+
+	// API key provided by user
+	if !o.IsManagedApiKey() {
+		if err = h.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Spec.Security.ApiKeySecretRef.Name}, secret); err != nil {
+			if k8serrors.IsNotFound(err) {
+				logger.Warnf("Secret %s not yet exist, try later", o.Spec.Security.ApiKeySecretRef.Name)
+				h.Recorder().Eventf(o, corev1.EventTypeWarning, "Failed", "Secret %s not yet exist", o.Spec.Security.ApiKeySecretRef.Name)
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "Error when get secret %s", o.Spec.Security.ApiKeySecretRef.Name)
+		}
+		if len(secret.Data[o.Spec.Security.ApiKeySecretRef.Key]) == 0 {
+			return nil, errors.Errorf("Secret %s must have a %s key", o.Spec.Security.ApiKeySecretRef.Name, o.Spec.Security.ApiKeySecretRef.Key)
+		}
+
+		return secret.Data[o.Spec.Security.ApiKeySecretRef.Key], nil
+	}
+
+	// API key created by operator on managed remote cluster
+	access, err := json.Marshal(o.Spec.Security.Access)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when convert API key access")
+	}
+	if err = h.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: GetRemoteClusterApiKeySecretName(o)}, secret); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "Error when get secret %s", GetRemoteClusterApiKeySecretName(o))
+		}
+		secret = nil
+	}
+	if secret != nil && len(secret.Data["apiKey"]) > 0 && string(secret.Data["access"]) == string(access) {
+		return secret.Data["apiKey"], nil
+	}
+
+	remoteClient, err := GetElasticsearchHandler(ctx, o, o.GetRemoteElasticsearchRef(), h.Client(), logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when connect on remote Elasticsearch")
+	}
+	if remoteClient == nil {
+		logger.Warn("Remote Elasticsearch not yet ready, try later")
+		return nil, nil
+	}
+
+	// Only the access change
+	if secret != nil && len(secret.Data["apiKey"]) > 0 {
+		if err = remoteClusterApiKeyUpdate(remoteClient, string(secret.Data["id"]), o.Spec.Security.Access); err != nil {
+			return nil, errors.Wrap(err, "Error when update cross-cluster API key")
+		}
+		secret.Data["access"] = access
+		if err = h.Client().Update(ctx, secret); err != nil {
+			return nil, errors.Wrapf(err, "Error when update secret %s", secret.Name)
+		}
+		h.Recorder().Eventf(o, corev1.EventTypeNormal, "Completed", "Cross-cluster API key access updated")
+
+		return secret.Data["apiKey"], nil
+	}
+
+	id, encoded, err := remoteClusterApiKeyCreate(remoteClient, fmt.Sprintf("%s-%s", o.Namespace, o.Name), o.Spec.Security.Access)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when create cross-cluster API key")
+	}
+	secretData := map[string][]byte{
+		"id":     []byte(id),
+		"apiKey": []byte(encoded),
+		"access": access,
+	}
+	if secret == nil {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GetRemoteClusterApiKeySecretName(o),
+				Namespace: o.Namespace,
+			},
+			Data: secretData,
+		}
+		// Set owner
+		if err = ctrl.SetControllerReference(o, secret, h.Client().Scheme()); err != nil {
+			return nil, errors.Wrapf(err, "Error when set owner reference on object '%s'", secret.GetName())
+		}
+		if err = h.Client().Create(ctx, secret); err != nil {
+			return nil, errors.Wrap(err, "Error when create secret that store cross-cluster API key")
+		}
+	} else {
+		secret.Data = secretData
+		if err = h.Client().Update(ctx, secret); err != nil {
+			return nil, errors.Wrap(err, "Error when update secret that store cross-cluster API key")
+		}
+	}
+	h.Recorder().Eventf(o, corev1.EventTypeNormal, "Completed", "Cross-cluster API key created on remote cluster")
+
+	return []byte(encoded), nil
+}
+
+// updateRemoteClusterSecret set the keys on the remote cluster secret of managed cluster
+// The key is removed when the value is nil
+func (h *remoteClusterReconciler) updateRemoteClusterSecret(ctx context.Context, es *elasticsearchcrd.Elasticsearch, expectedData map[string][]byte) (err error) {
+	_, err = updateElasticsearchSecret(ctx, h.Client(), es, elasticsearchcontrollers.GetSecretNameForRemoteCluster(es), expectedData)
+	return err
+}
+
+// computeRemoteClusterAddresses compute the addresses of managed remote cluster
+// The API key security use the remote cluster server port, that is only reachable from headless services
+func computeRemoteClusterAddresses(o *elasticsearchapicrd.RemoteCluster, remoteEs *elasticsearchcrd.Elasticsearch) (seeds []string, proxyAddress string, serverName string) {
+	hosts := []string{}
+	port := remoteClusterTransportPort
+	if o.GetSecurityMode() == elasticsearchapicrd.RemoteClusterSecurityModeApiKey {
+		port = remoteClusterServerPort
+		if o.Spec.Remote.Managed.TargetNodeGroup != "" {
+			hosts = append(hosts, fmt.Sprintf("%s.%s.svc", elasticsearchcontrollers.GetNodeGroupServiceNameHeadless(remoteEs, o.Spec.Remote.Managed.TargetNodeGroup), remoteEs.Namespace))
+		} else {
+			for _, nodeGroup := range remoteEs.Spec.NodeGroups {
+				hosts = append(hosts, fmt.Sprintf("%s.%s.svc", elasticsearchcontrollers.GetNodeGroupServiceNameHeadless(remoteEs, nodeGroup.Name), remoteEs.Namespace))
+			}
+		}
+	} else {
+		if o.Spec.Remote.Managed.TargetNodeGroup != "" {
+			hosts = append(hosts, fmt.Sprintf("%s.%s.svc", elasticsearchcontrollers.GetNodeGroupServiceName(remoteEs, o.Spec.Remote.Managed.TargetNodeGroup), remoteEs.Namespace))
+		} else {
+			hosts = append(hosts, fmt.Sprintf("%s.%s.svc", elasticsearchcontrollers.GetGlobalServiceName(remoteEs), remoteEs.Namespace))
+		}
+	}
+
+	if o.GetMode() == elasticsearchapicrd.RemoteClusterModeProxy {
+		if len(hosts) == 0 {
+			return nil, "", ""
+		}
+		return nil, fmt.Sprintf("%s:%d", hosts[0], port), hosts[0]
+	}
+
+	seeds = make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		seeds = append(seeds, fmt.Sprintf("%s:%d", host, port))
+	}
+
+	return seeds, "", ""
+}
+
+// getRemoteClusterCaKey return the key that store the transport CA on the remote cluster secret
+func getRemoteClusterCaKey(o *elasticsearchapicrd.RemoteCluster) string {
+	return fmt.Sprintf("%s-%s.crt", o.Namespace, o.Name)
+}
+
+// getRemoteClusterServerKey return the key that enable the remote cluster server on the remote cluster
+func getRemoteClusterServerKey(o *elasticsearchapicrd.RemoteCluster) string {
+	return fmt.Sprintf("%s-%s%s", o.Namespace, o.Name, elasticsearchcontrollers.RemoteClusterServerKeySuffix)
+}
+
+// getRemoteClusterCredentialsKey return the keystore setting that store the cross-cluster API key
+func getRemoteClusterCredentialsKey(o *elasticsearchapicrd.RemoteCluster) string {
+	return fmt.Sprintf("cluster.remote.%s.credentials", o.GetExternalName())
+}
@@ -0,0 +1,104 @@
+package elasticsearchapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// resourceSetExternalNameFields is the spec field that store the remote object name, when it's not `name`
+var resourceSetExternalNameFields = map[string]string{
+	"SnapshotLifecyclePolicy": "snapshotLifecyclePolicyName",
+	"User":                    "username",
+}
+
+// newResourceSetObject return new empty object of the given type
+func newResourceSetObject[k8sStepObject object.RemoteObject]() k8sStepObject {
+	return reflect.New(reflect.TypeOf((*k8sStepObject)(nil)).Elem().Elem()).Interface().(k8sStepObject)
+}
+
+// buildResourceSetObjects permit to generate the resources of the given kind for each selected Elasticsearch clusters
+func buildResourceSetObjects[k8sStepObject object.RemoteObject](o *elasticsearchapicrd.ResourceSet, kind string, clusters []elasticsearchcrd.Elasticsearch) (objects []k8sStepObject, err error) {
+	templates := o.GetTemplates(kind)
+	objects = make([]k8sStepObject, 0, len(templates)*len(clusters))
+
+	externalNameField, ok := resourceSetExternalNameFields[kind]
+	if !ok {
+		externalNameField = "name"
+	}
+
+	for _, template := range templates {
+		if template.Spec == nil {
+			return nil, errors.Errorf("Template %s/%s has no spec", kind, template.Name)
+		}
+
+		for _, cluster := range clusters {
+			// Copy the template spec to not alter it
+			spec := map[string]any{}
+			rawSpec, err := json.Marshal(template.Spec.Data)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error when encode spec of template %s/%s", kind, template.Name)
+			}
+			if err = json.Unmarshal(rawSpec, &spec); err != nil {
+				return nil, errors.Wrapf(err, "Error when decode spec of template %s/%s", kind, template.Name)
+			}
+
+			spec["elasticsearchRef"] = map[string]any{
+				"managed": map[string]any{
+					"name":      cluster.Name,
+					"namespace": cluster.Namespace,
+				},
+			}
+			if value, ok := spec[externalNameField]; !ok || value == "" {
+				spec[externalNameField] = template.Name
+			}
+
+			labels := map[string]string{}
+			for key, value := range template.Labels {
+				labels[key] = value
+			}
+			labels[elasticsearchapicrd.ResourceSetLabel] = elasticsearchapicrd.GetResourceSetLabelValue(o.Name)
+			labels[elasticsearchapicrd.ResourceSetTemplateLabel] = elasticsearchapicrd.GetResourceSetLabelValue(template.Name)
+			labels[elasticsearchapicrd.ResourceSetClusterNameLabel] = elasticsearchapicrd.GetResourceSetLabelValue(cluster.Name)
+			labels[elasticsearchapicrd.ResourceSetClusterNamespaceLabel] = cluster.Namespace
+
+			annotations := map[string]string{}
+			for key, value := range template.Annotations {
+				annotations[key] = value
+			}
+
+			rawObject, err := json.Marshal(map[string]any{
+				"metadata": metav1.ObjectMeta{
+					Name:        o.GetResourceName(template, cluster.Name, cluster.Namespace),
+					Namespace:   o.Namespace,
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				"spec": spec,
+			})
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error when encode %s from template %s", kind, template.Name)
+			}
+
+			object := newResourceSetObject[k8sStepObject]()
+			if err = json.Unmarshal(rawObject, object); err != nil {
+				return nil, errors.Wrapf(err, "Error when decode %s from template %s", kind, template.Name)
+			}
+
+			objects = append(objects, object)
+		}
+	}
+
+	return objects, nil
+}
+
+// resourceSetClusterKey return the key to identify the Elasticsearch cluster on status
+func resourceSetClusterKey(name string, namespace string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
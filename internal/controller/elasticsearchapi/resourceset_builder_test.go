@@ -0,0 +1,139 @@
+package elasticsearchapi
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestBuildResourceSetObjects(t *testing.T) {
+	o := &elasticsearchapicrd.ResourceSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test",
+		},
+		Spec: elasticsearchapicrd.ResourceSetSpec{
+			Templates: []elasticsearchapicrd.ResourceSetTemplate{
+				{
+					Kind: "Role",
+					Name: "reader",
+					Labels: map[string]string{
+						"team": "ops",
+					},
+					Annotations: map[string]string{
+						"elasticsearchapi.k8s.webcenter.fr/dry-run": "true",
+					},
+					Spec: &apis.MapAny{
+						Data: map[string]any{
+							"cluster": []any{"monitor"},
+						},
+					},
+				},
+				{
+					Kind: "Role",
+					Name: "writer",
+					Spec: &apis.MapAny{
+						Data: map[string]any{
+							"name":    "my_writer",
+							"cluster": []any{"all"},
+						},
+					},
+				},
+				{
+					Kind: "User",
+					Name: "admin",
+					Spec: &apis.MapAny{
+						Data: map[string]any{
+							"enabled": true,
+						},
+					},
+				},
+			},
+		},
+	}
+	clusters := []elasticsearchcrd.Elasticsearch{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "es",
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "prod",
+				Name:      "es",
+			},
+		},
+	}
+
+	// Roles
+	roles, err := buildResourceSetObjects[*elasticsearchapicrd.Role](o, "Role", clusters)
+	assert.NoError(t, err)
+	assert.Len(t, roles, 4)
+
+	assert.Equal(t, "test-reader-es", roles[0].Name)
+	assert.Equal(t, "default", roles[0].Namespace)
+	assert.Equal(t, map[string]string{
+		"team":                               "ops",
+		elasticsearchapicrd.ResourceSetLabel: "test",
+		elasticsearchapicrd.ResourceSetTemplateLabel:         "reader",
+		elasticsearchapicrd.ResourceSetClusterNameLabel:      "es",
+		elasticsearchapicrd.ResourceSetClusterNamespaceLabel: "default",
+	}, roles[0].Labels)
+	assert.Equal(t, map[string]string{"elasticsearchapi.k8s.webcenter.fr/dry-run": "true"}, roles[0].Annotations)
+	assert.Equal(t, "es", roles[0].Spec.ElasticsearchRef.ManagedElasticsearchRef.Name)
+	assert.Equal(t, "default", roles[0].Spec.ElasticsearchRef.ManagedElasticsearchRef.Namespace)
+	assert.Equal(t, "reader", roles[0].Spec.Name)
+	assert.Equal(t, []string{"monitor"}, roles[0].Spec.Cluster)
+
+	assert.Equal(t, "test-reader-prod-es", roles[1].Name)
+	assert.Equal(t, "default", roles[1].Namespace)
+	assert.Equal(t, "prod", roles[1].Spec.ElasticsearchRef.ManagedElasticsearchRef.Namespace)
+
+	assert.Equal(t, "test-writer-es", roles[2].Name)
+	assert.Equal(t, "my_writer", roles[2].Spec.Name)
+
+	// The template is not altered
+	assert.NotContains(t, o.Spec.Templates[0].Spec.Data, "elasticsearchRef")
+
+	// Users use username as remote name
+	users, err := buildResourceSetObjects[*elasticsearchapicrd.User](o, "User", clusters)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "admin", users[0].Spec.Username)
+
+	// When no template for kind
+	watches, err := buildResourceSetObjects[*elasticsearchapicrd.Watch](o, "Watch", clusters)
+	assert.NoError(t, err)
+	assert.Empty(t, watches)
+
+	// When names are too long to be label values
+	o.Name = strings.Repeat("a", 100)
+	longClusters := []elasticsearchcrd.Elasticsearch{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      strings.Repeat("b", 100),
+			},
+		},
+	}
+	roles, err = buildResourceSetObjects[*elasticsearchapicrd.Role](o, "Role", longClusters)
+	assert.NoError(t, err)
+	assert.Len(t, roles, 2)
+	for _, value := range roles[0].Labels {
+		assert.Empty(t, validation.IsValidLabelValue(value))
+	}
+	assert.Equal(t, elasticsearchapicrd.GetResourceSetLabelValue(o.Name), roles[0].Labels[elasticsearchapicrd.ResourceSetLabel])
+	assert.Equal(t, elasticsearchapicrd.GetResourceSetLabelValue(longClusters[0].Name), roles[0].Labels[elasticsearchapicrd.ResourceSetClusterNameLabel])
+
+	// When no cluster selected
+	roles, err = buildResourceSetObjects[*elasticsearchapicrd.Role](o, "Role", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, roles)
+}
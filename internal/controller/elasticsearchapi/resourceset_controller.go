@@ -0,0 +1,185 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearchapi
+
+import (
+	"context"
+	"sort"
+
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/multiphase"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	resourceSetName string = "resourceset"
+)
+
+// ResourceSetReconciler reconciles a ResourceSet object
+type ResourceSetReconciler struct {
+	controller.Controller
+	multiphase.MultiPhaseReconciler[*elasticsearchapicrd.ResourceSet]
+	multiphase.MultiPhaseReconcilerAction[*elasticsearchapicrd.ResourceSet]
+	name            string
+	stepReconcilers []multiphase.MultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, client.Object]
+}
+
+func NewResourceSetReconciler(c client.Client, logger *logrus.Entry, recorder record.EventRecorder) controller.Controller {
+	return &ResourceSetReconciler{
+		Controller: controller.NewController(),
+		MultiPhaseReconciler: multiphase.NewMultiPhaseReconciler[*elasticsearchapicrd.ResourceSet](
+			c,
+			resourceSetName,
+			"resourceset.elasticsearchapi.k8s.webcenter.fr/finalizer",
+			logger,
+			recorder,
+		),
+		MultiPhaseReconcilerAction: multiphase.NewMultiPhaseReconcilerAction[*elasticsearchapicrd.ResourceSet](
+			c,
+			controller.ReadyCondition,
+			recorder,
+		),
+		name: resourceSetName,
+		stepReconcilers: []multiphase.MultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, client.Object]{
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.SnapshotRepository, client.Object](newResourceSetReconciler[*elasticsearchapicrd.SnapshotRepository](c, recorder, "SnapshotRepository", func() client.ObjectList { return &elasticsearchapicrd.SnapshotRepositoryList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.IndexLifecyclePolicy, client.Object](newResourceSetReconciler[*elasticsearchapicrd.IndexLifecyclePolicy](c, recorder, "IndexLifecyclePolicy", func() client.ObjectList { return &elasticsearchapicrd.IndexLifecyclePolicyList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.SnapshotLifecyclePolicy, client.Object](newResourceSetReconciler[*elasticsearchapicrd.SnapshotLifecyclePolicy](c, recorder, "SnapshotLifecyclePolicy", func() client.ObjectList { return &elasticsearchapicrd.SnapshotLifecyclePolicyList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.ComponentTemplate, client.Object](newResourceSetReconciler[*elasticsearchapicrd.ComponentTemplate](c, recorder, "ComponentTemplate", func() client.ObjectList { return &elasticsearchapicrd.ComponentTemplateList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.IndexTemplate, client.Object](newResourceSetReconciler[*elasticsearchapicrd.IndexTemplate](c, recorder, "IndexTemplate", func() client.ObjectList { return &elasticsearchapicrd.IndexTemplateList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.StoredScript, client.Object](newResourceSetReconciler[*elasticsearchapicrd.StoredScript](c, recorder, "StoredScript", func() client.ObjectList { return &elasticsearchapicrd.StoredScriptList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.Role, client.Object](newResourceSetReconciler[*elasticsearchapicrd.Role](c, recorder, "Role", func() client.ObjectList { return &elasticsearchapicrd.RoleList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.RoleMapping, client.Object](newResourceSetReconciler[*elasticsearchapicrd.RoleMapping](c, recorder, "RoleMapping", func() client.ObjectList { return &elasticsearchapicrd.RoleMappingList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.User, client.Object](newResourceSetReconciler[*elasticsearchapicrd.User](c, recorder, "User", func() client.ObjectList { return &elasticsearchapicrd.UserList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.Transform, client.Object](newResourceSetReconciler[*elasticsearchapicrd.Transform](c, recorder, "Transform", func() client.ObjectList { return &elasticsearchapicrd.TransformList{} })),
+			multiphase.NewObjectMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, *elasticsearchapicrd.Watch, client.Object](newResourceSetReconciler[*elasticsearchapicrd.Watch](c, recorder, "Watch", func() client.ObjectList { return &elasticsearchapicrd.WatchList{} })),
+		},
+	}
+}
+
+//+kubebuilder:rbac:groups=elasticsearchapi.k8s.webcenter.fr,resources=resourcesets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=elasticsearchapi.k8s.webcenter.fr,resources=resourcesets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=elasticsearchapi.k8s.webcenter.fr,resources=resourcesets/finalizers,verbs=update
+//+kubebuilder:rbac:groups=elasticsearchapi.k8s.webcenter.fr,resources=componenttemplates;indexlifecyclepolicies;indextemplates;roles;rolemappings;snapshotlifecyclepolicies;snapshotrepositories;storedscripts;transforms;users;watches,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups="elasticsearch.k8s.webcenter.fr",resources=elasticsearches,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+// It create the templated resources for each selected Elasticsearch cluster
+//
+// For more details, check Reconcile and its Result here:
+// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+func (r *ResourceSetReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	rs := &elasticsearchapicrd.ResourceSet{}
+	data := map[string]any{}
+
+	return r.MultiPhaseReconciler.Reconcile(
+		ctx,
+		req,
+		rs,
+		data,
+		r,
+		r.stepReconcilers...,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResourceSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("elasticsearch-resourceset").
+		For(&elasticsearchapicrd.ResourceSet{}).
+		Owns(&elasticsearchapicrd.ComponentTemplate{}).
+		Owns(&elasticsearchapicrd.IndexLifecyclePolicy{}).
+		Owns(&elasticsearchapicrd.IndexTemplate{}).
+		Owns(&elasticsearchapicrd.Role{}).
+		Owns(&elasticsearchapicrd.RoleMapping{}).
+		Owns(&elasticsearchapicrd.SnapshotLifecyclePolicy{}).
+		Owns(&elasticsearchapicrd.SnapshotRepository{}).
+		Owns(&elasticsearchapicrd.StoredScript{}).
+		Owns(&elasticsearchapicrd.Transform{}).
+		Owns(&elasticsearchapicrd.User{}).
+		Owns(&elasticsearchapicrd.Watch{}).
+		Watches(&elasticsearchcrd.Elasticsearch{}, handler.EnqueueRequestsFromMapFunc(watchResourceSetElasticsearch(r.Client()))).
+		WithOptions(k8scontroller.Options{
+			RateLimiter: controller.DefaultControllerRateLimiter[reconcile.Request](),
+		}).
+		Complete(r)
+}
+
+func (r *ResourceSetReconciler) Client() client.Client {
+	return r.MultiPhaseReconcilerAction.Client()
+}
+
+func (r *ResourceSetReconciler) Recorder() record.EventRecorder {
+	return r.MultiPhaseReconcilerAction.Recorder()
+}
+
+func (r *ResourceSetReconciler) Configure(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.ResourceSet, data map[string]any, logger *logrus.Entry) (res reconcile.Result, err error) {
+	// Set prometheus Metrics
+	common.ControllerInstances.WithLabelValues(r.name, o.GetNamespace(), o.GetName()).Set(1)
+
+	return r.MultiPhaseReconcilerAction.Configure(ctx, req, o, data, logger)
+}
+
+func (r *ResourceSetReconciler) Delete(ctx context.Context, o *elasticsearchapicrd.ResourceSet, data map[string]any, logger *logrus.Entry) (err error) {
+	// Set prometheus Metrics
+	common.ControllerInstances.WithLabelValues(r.name, o.GetNamespace(), o.GetName()).Set(0)
+
+	return r.MultiPhaseReconcilerAction.Delete(ctx, o, data, logger)
+}
+
+func (r *ResourceSetReconciler) OnError(ctx context.Context, o *elasticsearchapicrd.ResourceSet, data map[string]any, currentErr error, logger *logrus.Entry) (res reconcile.Result, err error) {
+	common.TotalErrors.Inc()
+	common.ControllerErrors.WithLabelValues(r.name, o.GetNamespace(), o.GetName()).Inc()
+
+	return r.MultiPhaseReconcilerAction.OnError(ctx, o, data, currentErr, logger)
+}
+
+func (r *ResourceSetReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.ResourceSet, data map[string]any, logger *logrus.Entry) (res reconcile.Result, err error) {
+	// Reset the current cluster errors
+	common.ControllerErrors.WithLabelValues(r.name, o.GetNamespace(), o.GetName()).Set(0)
+
+	res, err = r.MultiPhaseReconcilerAction.OnSuccess(ctx, o, data, logger)
+	if err != nil {
+		return res, err
+	}
+
+	// Set the sync status per cluster
+	// The child resources are owned, so the resource set is reconciled again when their status change
+	clusterStatus := getResourceSetClusterStatus(data)
+	o.Status.Clusters = make([]elasticsearchapicrd.ResourceSetClusterStatus, 0, len(clusterStatus))
+	for _, status := range clusterStatus {
+		status.IsSync = status.SyncedResources == status.Resources
+		sort.Strings(status.ResourcesOnError)
+		o.Status.Clusters = append(o.Status.Clusters, *status)
+	}
+	sort.Slice(o.Status.Clusters, func(i, j int) bool {
+		return resourceSetClusterKey(o.Status.Clusters[i].Name, o.Status.Clusters[i].Namespace) < resourceSetClusterKey(o.Status.Clusters[j].Name, o.Status.Clusters[j].Namespace)
+	})
+
+	return res, nil
+}
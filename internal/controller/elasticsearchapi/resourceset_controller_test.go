@@ -0,0 +1,307 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (t *ElasticsearchapiControllerTestSuite) TestResourceSetReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-rs-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{
+		"namespace": "t-rs-ns-" + helper.RandomString(10),
+	}
+
+	testCase := test.NewTestCase[*elasticsearchapicrd.ResourceSet](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchapicrd.ResourceSet]{
+		doCreateResourceSetStep(),
+		doUpdateResourceSetStep(),
+		doNamespaceSelectorResourceSetStep(),
+		doDeleteResourceSetStep(),
+	}
+	testCase.PreTest = doMockResourceSet(t.mockElasticsearchHandler, t.k8sClient, key, data)
+
+	testCase.Run()
+}
+
+// doMockResourceSet create the selected Elasticsearch clusters and mock the roles created from the templates
+// The mocks only match the roles of this resource set to not conflict with the role reconciler test
+func doMockResourceSet(mockES *mocks.MockElasticsearchHandler, c client.Client, key types.NamespacedName, data map[string]any) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, _ map[string]any) (err error) {
+		isResourceSetRole := gomock.Cond(func(name string) bool {
+			return strings.HasPrefix(name, key.Name)
+		})
+
+		mockES.EXPECT().RoleGet(isResourceSetRole).AnyTimes().Return(nil, nil)
+		mockES.EXPECT().RoleUpdate(isResourceSetRole, gomock.Any()).AnyTimes().Return(nil)
+		mockES.EXPECT().RoleDelete(isResourceSetRole).AnyTimes().Return(nil)
+
+		// One cluster on the same namespace and one cluster on other namespace
+		namespace := data["namespace"].(string)
+		if err = c.Create(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}); err != nil {
+			return err
+		}
+		for _, ns := range []string{key.Namespace, namespace} {
+			es := &elasticsearchcrd.Elasticsearch{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "es",
+					Namespace: ns,
+					Labels: map[string]string{
+						"resourceset": key.Name,
+					},
+				},
+			}
+			if ns == key.Namespace {
+				es.Name = key.Name + "-es"
+			}
+			if err = c.Create(context.Background(), es); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func doCreateResourceSetStep() test.TestStep[*elasticsearchapicrd.ResourceSet] {
+	return test.TestStep[*elasticsearchapicrd.ResourceSet]{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.ResourceSet, data map[string]any) (err error) {
+			logrus.Infof("=== Add new resource set %s/%s ===\n\n", key.Namespace, key.Name)
+
+			rs := &elasticsearchapicrd.ResourceSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchapicrd.ResourceSetSpec{
+					ElasticsearchSelector: metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"resourceset": key.Name,
+						},
+					},
+					Templates: []elasticsearchapicrd.ResourceSetTemplate{
+						{
+							Kind: "Role",
+							Name: "reader",
+							Spec: &apis.MapAny{
+								Data: map[string]any{
+									"cluster": []any{"monitor"},
+								},
+							},
+						},
+						{
+							Kind: "Role",
+							Name: "writer",
+							Spec: &apis.MapAny{
+								Data: map[string]any{
+									"cluster": []any{"all"},
+								},
+							},
+						},
+					},
+				},
+			}
+			if err = c.Create(context.Background(), rs); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.ResourceSet, data map[string]any) (err error) {
+			rs := &elasticsearchapicrd.ResourceSet{}
+			roles := &elasticsearchapicrd.RoleList{}
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, rs); err != nil {
+					t.Fatal(err)
+				}
+				if err := c.List(context.Background(), roles, client.InNamespace(key.Namespace), client.MatchingLabels{elasticsearchapicrd.ResourceSetLabel: key.Name}); err != nil {
+					t.Fatal(err)
+				}
+				if len(roles.Items) != 2 || len(rs.Status.Clusters) != 1 {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get resource set roles: %s", err.Error())
+			}
+
+			for _, role := range roles.Items {
+				assert.True(t, metav1.IsControlledBy(&role, rs))
+				assert.Equal(t, key.Name+"-es", role.Spec.ElasticsearchRef.ManagedElasticsearchRef.Name)
+			}
+			assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: fmt.Sprintf("%s-reader-%s-es", key.Name, key.Name)}, &elasticsearchapicrd.Role{}))
+			assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: fmt.Sprintf("%s-writer-%s-es", key.Name, key.Name)}, &elasticsearchapicrd.Role{}))
+			assert.Equal(t, key.Name+"-es", rs.Status.Clusters[0].Name)
+			assert.Equal(t, 2, rs.Status.Clusters[0].Resources)
+
+			return nil
+		},
+	}
+}
+
+func doUpdateResourceSetStep() test.TestStep[*elasticsearchapicrd.ResourceSet] {
+	return test.TestStep[*elasticsearchapicrd.ResourceSet]{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.ResourceSet, data map[string]any) (err error) {
+			logrus.Infof("=== Remove template from resource set %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Resource set is null")
+			}
+
+			o.Spec.Templates = o.Spec.Templates[:1]
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.ResourceSet, data map[string]any) (err error) {
+			role := &elasticsearchapicrd.Role{}
+
+			// The resource of the removed template is deleted
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: fmt.Sprintf("%s-writer-%s-es", key.Name, key.Name)}, role); err != nil {
+					if k8serrors.IsNotFound(err) {
+						return nil
+					}
+					t.Fatal(err)
+				}
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Role of removed template still exist: %s", err.Error())
+			}
+
+			assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: fmt.Sprintf("%s-reader-%s-es", key.Name, key.Name)}, role))
+
+			return nil
+		},
+	}
+}
+
+func doNamespaceSelectorResourceSetStep() test.TestStep[*elasticsearchapicrd.ResourceSet] {
+	return test.TestStep[*elasticsearchapicrd.ResourceSet]{
+		Name: "namespaceSelector",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.ResourceSet, data map[string]any) (err error) {
+			logrus.Infof("=== Select other namespace on resource set %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Resource set is null")
+			}
+
+			o.Spec.NamespaceSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      corev1.LabelMetadataName,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{key.Namespace, data["namespace"].(string)},
+					},
+				},
+			}
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.ResourceSet, data map[string]any) (err error) {
+			rs := &elasticsearchapicrd.ResourceSet{}
+			role := &elasticsearchapicrd.Role{}
+			namespace := data["namespace"].(string)
+
+			// The resource is created on the resource set namespace for each selected cluster
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, rs); err != nil {
+					t.Fatal(err)
+				}
+				if err = c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: fmt.Sprintf("%s-reader-%s-es", key.Name, namespace)}, role); err != nil {
+					if k8serrors.IsNotFound(err) {
+						return errors.New("Not yet created")
+					}
+					t.Fatal(err)
+				}
+				if len(rs.Status.Clusters) != 2 {
+					return errors.New("Status not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get role of other namespace: %s", err.Error())
+			}
+
+			assert.Equal(t, "es", role.Spec.ElasticsearchRef.ManagedElasticsearchRef.Name)
+			assert.Equal(t, namespace, role.Spec.ElasticsearchRef.ManagedElasticsearchRef.Namespace)
+			assert.Equal(t, namespace, role.Labels[elasticsearchapicrd.ResourceSetClusterNamespaceLabel])
+			assert.NoError(t, c.Get(context.Background(), types.NamespacedName{Namespace: key.Namespace, Name: fmt.Sprintf("%s-reader-%s-es", key.Name, key.Name)}, role))
+
+			return nil
+		},
+	}
+}
+
+func doDeleteResourceSetStep() test.TestStep[*elasticsearchapicrd.ResourceSet] {
+	return test.TestStep[*elasticsearchapicrd.ResourceSet]{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.ResourceSet, data map[string]any) (err error) {
+			logrus.Infof("=== Delete resource set %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Resource set is null")
+			}
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), o, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.ResourceSet, data map[string]any) (err error) {
+			rs := &elasticsearchapicrd.ResourceSet{}
+			isDeleted := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, rs); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Resource set stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,175 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis/shared"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/multiphase"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/object"
+	"github.com/sirupsen/logrus"
+	elasticsearchcrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearch/v1"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	resourceSetClustersData      = "clusters"
+	resourceSetClusterStatusData = "clusterStatus"
+	resourceSetConditionSuffix   = "Ready"
+)
+
+type resourceSetReconciler[k8sStepObject object.RemoteObject] struct {
+	multiphase.MultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, k8sStepObject]
+	kind    string
+	newList func() client.ObjectList
+}
+
+// newResourceSetReconciler return the step reconciler that manage the resources of the given kind
+func newResourceSetReconciler[k8sStepObject object.RemoteObject](client client.Client, recorder record.EventRecorder, kind string, newList func() client.ObjectList) (multiPhaseStepReconcilerAction multiphase.MultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, k8sStepObject]) {
+	return &resourceSetReconciler[k8sStepObject]{
+		MultiPhaseStepReconcilerAction: multiphase.NewMultiPhaseStepReconcilerAction[*elasticsearchapicrd.ResourceSet, k8sStepObject](
+			client,
+			shared.PhaseName(kind),
+			shared.ConditionName(kind+resourceSetConditionSuffix),
+			recorder,
+		),
+		kind:    kind,
+		newList: newList,
+	}
+}
+
+// Read existing resources and compute the expected resources for each selected Elasticsearch cluster
+func (r *resourceSetReconciler[k8sStepObject]) Read(ctx context.Context, o *elasticsearchapicrd.ResourceSet, data map[string]any, logger *logrus.Entry) (read multiphase.MultiPhaseRead[k8sStepObject], res reconcile.Result, err error) {
+	read = multiphase.NewMultiPhaseRead[k8sStepObject]()
+
+	clusters, err := getResourceSetClusters(ctx, r.Client(), o, data)
+	if err != nil {
+		return read, res, err
+	}
+
+	// Read current resources
+	list := r.newList()
+	if err = r.Client().List(ctx, list, client.InNamespace(o.Namespace), client.MatchingLabels{elasticsearchapicrd.ResourceSetLabel: elasticsearchapicrd.GetResourceSetLabelValue(o.Name)}); err != nil {
+		return read, res, errors.Wrapf(err, "Error when read %s", r.kind)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return read, res, errors.Wrapf(err, "Error when extract %s from list", r.kind)
+	}
+	currentObjects := make(map[string]k8sStepObject, len(items))
+	for _, item := range items {
+		currentObject, ok := item.(k8sStepObject)
+		if !ok {
+			return read, res, errors.Errorf("Unexpected object %T on %s list", item, r.kind)
+		}
+		if !metav1.IsControlledBy(currentObject, o) {
+			continue
+		}
+		read.AddCurrentObject(currentObject)
+		currentObjects[currentObject.GetName()] = currentObject
+	}
+
+	// Generate expected resources
+	expectedObjects, err := buildResourceSetObjects[k8sStepObject](o, r.kind, clusters)
+	if err != nil {
+		return read, res, errors.Wrapf(err, "Error when generate %s", r.kind)
+	}
+	read.SetExpectedObjects(expectedObjects)
+
+	// Compute the sync status per cluster
+	clusterStatus := getResourceSetClusterStatus(data)
+	for _, expectedObject := range expectedObjects {
+		labels := expectedObject.GetLabels()
+		status, ok := clusterStatus[resourceSetClusterKey(labels[elasticsearchapicrd.ResourceSetClusterNameLabel], labels[elasticsearchapicrd.ResourceSetClusterNamespaceLabel])]
+		if !ok {
+			continue
+		}
+		status.Resources++
+
+		currentObject, ok := currentObjects[expectedObject.GetName()]
+		if !ok {
+			continue
+		}
+		if currentObject.GetStatus().GetIsOnError() {
+			status.ResourcesOnError = append(status.ResourcesOnError, fmt.Sprintf("%s/%s", r.kind, currentObject.GetName()))
+		} else if currentObject.GetStatus().GetIsSync() {
+			status.SyncedResources++
+		}
+	}
+
+	return read, res, nil
+}
+
+// getResourceSetClusters return the Elasticsearch clusters selected by the resource set
+// The result is cached on data to not compute it on each step
+func getResourceSetClusters(ctx context.Context, c client.Client, o *elasticsearchapicrd.ResourceSet, data map[string]any) (clusters []elasticsearchcrd.Elasticsearch, err error) {
+	if clusters, ok := data[resourceSetClustersData].([]elasticsearchcrd.Elasticsearch); ok {
+		return clusters, nil
+	}
+
+	esSelector, err := metav1.LabelSelectorAsSelector(&o.Spec.ElasticsearchSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when convert Elasticsearch selector")
+	}
+
+	namespaces := []string{o.Namespace}
+	if o.Spec.NamespaceSelector != nil {
+		nsSelector, err := metav1.LabelSelectorAsSelector(o.Spec.NamespaceSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error when convert namespace selector")
+		}
+		nsList := &corev1.NamespaceList{}
+		if err = c.List(ctx, nsList, client.MatchingLabelsSelector{Selector: nsSelector}); err != nil {
+			return nil, errors.Wrap(err, "Error when read namespaces")
+		}
+		namespaces = make([]string, 0, len(nsList.Items))
+		for _, ns := range nsList.Items {
+			namespaces = append(namespaces, ns.Name)
+		}
+	}
+
+	clusters = make([]elasticsearchcrd.Elasticsearch, 0)
+	for _, namespace := range namespaces {
+		esList := &elasticsearchcrd.ElasticsearchList{}
+		if err = c.List(ctx, esList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: esSelector}); err != nil {
+			return nil, errors.Wrapf(err, "Error when read Elasticsearch on namespace %s", namespace)
+		}
+		clusters = append(clusters, esList.Items...)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return resourceSetClusterKey(clusters[i].Name, clusters[i].Namespace) < resourceSetClusterKey(clusters[j].Name, clusters[j].Namespace)
+	})
+
+	// Init the cluster status
+	// It's indexed by the cluster labels set on resources, so the name can be truncated
+	clusterStatus := getResourceSetClusterStatus(data)
+	for _, cluster := range clusters {
+		clusterStatus[resourceSetClusterKey(elasticsearchapicrd.GetResourceSetLabelValue(cluster.Name), cluster.Namespace)] = &elasticsearchapicrd.ResourceSetClusterStatus{
+			Name:      cluster.Name,
+			Namespace: cluster.Namespace,
+		}
+	}
+
+	data[resourceSetClustersData] = clusters
+
+	return clusters, nil
+}
+
+// getResourceSetClusterStatus return the sync status per cluster computed by steps
+func getResourceSetClusterStatus(data map[string]any) map[string]*elasticsearchapicrd.ResourceSetClusterStatus {
+	clusterStatus, ok := data[resourceSetClusterStatusData].(map[string]*elasticsearchapicrd.ResourceSetClusterStatus)
+	if !ok {
+		clusterStatus = map[string]*elasticsearchapicrd.ResourceSetClusterStatus{}
+		data[resourceSetClusterStatusData] = clusterStatus
+	}
+
+	return clusterStatus
+}
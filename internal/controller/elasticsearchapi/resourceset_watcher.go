@@ -0,0 +1,72 @@
+package elasticsearchapi
+
+import (
+	"context"
+
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// watchResourceSetElasticsearch permit to reconcile the resource sets that select the Elasticsearch cluster
+// It also reconcile the resource sets that have already created resources on it, to clean them when cluster not match anymore
+func watchResourceSetElasticsearch(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		reconcileRequests := make([]reconcile.Request, 0)
+
+		listResourceSets := &elasticsearchapicrd.ResourceSetList{}
+		if err := c.List(context.Background(), listResourceSets); err != nil {
+			panic(err)
+		}
+
+		var nsLabels labels.Set
+		for _, rs := range listResourceSets.Items {
+			esSelector, err := metav1.LabelSelectorAsSelector(&rs.Spec.ElasticsearchSelector)
+			if err != nil {
+				continue
+			}
+
+			isSelected := esSelector.Matches(labels.Set(a.GetLabels()))
+			if isSelected {
+				if rs.Spec.NamespaceSelector == nil {
+					isSelected = rs.Namespace == a.GetNamespace()
+				} else {
+					nsSelector, err := metav1.LabelSelectorAsSelector(rs.Spec.NamespaceSelector)
+					if err != nil {
+						continue
+					}
+					if nsLabels == nil {
+						ns := &corev1.Namespace{}
+						if err = c.Get(context.Background(), types.NamespacedName{Name: a.GetNamespace()}, ns); err != nil {
+							panic(err)
+						}
+						nsLabels = labels.Set(ns.Labels)
+					}
+					isSelected = nsSelector.Matches(nsLabels)
+				}
+			}
+
+			if isSelected || isResourceSetTargetCluster(rs, a.GetName(), a.GetNamespace()) {
+				reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rs.Name, Namespace: rs.Namespace}})
+			}
+		}
+
+		return reconcileRequests
+	}
+}
+
+// isResourceSetTargetCluster return true if the resource set has resources on the Elasticsearch cluster from its last status
+func isResourceSetTargetCluster(rs elasticsearchapicrd.ResourceSet, name string, namespace string) bool {
+	for _, cluster := range rs.Status.Clusters {
+		if cluster.Name == name && cluster.Namespace == namespace {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,328 @@
+package elasticsearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// restore is the settings used to restore the snapshot
+type restore struct {
+	Indices             []string       `json:"indices,omitempty"`
+	IgnoreUnavailable   bool           `json:"ignore_unavailable,omitempty"`
+	IncludeGlobalState  bool           `json:"include_global_state,omitempty"`
+	FeatureStates       []string       `json:"feature_states,omitempty"`
+	IncludeAliases      *bool          `json:"include_aliases,omitempty"`
+	Partial             bool           `json:"partial,omitempty"`
+	RenamePattern       string         `json:"rename_pattern,omitempty"`
+	RenameReplacement   string         `json:"rename_replacement,omitempty"`
+	IndexSettings       map[string]any `json:"index_settings,omitempty"`
+	IgnoreIndexSettings []string       `json:"ignore_index_settings,omitempty"`
+}
+
+// restoreProgress is the progress of the shards restored from snapshot
+type restoreProgress struct {
+	Indices []string
+	Total   int64
+	Done    int64
+}
+
+type restoreRecoveryResponse map[string]struct {
+	Shards []struct {
+		Type   string `json:"type"`
+		Stage  string `json:"stage"`
+		Source struct {
+			Repository string `json:"repository"`
+			Snapshot   string `json:"snapshot"`
+		} `json:"source"`
+	} `json:"shards"`
+}
+
+// restoreInProgress is the restore in progress recorded on cluster state
+// It's removed from cluster state when the restore is completed
+type restoreInProgress struct {
+	Repository string `json:"repository"`
+	Snapshot   string `json:"snapshot"`
+	State      string `json:"state"`
+	Shards     []struct {
+		Index  string `json:"index"`
+		Shard  int64  `json:"shard"`
+		State  string `json:"state"`
+		Reason string `json:"reason"`
+	} `json:"shards"`
+}
+
+type restoreClusterStateResponse struct {
+	Restore struct {
+		Snapshots []restoreInProgress `json:"snapshots"`
+	} `json:"restore"`
+}
+
+type restoreApiClient struct {
+	remote.RemoteExternalReconciler[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler]
+}
+
+func newRestoreApiClient(client eshandler.ElasticsearchHandler) remote.RemoteExternalReconciler[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler] {
+	return &restoreApiClient{
+		RemoteExternalReconciler: remote.NewRemoteExternalReconciler[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler](client),
+	}
+}
+
+func (h *restoreApiClient) Build(o *elasticsearchapicrd.Restore) (r *restore, err error) {
+	r = &restore{
+		Indices:             o.Spec.Indices,
+		IgnoreUnavailable:   o.Spec.IgnoreUnavailable,
+		IncludeGlobalState:  o.Spec.IncludeGlobalState,
+		FeatureStates:       o.Spec.FeatureStates,
+		IncludeAliases:      o.Spec.IncludeAliases,
+		Partial:             o.Spec.Partial,
+		RenamePattern:       o.Spec.RenamePattern,
+		RenameReplacement:   o.Spec.RenameReplacement,
+		IgnoreIndexSettings: o.Spec.IgnoreIndexSettings,
+	}
+
+	if o.Spec.IndexSettings != nil {
+		r.IndexSettings = o.Spec.IndexSettings.Data
+	}
+
+	return r, nil
+}
+
+// Get return the expected restore if it has already been started
+// The restore is only run one time, so the start time on status is used to know if it's already started
+// When the start time is lost, it look on cluster if the restore is running or has been run to not restore again the snapshot
+func (h *restoreApiClient) Get(o *elasticsearchapicrd.Restore) (object *restore, err error) {
+	if o.Status.StartTime != nil {
+		return h.Build(o)
+	}
+
+	snapshot := o.GetSnapshotName()
+	if snapshot == elasticsearchapicrd.RestoreLatestSnapshot {
+		info, err := snapshotGetLatest(h.Client(), o.Spec.Repository)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error when get latest snapshot")
+		}
+		if info == nil {
+			return nil, nil
+		}
+		snapshot = info.Snapshot
+	}
+
+	isStarted, err := restoreIsStarted(h.Client(), o.Spec.Repository, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if !isStarted {
+		return nil, nil
+	}
+
+	// Record the restore found on cluster, so it will be tracked until completion
+	o.Status.Snapshot = snapshot
+	o.Status.StartTime = &metav1.Time{Time: time.Now()}
+
+	return h.Build(o)
+}
+
+func (h *restoreApiClient) Create(object *restore, o *elasticsearchapicrd.Restore) (err error) {
+	return restoreStart(h.Client(), o.Spec.Repository, o.GetSnapshotName(), object)
+}
+
+// Update do nothing, a restore can't be updated
+func (h *restoreApiClient) Update(object *restore, o *elasticsearchapicrd.Restore) (err error) {
+	return nil
+}
+
+// Delete do nothing, the restored indices are keeped
+func (h *restoreApiClient) Delete(o *elasticsearchapicrd.Restore) (err error) {
+	return nil
+}
+
+func (h *restoreApiClient) Diff(currentOject *restore, expectedObject *restore, originalObject *restore, o *elasticsearchapicrd.Restore, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	// If not yet started
+	if currentOject == nil {
+		expected, err := json.Marshal(expectedObject)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert expected object to byte sequence")
+		}
+
+		return &patch.PatchResult{
+			Patch:    expected,
+			Current:  expected,
+			Modified: expected,
+			Original: nil,
+			Patched:  expectedObject,
+		}, nil
+	}
+
+	// A restore is never updated
+	return &patch.PatchResult{
+		Patched: currentOject,
+	}, nil
+}
+
+// restoreStart permit to start the restore of snapshot
+// It not wait the restore completion
+func restoreStart(client eshandler.ElasticsearchHandler, repository string, snapshot string, r *restore) (err error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.Snapshot.Restore(
+		repository,
+		snapshot,
+		api.Snapshot.Restore.WithContext(context.Background()),
+		api.Snapshot.Restore.WithBody(bytes.NewReader(data)),
+		api.Snapshot.Restore.WithWaitForCompletion(false),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when restore snapshot %s from repository %s: %s", snapshot, repository, res.String())
+	}
+
+	return nil
+}
+
+// restoreIsStarted return true if the snapshot is being restored or has been restored on cluster
+// It read the restore in progress from cluster state, then the shard recoveries from snapshot
+func restoreIsStarted(client eshandler.ElasticsearchHandler, repository string, snapshot string) (isStarted bool, err error) {
+	inProgress, err := restoreGetInProgress(client, repository, snapshot)
+	if err != nil {
+		return false, err
+	}
+	if inProgress != nil {
+		return true, nil
+	}
+
+	progress, err := restoreGetProgress(client, repository, snapshot)
+	if err != nil {
+		return false, err
+	}
+
+	return progress.Total > 0, nil
+}
+
+// restoreGetInProgress permit to get the restore in progress of the snapshot from cluster state
+// It return nil when the snapshot is not being restored
+func restoreGetInProgress(client eshandler.ElasticsearchHandler, repository string, snapshot string) (inProgress *restoreInProgress, err error) {
+	api := client.Client().API
+	res, err := api.Cluster.State(
+		api.Cluster.State.WithContext(context.Background()),
+		api.Cluster.State.WithMetric("restore"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when get restore from cluster state: %s", res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &restoreClusterStateResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrap(err, "Error when decode cluster state")
+	}
+	for _, r := range resp.Restore.Snapshots {
+		if r.Repository == repository && r.Snapshot == snapshot {
+			return &r, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// restoreGetShardsTotal return the number of shards to restore from the snapshot
+// When indices are set, it only count the shards of the snapshot indices that match them
+func restoreGetShardsTotal(info *snapshotInfo, indices []string) (total int64) {
+	if len(indices) == 0 {
+		return info.Shards.Total
+	}
+
+	for _, index := range info.Indices {
+		isSelected := false
+		for _, pattern := range indices {
+			if strings.HasPrefix(pattern, "-") {
+				if isSelected && elasticsearchapicrd.IsIndexPatternsOverlap(pattern[1:], index) {
+					isSelected = false
+				}
+			} else if elasticsearchapicrd.IsIndexPatternsOverlap(pattern, index) {
+				isSelected = true
+			}
+		}
+		if isSelected {
+			total += info.IndexDetails[index].ShardCount
+		}
+	}
+
+	return total
+}
+
+// restoreGetProgress permit to get the progress of the shards restored from snapshot
+// It read the shard recoveries from type SNAPSHOT with the snapshot as source
+func restoreGetProgress(client eshandler.ElasticsearchHandler, repository string, snapshot string) (progress *restoreProgress, err error) {
+	api := client.Client().API
+	res, err := api.Indices.Recovery(
+		api.Indices.Recovery.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when get recoveries: %s", res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := restoreRecoveryResponse{}
+	if err = json.Unmarshal(b, &resp); err != nil {
+		return nil, errors.Wrap(err, "Error when decode recoveries")
+	}
+
+	progress = &restoreProgress{
+		Indices: []string{},
+	}
+	for index, recovery := range resp {
+		isRestored := false
+		for _, shard := range recovery.Shards {
+			if shard.Type != "SNAPSHOT" || shard.Source.Repository != repository || shard.Source.Snapshot != snapshot {
+				continue
+			}
+			isRestored = true
+			progress.Total++
+			if shard.Stage == "DONE" {
+				progress.Done++
+			}
+		}
+		if isRestored {
+			progress.Indices = append(progress.Indices, index)
+		}
+	}
+	sort.Strings(progress.Indices)
+
+	return progress, nil
+}
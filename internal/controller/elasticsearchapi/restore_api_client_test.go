@@ -0,0 +1,228 @@
+package elasticsearchapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestRestoreBuild(t *testing.T) {
+	var (
+		o               *elasticsearchapicrd.Restore
+		r               *restore
+		expectedRestore *restore
+		err             error
+		client          *restoreApiClient
+		recoveries      string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/_cluster/state/restore":
+			_, _ = w.Write([]byte(`{"cluster_name": "test", "restore": {"snapshots": []}}`))
+		case "/_recovery":
+			_, _ = w.Write([]byte(recoveries))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	client = newRestoreApiClient(mockES).(*restoreApiClient)
+
+	o = &elasticsearchapicrd.Restore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.RestoreSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Repository:        "backup",
+			Snapshot:          "snapshot",
+			Indices:           []string{"logs-*"},
+			IncludeAliases:    ptr.To[bool](false),
+			Partial:           true,
+			RenamePattern:     "(.+)",
+			RenameReplacement: "restored-$1",
+			IndexSettings: &apis.MapAny{
+				Data: map[string]any{
+					"index.number_of_replicas": 0,
+				},
+			},
+			IgnoreIndexSettings: []string{"index.refresh_interval"},
+		},
+	}
+
+	expectedRestore = &restore{
+		Indices:           []string{"logs-*"},
+		IncludeAliases:    ptr.To[bool](false),
+		Partial:           true,
+		RenamePattern:     "(.+)",
+		RenameReplacement: "restored-$1",
+		IndexSettings: map[string]any{
+			"index.number_of_replicas": 0,
+		},
+		IgnoreIndexSettings: []string{"index.refresh_interval"},
+	}
+
+	r, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRestore, r)
+
+	// Get return nil while the restore is not started
+	recoveries = `{}`
+	r, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+	assert.Nil(t, o.Status.StartTime)
+
+	// Get return the restore when the start time is lost but the snapshot has been restored on cluster
+	recoveries = `{"restored-logs": {"shards": [{"type": "SNAPSHOT", "stage": "DONE", "source": {"repository": "backup", "snapshot": "snapshot", "index": "logs"}}]}}`
+	r, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRestore, r)
+	assert.NotNil(t, o.Status.StartTime)
+	assert.Equal(t, "snapshot", o.Status.Snapshot)
+
+	o.Status.StartTime = &metav1.Time{}
+	r, err = client.Get(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRestore, r)
+}
+
+func TestRestoreApi(t *testing.T) {
+	var (
+		method string
+		path   string
+		query  string
+		body   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		query = r.URL.RawQuery
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		if r.URL.Path == "/_cluster/state/restore" {
+			_, _ = w.Write([]byte(`{
+				"cluster_name": "test",
+				"restore": {"snapshots": [
+					{"snapshot": "running", "repository": "backup", "uuid": "uuid", "state": "STARTED", "indices": ["logs"], "shards": []}
+				]}
+			}`))
+			return
+		}
+		if r.URL.Path == "/_recovery" {
+			_, _ = w.Write([]byte(`{
+				"restored-logs": {"shards": [
+					{"type": "SNAPSHOT", "stage": "DONE", "source": {"repository": "backup", "snapshot": "snapshot", "index": "logs"}},
+					{"type": "SNAPSHOT", "stage": "INDEX", "source": {"repository": "backup", "snapshot": "snapshot", "index": "logs"}},
+					{"type": "PEER", "stage": "DONE", "source": {"name": "node1"}}
+				]},
+				"restored-metrics": {"shards": [
+					{"type": "SNAPSHOT", "stage": "DONE", "source": {"repository": "backup", "snapshot": "snapshot", "index": "metrics"}}
+				]},
+				"other": {"shards": [
+					{"type": "SNAPSHOT", "stage": "DONE", "source": {"repository": "backup", "snapshot": "other", "index": "other"}}
+				]}
+			}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"accepted":true}`))
+	}))
+
+	// Start
+	err := restoreStart(mockES, "backup", "snapshot", &restore{
+		Indices:           []string{"logs-*"},
+		RenamePattern:     "(.+)",
+		RenameReplacement: "restored-$1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/_snapshot/backup/snapshot/_restore", path)
+	assert.Equal(t, "wait_for_completion=false", query)
+	assert.JSONEq(t, `{"indices":["logs-*"],"rename_pattern":"(.+)","rename_replacement":"restored-$1"}`, body)
+
+	// Progress
+	progress, err := restoreGetProgress(mockES, "backup", "snapshot")
+	assert.NoError(t, err)
+	assert.Equal(t, &restoreProgress{
+		Indices: []string{"restored-logs", "restored-metrics"},
+		Total:   3,
+		Done:    2,
+	}, progress)
+
+	// Is started from cluster state
+	isStarted, err := restoreIsStarted(mockES, "backup", "running")
+	assert.NoError(t, err)
+	assert.True(t, isStarted)
+
+	// Is started from recoveries
+	isStarted, err = restoreIsStarted(mockES, "backup", "other")
+	assert.NoError(t, err)
+	assert.True(t, isStarted)
+
+	// Not started
+	isStarted, err = restoreIsStarted(mockES, "backup", "unknown")
+	assert.NoError(t, err)
+	assert.False(t, isStarted)
+
+	// In progress from cluster state
+	inProgress, err := restoreGetInProgress(mockES, "backup", "running")
+	assert.NoError(t, err)
+	assert.NotNil(t, inProgress)
+	assert.Equal(t, "STARTED", inProgress.State)
+
+	inProgress, err = restoreGetInProgress(mockES, "backup", "snapshot")
+	assert.NoError(t, err)
+	assert.Nil(t, inProgress)
+}
+
+func TestRestoreGetShardsTotal(t *testing.T) {
+	info := &snapshotInfo{}
+	if err := json.Unmarshal([]byte(`{
+		"snapshot": "snapshot",
+		"indices": ["logs-1", "logs-2", "metrics"],
+		"index_details": {
+			"logs-1": {"shard_count": 2},
+			"logs-2": {"shard_count": 3},
+			"metrics": {"shard_count": 1}
+		},
+		"shards": {"total": 6, "failed": 0, "successful": 6}
+	}`), info); err != nil {
+		t.Fatal(err)
+	}
+
+	// All indices
+	assert.Equal(t, int64(6), restoreGetShardsTotal(info, nil))
+
+	// Some indices
+	assert.Equal(t, int64(5), restoreGetShardsTotal(info, []string{"logs-*"}))
+	assert.Equal(t, int64(3), restoreGetShardsTotal(info, []string{"logs-1", "metrics"}))
+
+	// With exclusion
+	assert.Equal(t, int64(3), restoreGetShardsTotal(info, []string{"*", "-logs-2"}))
+
+	// No indices
+	assert.Equal(t, int64(0), restoreGetShardsTotal(info, []string{"-*"}))
+}
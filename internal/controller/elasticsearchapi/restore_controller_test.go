@@ -0,0 +1,243 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (t *ElasticsearchapiControllerTestSuite) TestRestoreReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-restore-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase[*elasticsearchapicrd.Restore](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchapicrd.Restore]{
+		doCreateRestoreStep(),
+		doDeleteRestoreStep(),
+	}
+	testCase.PreTest = doMockRestore(t.fakeElasticsearchMux, key.Name)
+
+	testCase.Run()
+}
+
+func (t *ElasticsearchapiControllerTestSuite) TestRestoreFailedReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-restore-failed-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{
+		"isFailed": true,
+	}
+
+	testCase := test.NewTestCase[*elasticsearchapicrd.Restore](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchapicrd.Restore]{
+		doCreateRestoreStep(),
+		doDeleteRestoreStep(),
+	}
+	testCase.PreTest = doMockRestore(t.fakeElasticsearchMux, key.Name)
+
+	testCase.Run()
+}
+
+// doMockRestore mock a restore of the index logs with 2 shards
+// The first shard is recovered before the second, so the recoveries are only a subset of the shards while the restore is in progress
+// When isFailed is set on data, the second shard failed
+func doMockRestore(mux *http.ServeMux, name string) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		var mutex sync.Mutex
+		isStarted := false
+		isFailed := false
+		nbGet := 0
+
+		if b, ok := data["isFailed"]; ok {
+			isFailed = b.(bool)
+		}
+
+		mux.HandleFunc("/_snapshot/restore/"+name+"/_restore", func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			isStarted = true
+			data["isCreated"] = true
+			_, _ = w.Write([]byte(`{"accepted":true}`))
+		})
+
+		mux.HandleFunc("/_snapshot/restore/"+name, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"snapshots":[{"snapshot":"` + name + `","state":"SUCCESS","indices":["logs","metrics"],"index_details":{"logs":{"shard_count":2},"metrics":{"shard_count":1}},"shards":{"total":3,"failed":0,"successful":3}}]}`))
+		})
+
+		// The restore is completed after some reconcile
+		mux.HandleFunc("/_cluster/state/restore", func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if !isStarted || nbGet >= 3 {
+				_, _ = w.Write([]byte(`{"cluster_name":"test","restore":{"snapshots":[]}}`))
+				return
+			}
+
+			nbGet++
+			shardState := "INIT"
+			if isFailed {
+				shardState = "FAILURE"
+			}
+			_, _ = w.Write([]byte(`{"cluster_name":"test","restore":{"snapshots":[{"snapshot":"` + name + `","repository":"restore","state":"STARTED","indices":["restored-logs"],"shards":[{"index":"restored-logs","shard":0,"state":"SUCCESS"},{"index":"restored-logs","shard":1,"state":"` + shardState + `","reason":"failed to recover"}]}]}}`))
+		})
+
+		mux.HandleFunc("/_recovery", func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if !isStarted {
+				_, _ = w.Write([]byte(`{}`))
+				return
+			}
+
+			shards := `{"type":"SNAPSHOT","stage":"DONE","source":{"repository":"restore","snapshot":"` + name + `","index":"logs"}}`
+			if nbGet >= 3 && !isFailed {
+				shards += `,{"type":"SNAPSHOT","stage":"DONE","source":{"repository":"restore","snapshot":"` + name + `","index":"logs"}}`
+			}
+			_, _ = w.Write([]byte(`{"restored-logs":{"shards":[` + shards + `]}}`))
+		})
+
+		return nil
+	}
+}
+
+func doCreateRestoreStep() test.TestStep[*elasticsearchapicrd.Restore] {
+	return test.TestStep[*elasticsearchapicrd.Restore]{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Restore, data map[string]any) (err error) {
+			logrus.Infof("=== Add new restore %s/%s ===\n\n", key.Namespace, key.Name)
+
+			restore := &elasticsearchapicrd.Restore{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchapicrd.RestoreSpec{
+					ElasticsearchRef: shared.ElasticsearchRef{
+						ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+							Name: "test",
+						},
+					},
+					Repository:        "restore",
+					Snapshot:          key.Name,
+					Indices:           []string{"logs"},
+					RenamePattern:     "(.+)",
+					RenameReplacement: "restored-$1",
+				},
+			}
+			if err = c.Create(context.Background(), restore); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Restore, data map[string]any) (err error) {
+			restore := &elasticsearchapicrd.Restore{}
+			isCreated := false
+
+			isFailed := false
+			if b, ok := data["isFailed"]; ok {
+				isFailed = b.(bool)
+			}
+			expectedState := "SUCCESS"
+			if isFailed {
+				expectedState = "FAILED"
+			}
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, restore); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated || restore.Status.State != expectedState {
+					return errors.New("Not yet completed")
+				}
+				return nil
+			}, time.Second*60, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Restore: %s", err.Error())
+			}
+			assert.Equal(t, []string{"restored-logs"}, restore.Status.Indices)
+			assert.NotNil(t, restore.Status.StartTime)
+			assert.NotNil(t, restore.Status.EndTime)
+			assert.Equal(t, int64(2), restore.Status.Shards.Total)
+
+			if isFailed {
+				assert.True(t, condition.IsStatusConditionPresentAndEqual(restore.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionFalse))
+				assert.Equal(t, int64(1), restore.Status.Shards.Successful)
+				assert.Equal(t, int64(1), restore.Status.Shards.Failed)
+			} else {
+				assert.True(t, condition.IsStatusConditionPresentAndEqual(restore.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+				assert.Equal(t, int64(2), restore.Status.Shards.Successful)
+				assert.Equal(t, int64(0), restore.Status.Shards.Failed)
+			}
+
+			return nil
+		},
+	}
+}
+
+func doDeleteRestoreStep() test.TestStep[*elasticsearchapicrd.Restore] {
+	return test.TestStep[*elasticsearchapicrd.Restore]{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Restore, data map[string]any) (err error) {
+			logrus.Infof("=== Delete restore %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Restore is null")
+			}
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), o, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Restore, data map[string]any) (err error) {
+			restore := &elasticsearchapicrd.Restore{}
+			isDeleted := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, restore); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Restore stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,193 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/sirupsen/logrus"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	corev1 "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	restoreStateInProgress = "IN_PROGRESS"
+	restoreStateSuccess    = "SUCCESS"
+	restoreStateFailed     = "FAILED"
+
+	restoreShardStateFailure = "FAILURE"
+
+	restoreRefreshInterval = 10 * time.Second
+)
+
+type restoreReconciler struct {
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler]
+	name string
+}
+
+func newRestoreReconciler(name string, client client.Client, recorder record.EventRecorder) remote.RemoteReconcilerAction[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler] {
+	return &restoreReconciler{
+		RemoteReconcilerAction: remote.NewRemoteReconcilerAction[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler](
+			client,
+			recorder,
+		),
+		name: name,
+	}
+}
+
+func (h *restoreReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.Restore, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+	esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, h.Client(), logger)
+	if err != nil && o.DeletionTimestamp.IsZero() {
+		return nil, res, err
+	}
+
+	// Elastic not ready
+	if esClient == nil {
+		if o.DeletionTimestamp.IsZero() {
+			return nil, reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+		}
+
+		return nil, res, nil
+	}
+
+	handler = newRestoreApiClient(esClient)
+
+	return handler, res, nil
+}
+
+// Create start the restore and record the start time on status
+// When the snapshot is `latest`, it resolve the most recent successful snapshot before to start the restore
+func (h *restoreReconciler) Create(ctx context.Context, o *elasticsearchapicrd.Restore, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler], object *restore, logger *logrus.Entry) (res reconcile.Result, err error) {
+	if o.Spec.Snapshot == elasticsearchapicrd.RestoreLatestSnapshot {
+		info, err := snapshotGetLatest(handler.Client(), o.Spec.Repository)
+		if err != nil {
+			return res, errors.Wrap(err, "Error when get latest snapshot")
+		}
+		if info == nil {
+			return res, errors.Errorf("There are no successful snapshot on repository %s", o.Spec.Repository)
+		}
+		o.Status.Snapshot = info.Snapshot
+	} else {
+		o.Status.Snapshot = o.Spec.Snapshot
+	}
+
+	if res, err = h.RemoteReconcilerAction.Create(ctx, o, data, handler, object, logger); err != nil {
+		return res, err
+	}
+
+	o.Status.StartTime = &metav1.Time{Time: time.Now()}
+	o.Status.State = restoreStateInProgress
+	h.Recorder().Eventf(o, corev1.EventTypeNormal, "RestoreStarted", "Restore of snapshot %s from repository %s started", o.GetSnapshotName(), o.Spec.Repository)
+
+	// Persist the start state now, the snapshot must not be restored again if the reconcile fail after
+	if err = h.Client().Status().Update(ctx, o); err != nil {
+		return res, errors.Wrap(err, "Error when persist restore start on status")
+	}
+
+	return res, nil
+}
+
+// OnSuccess track the restore until it's completed
+// The restore is in progress while it's recorded on cluster state, then the restored shards are compared with the shards of the snapshot
+func (h *restoreReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.Restore, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*restore], logger *logrus.Entry) (res reconcile.Result, err error) {
+	switch o.Status.State {
+	case restoreStateSuccess:
+		return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	case restoreStateFailed:
+		h.setNotReady(o, "Failed", "Restore failed")
+		o.GetStatus().SetIsOnError(true)
+		return res, nil
+	}
+
+	inProgress, err := restoreGetInProgress(handler.Client(), o.Spec.Repository, o.GetSnapshotName())
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get restore in progress")
+	}
+	progress, err := restoreGetProgress(handler.Client(), o.Spec.Repository, o.GetSnapshotName())
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get restore progress")
+	}
+	info, err := snapshotGet(handler.Client(), o.Spec.Repository, o.GetSnapshotName())
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get snapshot")
+	}
+	if info == nil {
+		return res, errors.Errorf("Snapshot %s not found on repository %s", o.GetSnapshotName(), o.Spec.Repository)
+	}
+
+	o.Status.Indices = progress.Indices
+	o.Status.Shards = &elasticsearchapicrd.SnapshotShardsStatus{
+		Total:      restoreGetShardsTotal(info, o.Spec.Indices),
+		Successful: progress.Done,
+	}
+
+	if inProgress != nil {
+		var reason string
+		for _, shard := range inProgress.Shards {
+			if shard.State == restoreShardStateFailure {
+				o.Status.Shards.Failed++
+				reason = shard.Reason
+			}
+		}
+		if o.Status.Shards.Failed > 0 {
+			return h.setFailed(o, reason)
+		}
+
+		h.setNotReady(o, "InProgress", "Wait restore completion")
+		o.Status.State = restoreStateInProgress
+
+		return reconcile.Result{RequeueAfter: restoreRefreshInterval}, nil
+	}
+
+	// The restore is completed, the shards not restored have failed
+	if o.Status.Shards.Successful < o.Status.Shards.Total {
+		o.Status.Shards.Failed = o.Status.Shards.Total - o.Status.Shards.Successful
+		return h.setFailed(o, "")
+	}
+
+	o.Status.State = restoreStateSuccess
+	o.Status.EndTime = &metav1.Time{Time: time.Now()}
+	logger.Infof("Restore of snapshot %s successfully completed", o.GetSnapshotName())
+	h.Recorder().Eventf(o, corev1.EventTypeNormal, "RestoreCompleted", "Restore of snapshot %s successfully completed on %d indices", o.GetSnapshotName(), len(o.Status.Indices))
+
+	return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+}
+
+// setFailed record the restore failure on status
+func (h *restoreReconciler) setFailed(o *elasticsearchapicrd.Restore, reason string) (res reconcile.Result, err error) {
+	o.Status.State = restoreStateFailed
+	o.Status.EndTime = &metav1.Time{Time: time.Now()}
+	if reason == "" {
+		h.Recorder().Eventf(o, corev1.EventTypeWarning, "RestoreFailed", "Restore of snapshot %s failed on %d shards", o.GetSnapshotName(), o.Status.Shards.Failed)
+	} else {
+		h.Recorder().Eventf(o, corev1.EventTypeWarning, "RestoreFailed", "Restore of snapshot %s failed on %d shards: %s", o.GetSnapshotName(), o.Status.Shards.Failed, reason)
+	}
+
+	h.setNotReady(o, "Failed", "Restore failed")
+	o.GetStatus().SetIsOnError(true)
+
+	return res, nil
+}
+
+// setNotReady set the ready condition to false when the restore is not completed
+func (h *restoreReconciler) setNotReady(o *elasticsearchapicrd.Restore, reason string, message string) {
+	conditions := o.GetStatus().GetConditions()
+	condition.SetStatusCondition(&conditions, metav1.Condition{
+		Type:    h.Condition().String(),
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	o.GetStatus().SetConditions(conditions)
+
+	o.GetStatus().SetIsOnError(false)
+	o.GetStatus().SetIsSync(true)
+	o.GetStatus().SetObservedGeneration(o.GetGeneration())
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,10 +51,13 @@ func NewRoleReconciler(client client.Client, logger *logrus.Entry, recorder reco
 			logger,
 			recorder,
 		),
-		RemoteReconcilerAction: newRoleReconciler(
-			roleName,
-			client,
-			recorder,
+		RemoteReconcilerAction: common.NewRemoteReconcilerAction(
+			elasticsearchapicrd.ElasticsearchApiAnnotationKey,
+			newRoleReconciler(
+				roleName,
+				client,
+				recorder,
+			),
 		),
 		name: roleName,
 	}
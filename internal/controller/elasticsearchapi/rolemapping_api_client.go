@@ -1,32 +1,81 @@
 package elasticsearchapi
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/generic-objectmatcher/patch"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
-	olivere "github.com/olivere/elastic/v7"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 )
 
+// roleMapping is the role mapping object stored on Elasticsearch
+// It's used instead of olivere.XPackSecurityRoleMapping that not support role templates
+type roleMapping struct {
+	Enabled       bool                  `json:"enabled"`
+	Roles         []string              `json:"roles,omitempty"`
+	RoleTemplates []roleMappingTemplate `json:"role_templates,omitempty"`
+	Rules         map[string]any        `json:"rules"`
+	Metadata      any                   `json:"metadata"`
+}
+
+// roleMappingTemplate is the role template
+// Elasticsearch return the template as JSON string, so we send it as JSON string too
+type roleMappingTemplate struct {
+	Template string `json:"template"`
+	Format   string `json:"format,omitempty"`
+}
+
 type roleMappingApiClient struct {
-	remote.RemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler]
+	remote.RemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler]
 }
 
-func newRoleMappingApiClient(client eshandler.ElasticsearchHandler) remote.RemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler] {
+func newRoleMappingApiClient(client eshandler.ElasticsearchHandler) remote.RemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler] {
 	return &roleMappingApiClient{
-		RemoteExternalReconciler: remote.NewRemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler](client),
+		RemoteExternalReconciler: remote.NewRemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler](client),
 	}
 }
 
-func (h *roleMappingApiClient) Build(o *elasticsearchapicrd.RoleMapping) (rm *olivere.XPackSecurityRoleMapping, err error) {
-	rm = &olivere.XPackSecurityRoleMapping{
+func (h *roleMappingApiClient) Build(o *elasticsearchapicrd.RoleMapping) (rm *roleMapping, err error) {
+	rm = &roleMapping{
 		Enabled:  o.Spec.Enabled,
 		Roles:    o.Spec.Roles,
 		Metadata: make(map[string]any), // Fix issue on V8, metadata can't be null
 	}
 
 	if o.Spec.Rules != nil {
-		rm.Rules = o.Spec.Rules.Data
+		// Convert the typed rules tree on raw map
+		b, err := json.Marshal(o.Spec.Rules)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error when convert rules")
+		}
+		if err = json.Unmarshal(b, &rm.Rules); err != nil {
+			return nil, errors.Wrap(err, "Error when convert rules")
+		}
+	}
+
+	if len(o.Spec.RoleTemplates) > 0 {
+		rm.RoleTemplates = make([]roleMappingTemplate, 0, len(o.Spec.RoleTemplates))
+		for _, roleTemplate := range o.Spec.RoleTemplates {
+			template, err := json.Marshal(map[string]string{
+				"source": roleTemplate.Source,
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "Error when convert role template")
+			}
+			format := roleTemplate.Format
+			if format == "" {
+				format = "string"
+			}
+			rm.RoleTemplates = append(rm.RoleTemplates, roleMappingTemplate{
+				Template: string(template),
+				Format:   format,
+			})
+		}
 	}
 
 	if o.Spec.Metadata != nil {
@@ -36,22 +85,95 @@ func (h *roleMappingApiClient) Build(o *elasticsearchapicrd.RoleMapping) (rm *ol
 	return rm, nil
 }
 
-func (h *roleMappingApiClient) Get(o *elasticsearchapicrd.RoleMapping) (object *olivere.XPackSecurityRoleMapping, err error) {
-	return h.Client().RoleMappingGet(o.GetExternalName())
+func (h *roleMappingApiClient) Get(o *elasticsearchapicrd.RoleMapping) (object *roleMapping, err error) {
+	return roleMappingGet(h.Client(), o.GetExternalName())
 }
 
-func (h *roleMappingApiClient) Create(object *olivere.XPackSecurityRoleMapping, o *elasticsearchapicrd.RoleMapping) (err error) {
-	return h.Client().RoleMappingUpdate(o.GetExternalName(), object)
+func (h *roleMappingApiClient) Create(object *roleMapping, o *elasticsearchapicrd.RoleMapping) (err error) {
+	return roleMappingUpdate(h.Client(), o.GetExternalName(), object)
 }
 
-func (h *roleMappingApiClient) Update(object *olivere.XPackSecurityRoleMapping, o *elasticsearchapicrd.RoleMapping) (err error) {
-	return h.Client().RoleMappingUpdate(o.GetExternalName(), object)
+func (h *roleMappingApiClient) Update(object *roleMapping, o *elasticsearchapicrd.RoleMapping) (err error) {
+	return roleMappingUpdate(h.Client(), o.GetExternalName(), object)
 }
 
 func (h *roleMappingApiClient) Delete(o *elasticsearchapicrd.RoleMapping) (err error) {
 	return h.Client().RoleMappingDelete(o.GetExternalName())
 }
 
-func (h *roleMappingApiClient) Diff(currentOject *olivere.XPackSecurityRoleMapping, expectedObject *olivere.XPackSecurityRoleMapping, originalObject *olivere.XPackSecurityRoleMapping, o *elasticsearchapicrd.RoleMapping, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
-	return h.Client().RoleMappingDiff(currentOject, expectedObject, originalObject)
+func (h *roleMappingApiClient) Diff(currentOject *roleMapping, expectedObject *roleMapping, originalObject *roleMapping, o *elasticsearchapicrd.RoleMapping, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	// If not yet exist
+	if currentOject == nil {
+		expected, err := json.Marshal(expectedObject)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert expected object to byte sequence")
+		}
+
+		return &patch.PatchResult{
+			Patch:    expected,
+			Current:  expected,
+			Modified: expected,
+			Original: nil,
+			Patched:  expectedObject,
+		}, nil
+	}
+
+	return patch.DefaultPatchMaker.Calculate(currentOject, expectedObject, originalObject, ignoresDiff...)
+}
+
+// roleMappingGet permit to get role mapping
+// It return nil if role mapping not exist
+func roleMappingGet(client eshandler.ElasticsearchHandler, name string) (rm *roleMapping, err error) {
+	api := client.Client().API
+	res, err := api.Security.GetRoleMapping(
+		api.Security.GetRoleMapping.WithContext(context.Background()),
+		api.Security.GetRoleMapping.WithName(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get role mapping %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := map[string]*roleMapping{}
+	if err = json.Unmarshal(b, &resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode role mapping %s", name)
+	}
+
+	return resp[name], nil
+}
+
+// roleMappingUpdate permit to create or update role mapping
+func roleMappingUpdate(client eshandler.ElasticsearchHandler, name string, rm *roleMapping) (err error) {
+	data, err := json.Marshal(rm)
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.Security.PutRoleMapping(
+		name,
+		bytes.NewReader(data),
+		api.Security.PutRoleMapping.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when add role mapping %s: %s", name, res.String())
+	}
+
+	return nil
 }
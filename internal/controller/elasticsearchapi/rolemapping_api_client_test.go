@@ -1,21 +1,24 @@
 package elasticsearchapi
 
 import (
+	"io"
+	"net/http"
 	"testing"
 
+	"github.com/disaster37/es-handler/v8/mocks"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
-	olivere "github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/assert"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestRoleMappingBuild(t *testing.T) {
 	var (
 		o          *elasticsearchapicrd.RoleMapping
-		rm         *olivere.XPackSecurityRoleMapping
-		expectedRm *olivere.XPackSecurityRoleMapping
+		rm         *roleMapping
+		expectedRm *roleMapping
 		err        error
 	)
 
@@ -37,16 +40,18 @@ func TestRoleMappingBuild(t *testing.T) {
 			Roles: []string{
 				"superuser",
 			},
-			Rules: &apis.MapAny{
-				Data: map[string]any{
-					"any": []map[string]any{
-						{
-							"field": map[string]any{
+			Rules: &elasticsearchapicrd.RoleMappingRule{
+				Any: []elasticsearchapicrd.RoleMappingRule{
+					{
+						Field: &apis.MapAny{
+							Data: map[string]any{
 								"groups": "CN=ELS_ADMINS,OU=LOCAL,OU=AD",
 							},
 						},
-						{
-							"field": map[string]any{
+					},
+					{
+						Field: &apis.MapAny{
+							Data: map[string]any{
 								"groups": "CN=ELS_OPS,OU=LOCAL,OU=AD",
 							},
 						},
@@ -56,19 +61,19 @@ func TestRoleMappingBuild(t *testing.T) {
 		},
 	}
 
-	expectedRm = &olivere.XPackSecurityRoleMapping{
+	expectedRm = &roleMapping{
 		Enabled: true,
 		Roles: []string{
 			"superuser",
 		},
 		Rules: map[string]any{
-			"any": []map[string]any{
-				{
+			"any": []any{
+				map[string]any{
 					"field": map[string]any{
 						"groups": "CN=ELS_ADMINS,OU=LOCAL,OU=AD",
 					},
 				},
-				{
+				map[string]any{
 					"field": map[string]any{
 						"groups": "CN=ELS_OPS,OU=LOCAL,OU=AD",
 					},
@@ -82,7 +87,7 @@ func TestRoleMappingBuild(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedRm, rm)
 
-	// With metadata
+	// With role templates, except rule and metadata
 	o = &elasticsearchapicrd.RoleMapping{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "test",
@@ -95,20 +100,30 @@ func TestRoleMappingBuild(t *testing.T) {
 				},
 			},
 			Enabled: true,
-			Roles: []string{
-				"superuser",
+			RoleTemplates: []elasticsearchapicrd.RoleMappingRoleTemplate{
+				{
+					Source: "{{#tojson}}groups{{/tojson}}",
+					Format: "json",
+				},
+				{
+					Source: "_user_{{username}}",
+				},
 			},
-			Rules: &apis.MapAny{
-				Data: map[string]any{
-					"any": []map[string]any{
-						{
-							"field": map[string]any{
-								"groups": "CN=ELS_ADMINS,OU=LOCAL,OU=AD",
+			Rules: &elasticsearchapicrd.RoleMappingRule{
+				All: []elasticsearchapicrd.RoleMappingRule{
+					{
+						Field: &apis.MapAny{
+							Data: map[string]any{
+								"realm.name": "ldap1",
 							},
 						},
-						{
-							"field": map[string]any{
-								"groups": "CN=ELS_OPS,OU=LOCAL,OU=AD",
+					},
+					{
+						Except: &elasticsearchapicrd.RoleMappingRule{
+							Field: &apis.MapAny{
+								Data: map[string]any{
+									"metadata.disabled": true,
+								},
 							},
 						},
 					},
@@ -122,21 +137,30 @@ func TestRoleMappingBuild(t *testing.T) {
 		},
 	}
 
-	expectedRm = &olivere.XPackSecurityRoleMapping{
+	expectedRm = &roleMapping{
 		Enabled: true,
-		Roles: []string{
-			"superuser",
+		RoleTemplates: []roleMappingTemplate{
+			{
+				Template: `{"source":"{{#tojson}}groups{{/tojson}}"}`,
+				Format:   "json",
+			},
+			{
+				Template: `{"source":"_user_{{username}}"}`,
+				Format:   "string",
+			},
 		},
 		Rules: map[string]any{
-			"any": []map[string]any{
-				{
+			"all": []any{
+				map[string]any{
 					"field": map[string]any{
-						"groups": "CN=ELS_ADMINS,OU=LOCAL,OU=AD",
+						"realm.name": "ldap1",
 					},
 				},
-				{
-					"field": map[string]any{
-						"groups": "CN=ELS_OPS,OU=LOCAL,OU=AD",
+				map[string]any{
+					"except": map[string]any{
+						"field": map[string]any{
+							"metadata.disabled": true,
+						},
 					},
 				},
 			},
@@ -150,3 +174,67 @@ func TestRoleMappingBuild(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedRm, rm)
 }
+
+func TestRoleMappingApi(t *testing.T) {
+	var (
+		method string
+		path   string
+		body   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		switch r.URL.Path {
+		case "/_security/role_mapping/test":
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`{"test":{"enabled":true,"role_templates":[{"template":"{\"source\":\"_user_{{username}}\"}","format":"string"}],"rules":{"field":{"username":"*"}},"metadata":{}}}`))
+				return
+			}
+		case "/_security/role_mapping/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"role_mapping":{"created":true}}`))
+	}))
+
+	expectedRm := &roleMapping{
+		Enabled: true,
+		RoleTemplates: []roleMappingTemplate{
+			{
+				Template: `{"source":"_user_{{username}}"}`,
+				Format:   "string",
+			},
+		},
+		Rules: map[string]any{
+			"field": map[string]any{
+				"username": "*",
+			},
+		},
+		Metadata: map[string]any{},
+	}
+
+	// Get
+	rm, err := roleMappingGet(mockES, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedRm, rm)
+
+	// Get when not exist
+	rm, err = roleMappingGet(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, rm)
+
+	// Update
+	err = roleMappingUpdate(mockES, "test", expectedRm)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_security/role_mapping/test", path)
+	assert.JSONEq(t, `{"enabled":true,"role_templates":[{"template":"{\"source\":\"_user_{{username}}\"}","format":"string"}],"rules":{"field":{"username":"*"}},"metadata":{}}`, body)
+}
@@ -19,9 +19,9 @@ import (
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
-	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,25 +36,28 @@ const (
 // RoleMappingReconciler reconciles a RoleMapping object
 type RoleMappingReconciler struct {
 	controller.Controller
-	remote.RemoteReconciler[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler]
-	remote.RemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler]
+	remote.RemoteReconciler[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler]
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler]
 	name string
 }
 
 func NewRoleMappingReconciler(client client.Client, logger *logrus.Entry, recorder record.EventRecorder) controller.Controller {
 	return &RoleMappingReconciler{
 		Controller: controller.NewController(),
-		RemoteReconciler: remote.NewRemoteReconciler[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler](
+		RemoteReconciler: remote.NewRemoteReconciler[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler](
 			client,
 			roleMappingName,
 			"rolemapping.elasticsearchapi.k8s.webcenter.fr/finalizer",
 			logger,
 			recorder,
 		),
-		RemoteReconcilerAction: newRoleMappingReconciler(
-			roleMappingName,
-			client,
-			recorder,
+		RemoteReconcilerAction: common.NewRemoteReconcilerAction(
+			elasticsearchapicrd.ElasticsearchApiAnnotationKey,
+			newRoleMappingReconciler(
+				roleMappingName,
+				client,
+				recorder,
+			),
 		),
 		name: roleMappingName,
 	}
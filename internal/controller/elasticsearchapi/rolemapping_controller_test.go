@@ -2,22 +2,22 @@ package elasticsearchapi
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"emperror.dev/errors"
-	"github.com/disaster37/es-handler/v8/mocks"
-	"github.com/disaster37/generic-objectmatcher/patch"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
-	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
-	"go.uber.org/mock/gomock"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	condition "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,97 +38,42 @@ func (t *ElasticsearchapiControllerTestSuite) TestRoleMappingReconciler() {
 		doUpdateRoleMappingStep(),
 		doDeleteRoleMappingStep(),
 	}
-	testCase.PreTest = doMockRoleMapping(t.mockElasticsearchHandler)
+	testCase.PreTest = doMockRoleMapping(t.fakeElasticsearchMux)
 
 	testCase.Run()
 }
 
-func doMockRoleMapping(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+func doMockRoleMapping(mux *http.ServeMux) func(stepName *string, data map[string]any) error {
 	return func(stepName *string, data map[string]any) (err error) {
-		isCreated := false
-		isUpdated := false
-
-		mockES.EXPECT().RoleMappingGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*olivere.XPackSecurityRoleMapping, error) {
-			switch *stepName {
-			case "create":
-				if !isCreated {
-					return nil, nil
-				} else {
-					resp := &olivere.XPackSecurityRoleMapping{
-						Enabled: true,
-						Roles:   []string{"superuser"},
-						Rules: map[string]any{
-							"foo": "bar",
-						},
-					}
-					return resp, nil
-				}
-			case "update":
-				if !isUpdated {
-					resp := &olivere.XPackSecurityRoleMapping{
-						Enabled: true,
-						Roles:   []string{"superuser"},
-						Rules: map[string]any{
-							"foo": "bar",
-						},
-					}
-					return resp, nil
-				} else {
-					resp := &olivere.XPackSecurityRoleMapping{
-						Enabled: false,
-						Roles:   []string{"superuser"},
-						Rules: map[string]any{
-							"foo": "bar",
-						},
-					}
-					return resp, nil
-				}
-			}
-
-			return nil, nil
-		})
-
-		mockES.EXPECT().RoleMappingDiff(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected, original *olivere.XPackSecurityRoleMapping) (*patch.PatchResult, error) {
-			switch *stepName {
-			case "create":
-				if !isCreated {
-					return &patch.PatchResult{
-						Patch: []byte("fake change"),
-					}, nil
-				} else {
-					return &patch.PatchResult{}, nil
+		var current []byte
+
+		mux.HandleFunc("/_security/role_mapping/", func(w http.ResponseWriter, r *http.Request) {
+			name := strings.TrimPrefix(r.URL.Path, "/_security/role_mapping/")
+			switch r.Method {
+			case http.MethodGet:
+				if current == nil {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{}`))
+					return
 				}
-			case "update":
-				if !isUpdated {
-					return &patch.PatchResult{
-						Patch: []byte("fake change"),
-					}, nil
-				} else {
-					return &patch.PatchResult{}, nil
+				_, _ = fmt.Fprintf(w, `{"%s":%s}`, name, current)
+				return
+			case http.MethodPut, http.MethodPost:
+				current, _ = io.ReadAll(r.Body)
+				switch *stepName {
+				case "create":
+					data["isCreated"] = true
+				case "update":
+					data["isUpdated"] = true
 				}
+				_, _ = w.Write([]byte(`{"role_mapping":{"created":true}}`))
+				return
+			case http.MethodDelete:
+				current = nil
+				data["isDeleted"] = true
+				_, _ = w.Write([]byte(`{"found":true}`))
+				return
 			}
-
-			return nil, nil
-		})
-
-		mockES.EXPECT().RoleMappingUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, policy *olivere.XPackSecurityRoleMapping) error {
-			switch *stepName {
-			case "create":
-				isCreated = true
-				data["isCreated"] = true
-				return nil
-			case "update":
-				isUpdated = true
-				data["isUpdated"] = true
-				return nil
-			}
-
-			return nil
-		})
-
-		mockES.EXPECT().RoleMappingDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
-			data["isDeleted"] = true
-			return nil
 		})
 
 		return nil
@@ -154,9 +99,11 @@ func doCreateRoleMappingStep() test.TestStep[*elasticsearchapicrd.RoleMapping] {
 					},
 					Enabled: true,
 					Roles:   []string{"superuser"},
-					Rules: &apis.MapAny{
-						Data: map[string]any{
-							"foo": "bar",
+					Rules: &elasticsearchapicrd.RoleMappingRule{
+						Field: &apis.MapAny{
+							Data: map[string]any{
+								"username": "*",
+							},
 						},
 					},
 				},
@@ -6,7 +6,6 @@ import (
 
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
-	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"k8s.io/client-go/tools/record"
@@ -15,13 +14,13 @@ import (
 )
 
 type roleMappingReconciler struct {
-	remote.RemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler]
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler]
 	name string
 }
 
-func newRoleMappingReconciler(name string, client client.Client, recorder record.EventRecorder) remote.RemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler] {
+func newRoleMappingReconciler(name string, client client.Client, recorder record.EventRecorder) remote.RemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler] {
 	return &roleMappingReconciler{
-		RemoteReconcilerAction: remote.NewRemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler](
+		RemoteReconcilerAction: remote.NewRemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler](
 			client,
 			recorder,
 		),
@@ -29,7 +28,7 @@ func newRoleMappingReconciler(name string, client client.Client, recorder record
 	}
 }
 
-func (h *roleMappingReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.RoleMapping, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+func (h *roleMappingReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.RoleMapping, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
 	esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, h.Client(), logger)
 	if err != nil && o.DeletionTimestamp.IsZero() {
 		return nil, res, err
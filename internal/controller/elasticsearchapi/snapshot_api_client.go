@@ -0,0 +1,256 @@
+package elasticsearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+)
+
+// snapshot is the settings used to take the snapshot
+type snapshot struct {
+	Indices            []string       `json:"indices,omitempty"`
+	IgnoreUnavailable  bool           `json:"ignore_unavailable,omitempty"`
+	IncludeGlobalState *bool          `json:"include_global_state,omitempty"`
+	FeatureStates      []string       `json:"feature_states,omitempty"`
+	Partial            bool           `json:"partial,omitempty"`
+	Metadata           map[string]any `json:"metadata,omitempty"`
+}
+
+// snapshotInfo is the snapshot state returned by Elasticsearch
+type snapshotInfo struct {
+	Snapshot          string   `json:"snapshot"`
+	State             string   `json:"state"`
+	Indices           []string `json:"indices"`
+	StartTimeInMillis int64    `json:"start_time_in_millis"`
+	EndTimeInMillis   int64    `json:"end_time_in_millis"`
+	IndexDetails      map[string]struct {
+		ShardCount int64 `json:"shard_count"`
+	} `json:"index_details"`
+	Shards struct {
+		Total      int64 `json:"total"`
+		Failed     int64 `json:"failed"`
+		Successful int64 `json:"successful"`
+	} `json:"shards"`
+	Failures []struct {
+		Index   string `json:"index"`
+		ShardID int64  `json:"shard_id"`
+		Reason  string `json:"reason"`
+	} `json:"failures"`
+}
+
+type snapshotGetResponse struct {
+	Snapshots []snapshotInfo `json:"snapshots"`
+}
+
+type snapshotApiClient struct {
+	remote.RemoteExternalReconciler[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler]
+}
+
+func newSnapshotApiClient(client eshandler.ElasticsearchHandler) remote.RemoteExternalReconciler[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler] {
+	return &snapshotApiClient{
+		RemoteExternalReconciler: remote.NewRemoteExternalReconciler[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler](client),
+	}
+}
+
+func (h *snapshotApiClient) Build(o *elasticsearchapicrd.Snapshot) (s *snapshot, err error) {
+	s = &snapshot{
+		Indices:            o.Spec.Indices,
+		IgnoreUnavailable:  o.Spec.IgnoreUnavailable,
+		IncludeGlobalState: o.Spec.IncludeGlobalState,
+		FeatureStates:      o.Spec.FeatureStates,
+		Partial:            o.Spec.Partial,
+	}
+
+	if o.Spec.Metadata != nil {
+		s.Metadata = o.Spec.Metadata.Data
+	}
+
+	return s, nil
+}
+
+// Get return the expected snapshot if it already exist
+// A snapshot can't be updated, so it's not needed to read its settings
+func (h *snapshotApiClient) Get(o *elasticsearchapicrd.Snapshot) (object *snapshot, err error) {
+	info, err := snapshotGet(h.Client(), o.Spec.Repository, o.GetExternalName())
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, nil
+	}
+
+	return h.Build(o)
+}
+
+func (h *snapshotApiClient) Create(object *snapshot, o *elasticsearchapicrd.Snapshot) (err error) {
+	return snapshotCreate(h.Client(), o.Spec.Repository, o.GetExternalName(), object)
+}
+
+// Update do nothing, a snapshot can't be updated
+func (h *snapshotApiClient) Update(object *snapshot, o *elasticsearchapicrd.Snapshot) (err error) {
+	return nil
+}
+
+func (h *snapshotApiClient) Delete(o *elasticsearchapicrd.Snapshot) (err error) {
+	return snapshotDelete(h.Client(), o.Spec.Repository, o.GetExternalName())
+}
+
+func (h *snapshotApiClient) Diff(currentOject *snapshot, expectedObject *snapshot, originalObject *snapshot, o *elasticsearchapicrd.Snapshot, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	// If not yet exist
+	if currentOject == nil {
+		expected, err := json.Marshal(expectedObject)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert expected object to byte sequence")
+		}
+
+		return &patch.PatchResult{
+			Patch:    expected,
+			Current:  expected,
+			Modified: expected,
+			Original: nil,
+			Patched:  expectedObject,
+		}, nil
+	}
+
+	// A snapshot is never updated
+	return &patch.PatchResult{
+		Patched: currentOject,
+	}, nil
+}
+
+// snapshotGet permit to get snapshot state
+// It return nil if snapshot not exist
+func snapshotGet(client eshandler.ElasticsearchHandler, repository string, name string) (info *snapshotInfo, err error) {
+	api := client.Client().API
+	res, err := api.Snapshot.Get(
+		repository,
+		[]string{name},
+		api.Snapshot.Get.WithContext(context.Background()),
+		api.Snapshot.Get.WithIndexDetails(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get snapshot %s on repository %s: %s", name, repository, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &snapshotGetResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode snapshot %s", name)
+	}
+
+	for _, s := range resp.Snapshots {
+		if s.Snapshot == name {
+			return &s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// snapshotGetLatest permit to get the most recent successful snapshot of the repository
+// It return nil if there are no successful snapshot
+func snapshotGetLatest(client eshandler.ElasticsearchHandler, repository string) (info *snapshotInfo, err error) {
+	api := client.Client().API
+	res, err := api.Snapshot.Get(
+		repository,
+		[]string{"_all"},
+		api.Snapshot.Get.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when get snapshots on repository %s: %s", repository, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &snapshotGetResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode snapshots of repository %s", repository)
+	}
+
+	for i, s := range resp.Snapshots {
+		if s.State != snapshotStateSuccess {
+			continue
+		}
+		if info == nil || s.StartTimeInMillis > info.StartTimeInMillis {
+			info = &resp.Snapshots[i]
+		}
+	}
+
+	return info, nil
+}
+
+// snapshotCreate permit to start a snapshot
+// It not wait the snapshot completion
+func snapshotCreate(client eshandler.ElasticsearchHandler, repository string, name string, s *snapshot) (err error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.Snapshot.Create(
+		repository,
+		name,
+		api.Snapshot.Create.WithContext(context.Background()),
+		api.Snapshot.Create.WithBody(bytes.NewReader(data)),
+		api.Snapshot.Create.WithWaitForCompletion(false),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when create snapshot %s on repository %s: %s", name, repository, res.String())
+	}
+
+	return nil
+}
+
+// snapshotDelete permit to delete snapshot
+func snapshotDelete(client eshandler.ElasticsearchHandler, repository string, name string) (err error) {
+	api := client.Client().API
+	res, err := api.Snapshot.Delete(
+		repository,
+		[]string{name},
+		api.Snapshot.Delete.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete snapshot %s on repository %s: %s", name, repository, res.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package elasticsearchapi
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestSnapshotBuild(t *testing.T) {
+	var (
+		o                *elasticsearchapicrd.Snapshot
+		s                *snapshot
+		expectedSnapshot *snapshot
+		err              error
+		client           *snapshotApiClient
+	)
+
+	client = &snapshotApiClient{}
+
+	// With minimal spec
+	o = &elasticsearchapicrd.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.SnapshotSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Repository: "backup",
+		},
+	}
+
+	expectedSnapshot = &snapshot{}
+
+	s, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSnapshot, s)
+
+	// With all fields
+	o = &elasticsearchapicrd.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.SnapshotSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Repository:         "backup",
+			Indices:            []string{"logs-*"},
+			IgnoreUnavailable:  true,
+			IncludeGlobalState: ptr.To[bool](false),
+			FeatureStates:      []string{"security"},
+			Partial:            true,
+			Metadata: &apis.MapAny{
+				Data: map[string]any{
+					"taken_by": "operator",
+				},
+			},
+		},
+	}
+
+	expectedSnapshot = &snapshot{
+		Indices:            []string{"logs-*"},
+		IgnoreUnavailable:  true,
+		IncludeGlobalState: ptr.To[bool](false),
+		FeatureStates:      []string{"security"},
+		Partial:            true,
+		Metadata: map[string]any{
+			"taken_by": "operator",
+		},
+	}
+
+	s, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSnapshot, s)
+}
+
+func TestSnapshotApi(t *testing.T) {
+	var (
+		method string
+		path   string
+		query  string
+		body   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		query = r.URL.RawQuery
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		switch {
+		case r.URL.Path == "/_snapshot/backup/test" && r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"snapshots":[{"snapshot":"test","state":"PARTIAL","start_time_in_millis":1000,"end_time_in_millis":2000,"shards":{"total":2,"failed":1,"successful":1},"failures":[{"index":"logs","shard_id":0,"reason":"shard unavailable"}]}]}`))
+			return
+		case r.URL.Path == "/_snapshot/backup/_all":
+			_, _ = w.Write([]byte(`{"snapshots":[{"snapshot":"snap-1","state":"SUCCESS","start_time_in_millis":1000},{"snapshot":"snap-3","state":"FAILED","start_time_in_millis":3000},{"snapshot":"snap-2","state":"SUCCESS","start_time_in_millis":2000}]}`))
+			return
+		case r.URL.Path == "/_snapshot/empty/_all":
+			_, _ = w.Write([]byte(`{"snapshots":[]}`))
+			return
+		case r.URL.Path == "/_snapshot/backup/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"type":"snapshot_missing_exception"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"accepted":true}`))
+	}))
+
+	// Get
+	info, err := snapshotGet(mockES, "backup", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "PARTIAL", info.State)
+	assert.Equal(t, int64(1000), info.StartTimeInMillis)
+	assert.Equal(t, int64(2000), info.EndTimeInMillis)
+	assert.Equal(t, int64(2), info.Shards.Total)
+	assert.Equal(t, int64(1), info.Shards.Failed)
+	assert.Equal(t, "shard unavailable", info.Failures[0].Reason)
+
+	// Get when not exist
+	info, err = snapshotGet(mockES, "backup", "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	// Get latest successful snapshot
+	info, err = snapshotGetLatest(mockES, "backup")
+	assert.NoError(t, err)
+	assert.Equal(t, "snap-2", info.Snapshot)
+
+	// Get latest when there are no snapshot
+	info, err = snapshotGetLatest(mockES, "empty")
+	assert.NoError(t, err)
+	assert.Nil(t, info)
+
+	// Create
+	err = snapshotCreate(mockES, "backup", "test", &snapshot{
+		Indices: []string{"logs-*"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_snapshot/backup/test", path)
+	assert.Equal(t, "wait_for_completion=false", query)
+	assert.JSONEq(t, `{"indices":["logs-*"]}`, body)
+
+	// Delete
+	err = snapshotDelete(mockES, "backup", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/_snapshot/backup/test", path)
+
+	// Delete when not exist
+	err = snapshotDelete(mockES, "backup", "missing")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,179 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (t *ElasticsearchapiControllerTestSuite) TestSnapshotReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-snapshot-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase[*elasticsearchapicrd.Snapshot](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchapicrd.Snapshot]{
+		doCreateSnapshotStep(),
+		doDeleteSnapshotStep(),
+	}
+	testCase.PreTest = doMockSnapshot(t.fakeElasticsearchMux, key.Name)
+
+	testCase.Run()
+}
+
+func doMockSnapshot(mux *http.ServeMux, name string) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		var mutex sync.Mutex
+		isExist := false
+		nbGet := 0
+
+		mux.HandleFunc("/_snapshot/backup/"+name, func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			switch r.Method {
+			case http.MethodGet:
+				if !isExist {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"error":{"type":"snapshot_missing_exception"}}`))
+					return
+				}
+				// The snapshot is completed after some reconcile
+				nbGet++
+				if nbGet < 3 {
+					_, _ = w.Write([]byte(`{"snapshots":[{"snapshot":"` + name + `","state":"IN_PROGRESS","start_time_in_millis":1000,"shards":{"total":0,"failed":0,"successful":0}}]}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"snapshots":[{"snapshot":"` + name + `","state":"SUCCESS","start_time_in_millis":1000,"end_time_in_millis":2000,"shards":{"total":2,"failed":0,"successful":2}}]}`))
+				return
+			case http.MethodPut:
+				isExist = true
+				data["isCreated"] = true
+			case http.MethodDelete:
+				isExist = false
+				data["isDeleted"] = true
+			}
+			_, _ = w.Write([]byte(`{"accepted":true}`))
+		})
+
+		return nil
+	}
+}
+
+func doCreateSnapshotStep() test.TestStep[*elasticsearchapicrd.Snapshot] {
+	return test.TestStep[*elasticsearchapicrd.Snapshot]{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Snapshot, data map[string]any) (err error) {
+			logrus.Infof("=== Add new snapshot %s/%s ===\n\n", key.Namespace, key.Name)
+
+			snapshot := &elasticsearchapicrd.Snapshot{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchapicrd.SnapshotSpec{
+					ElasticsearchRef: shared.ElasticsearchRef{
+						ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+							Name: "test",
+						},
+					},
+					DeletionPolicy:    shared.DeletionPolicyDelete,
+					Repository:        "backup",
+					Indices:           []string{"logs-*"},
+					WaitForCompletion: true,
+				},
+			}
+			if err = c.Create(context.Background(), snapshot); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Snapshot, data map[string]any) (err error) {
+			snapshot := &elasticsearchapicrd.Snapshot{}
+			isCreated := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, snapshot); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated || snapshot.Status.State != "SUCCESS" {
+					return errors.New("Not yet completed")
+				}
+				return nil
+			}, time.Second*60, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Snapshot: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(snapshot.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *snapshot.Status.IsSync)
+			assert.Equal(t, int64(2), snapshot.Status.Shards.Successful)
+			assert.NotNil(t, snapshot.Status.EndTime)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteSnapshotStep() test.TestStep[*elasticsearchapicrd.Snapshot] {
+	return test.TestStep[*elasticsearchapicrd.Snapshot]{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Snapshot, data map[string]any) (err error) {
+			logrus.Infof("=== Delete snapshot %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Snapshot is null")
+			}
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), o, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Snapshot, data map[string]any) (err error) {
+			snapshot := &elasticsearchapicrd.Snapshot{}
+			isDeleted := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, snapshot); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Snapshot stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+			assert.Equal(t, true, data["isDeleted"])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,141 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/sirupsen/logrus"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	corev1 "k8s.io/api/core/v1"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	snapshotStateInProgress = "IN_PROGRESS"
+	snapshotStateSuccess    = "SUCCESS"
+	snapshotStatePartial    = "PARTIAL"
+	snapshotStateFailed     = "FAILED"
+
+	snapshotRefreshInterval = 10 * time.Second
+)
+
+type snapshotReconciler struct {
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler]
+	name string
+}
+
+func newSnapshotReconciler(name string, client client.Client, recorder record.EventRecorder) remote.RemoteReconcilerAction[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler] {
+	return &snapshotReconciler{
+		RemoteReconcilerAction: remote.NewRemoteReconcilerAction[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler](
+			client,
+			recorder,
+		),
+		name: name,
+	}
+}
+
+func (h *snapshotReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.Snapshot, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+	esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, h.Client(), logger)
+	if err != nil && o.DeletionTimestamp.IsZero() {
+		return nil, res, err
+	}
+
+	// Elastic not ready
+	if esClient == nil {
+		if o.DeletionTimestamp.IsZero() {
+			return nil, reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+		}
+
+		return nil, res, nil
+	}
+
+	handler = newSnapshotApiClient(esClient)
+
+	return handler, res, nil
+}
+
+// OnSuccess track the snapshot until it's completed
+func (h *snapshotReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.Snapshot, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*snapshot], logger *logrus.Entry) (res reconcile.Result, err error) {
+	info, err := snapshotGet(handler.Client(), o.Spec.Repository, o.GetExternalName())
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get snapshot")
+	}
+	if info == nil {
+		return res, errors.Errorf("Snapshot %s not found on repository %s", o.GetExternalName(), o.Spec.Repository)
+	}
+
+	if info.State != o.Status.State {
+		switch info.State {
+		case snapshotStateSuccess:
+			logger.Infof("Snapshot %s successfully completed", o.GetExternalName())
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "SnapshotCompleted", "Snapshot %s successfully completed", o.GetExternalName())
+		case snapshotStatePartial:
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "SnapshotPartial", "Snapshot %s completed with %d failed shards", o.GetExternalName(), info.Shards.Failed)
+		case snapshotStateFailed:
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "SnapshotFailed", "Snapshot %s failed", o.GetExternalName())
+		}
+	}
+
+	o.Status.State = info.State
+	o.Status.Shards = &elasticsearchapicrd.SnapshotShardsStatus{
+		Total:      info.Shards.Total,
+		Successful: info.Shards.Successful,
+		Failed:     info.Shards.Failed,
+	}
+	if info.StartTimeInMillis > 0 {
+		o.Status.StartTime = &metav1.Time{Time: time.UnixMilli(info.StartTimeInMillis)}
+	}
+	if info.EndTimeInMillis > 0 {
+		o.Status.EndTime = &metav1.Time{Time: time.UnixMilli(info.EndTimeInMillis)}
+	}
+	o.Status.Failure = ""
+	if len(info.Failures) > 0 {
+		o.Status.Failure = info.Failures[0].Reason
+	}
+
+	switch info.State {
+	case snapshotStateFailed:
+		h.setNotReady(o, "Failed", "Snapshot failed")
+		o.GetStatus().SetIsOnError(true)
+		return res, nil
+	case snapshotStateInProgress:
+		if o.Spec.WaitForCompletion {
+			h.setNotReady(o, "InProgress", "Wait snapshot completion")
+			return reconcile.Result{RequeueAfter: snapshotRefreshInterval}, nil
+		}
+	}
+
+	if res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger); err != nil {
+		return res, err
+	}
+
+	// Refresh the state until the snapshot is completed
+	if info.State == snapshotStateInProgress && (res.RequeueAfter == 0 || res.RequeueAfter > snapshotRefreshInterval) {
+		res.RequeueAfter = snapshotRefreshInterval
+	}
+
+	return res, nil
+}
+
+// setNotReady set the ready condition to false when the snapshot is not completed
+func (h *snapshotReconciler) setNotReady(o *elasticsearchapicrd.Snapshot, reason string, message string) {
+	conditions := o.GetStatus().GetConditions()
+	condition.SetStatusCondition(&conditions, metav1.Condition{
+		Type:    h.Condition().String(),
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	o.GetStatus().SetConditions(conditions)
+
+	o.GetStatus().SetIsOnError(false)
+	o.GetStatus().SetIsSync(true)
+	o.GetStatus().SetObservedGeneration(o.GetGeneration())
+}
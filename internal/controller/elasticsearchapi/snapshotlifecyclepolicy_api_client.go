@@ -1,12 +1,43 @@
 package elasticsearchapi
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/generic-objectmatcher/patch"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 )
 
+// slmPolicyResponse is the execution status of the policy returned by _slm/policy
+type slmPolicyResponse struct {
+	LastSuccess         *slmInvocationResponse `json:"last_success,omitempty"`
+	LastFailure         *slmInvocationResponse `json:"last_failure,omitempty"`
+	NextExecutionMillis int64                  `json:"next_execution_millis,omitempty"`
+	Stats               *struct {
+		SnapshotsTaken           int64 `json:"snapshots_taken"`
+		SnapshotsFailed          int64 `json:"snapshots_failed"`
+		SnapshotsDeleted         int64 `json:"snapshots_deleted"`
+		SnapshotDeletionFailures int64 `json:"snapshot_deletion_failures"`
+	} `json:"stats,omitempty"`
+}
+
+// slmInvocationResponse is a snapshot taken by the policy
+type slmInvocationResponse struct {
+	SnapshotName string `json:"snapshot_name"`
+	Time         int64  `json:"time"`
+	Details      string `json:"details,omitempty"`
+}
+
+// slmExecuteResponse is the response of _slm/policy/<id>/_execute
+type slmExecuteResponse struct {
+	SnapshotName string `json:"snapshot_name"`
+}
+
 type snapshotLifecyclePolicyApiClient struct {
 	remote.RemoteExternalReconciler[*elasticsearchapicrd.SnapshotLifecyclePolicy, *eshandler.SnapshotLifecyclePolicySpec, eshandler.ElasticsearchHandler]
 }
@@ -69,3 +100,64 @@ func (h *snapshotLifecyclePolicyApiClient) Delete(o *elasticsearchapicrd.Snapsho
 func (h *snapshotLifecyclePolicyApiClient) Diff(currentOject *eshandler.SnapshotLifecyclePolicySpec, expectedObject *eshandler.SnapshotLifecyclePolicySpec, originalObject *eshandler.SnapshotLifecyclePolicySpec, o *elasticsearchapicrd.SnapshotLifecyclePolicy, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
 	return h.Client().SLMDiff(currentOject, expectedObject, originalObject)
 }
+
+// slmGetStatus permit to get the execution status of the policy
+// It return nil if the policy not exist
+func slmGetStatus(client eshandler.ElasticsearchHandler, name string) (status *slmPolicyResponse, err error) {
+	api := client.Client().API
+	res, err := api.SlmGetLifecycle(
+		api.SlmGetLifecycle.WithContext(context.Background()),
+		api.SlmGetLifecycle.WithPolicyID(name),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, errors.Errorf("Error when get status of SLM policy %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := map[string]*slmPolicyResponse{}
+	if err = json.Unmarshal(b, &resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode status of SLM policy %s", name)
+	}
+
+	return resp[name], nil
+}
+
+// slmExecute permit to take a snapshot now with the policy
+// It return the snapshot name
+func slmExecute(client eshandler.ElasticsearchHandler, name string) (snapshotName string, err error) {
+	api := client.Client().API
+	res, err := api.SlmExecuteLifecycle(
+		name,
+		api.SlmExecuteLifecycle.WithContext(context.Background()),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return "", errors.Errorf("Error when execute SLM policy %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	resp := &slmExecuteResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return "", errors.Wrapf(err, "Error when decode execution of SLM policy %s", name)
+	}
+
+	return resp.SnapshotName, nil
+}
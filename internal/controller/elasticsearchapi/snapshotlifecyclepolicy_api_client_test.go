@@ -1,12 +1,15 @@
 package elasticsearchapi
 
 import (
+	"net/http"
 	"testing"
 
 	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/es-handler/v8/mocks"
 	"github.com/stretchr/testify/assert"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -118,3 +121,58 @@ func TestSnapshotLifecyclePolicyBuild(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedSLM, slm)
 }
+
+func TestSnapshotLifecyclePolicyApi(t *testing.T) {
+	var (
+		method string
+		path   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+
+		switch r.URL.Path {
+		case "/_slm/policy/daily":
+			_, _ = w.Write([]byte(`{"daily": {"version": 1, "policy": {"name": "<daily-snap-{now/d}>"}, "last_success": {"snapshot_name": "daily-snap-2024.01.02", "time": 1704159000000}, "last_failure": {"snapshot_name": "daily-snap-2024.01.01", "time": 1704072600000, "details": "repository missing"}, "next_execution_millis": 1704245400000, "stats": {"policy": "daily", "snapshots_taken": 2, "snapshots_failed": 1, "snapshots_deleted": 3, "snapshot_deletion_failures": 4}}}`))
+		case "/_slm/policy/daily/_execute":
+			_, _ = w.Write([]byte(`{"snapshot_name": "daily-snap-2024.01.03"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"type": "resource_not_found_exception", "reason": "snapshot lifecycle policy or policies [missing] not found"}, "status": 404}`))
+		}
+	}))
+
+	// Get status
+	status, err := slmGetStatus(mockES, "daily")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, method)
+	assert.Equal(t, "/_slm/policy/daily", path)
+	assert.Equal(t, "daily-snap-2024.01.02", status.LastSuccess.SnapshotName)
+	assert.Equal(t, int64(1704159000000), status.LastSuccess.Time)
+	assert.Equal(t, "repository missing", status.LastFailure.Details)
+	assert.Equal(t, int64(1704245400000), status.NextExecutionMillis)
+	assert.Equal(t, int64(2), status.Stats.SnapshotsTaken)
+	assert.Equal(t, int64(1), status.Stats.SnapshotsFailed)
+	assert.Equal(t, int64(3), status.Stats.SnapshotsDeleted)
+	assert.Equal(t, int64(4), status.Stats.SnapshotDeletionFailures)
+
+	// Get status when policy not exist
+	status, err = slmGetStatus(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+
+	// Execute
+	snapshotName, err := slmExecute(mockES, "daily")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_slm/policy/daily/_execute", path)
+	assert.Equal(t, "daily-snap-2024.01.03", snapshotName)
+
+	// Execute when policy not exist
+	_, err = slmExecute(mockES, "missing")
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package elasticsearchapi
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 
@@ -37,18 +38,27 @@ func (t *ElasticsearchapiControllerTestSuite) TestSnapshotLifecyclePolicyReconci
 	testCase.Steps = []test.TestStep[*elasticsearchapicrd.SnapshotLifecyclePolicy]{
 		doCreateSLMStep(),
 		doUpdateSLMStep(),
+		doExecuteSLMStep(),
 		doDeleteSLMStep(),
 	}
-	testCase.PreTest = doMockSLM(t.mockElasticsearchHandler)
+	testCase.PreTest = doMockSLM(t.mockElasticsearchHandler, t.fakeElasticsearchMux, key.Name)
 
 	testCase.Run()
 }
 
-func doMockSLM(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+func doMockSLM(mockES *mocks.MockElasticsearchHandler, mux *http.ServeMux, name string) func(stepName *string, data map[string]any) error {
 	return func(stepName *string, data map[string]any) (err error) {
 		isCreated := false
 		isUpdated := false
 
+		mux.HandleFunc("/_slm/policy/"+name, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"` + name + `": {"version": 1, "last_success": {"snapshot_name": "daily-snap-2024.01.01", "time": 1704072600000}, "next_execution_millis": 1704159000000, "stats": {"snapshots_taken": 1, "snapshots_failed": 0, "snapshots_deleted": 0, "snapshot_deletion_failures": 0}}}`))
+		})
+		mux.HandleFunc("/_slm/policy/"+name+"/_execute", func(w http.ResponseWriter, r *http.Request) {
+			data["isExecuted"] = true
+			_, _ = w.Write([]byte(`{"snapshot_name": "daily-snap-2024.01.02"}`))
+		})
+
 		mockES.EXPECT().SnapshotRepositoryGet(gomock.Eq("my_repository")).AnyTimes().DoAndReturn(func(name string) (*olivere.SnapshotRepositoryMetaData, error) {
 			return &olivere.SnapshotRepositoryMetaData{
 				Type: "url",
@@ -241,6 +251,10 @@ func doCreateSLMStep() test.TestStep[*elasticsearchapicrd.SnapshotLifecyclePolic
 
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(slm.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
 			assert.True(t, *slm.Status.IsSync)
+			assert.NotNil(t, slm.Status.LastSuccess)
+			assert.Equal(t, "daily-snap-2024.01.01", slm.Status.LastSuccess.SnapshotName)
+			assert.NotNil(t, slm.Status.NextExecution)
+			assert.Equal(t, int64(1), slm.Status.Stats.SnapshotsTaken)
 
 			return nil
 		},
@@ -294,6 +308,48 @@ func doUpdateSLMStep() test.TestStep[*elasticsearchapicrd.SnapshotLifecyclePolic
 	}
 }
 
+func doExecuteSLMStep() test.TestStep[*elasticsearchapicrd.SnapshotLifecyclePolicy] {
+	return test.TestStep[*elasticsearchapicrd.SnapshotLifecyclePolicy]{
+		Name: "execute",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.SnapshotLifecyclePolicy, data map[string]any) (err error) {
+			logrus.Infof("=== Execute SLM policy %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("SLM is null")
+			}
+
+			o.Annotations = map[string]string{
+				slmExecuteAnnotation: "true",
+			}
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.SnapshotLifecyclePolicy, data map[string]any) (err error) {
+			slm := &elasticsearchapicrd.SnapshotLifecyclePolicy{}
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, slm); err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := data["isExecuted"]; !ok || slm.Status.LastExecution == nil {
+					return errors.New("Not yet executed")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get SLM: %s", err.Error())
+			}
+			assert.Equal(t, "daily-snap-2024.01.02", slm.Status.LastExecution.SnapshotName)
+			assert.NotContains(t, slm.Annotations, slmExecuteAnnotation)
+
+			return nil
+		},
+	}
+}
+
 func doDeleteSLMStep() test.TestStep[*elasticsearchapicrd.SnapshotLifecyclePolicy] {
 	return test.TestStep[*elasticsearchapicrd.SnapshotLifecyclePolicy]{
 		Name: "delete",
@@ -2,6 +2,7 @@ package elasticsearchapi
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"emperror.dev/errors"
@@ -9,11 +10,24 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// slmStatusRefreshInterval is the interval to refresh the execution status of the policy
+	slmStatusRefreshInterval = 5 * time.Minute
+)
+
+var (
+	// slmExecuteAnnotation is the annotation that take a snapshot now with the policy
+	slmExecuteAnnotation = fmt.Sprintf("%s/execute", elasticsearchapicrd.ElasticsearchApiAnnotationKey)
+)
+
 type snapshotLifecyclePolicyReconciler struct {
 	remote.RemoteReconcilerAction[*elasticsearchapicrd.SnapshotLifecyclePolicy, *eshandler.SnapshotLifecyclePolicySpec, eshandler.ElasticsearchHandler]
 	name string
@@ -62,3 +76,91 @@ func (h *snapshotLifecyclePolicyReconciler) Create(ctx context.Context, o *elast
 
 	return h.RemoteReconcilerAction.Create(ctx, o, data, handler, object, logger)
 }
+
+// OnSuccess take a snapshot when the execute annotation is set, then read the execution status of the policy
+func (h *snapshotLifecyclePolicyReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.SnapshotLifecyclePolicy, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.SnapshotLifecyclePolicy, *eshandler.SnapshotLifecyclePolicySpec, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*eshandler.SnapshotLifecyclePolicySpec], logger *logrus.Entry) (res reconcile.Result, err error) {
+	// Not take snapshot when the dry-run annotation is set, the policy can be not yet applied
+	if o.GetDryRunStatus() == nil && o.GetAnnotations()[slmExecuteAnnotation] == "true" {
+		snapshotName, err := slmExecute(handler.Client(), o.GetExternalName())
+		if err != nil {
+			return res, errors.Wrapf(err, "Error when execute SLM policy %s", o.GetExternalName())
+		}
+		o.Status.LastExecution = &elasticsearchapicrd.SLMInvocation{
+			SnapshotName: snapshotName,
+			Time:         &metav1.Time{Time: time.Now()},
+		}
+		logger.Infof("Snapshot %s started by SLM policy %s", snapshotName, o.GetExternalName())
+		h.Recorder().Eventf(o, corev1.EventTypeNormal, "Executed", "Snapshot %s started by SLM policy %s", snapshotName, o.GetExternalName())
+
+		if err = common.RemoveAnnotation(ctx, h.Client(), o, slmExecuteAnnotation); err != nil {
+			return res, err
+		}
+	}
+
+	status, err := slmGetStatus(handler.Client(), o.GetExternalName())
+	if err != nil {
+		return res, errors.Wrapf(err, "Error when get status of SLM policy %s", o.GetExternalName())
+	}
+	h.setExecutionStatus(o, status)
+
+	res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	if err != nil {
+		return res, err
+	}
+
+	// Refresh the execution status to notice when the snapshots stop working
+	if !res.Requeue && (res.RequeueAfter == 0 || res.RequeueAfter > slmStatusRefreshInterval) {
+		res.RequeueAfter = slmStatusRefreshInterval
+	}
+
+	return res, nil
+}
+
+// setExecutionStatus set the execution status of the policy on status
+// It notify when a new snapshot failed
+func (h *snapshotLifecyclePolicyReconciler) setExecutionStatus(o *elasticsearchapicrd.SnapshotLifecyclePolicy, status *slmPolicyResponse) {
+	if status == nil {
+		o.Status.LastSuccess = nil
+		o.Status.LastFailure = nil
+		o.Status.NextExecution = nil
+		o.Status.Stats = nil
+		return
+	}
+
+	o.Status.LastSuccess = slmInvocationToStatus(status.LastSuccess)
+	lastFailure := slmInvocationToStatus(status.LastFailure)
+	if lastFailure != nil && (o.Status.LastFailure == nil || o.Status.LastFailure.SnapshotName != lastFailure.SnapshotName) && (o.Status.LastSuccess == nil || lastFailure.Time.After(o.Status.LastSuccess.Time.Time)) {
+		h.Recorder().Eventf(o, corev1.EventTypeWarning, "SnapshotFailed", "Snapshot %s of SLM policy %s failed: %s", lastFailure.SnapshotName, o.GetExternalName(), lastFailure.Details)
+	}
+	o.Status.LastFailure = lastFailure
+
+	if status.NextExecutionMillis > 0 {
+		o.Status.NextExecution = &metav1.Time{Time: time.UnixMilli(status.NextExecutionMillis)}
+	} else {
+		o.Status.NextExecution = nil
+	}
+
+	if status.Stats != nil {
+		o.Status.Stats = &elasticsearchapicrd.SLMStats{
+			SnapshotsTaken:           status.Stats.SnapshotsTaken,
+			SnapshotsFailed:          status.Stats.SnapshotsFailed,
+			SnapshotsDeleted:         status.Stats.SnapshotsDeleted,
+			SnapshotDeletionFailures: status.Stats.SnapshotDeletionFailures,
+		}
+	} else {
+		o.Status.Stats = nil
+	}
+}
+
+// slmInvocationToStatus convert the snapshot taken by the policy on status
+func slmInvocationToStatus(invocation *slmInvocationResponse) *elasticsearchapicrd.SLMInvocation {
+	if invocation == nil {
+		return nil
+	}
+
+	return &elasticsearchapicrd.SLMInvocation{
+		SnapshotName: invocation.SnapshotName,
+		Time:         &metav1.Time{Time: time.UnixMilli(invocation.Time)},
+		Details:      invocation.Details,
+	}
+}
@@ -1,6 +1,13 @@
 package elasticsearchapi
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/generic-objectmatcher/patch"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
@@ -8,6 +15,32 @@ import (
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 )
 
+// snapshotRepositoryVerifyResponse is the response of the repository verification
+type snapshotRepositoryVerifyResponse struct {
+	Nodes map[string]struct {
+		Name string `json:"name"`
+	} `json:"nodes"`
+}
+
+// nodesReloadSecureSettingsResponse is the response of the secure settings reload
+type nodesReloadSecureSettingsResponse struct {
+	Nodes map[string]struct {
+		Name            string `json:"name"`
+		ReloadException *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"reload_exception,omitempty"`
+	} `json:"nodes"`
+}
+
+// elasticsearchErrorResponse is the error returned by Elasticsearch
+type elasticsearchErrorResponse struct {
+	Error struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+}
+
 type snapshotRepositoryApiClient struct {
 	remote.RemoteExternalReconciler[*elasticsearchapicrd.SnapshotRepository, *olivere.SnapshotRepositoryMetaData, eshandler.ElasticsearchHandler]
 }
@@ -49,3 +82,87 @@ func (h *snapshotRepositoryApiClient) Delete(o *elasticsearchapicrd.SnapshotRepo
 func (h *snapshotRepositoryApiClient) Diff(currentOject *olivere.SnapshotRepositoryMetaData, expectedObject *olivere.SnapshotRepositoryMetaData, originalObject *olivere.SnapshotRepositoryMetaData, o *elasticsearchapicrd.SnapshotRepository, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
 	return h.Client().SnapshotRepositoryDiff(currentOject, expectedObject, originalObject)
 }
+
+// snapshotRepositoryVerify permit to check all nodes can access to the repository
+// It return the nodes that have verified the repository, or the verification error with the nodes on failure
+func snapshotRepositoryVerify(client eshandler.ElasticsearchHandler, name string) (nodes []elasticsearchapicrd.SnapshotRepositoryVerifiedNode, err error) {
+	api := client.Client().API
+	res, err := api.Snapshot.VerifyRepository(
+		name,
+		api.Snapshot.VerifyRepository.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		errResp := &elasticsearchErrorResponse{}
+		if err = json.Unmarshal(b, errResp); err != nil || errResp.Error.Reason == "" {
+			return nil, errors.Errorf("Error when verify snapshot repository %s: %s", name, res.String())
+		}
+		return nil, errors.Errorf("%s: %s", errResp.Error.Type, errResp.Error.Reason)
+	}
+
+	resp := &snapshotRepositoryVerifyResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode verification of snapshot repository %s", name)
+	}
+
+	nodes = make([]elasticsearchapicrd.SnapshotRepositoryVerifiedNode, 0, len(resp.Nodes))
+	for id, node := range resp.Nodes {
+		nodes = append(nodes, elasticsearchapicrd.SnapshotRepositoryVerifiedNode{
+			Id:   id,
+			Name: node.Name,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	return nodes, nil
+}
+
+// nodesReloadSecureSettings permit to reload the keystore on all nodes
+// It return an error with the nodes that have failed to reload it
+func nodesReloadSecureSettings(client eshandler.ElasticsearchHandler) (err error) {
+	api := client.Client().API
+	res, err := api.Nodes.ReloadSecureSettings(
+		api.Nodes.ReloadSecureSettings.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when reload secure settings: %s", res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	resp := &nodesReloadSecureSettingsResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return errors.Wrap(err, "Error when decode secure settings reload")
+	}
+
+	failures := make([]string, 0)
+	for _, node := range resp.Nodes {
+		if node.ReloadException != nil {
+			failures = append(failures, node.Name+": "+node.ReloadException.Reason)
+		}
+	}
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return errors.Errorf("Error when reload secure settings on nodes: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
@@ -1,13 +1,16 @@
 package elasticsearchapi
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/disaster37/es-handler/v8/mocks"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/assert"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -75,3 +78,68 @@ func TestSnapshotRepositoryBuild(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedSr, sr)
 }
+
+func TestSnapshotRepositoryApi(t *testing.T) {
+	var (
+		method string
+		path   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+
+		switch r.URL.Path {
+		case "/_snapshot/backup/_verify":
+			_, _ = w.Write([]byte(`{"nodes": {"id2": {"name": "node2"}, "id1": {"name": "node1"}}}`))
+		case "/_snapshot/broken/_verify":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error": {"type": "repository_verification_exception", "reason": "[broken] [[id2, 'RemoteTransportException[[node2][access denied]]']]"}, "status": 500}`))
+		case "/_nodes/reload_secure_settings":
+			_, _ = w.Write([]byte(`{"_nodes": {"total": 2, "successful": 2, "failed": 0}, "cluster_name": "test", "nodes": {"id1": {"name": "node1"}, "id2": {"name": "node2"}}}`))
+		}
+	}))
+
+	// Verify
+	nodes, err := snapshotRepositoryVerify(mockES, "backup")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/_snapshot/backup/_verify", path)
+	assert.Equal(t, []elasticsearchapicrd.SnapshotRepositoryVerifiedNode{
+		{
+			Id:   "id1",
+			Name: "node1",
+		},
+		{
+			Id:   "id2",
+			Name: "node2",
+		},
+	}, nodes)
+
+	// Verify on failure
+	_, err = snapshotRepositoryVerify(mockES, "broken")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "node2")
+
+	// Reload secure settings
+	err = nodesReloadSecureSettings(mockES)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/_nodes/reload_secure_settings", path)
+}
+
+func TestNodesReloadSecureSettingsFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"_nodes": {"total": 2, "successful": 2, "failed": 0}, "cluster_name": "test", "nodes": {"id1": {"name": "node1"}, "id2": {"name": "node2", "reload_exception": {"type": "illegal_state_exception", "reason": "keystore is missing"}}}}`))
+	}))
+
+	err := nodesReloadSecureSettings(mockES)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "node2: keystore is missing")
+}
@@ -15,6 +15,7 @@ package elasticsearchapi
 
 import (
 	"context"
+	"fmt"
 
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
@@ -22,10 +23,15 @@ import (
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	k8scontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -51,10 +57,13 @@ func NewSnapshotRepositoryReconciler(client client.Client, logger *logrus.Entry,
 			logger,
 			recorder,
 		),
-		RemoteReconcilerAction: newSnapshotRepositoryReconciler(
-			snapshotRepositoryName,
-			client,
-			recorder,
+		RemoteReconcilerAction: common.NewRemoteReconcilerAction(
+			elasticsearchapicrd.ElasticsearchApiAnnotationKey,
+			newSnapshotRepositoryReconciler(
+				snapshotRepositoryName,
+				client,
+				recorder,
+			),
 		),
 		name: snapshotRepositoryName,
 	}
@@ -64,6 +73,7 @@ func NewSnapshotRepositoryReconciler(client client.Client, logger *logrus.Entry,
 //+kubebuilder:rbac:groups=elasticsearchapi.k8s.webcenter.fr,resources=snapshotrepositories/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=elasticsearchapi.k8s.webcenter.fr,resources=snapshotrepositories/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=events,verbs=patch;get;create
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
 //+kubebuilder:rbac:groups="elasticsearch.k8s.webcenter.fr",resources=elasticsearches,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -92,6 +102,7 @@ func (r *SnapshotRepositoryReconciler) Reconcile(ctx context.Context, req reconc
 func (r *SnapshotRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&elasticsearchapicrd.SnapshotRepository{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(watchSnapshotRepositorySecret(r.Client()))).
 		WithOptions(k8scontroller.Options{
 			RateLimiter: controller.DefaultControllerRateLimiter[reconcile.Request](),
 		}).
@@ -105,3 +116,24 @@ func (h *SnapshotRepositoryReconciler) Client() client.Client {
 func (h *SnapshotRepositoryReconciler) Recorder() record.EventRecorder {
 	return h.RemoteReconcilerAction.Recorder()
 }
+
+// watchSnapshotRepositorySecret permit to update the credentials on keystore if secret change
+func watchSnapshotRepositorySecret(c client.Client) handler.MapFunc {
+	return func(ctx context.Context, a client.Object) []reconcile.Request {
+		reconcileRequests := make([]reconcile.Request, 0)
+		listSnapshotRepositories := &elasticsearchapicrd.SnapshotRepositoryList{}
+
+		fs := fields.ParseSelectorOrDie(fmt.Sprintf("spec.credentialsSecretRef.name=%s", a.GetName()))
+
+		// Get all snapshot repositories linked with secret
+		if err := c.List(context.Background(), listSnapshotRepositories, &client.ListOptions{Namespace: a.GetNamespace(), FieldSelector: fs}); err != nil {
+			panic(err)
+		}
+
+		for _, sr := range listSnapshotRepositories.Items {
+			reconcileRequests = append(reconcileRequests, reconcile.Request{NamespacedName: types.NamespacedName{Name: sr.Name, Namespace: sr.Namespace}})
+		}
+
+		return reconcileRequests
+	}
+}
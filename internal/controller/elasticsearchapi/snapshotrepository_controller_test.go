@@ -2,6 +2,7 @@ package elasticsearchapi
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
@@ -38,16 +39,20 @@ func (t *ElasticsearchapiControllerTestSuite) TestSnapshotRepositoryReconciler()
 		doUpdateSnapshotRepositoryStep(),
 		doDeleteSnapshotRepositoryStep(),
 	}
-	testCase.PreTest = doMockSnapshotRepository(t.mockElasticsearchHandler)
+	testCase.PreTest = doMockSnapshotRepository(t.mockElasticsearchHandler, t.fakeElasticsearchMux, key.Name)
 
 	testCase.Run()
 }
 
-func doMockSnapshotRepository(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+func doMockSnapshotRepository(mockES *mocks.MockElasticsearchHandler, mux *http.ServeMux, name string) func(stepName *string, data map[string]any) error {
 	return func(stepName *string, data map[string]any) (err error) {
 		isCreated := false
 		isUpdated := false
 
+		mux.HandleFunc("/_snapshot/"+name+"/_verify", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"nodes":{"node1":{"name":"test-es-all-0"}}}`))
+		})
+
 		mockES.EXPECT().SnapshotRepositoryGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*olivere.SnapshotRepositoryMetaData, error) {
 			switch *stepName {
 			case "create":
@@ -184,6 +189,9 @@ func doCreateSnapshotRepositoryStep() test.TestStep[*elasticsearchapicrd.Snapsho
 			}
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(repo.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
 			assert.True(t, *repo.Status.IsSync)
+			assert.NotNil(t, repo.Status.Verification)
+			assert.True(t, repo.Status.Verification.IsVerified)
+			assert.Equal(t, []elasticsearchapicrd.SnapshotRepositoryVerifiedNode{{Id: "node1", Name: "test-es-all-0"}}, repo.Status.Verification.Nodes)
 
 			return nil
 		},
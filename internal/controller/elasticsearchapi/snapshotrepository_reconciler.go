@@ -1,19 +1,34 @@
 package elasticsearchapi
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"sort"
 	"time"
 
+	"emperror.dev/errors"
+	"github.com/codingsince1985/checksum"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
+	elasticsearchcontrollers "github.com/webcenter-fr/elasticsearch-operator/internal/controller/elasticsearch"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// snapshotRepositoryVerifyInterval is the interval to verify again the repository while it's on failure
+	snapshotRepositoryVerifyInterval = 60 * time.Second
+)
+
 type snapshotRepositoryReconciler struct {
 	remote.RemoteReconcilerAction[*elasticsearchapicrd.SnapshotRepository, *olivere.SnapshotRepositoryMetaData, eshandler.ElasticsearchHandler]
 	name string
@@ -48,3 +63,178 @@ func (h *snapshotRepositoryReconciler) GetRemoteHandler(ctx context.Context, req
 
 	return handler, res, nil
 }
+
+// Create inject the credentials on keystore before create the repository, Elasticsearch verify the repository when it's created
+func (h *snapshotRepositoryReconciler) Create(ctx context.Context, o *elasticsearchapicrd.SnapshotRepository, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.SnapshotRepository, *olivere.SnapshotRepositoryMetaData, eshandler.ElasticsearchHandler], object *olivere.SnapshotRepositoryMetaData, logger *logrus.Entry) (res reconcile.Result, err error) {
+	if _, err = h.syncCredentials(ctx, o, handler, logger); err != nil {
+		return res, err
+	}
+
+	return h.RemoteReconcilerAction.Create(ctx, o, data, handler, object, logger)
+}
+
+// Update inject the credentials on keystore before update the repository, Elasticsearch verify the repository when it's updated
+func (h *snapshotRepositoryReconciler) Update(ctx context.Context, o *elasticsearchapicrd.SnapshotRepository, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.SnapshotRepository, *olivere.SnapshotRepositoryMetaData, eshandler.ElasticsearchHandler], object *olivere.SnapshotRepositoryMetaData, logger *logrus.Entry) (res reconcile.Result, err error) {
+	if _, err = h.syncCredentials(ctx, o, handler, logger); err != nil {
+		return res, err
+	}
+
+	return h.RemoteReconcilerAction.Update(ctx, o, data, handler, object, logger)
+}
+
+// OnSuccess inject the credentials on keystore when only them change, then verify the repository on all nodes
+func (h *snapshotRepositoryReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.SnapshotRepository, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.SnapshotRepository, *olivere.SnapshotRepositoryMetaData, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*olivere.SnapshotRepositoryMetaData], logger *logrus.Entry) (res reconcile.Result, err error) {
+	// Not touch the cluster when the dry-run annotation is set
+	if o.GetDryRunStatus() != nil {
+		return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	}
+
+	checksum, err := h.syncCredentials(ctx, o, handler, logger)
+	if err != nil {
+		return res, err
+	}
+
+	nodes, err := snapshotRepositoryVerify(handler.Client(), o.GetExternalName())
+	verification := &elasticsearchapicrd.SnapshotRepositoryVerificationStatus{
+		IsVerified:           err == nil,
+		LastVerificationTime: &metav1.Time{Time: time.Now()},
+		Nodes:                nodes,
+	}
+	if err != nil {
+		verification.Error = err.Error()
+		if o.Status.Verification == nil || o.Status.Verification.IsVerified {
+			h.Recorder().Eventf(o, corev1.EventTypeWarning, "VerificationFailed", "Snapshot repository %s can't be verified: %s", o.GetExternalName(), err.Error())
+		}
+		logger.Warnf("Snapshot repository %s can't be verified: %s", o.GetExternalName(), err.Error())
+	} else if o.Status.Verification == nil || !o.Status.Verification.IsVerified {
+		h.Recorder().Eventf(o, corev1.EventTypeNormal, "Verified", "Snapshot repository %s is verified on %d nodes", o.GetExternalName(), len(nodes))
+	}
+	o.Status.Verification = verification
+
+	// The credentials are loaded only when the repository is verified with them
+	if verification.IsVerified {
+		o.Status.CredentialsChecksum = checksum
+	}
+
+	res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	if err != nil {
+		return res, err
+	}
+
+	// The keystore can take time to be updated on all nodes
+	if !verification.IsVerified && (res.RequeueAfter == 0 || res.RequeueAfter > snapshotRepositoryVerifyInterval) {
+		res.RequeueAfter = snapshotRepositoryVerifyInterval
+	}
+
+	return res, nil
+}
+
+// Delete remove the repository, then the credentials from keystore
+func (h *snapshotRepositoryReconciler) Delete(ctx context.Context, o *elasticsearchapicrd.SnapshotRepository, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.SnapshotRepository, *olivere.SnapshotRepositoryMetaData, eshandler.ElasticsearchHandler], logger *logrus.Entry) (err error) {
+	if err = h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger); err != nil {
+		return err
+	}
+
+	// The repository is keeped, so it still need the credentials
+	if len(o.Status.CredentialsKeys) == 0 || o.GetDeletionPolicy().IsOrphan() {
+		return nil
+	}
+
+	es, err := common.GetElasticsearchFromRef(ctx, h.Client(), o, o.Spec.ElasticsearchRef)
+	if err != nil {
+		return errors.Wrap(err, "Error when get Elasticsearch")
+	}
+	if es == nil {
+		return nil
+	}
+	expectedData := map[string][]byte{}
+	for _, key := range o.Status.CredentialsKeys {
+		expectedData[key] = nil
+	}
+	if _, err = updateElasticsearchSecret(ctx, h.Client(), es, elasticsearchcontrollers.GetSecretNameForSnapshotRepository(es), expectedData); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// syncCredentials put the credentials on the secret injected on the keystore of managed cluster
+// Then it reload the secure settings when the credentials are not yet loaded
+// It return the checksum of the credentials
+func (h *snapshotRepositoryReconciler) syncCredentials(ctx context.Context, o *elasticsearchapicrd.SnapshotRepository, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.SnapshotRepository, *olivere.SnapshotRepositoryMetaData, eshandler.ElasticsearchHandler], logger *logrus.Entry) (checksum string, err error) {
+	if !o.Spec.ElasticsearchRef.IsManaged() || (o.Spec.CredentialsSecretRef == nil && len(o.Status.CredentialsKeys) == 0) {
+		return "", nil
+	}
+
+	es, err := common.GetElasticsearchFromRef(ctx, h.Client(), o, o.Spec.ElasticsearchRef)
+	if err != nil {
+		return "", errors.Wrap(err, "Error when get Elasticsearch")
+	}
+	if es == nil {
+		return "", errors.Errorf("Elasticsearch %s not found, the credentials can't be injected on its keystore", o.Spec.ElasticsearchRef.ManagedElasticsearchRef.Name)
+	}
+
+	// Remove the keys no more provided
+	expectedData := map[string][]byte{}
+	for _, key := range o.Status.CredentialsKeys {
+		expectedData[key] = nil
+	}
+	credentials := map[string][]byte{}
+	if o.Spec.CredentialsSecretRef != nil {
+		secret := &corev1.Secret{}
+		if err = h.Client().Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Spec.CredentialsSecretRef.Name}, secret); err != nil {
+			return "", errors.Wrapf(err, "Error when get secret %s", o.Spec.CredentialsSecretRef.Name)
+		}
+		credentials = secret.Data
+	}
+	keys := make([]string, 0, len(credentials))
+	for key, value := range credentials {
+		if !elasticsearchapicrd.IsSnapshotRepositoryCredentialsKey(key) {
+			return "", errors.Errorf("The key %s of secret %s is not a client setting, it need to match `<s3|gcs|azure>.client.<name>.<setting>`", key, o.Spec.CredentialsSecretRef.Name)
+		}
+		expectedData[key] = value
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	isUpdated, err := updateElasticsearchSecret(ctx, h.Client(), es, elasticsearchcontrollers.GetSecretNameForSnapshotRepository(es), expectedData)
+	if err != nil {
+		return "", err
+	}
+	if isUpdated {
+		logger.Infof("Credentials of snapshot repository %s updated on keystore of Elasticsearch %s", o.GetExternalName(), es.Name)
+		h.Recorder().Eventf(o, corev1.EventTypeNormal, "CredentialsUpdated", "Credentials updated on keystore of Elasticsearch %s", es.Name)
+	}
+	o.Status.CredentialsKeys = keys
+
+	if len(credentials) == 0 {
+		return "", nil
+	}
+
+	// Reload the secure settings while the repository is not verified with the credentials
+	checksum, err = getSnapshotRepositoryCredentialsChecksum(credentials)
+	if err != nil {
+		return "", err
+	}
+	if checksum != o.Status.CredentialsChecksum {
+		if err = nodesReloadSecureSettings(handler.Client()); err != nil {
+			logger.Warnf("Secure settings not yet reloaded: %s", err.Error())
+		}
+	}
+
+	return checksum, nil
+}
+
+// getSnapshotRepositoryCredentialsChecksum return the sha256 of the credentials
+func getSnapshotRepositoryCredentialsChecksum(credentials map[string][]byte) (hash string, err error) {
+	j, err := json.Marshal(credentials)
+	if err != nil {
+		return "", errors.Wrap(err, "Error when convert credentials to JSON")
+	}
+	hash, err = checksum.SHA256sumReader(bytes.NewReader(j))
+	if err != nil {
+		return "", errors.Wrap(err, "Error when compute credentials hash")
+	}
+
+	return hash, nil
+}
@@ -0,0 +1,279 @@
+package elasticsearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"regexp"
+	"slices"
+	"time"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"github.com/sirupsen/logrus"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// storedScriptCompileTimeout is the max time to wait the compilation from webhook
+const storedScriptCompileTimeout = 10 * time.Second
+
+// storedScriptContextVariables are the variables provided by the execution context of the script (update, ingest, score, aggregation...)
+// They not exist on the `painless_test` context used to compile the script
+var storedScriptContextVariables = []string{"ctx", "doc", "_score", "_source", "_value", "_fields", "state", "states", "emit", "field"}
+
+// storedScriptUnresolvedSymbol match the compile error returned by painless when a variable not exist
+var storedScriptUnresolvedSymbol = regexp.MustCompile(`(?:cannot resolve symbol|[Vv]ariable) \[([A-Za-z_][A-Za-z0-9_]*)`)
+
+// errStoredScriptNotChecked is returned when the script can't be compiled by Elasticsearch
+var errStoredScriptNotChecked = errors.New("Stored script can't be checked")
+
+// storedScript is the script object stored on Elasticsearch
+type storedScript struct {
+	Lang    string            `json:"lang"`
+	Source  string            `json:"source"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+type storedScriptGetResponse struct {
+	Found  bool          `json:"found"`
+	Script *storedScript `json:"script,omitempty"`
+}
+
+type storedScriptApiClient struct {
+	remote.RemoteExternalReconciler[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler]
+}
+
+func newStoredScriptApiClient(client eshandler.ElasticsearchHandler) remote.RemoteExternalReconciler[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler] {
+	return &storedScriptApiClient{
+		RemoteExternalReconciler: remote.NewRemoteExternalReconciler[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler](client),
+	}
+}
+
+func (h *storedScriptApiClient) Build(o *elasticsearchapicrd.StoredScript) (script *storedScript, err error) {
+	script = &storedScript{
+		Lang:    string(o.GetLang()),
+		Source:  o.Spec.Source,
+		Options: o.Spec.Options,
+	}
+
+	return script, nil
+}
+
+func (h *storedScriptApiClient) Get(o *elasticsearchapicrd.StoredScript) (object *storedScript, err error) {
+	return storedScriptGet(h.Client(), o.GetExternalName())
+}
+
+func (h *storedScriptApiClient) Create(object *storedScript, o *elasticsearchapicrd.StoredScript) (err error) {
+	return storedScriptUpdate(h.Client(), o.GetExternalName(), o.Spec.Context, object)
+}
+
+func (h *storedScriptApiClient) Update(object *storedScript, o *elasticsearchapicrd.StoredScript) (err error) {
+	return storedScriptUpdate(h.Client(), o.GetExternalName(), o.Spec.Context, object)
+}
+
+func (h *storedScriptApiClient) Delete(o *elasticsearchapicrd.StoredScript) (err error) {
+	return storedScriptDelete(h.Client(), o.GetExternalName())
+}
+
+func (h *storedScriptApiClient) Diff(currentOject *storedScript, expectedObject *storedScript, originalObject *storedScript, o *elasticsearchapicrd.StoredScript, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	// If not yet exist
+	if currentOject == nil {
+		expected, err := json.Marshal(expectedObject)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to convert expected object to byte sequence")
+		}
+
+		return &patch.PatchResult{
+			Patch:    expected,
+			Current:  expected,
+			Modified: expected,
+			Original: nil,
+			Patched:  expectedObject,
+		}, nil
+	}
+
+	return patch.DefaultPatchMaker.Calculate(currentOject, expectedObject, originalObject, ignoresDiff...)
+}
+
+// storedScriptGet permit to get stored script
+// It return nil if script not exist
+func storedScriptGet(client eshandler.ElasticsearchHandler, id string) (script *storedScript, err error) {
+	api := client.Client().API
+	res, err := api.GetScript(
+		id,
+		api.GetScript.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get stored script %s: %s", id, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &storedScriptGetResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode stored script %s", id)
+	}
+
+	if !resp.Found {
+		return nil, nil
+	}
+
+	return resp.Script, nil
+}
+
+// storedScriptUpdate permit to create or update stored script
+func storedScriptUpdate(client eshandler.ElasticsearchHandler, id string, scriptContext string, script *storedScript) (err error) {
+	data, err := json.Marshal(map[string]any{
+		"script": script,
+	})
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	opts := []func(*esapi.PutScriptRequest){
+		api.PutScript.WithContext(context.Background()),
+	}
+	if scriptContext != "" {
+		opts = append(opts, api.PutScript.WithScriptContext(scriptContext))
+	}
+	res, err := api.PutScript(
+		id,
+		bytes.NewReader(data),
+		opts...,
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when add stored script %s: %s", id, res.String())
+	}
+
+	return nil
+}
+
+// storedScriptDelete permit to delete stored script
+func storedScriptDelete(client eshandler.ElasticsearchHandler, id string) (err error) {
+	api := client.Client().API
+	res, err := api.DeleteScript(
+		id,
+		api.DeleteScript.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when delete stored script %s: %s", id, res.String())
+	}
+
+	return nil
+}
+
+// storedScriptCompile permit to check the painless script with the painless execute API
+// The script is compiled on the `painless_test` context, so the variables provided by the real execution context (`ctx`, `doc`...) not exist.
+// It only return error when the script not compile for an other reason
+func storedScriptCompile(ctx context.Context, client eshandler.ElasticsearchHandler, source string) (err error) {
+	data, err := json.Marshal(map[string]any{
+		"script": map[string]any{
+			"source": source,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.ScriptsPainlessExecute(
+		api.ScriptsPainlessExecute.WithContext(ctx),
+		api.ScriptsPainlessExecute.WithBody(bytes.NewReader(data)),
+	)
+	if err != nil {
+		return errors.Wrap(errStoredScriptNotChecked, err.Error())
+	}
+	defer res.Body.Close()
+
+	if !res.IsError() {
+		return nil
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return errors.Wrap(errStoredScriptNotChecked, err.Error())
+	}
+	resp := &struct {
+		Error struct {
+			Type     string `json:"type"`
+			Reason   string `json:"reason"`
+			CausedBy struct {
+				Reason string `json:"reason"`
+			} `json:"caused_by"`
+		} `json:"error"`
+	}{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return errors.Wrap(errStoredScriptNotChecked, res.String())
+	}
+
+	// Runtime errors are expected because the script is executed without params and documents
+	if resp.Error.Type != "script_exception" || resp.Error.Reason != "compile error" {
+		return errors.Wrap(errStoredScriptNotChecked, res.String())
+	}
+
+	// The script use variable from it's execution context, we can't check it without this context
+	if match := storedScriptUnresolvedSymbol.FindStringSubmatch(resp.Error.CausedBy.Reason); match != nil && slices.Contains(storedScriptContextVariables, match[1]) {
+		return errors.Wrap(errStoredScriptNotChecked, resp.Error.CausedBy.Reason)
+	}
+
+	return errors.Errorf("Script not compile: %s", resp.Error.CausedBy.Reason)
+}
+
+// NewStoredScriptCompiler return the compiler used by the StoredScript webhook
+// It connect on the target Elasticsearch cluster and skip the check if it's not reachable
+func NewStoredScriptCompiler(logger *logrus.Entry) elasticsearchapicrd.StoredScriptCompiler {
+	return func(ctx context.Context, c client.Client, o *elasticsearchapicrd.StoredScript) (err error) {
+		log := logger.WithFields(logrus.Fields{
+			"namespace": o.Namespace,
+			"name":      o.Name,
+		})
+
+		ctx, cancel := context.WithTimeout(ctx, storedScriptCompileTimeout)
+		defer cancel()
+
+		esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, c, log)
+		if err != nil || esClient == nil {
+			log.Debugf("Elasticsearch not reachable, skip script compilation: %v", err)
+			return nil
+		}
+
+		if err = storedScriptCompile(ctx, esClient, o.Spec.Source); err != nil {
+			if errors.Is(err, errStoredScriptNotChecked) {
+				log.Warnf("Skip script compilation: %s", err.Error())
+				return nil
+			}
+			return err
+		}
+
+		return nil
+	}
+}
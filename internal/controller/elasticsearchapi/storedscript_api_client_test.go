@@ -0,0 +1,203 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStoredScriptBuild(t *testing.T) {
+	var (
+		o              *elasticsearchapicrd.StoredScript
+		script         *storedScript
+		expectedScript *storedScript
+		err            error
+		client         *storedScriptApiClient
+	)
+
+	client = &storedScriptApiClient{}
+
+	// With minimal parameters
+	o = &elasticsearchapicrd.StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Source: "Math.log(_score * 2)",
+		},
+	}
+
+	expectedScript = &storedScript{
+		Lang:   "painless",
+		Source: "Math.log(_score * 2)",
+	}
+
+	script, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedScript, script)
+
+	// With all parameters
+	o = &elasticsearchapicrd.StoredScript{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.StoredScriptSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Lang:   elasticsearchapicrd.StoredScriptLangMustache,
+			Source: `{"query": {"match": {"message": "{{query_string}}"}}}`,
+			Options: map[string]string{
+				"content_type": "application/json",
+			},
+		},
+	}
+
+	expectedScript = &storedScript{
+		Lang:   "mustache",
+		Source: `{"query": {"match": {"message": "{{query_string}}"}}}`,
+		Options: map[string]string{
+			"content_type": "application/json",
+		},
+	}
+
+	script, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedScript, script)
+}
+
+func TestStoredScriptApi(t *testing.T) {
+	var (
+		method string
+		path   string
+		query  string
+		body   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		query = r.URL.RawQuery
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		switch r.URL.Path {
+		case "/_scripts/test":
+			if r.Method == http.MethodGet {
+				_, _ = w.Write([]byte(`{"_id":"test","found":true,"script":{"lang":"painless","source":"Math.log(_score * 2)"}}`))
+				return
+			}
+		case "/_scripts/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"_id":"missing","found":false}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	}))
+
+	// Get
+	script, err := storedScriptGet(mockES, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, &storedScript{Lang: "painless", Source: "Math.log(_score * 2)"}, script)
+
+	// Get when not exist
+	script, err = storedScriptGet(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, script)
+
+	// Update
+	err = storedScriptUpdate(mockES, "test", "", &storedScript{Lang: "painless", Source: "Math.log(_score * 2)"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_scripts/test", path)
+	assert.JSONEq(t, `{"script":{"lang":"painless","source":"Math.log(_score * 2)"}}`, body)
+
+	// Update with context
+	err = storedScriptUpdate(mockES, "test", "score", &storedScript{Lang: "painless", Source: "Math.log(_score * 2)"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/_scripts/test/score", path)
+
+	// Delete
+	err = storedScriptDelete(mockES, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, method)
+	assert.Equal(t, "/_scripts/test", path)
+
+	// Delete when not exist
+	err = storedScriptDelete(mockES, "missing")
+	assert.NoError(t, err)
+
+	assert.Empty(t, query)
+}
+
+func TestStoredScriptCompile(t *testing.T) {
+	var response string
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if response == "" {
+			_, _ = w.Write([]byte(`{"result":"1"}`))
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(response))
+	}))
+
+	// When script compile
+	err := storedScriptCompile(context.Background(), mockES, "1")
+	assert.NoError(t, err)
+
+	// When script not compile
+	response = `{"error":{"type":"script_exception","reason":"compile error","caused_by":{"type":"illegal_argument_exception","reason":"cannot resolve symbol [foo]"}},"status":400}`
+	err = storedScriptCompile(context.Background(), mockES, "foo")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, errStoredScriptNotChecked))
+	assert.Contains(t, err.Error(), "cannot resolve symbol [foo]")
+
+	// When script use variable from it's execution context
+	response = `{"error":{"type":"script_exception","reason":"compile error","caused_by":{"type":"illegal_argument_exception","reason":"cannot resolve symbol [ctx._source.count]"}},"status":400}`
+	err = storedScriptCompile(context.Background(), mockES, "ctx._source.count += 1")
+	assert.ErrorIs(t, err, errStoredScriptNotChecked)
+
+	response = `{"error":{"type":"script_exception","reason":"compile error","caused_by":{"type":"illegal_argument_exception","reason":"Variable [doc] is not defined."}},"status":400}`
+	err = storedScriptCompile(context.Background(), mockES, "doc['my_field'].value")
+	assert.ErrorIs(t, err, errStoredScriptNotChecked)
+
+	// When script fail at runtime
+	response = `{"error":{"type":"script_exception","reason":"runtime error","caused_by":{"type":"null_pointer_exception","reason":"cannot access method/field [my_modifier] from a null def reference"}},"status":400}`
+	err = storedScriptCompile(context.Background(), mockES, "params['my_modifier'].length()")
+	assert.ErrorIs(t, err, errStoredScriptNotChecked)
+}
+
+func TestGetStoredScriptContentHash(t *testing.T) {
+	hash, err := getStoredScriptContentHash(&storedScript{Lang: "painless", Source: "Math.log(_score * 2)"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, hash)
+
+	hash2, err := getStoredScriptContentHash(&storedScript{Lang: "painless", Source: "Math.log(_score * 3)"})
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, hash2)
+}
@@ -0,0 +1,219 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (t *ElasticsearchapiControllerTestSuite) TestStoredScriptReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-storedscript-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase[*elasticsearchapicrd.StoredScript](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchapicrd.StoredScript]{
+		doCreateStoredScriptStep(),
+		doUpdateStoredScriptStep(),
+		doDeleteStoredScriptStep(),
+	}
+	testCase.PreTest = doMockStoredScript(t.fakeElasticsearchMux)
+
+	testCase.Run()
+}
+
+func doMockStoredScript(mux *http.ServeMux) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		var current []byte
+
+		mux.HandleFunc("/_scripts/", func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodGet:
+				if current == nil {
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"found":false}`))
+					return
+				}
+				_, _ = fmt.Fprintf(w, `{"found":true,%s`, current[1:])
+				return
+			case http.MethodPut, http.MethodPost:
+				current, _ = io.ReadAll(r.Body)
+				switch *stepName {
+				case "create":
+					data["isCreated"] = true
+				case "update":
+					data["isUpdated"] = true
+				}
+			case http.MethodDelete:
+				current = nil
+				data["isDeleted"] = true
+			}
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		})
+
+		return nil
+	}
+}
+
+func doCreateStoredScriptStep() test.TestStep[*elasticsearchapicrd.StoredScript] {
+	return test.TestStep[*elasticsearchapicrd.StoredScript]{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.StoredScript, data map[string]any) (err error) {
+			logrus.Infof("=== Add new stored script %s/%s ===\n\n", key.Namespace, key.Name)
+
+			script := &elasticsearchapicrd.StoredScript{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchapicrd.StoredScriptSpec{
+					ElasticsearchRef: shared.ElasticsearchRef{
+						ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+							Name: "test",
+						},
+					},
+					Source: "Math.log(_score * 2)",
+				},
+			}
+			if err = c.Create(context.Background(), script); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.StoredScript, data map[string]any) (err error) {
+			script := &elasticsearchapicrd.StoredScript{}
+			isCreated := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, script); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated || script.GetStatus().GetObservedGeneration() == 0 {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Stored script: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(script.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *script.Status.IsSync)
+			assert.NotEmpty(t, script.Status.ContentHash)
+
+			return nil
+		},
+	}
+}
+
+func doUpdateStoredScriptStep() test.TestStep[*elasticsearchapicrd.StoredScript] {
+	return test.TestStep[*elasticsearchapicrd.StoredScript]{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.StoredScript, data map[string]any) (err error) {
+			logrus.Infof("=== Update stored script %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Stored script is null")
+			}
+
+			data["lastGeneration"] = o.GetStatus().GetObservedGeneration()
+			data["lastContentHash"] = o.Status.ContentHash
+			o.Spec.Source = "Math.log(_score * 3)"
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.StoredScript, data map[string]any) (err error) {
+			script := &elasticsearchapicrd.StoredScript{}
+			isUpdated := false
+
+			lastGeneration := data["lastGeneration"].(int64)
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, script); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated || lastGeneration == script.GetStatus().GetObservedGeneration() {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Stored script: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(script.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *script.Status.IsSync)
+			assert.NotEqual(t, data["lastContentHash"], script.Status.ContentHash)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteStoredScriptStep() test.TestStep[*elasticsearchapicrd.StoredScript] {
+	return test.TestStep[*elasticsearchapicrd.StoredScript]{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.StoredScript, data map[string]any) (err error) {
+			logrus.Infof("=== Delete stored script %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Stored script is null")
+			}
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), o, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.StoredScript, data map[string]any) (err error) {
+			script := &elasticsearchapicrd.StoredScript{}
+			isDeleted := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, script); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Stored script stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+			return nil
+		},
+	}
+}
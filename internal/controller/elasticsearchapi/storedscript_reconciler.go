@@ -0,0 +1,81 @@
+package elasticsearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/codingsince1985/checksum"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/sirupsen/logrus"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type storedScriptReconciler struct {
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler]
+	name string
+}
+
+func newStoredScriptReconciler(name string, client client.Client, recorder record.EventRecorder) remote.RemoteReconcilerAction[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler] {
+	return &storedScriptReconciler{
+		RemoteReconcilerAction: remote.NewRemoteReconcilerAction[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler](
+			client,
+			recorder,
+		),
+		name: name,
+	}
+}
+
+func (h *storedScriptReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.StoredScript, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+	esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, h.Client(), logger)
+	if err != nil && o.DeletionTimestamp.IsZero() {
+		return nil, res, err
+	}
+
+	// Elastic not ready
+	if esClient == nil {
+		if o.DeletionTimestamp.IsZero() {
+			return nil, reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+		}
+
+		return nil, res, nil
+	}
+
+	handler = newStoredScriptApiClient(esClient)
+
+	return handler, res, nil
+}
+
+func (h *storedScriptReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.StoredScript, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*storedScript], logger *logrus.Entry) (res reconcile.Result, err error) {
+	// Compute the content hash, it permit to apps to detect when script change
+	script, err := handler.Build(o)
+	if err != nil {
+		return res, errors.Wrap(err, "Error when build stored script")
+	}
+	o.Status.ContentHash, err = getStoredScriptContentHash(script)
+	if err != nil {
+		return res, err
+	}
+
+	return h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+}
+
+// getStoredScriptContentHash return the sha256 of the stored script
+func getStoredScriptContentHash(script *storedScript) (hash string, err error) {
+	j, err := json.Marshal(script)
+	if err != nil {
+		return "", errors.Wrap(err, "Error when convert stored script to JSON")
+	}
+	hash, err = checksum.SHA256sumReader(bytes.NewReader(j))
+	if err != nil {
+		return "", errors.Wrap(err, "Error when compute stored script hash")
+	}
+
+	return hash, nil
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"path/filepath"
 	"testing"
 	"time"
@@ -48,6 +49,7 @@ type ElasticsearchapiControllerTestSuite struct {
 	cfg                      *rest.Config
 	mockCtrl                 *gomock.Controller
 	mockElasticsearchHandler *mocks.MockElasticsearchHandler
+	fakeElasticsearchMux     *http.ServeMux
 }
 
 func TestElasticsearchapiControllerSuite(t *testing.T) {
@@ -58,6 +60,10 @@ func (t *ElasticsearchapiControllerTestSuite) SetupSuite() {
 	t.mockCtrl = gomock.NewController(t.T())
 	t.mockElasticsearchHandler = mocks.NewMockElasticsearchHandler(t.mockCtrl)
 
+	// Serve the Elasticsearch API not covered by the handler
+	t.fakeElasticsearchMux = http.NewServeMux()
+	t.mockElasticsearchHandler.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t.T(), t.fakeElasticsearchMux.ServeHTTP))
+
 	logf.SetLogger(zap.New(zap.UseDevMode(true)))
 	logrus.SetLevel(logrus.TraceLevel)
 	logrus.SetFormatter(&logrus.TextFormatter{
@@ -149,10 +155,16 @@ func (t *ElasticsearchapiControllerTestSuite) SetupSuite() {
 		elasticsearchapicrd.SetupIndexLifecyclePolicyIndexer,
 		elasticsearchapicrd.SetupIndexTemplateIndexer,
 		elasticsearchapicrd.SetupLicenceIndexer,
+		elasticsearchapicrd.SetupRemoteClusterIndexer,
+		elasticsearchapicrd.SetupFollowerIndexIndexer,
+		elasticsearchapicrd.SetupAutoFollowPatternIndexer,
+		elasticsearchapicrd.SetupSnapshotIndexer,
 		elasticsearchapicrd.SetupRoleIndexer,
 		elasticsearchapicrd.SetupRoleMappingIndexer,
 		elasticsearchapicrd.SetupSnapshotLifecyclePolicyIndexer,
 		elasticsearchapicrd.SetupSnapshotRepositoryIndexer,
+		elasticsearchapicrd.SetupTransformIndexer,
+		elasticsearchapicrd.SetupStoredScriptIndexer,
 		elasticsearchapicrd.SetupUserIndexexer,
 		elasticsearchapicrd.SetupWatchIndexer,
 		kibanaapicrd.SetupLogstashPipelineIndexer,
@@ -169,18 +181,27 @@ func (t *ElasticsearchapiControllerTestSuite) SetupSuite() {
 		beatcrd.SetupFilebeatWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		beatcrd.SetupMetricbeatWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		cerebrocrd.SetupHostWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchcrd.SetupElasticsearchWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		kibanacrd.SetupKibanaWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		logstashcrd.SetupLogstashWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupComponentTemplateWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupIndexLifecyclePolicyWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupIndexTemplateWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupLicenseWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchapicrd.SetupRemoteClusterWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchapicrd.SetupFollowerIndexWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchapicrd.SetupAutoFollowPatternWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchapicrd.SetupSnapshotWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchapicrd.SetupRestoreWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupRoleWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupRoleMappingWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupSnapshotLifecyclePolicyWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupSnapshotRepositoryWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchapicrd.SetupTransformWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchapicrd.SetupStoredScriptWebhookWithManager(logrus.NewEntry(logrus.StandardLogger()), nil),
 		elasticsearchapicrd.SetupUserWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		elasticsearchapicrd.SetupWatchWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
+		elasticsearchapicrd.SetupResourceSetWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		kibanaapicrd.SetupLogstashPipelineWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		kibanaapicrd.SetupRoleWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
 		kibanaapicrd.SetupUserSpaceWebhookWithManager(logrus.NewEntry(logrus.StandardLogger())),
@@ -240,9 +261,9 @@ func (t *ElasticsearchapiControllerTestSuite) SetupSuite() {
 		logrus.NewEntry(logrus.StandardLogger()),
 		k8sManager.GetEventRecorderFor("elasticsearch-rolemapping-controller"),
 	)
-	roleMappingReconciler.(*RoleMappingReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler](
+	roleMappingReconciler.(*RoleMappingReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler](
 		roleMappingReconciler.(*RoleMappingReconciler).RemoteReconcilerAction,
-		func(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.RoleMapping, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *olivere.XPackSecurityRoleMapping, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+		func(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.RoleMapping, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.RoleMapping, *roleMapping, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
 			return newRoleMappingApiClient(t.mockElasticsearchHandler), res, nil
 		},
 	)
@@ -295,6 +316,111 @@ func (t *ElasticsearchapiControllerTestSuite) SetupSuite() {
 		panic(err)
 	}
 
+	transformReconciler := NewTransformReconciler(
+		k8sClient,
+		logrus.NewEntry(logrus.StandardLogger()),
+		k8sManager.GetEventRecorderFor("elasticsearch-transform-controller"),
+	)
+	transformReconciler.(*TransformReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler](
+		transformReconciler.(*TransformReconciler).RemoteReconcilerAction,
+		func(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.Transform, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+			return newTransformApiClient(t.mockElasticsearchHandler), res, nil
+		},
+	)
+	if err = transformReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	storedScriptReconciler := NewStoredScriptReconciler(
+		k8sClient,
+		logrus.NewEntry(logrus.StandardLogger()),
+		k8sManager.GetEventRecorderFor("elasticsearch-storedscript-controller"),
+	)
+	storedScriptReconciler.(*StoredScriptReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler](
+		storedScriptReconciler.(*StoredScriptReconciler).RemoteReconcilerAction,
+		func(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.StoredScript, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.StoredScript, *storedScript, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+			return newStoredScriptApiClient(t.mockElasticsearchHandler), res, nil
+		},
+	)
+	if err = storedScriptReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	remoteClusterReconciler := NewRemoteClusterReconciler(
+		k8sClient,
+		logrus.NewEntry(logrus.StandardLogger()),
+		k8sManager.GetEventRecorderFor("elasticsearch-remotecluster-controller"),
+	)
+	remoteClusterReconciler.(*RemoteClusterReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler](
+		remoteClusterReconciler.(*RemoteClusterReconciler).RemoteReconcilerAction,
+		func(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.RemoteCluster, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.RemoteCluster, *remoteClusterSettings, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+			return newRemoteClusterApiClient(t.mockElasticsearchHandler), res, nil
+		},
+	)
+	if err = remoteClusterReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	followerIndexReconciler := NewFollowerIndexReconciler(
+		k8sClient,
+		logrus.NewEntry(logrus.StandardLogger()),
+		k8sManager.GetEventRecorderFor("elasticsearch-followerindex-controller"),
+	)
+	followerIndexReconciler.(*FollowerIndexReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler](
+		followerIndexReconciler.(*FollowerIndexReconciler).RemoteReconcilerAction,
+		func(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.FollowerIndex, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.FollowerIndex, *followerIndex, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+			return newFollowerIndexApiClient(t.mockElasticsearchHandler), res, nil
+		},
+	)
+	if err = followerIndexReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	autoFollowPatternReconciler := NewAutoFollowPatternReconciler(
+		k8sClient,
+		logrus.NewEntry(logrus.StandardLogger()),
+		k8sManager.GetEventRecorderFor("elasticsearch-autofollowpattern-controller"),
+	)
+	autoFollowPatternReconciler.(*AutoFollowPatternReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler](
+		autoFollowPatternReconciler.(*AutoFollowPatternReconciler).RemoteReconcilerAction,
+		func(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.AutoFollowPattern, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.AutoFollowPattern, *autoFollowPattern, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+			return newAutoFollowPatternApiClient(t.mockElasticsearchHandler), res, nil
+		},
+	)
+	if err = autoFollowPatternReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	snapshotReconciler := NewSnapshotReconciler(
+		k8sClient,
+		logrus.NewEntry(logrus.StandardLogger()),
+		k8sManager.GetEventRecorderFor("elasticsearch-snapshot-controller"),
+	)
+	snapshotReconciler.(*SnapshotReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler](
+		snapshotReconciler.(*SnapshotReconciler).RemoteReconcilerAction,
+		func(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.Snapshot, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Snapshot, *snapshot, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+			return newSnapshotApiClient(t.mockElasticsearchHandler), res, nil
+		},
+	)
+	if err = snapshotReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
+	restoreReconciler := NewRestoreReconciler(
+		k8sClient,
+		logrus.NewEntry(logrus.StandardLogger()),
+		k8sManager.GetEventRecorderFor("elasticsearch-restore-controller"),
+	)
+	restoreReconciler.(*RestoreReconciler).RemoteReconcilerAction = mock.NewMockRemoteReconcilerAction[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler](
+		restoreReconciler.(*RestoreReconciler).RemoteReconcilerAction,
+		func(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.Restore, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Restore, *restore, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+			return newRestoreApiClient(t.mockElasticsearchHandler), res, nil
+		},
+	)
+	if err = restoreReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
 	componentTemplateReconciler := NewComponentTemplateReconciler(
 		k8sClient,
 		logrus.NewEntry(logrus.StandardLogger()),
@@ -340,6 +466,15 @@ func (t *ElasticsearchapiControllerTestSuite) SetupSuite() {
 		panic(err)
 	}
 
+	resourceSetReconciler := NewResourceSetReconciler(
+		k8sClient,
+		logrus.NewEntry(logrus.StandardLogger()),
+		k8sManager.GetEventRecorderFor("elasticsearch-resourceset-controller"),
+	)
+	if err = resourceSetReconciler.SetupWithManager(k8sManager); err != nil {
+		panic(err)
+	}
+
 	go func() {
 		err = k8sManager.Start(ctrl.SetupSignalHandler())
 		if err != nil {
@@ -0,0 +1,307 @@
+package elasticsearchapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+)
+
+type transformApiClient struct {
+	remote.RemoteExternalReconciler[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler]
+}
+
+func newTransformApiClient(client eshandler.ElasticsearchHandler) remote.RemoteExternalReconciler[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler] {
+	return &transformApiClient{
+		RemoteExternalReconciler: remote.NewRemoteExternalReconciler[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler](client),
+	}
+}
+
+func (h *transformApiClient) Build(o *elasticsearchapicrd.Transform) (transform *eshandler.Transform, err error) {
+	transform = &eshandler.Transform{
+		Description: o.Spec.Description,
+		Frequency:   o.Spec.Frequency,
+		Source: &eshandler.TransformSource{
+			Index: o.Spec.Source.Indices,
+		},
+		Destination: &eshandler.TransformDest{
+			Index:    o.Spec.Dest.Index,
+			Pipeline: o.Spec.Dest.Pipeline,
+		},
+	}
+
+	if o.Spec.Source.Query != nil {
+		transform.Source.Query = o.Spec.Source.Query.Data
+	}
+
+	if o.Spec.Source.RuntimeMappings != nil {
+		transform.Source.RuntimeMappings = o.Spec.Source.RuntimeMappings.Data
+	}
+
+	if o.Spec.Pivot != nil {
+		transform.Pivot = &eshandler.TransformPivot{}
+		if o.Spec.Pivot.GroupBy != nil {
+			transform.Pivot.GroupBy = o.Spec.Pivot.GroupBy.Data
+		}
+		if o.Spec.Pivot.Aggregations != nil {
+			transform.Pivot.Aggregations = o.Spec.Pivot.Aggregations.Data
+		}
+	}
+
+	if o.Spec.Latest != nil {
+		transform.Lastest = &eshandler.TransformLatest{
+			Sort:      o.Spec.Latest.Sort,
+			UniqueKey: o.Spec.Latest.UniqueKey,
+		}
+	}
+
+	if o.Spec.Sync != nil {
+		transform.Sync = &eshandler.TransformSync{
+			Time: eshandler.TransformSyncTime{
+				Field: o.Spec.Sync.Field,
+				Delay: o.Spec.Sync.Delay,
+			},
+		}
+	}
+
+	if o.Spec.RetentionPolicy != nil {
+		transform.Retention = &eshandler.TransformRetention{
+			Time: eshandler.TransformRetentionTime{
+				Field:  o.Spec.RetentionPolicy.Field,
+				MaxAge: o.Spec.RetentionPolicy.MaxAge,
+			},
+		}
+	}
+
+	if o.Spec.Settings != nil {
+		transform.Settings = o.Spec.Settings.Data
+	}
+
+	if o.Spec.Metadata != nil {
+		transform.Metadata = o.Spec.Metadata.Data
+	}
+
+	return transform, nil
+}
+
+func (h *transformApiClient) Get(o *elasticsearchapicrd.Transform) (object *eshandler.Transform, err error) {
+	return h.Client().TransformGet(o.GetExternalName())
+}
+
+func (h *transformApiClient) Create(object *eshandler.Transform, o *elasticsearchapicrd.Transform) (err error) {
+	return h.Client().TransformUpdate(o.GetExternalName(), object)
+}
+
+// Update use the transform update API
+// Pivot and latest can't be updated, the reconciler recreate the transform when they change
+func (h *transformApiClient) Update(object *eshandler.Transform, o *elasticsearchapicrd.Transform) (err error) {
+	return transformUpdate(h.Client(), o.GetExternalName(), object)
+}
+
+func (h *transformApiClient) Delete(o *elasticsearchapicrd.Transform) (err error) {
+	return h.Client().TransformDelete(o.GetExternalName())
+}
+
+func (h *transformApiClient) Diff(currentOject *eshandler.Transform, expectedObject *eshandler.Transform, originalObject *eshandler.Transform, o *elasticsearchapicrd.Transform, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
+	return h.Client().TransformDiff(currentOject, expectedObject, originalObject)
+}
+
+// transformUpdateRequest is the body accepted by the transform update API
+type transformUpdateRequest struct {
+	Description string                        `json:"description,omitempty"`
+	Destination *eshandler.TransformDest      `json:"dest,omitempty"`
+	Frequency   string                        `json:"frequency,omitempty"`
+	Metadata    map[string]any                `json:"_meta,omitempty"`
+	Retention   *eshandler.TransformRetention `json:"retention_policy,omitempty"`
+	Settings    map[string]any                `json:"settings,omitempty"`
+	Source      *eshandler.TransformSource    `json:"source,omitempty"`
+	Sync        *eshandler.TransformSync      `json:"sync,omitempty"`
+}
+
+// transformStatsResponse is the response of the transform stats API
+type transformStatsResponse struct {
+	Transforms []transformStats `json:"transforms"`
+}
+
+type transformStats struct {
+	Id     string `json:"id"`
+	State  string `json:"state"`
+	Health struct {
+		Status string `json:"status"`
+	} `json:"health"`
+	Stats struct {
+		DocumentsProcessed int64 `json:"documents_processed"`
+		DocumentsIndexed   int64 `json:"documents_indexed"`
+	} `json:"stats"`
+	Checkpointing struct {
+		Last struct {
+			Checkpoint int64 `json:"checkpoint"`
+		} `json:"last"`
+	} `json:"checkpointing"`
+}
+
+// isTransformRecreateNeeded return true if the change can't be applied with the transform update API
+func isTransformRecreateNeeded(currentObject *eshandler.Transform, expectedObject *eshandler.Transform) (bool, error) {
+	if currentObject == nil || expectedObject == nil {
+		return false, nil
+	}
+
+	for _, objects := range [][2]any{
+		{currentObject.Pivot, expectedObject.Pivot},
+		{currentObject.Lastest, expectedObject.Lastest},
+	} {
+		current, err := json.Marshal(objects[0])
+		if err != nil {
+			return false, errors.Wrap(err, "Error when convert current transform")
+		}
+		expected, err := json.Marshal(objects[1])
+		if err != nil {
+			return false, errors.Wrap(err, "Error when convert expected transform")
+		}
+		if !bytes.Equal(current, expected) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// transformUpdate permit to update the mutable fields of existing transform
+func transformUpdate(client eshandler.ElasticsearchHandler, name string, transform *eshandler.Transform) (err error) {
+	data, err := json.Marshal(&transformUpdateRequest{
+		Description: transform.Description,
+		Destination: transform.Destination,
+		Frequency:   transform.Frequency,
+		Metadata:    transform.Metadata,
+		Retention:   transform.Retention,
+		Settings:    transform.Settings,
+		Source:      transform.Source,
+		Sync:        transform.Sync,
+	})
+	if err != nil {
+		return err
+	}
+
+	api := client.Client().API
+	res, err := api.TransformUpdateTransform(
+		bytes.NewReader(data),
+		name,
+		api.TransformUpdateTransform.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when update transform %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// transformStart permit to start transform
+func transformStart(client eshandler.ElasticsearchHandler, name string) (err error) {
+	api := client.Client().API
+	res, err := api.TransformStartTransform(
+		name,
+		api.TransformStartTransform.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when start transform %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// transformStop permit to stop transform and wait it's stopped
+func transformStop(client eshandler.ElasticsearchHandler, name string, force bool) (err error) {
+	api := client.Client().API
+	res, err := api.TransformStopTransform(
+		name,
+		api.TransformStopTransform.WithContext(context.Background()),
+		api.TransformStopTransform.WithForce(force),
+		api.TransformStopTransform.WithWaitForCompletion(true),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil
+		}
+		return errors.Errorf("Error when stop transform %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// transformReset permit to reset transform
+// It delete the destination index and the checkpoints
+func transformReset(client eshandler.ElasticsearchHandler, name string) (err error) {
+	api := client.Client().API
+	res, err := api.TransformResetTransform(
+		name,
+		api.TransformResetTransform.WithContext(context.Background()),
+		api.TransformResetTransform.WithForce(true),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when reset transform %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// transformGetStats permit to get the transform stats
+// It return nil if transform not exist
+func transformGetStats(client eshandler.ElasticsearchHandler, name string) (stats *transformStats, err error) {
+	api := client.Client().API
+	res, err := api.TransformGetTransformStats(
+		name,
+		api.TransformGetTransformStats.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		if res.StatusCode == 404 {
+			return nil, nil
+		}
+		return nil, errors.Errorf("Error when get stats of transform %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &transformStatsResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode stats of transform %s", name)
+	}
+
+	if len(resp.Transforms) == 0 {
+		return nil, nil
+	}
+
+	return &resp.Transforms[0], nil
+}
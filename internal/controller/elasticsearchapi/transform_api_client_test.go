@@ -0,0 +1,357 @@
+package elasticsearchapi
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTransformBuild(t *testing.T) {
+	var (
+		o                 *elasticsearchapicrd.Transform
+		transform         *eshandler.Transform
+		expectedTransform *eshandler.Transform
+		err               error
+		client            *transformApiClient
+	)
+
+	client = &transformApiClient{}
+
+	// With minimal parameters
+	o = &elasticsearchapicrd.Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Source: elasticsearchapicrd.TransformSource{
+				Indices: []string{"source"},
+			},
+			Dest: elasticsearchapicrd.TransformDest{
+				Index: "dest",
+			},
+			Latest: &elasticsearchapicrd.TransformLatest{
+				Sort:      "@timestamp",
+				UniqueKey: []string{"id"},
+			},
+		},
+	}
+
+	expectedTransform = &eshandler.Transform{
+		Source: &eshandler.TransformSource{
+			Index: []string{"source"},
+		},
+		Destination: &eshandler.TransformDest{
+			Index: "dest",
+		},
+		Lastest: &eshandler.TransformLatest{
+			Sort:      "@timestamp",
+			UniqueKey: []string{"id"},
+		},
+	}
+
+	transform, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTransform, transform)
+
+	// With all parameters
+	o = &elasticsearchapicrd.Transform{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+		Spec: elasticsearchapicrd.TransformSpec{
+			ElasticsearchRef: shared.ElasticsearchRef{
+				ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+					Name: "test",
+				},
+			},
+			Description: "my transform",
+			Frequency:   "5m",
+			Source: elasticsearchapicrd.TransformSource{
+				Indices: []string{"source"},
+				Query: &apis.MapAny{
+					Data: map[string]any{
+						"match_all": map[string]any{},
+					},
+				},
+				RuntimeMappings: &apis.MapAny{
+					Data: map[string]any{
+						"day_of_week": map[string]any{
+							"type": "keyword",
+						},
+					},
+				},
+			},
+			Dest: elasticsearchapicrd.TransformDest{
+				Index:    "dest",
+				Pipeline: "pipeline",
+			},
+			Pivot: &elasticsearchapicrd.TransformPivot{
+				GroupBy: &apis.MapAny{
+					Data: map[string]any{
+						"customer_id": map[string]any{
+							"terms": map[string]any{
+								"field": "customer_id",
+							},
+						},
+					},
+				},
+				Aggregations: &apis.MapAny{
+					Data: map[string]any{
+						"max_price": map[string]any{
+							"max": map[string]any{
+								"field": "price",
+							},
+						},
+					},
+				},
+			},
+			Sync: &elasticsearchapicrd.TransformSync{
+				Field: "@timestamp",
+				Delay: "60s",
+			},
+			RetentionPolicy: &elasticsearchapicrd.TransformRetentionPolicy{
+				Field:  "@timestamp",
+				MaxAge: "30d",
+			},
+			Settings: &apis.MapAny{
+				Data: map[string]any{
+					"max_page_search_size": 500,
+				},
+			},
+			Metadata: &apis.MapAny{
+				Data: map[string]any{
+					"team": "test",
+				},
+			},
+		},
+	}
+
+	expectedTransform = &eshandler.Transform{
+		Description: "my transform",
+		Frequency:   "5m",
+		Source: &eshandler.TransformSource{
+			Index: []string{"source"},
+			Query: map[string]any{
+				"match_all": map[string]any{},
+			},
+			RuntimeMappings: map[string]any{
+				"day_of_week": map[string]any{
+					"type": "keyword",
+				},
+			},
+		},
+		Destination: &eshandler.TransformDest{
+			Index:    "dest",
+			Pipeline: "pipeline",
+		},
+		Pivot: &eshandler.TransformPivot{
+			GroupBy: map[string]any{
+				"customer_id": map[string]any{
+					"terms": map[string]any{
+						"field": "customer_id",
+					},
+				},
+			},
+			Aggregations: map[string]any{
+				"max_price": map[string]any{
+					"max": map[string]any{
+						"field": "price",
+					},
+				},
+			},
+		},
+		Sync: &eshandler.TransformSync{
+			Time: eshandler.TransformSyncTime{
+				Field: "@timestamp",
+				Delay: "60s",
+			},
+		},
+		Retention: &eshandler.TransformRetention{
+			Time: eshandler.TransformRetentionTime{
+				Field:  "@timestamp",
+				MaxAge: "30d",
+			},
+		},
+		Settings: map[string]any{
+			"max_page_search_size": 500,
+		},
+		Metadata: map[string]any{
+			"team": "test",
+		},
+	}
+
+	transform, err = client.Build(o)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedTransform, transform)
+}
+
+func TestIsTransformRecreateNeeded(t *testing.T) {
+	current := &eshandler.Transform{
+		Description: "test",
+		Pivot: &eshandler.TransformPivot{
+			GroupBy: map[string]any{
+				"customer_id": map[string]any{
+					"terms": map[string]any{
+						"field": "customer_id",
+					},
+				},
+			},
+			Aggregations: map[string]any{
+				"max_price": map[string]any{
+					"max": map[string]any{
+						"field": "price",
+					},
+				},
+			},
+		},
+	}
+
+	// When transform not exist
+	isRecreate, err := isTransformRecreateNeeded(nil, current)
+	assert.NoError(t, err)
+	assert.False(t, isRecreate)
+
+	// When only mutable fields change
+	expected := &eshandler.Transform{
+		Description: "test2",
+		Pivot: &eshandler.TransformPivot{
+			GroupBy: map[string]any{
+				"customer_id": map[string]any{
+					"terms": map[string]any{
+						"field": "customer_id",
+					},
+				},
+			},
+			Aggregations: map[string]any{
+				"max_price": map[string]any{
+					"max": map[string]any{
+						"field": "price",
+					},
+				},
+			},
+		},
+	}
+	isRecreate, err = isTransformRecreateNeeded(current, expected)
+	assert.NoError(t, err)
+	assert.False(t, isRecreate)
+
+	// When pivot change
+	expected.Pivot.Aggregations = map[string]any{
+		"min_price": map[string]any{
+			"min": map[string]any{
+				"field": "price",
+			},
+		},
+	}
+	isRecreate, err = isTransformRecreateNeeded(current, expected)
+	assert.NoError(t, err)
+	assert.True(t, isRecreate)
+
+	// When switch from pivot to latest
+	expected.Pivot = nil
+	expected.Lastest = &eshandler.TransformLatest{
+		Sort:      "@timestamp",
+		UniqueKey: []string{"customer_id"},
+	}
+	isRecreate, err = isTransformRecreateNeeded(current, expected)
+	assert.NoError(t, err)
+	assert.True(t, isRecreate)
+}
+
+func TestTransformLifecycle(t *testing.T) {
+	var (
+		method string
+		path   string
+		query  string
+		body   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		query = r.URL.RawQuery
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		switch r.URL.Path {
+		case "/_transform/test/_stats":
+			_, _ = w.Write([]byte(`{"count":1,"transforms":[{"id":"test","state":"indexing","health":{"status":"green"},"stats":{"documents_processed":10,"documents_indexed":2},"checkpointing":{"last":{"checkpoint":3}}}]}`))
+		case "/_transform/missing/_stats", "/_transform/missing/_stop":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		}
+	}))
+
+	// Start
+	err := transformStart(mockES, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/_transform/test/_start", path)
+
+	// Stop
+	err = transformStop(mockES, "test", true)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/_transform/test/_stop", path)
+	assert.Contains(t, query, "force=true")
+	assert.Contains(t, query, "wait_for_completion=true")
+
+	// Stop when not exist
+	err = transformStop(mockES, "missing", true)
+	assert.NoError(t, err)
+
+	// Reset
+	err = transformReset(mockES, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/_transform/test/_reset", path)
+
+	// Update
+	err = transformUpdate(mockES, "test", &eshandler.Transform{
+		Description: "test",
+		Pivot: &eshandler.TransformPivot{
+			GroupBy: map[string]any{
+				"customer_id": map[string]any{},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "/_transform/test/_update", path)
+	assert.JSONEq(t, `{"description":"test"}`, body)
+
+	// Stats
+	stats, err := transformGetStats(mockES, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "indexing", stats.State)
+	assert.Equal(t, "green", stats.Health.Status)
+	assert.Equal(t, int64(10), stats.Stats.DocumentsProcessed)
+	assert.Equal(t, int64(2), stats.Stats.DocumentsIndexed)
+	assert.Equal(t, int64(3), stats.Checkpointing.Last.Checkpoint)
+
+	// Stats when not exist
+	stats, err = transformGetStats(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, stats)
+}
@@ -0,0 +1,369 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/es-handler/v8/mocks"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/helper"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/test"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	condition "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func (t *ElasticsearchapiControllerTestSuite) TestTransformReconciler() {
+	key := types.NamespacedName{
+		Name:      "t-transform-" + helper.RandomString(10),
+		Namespace: "default",
+	}
+	data := map[string]any{}
+
+	testCase := test.NewTestCase[*elasticsearchapicrd.Transform](t.T(), t.k8sClient, key, 5*time.Second, data)
+	testCase.Steps = []test.TestStep[*elasticsearchapicrd.Transform]{
+		doCreateTransformStep(),
+		doUpdateTransformStep(),
+		doStopTransformStep(),
+		doDeleteTransformStep(),
+	}
+	testCase.PreTest = doMockTransform(t.fakeElasticsearchMux, t.mockElasticsearchHandler)
+
+	testCase.Run()
+}
+
+func doMockTransform(mux *http.ServeMux, mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+	return func(stepName *string, data map[string]any) (err error) {
+		isCreated := false
+		isUpdated := false
+		state := "stopped"
+
+		mux.HandleFunc("/_transform/", func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/_start"):
+				state = "started"
+			case strings.HasSuffix(r.URL.Path, "/_stop"):
+				state = "stopped"
+				if *stepName == "stop" {
+					data["isStopped"] = true
+				}
+			case strings.HasSuffix(r.URL.Path, "/_update"):
+				isUpdated = true
+				data["isUpdated"] = true
+			case strings.HasSuffix(r.URL.Path, "/_stats"):
+				_, _ = fmt.Fprintf(w, `{"count":1,"transforms":[{"id":"test","state":"%s","health":{"status":"green"},"stats":{"documents_processed":10,"documents_indexed":2},"checkpointing":{"last":{"checkpoint":1}}}]}`, state)
+				return
+			}
+			_, _ = w.Write([]byte(`{"acknowledged":true}`))
+		})
+
+		mockES.EXPECT().TransformGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*eshandler.Transform, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return nil, nil
+				} else {
+					resp := &eshandler.Transform{
+						Description: "test",
+						Source: &eshandler.TransformSource{
+							Index: []string{"source"},
+						},
+						Destination: &eshandler.TransformDest{
+							Index: "dest",
+						},
+						Lastest: &eshandler.TransformLatest{
+							Sort:      "@timestamp",
+							UniqueKey: []string{"id"},
+						},
+					}
+					return resp, nil
+				}
+			case "update", "stop":
+				description := "test"
+				if isUpdated {
+					description = "test2"
+				}
+				resp := &eshandler.Transform{
+					Description: description,
+					Source: &eshandler.TransformSource{
+						Index: []string{"source"},
+					},
+					Destination: &eshandler.TransformDest{
+						Index: "dest",
+					},
+					Lastest: &eshandler.TransformLatest{
+						Sort:      "@timestamp",
+						UniqueKey: []string{"id"},
+					},
+				}
+				return resp, nil
+			}
+
+			return nil, nil
+		})
+
+		mockES.EXPECT().TransformDiff(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(actual, expected, original *eshandler.Transform) (*patch.PatchResult, error) {
+			switch *stepName {
+			case "create":
+				if !isCreated {
+					return &patch.PatchResult{
+						Patch: []byte("fake change"),
+					}, nil
+				} else {
+					return &patch.PatchResult{}, nil
+				}
+			case "update":
+				if !isUpdated {
+					return &patch.PatchResult{
+						Patch: []byte("fake change"),
+					}, nil
+				} else {
+					return &patch.PatchResult{}, nil
+				}
+			}
+
+			return &patch.PatchResult{}, nil
+		})
+
+		mockES.EXPECT().TransformUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, transform *eshandler.Transform) error {
+			switch *stepName {
+			case "create":
+				isCreated = true
+				data["isCreated"] = true
+				return nil
+			}
+
+			return nil
+		})
+
+		mockES.EXPECT().TransformDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
+			data["isDeleted"] = true
+			return nil
+		})
+
+		return nil
+	}
+}
+
+func doCreateTransformStep() test.TestStep[*elasticsearchapicrd.Transform] {
+	return test.TestStep[*elasticsearchapicrd.Transform]{
+		Name: "create",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Transform, data map[string]any) (err error) {
+			logrus.Infof("=== Add new transform %s/%s ===\n\n", key.Namespace, key.Name)
+
+			transform := &elasticsearchapicrd.Transform{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+				},
+				Spec: elasticsearchapicrd.TransformSpec{
+					ElasticsearchRef: shared.ElasticsearchRef{
+						ManagedElasticsearchRef: &shared.ElasticsearchManagedRef{
+							Name: "test",
+						},
+					},
+					Description: "test",
+					Source: elasticsearchapicrd.TransformSource{
+						Indices: []string{"source"},
+					},
+					Dest: elasticsearchapicrd.TransformDest{
+						Index: "dest",
+					},
+					Latest: &elasticsearchapicrd.TransformLatest{
+						Sort:      "@timestamp",
+						UniqueKey: []string{"id"},
+					},
+					Settings: &apis.MapAny{
+						Data: map[string]any{
+							"max_page_search_size": 500,
+						},
+					},
+				},
+			}
+			if err = c.Create(context.Background(), transform); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Transform, data map[string]any) (err error) {
+			transform := &elasticsearchapicrd.Transform{}
+			isCreated := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, transform); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isCreated"]; ok {
+					isCreated = b.(bool)
+				}
+				if !isCreated || transform.GetStatus().GetObservedGeneration() == 0 {
+					return errors.New("Not yet created")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Transform: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(transform.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *transform.Status.IsSync)
+			assert.Equal(t, "started", transform.Status.State)
+			assert.Equal(t, "green", transform.Status.Health)
+			assert.Equal(t, int64(10), transform.Status.DocumentsProcessed)
+			assert.Equal(t, int64(2), transform.Status.DocumentsIndexed)
+			assert.Equal(t, int64(1), transform.Status.Checkpoint)
+
+			return nil
+		},
+	}
+}
+
+func doUpdateTransformStep() test.TestStep[*elasticsearchapicrd.Transform] {
+	return test.TestStep[*elasticsearchapicrd.Transform]{
+		Name: "update",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Transform, data map[string]any) (err error) {
+			logrus.Infof("=== Update transform %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Transform is null")
+			}
+
+			data["lastGeneration"] = o.GetStatus().GetObservedGeneration()
+			o.Spec.Description = "test2"
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Transform, data map[string]any) (err error) {
+			transform := &elasticsearchapicrd.Transform{}
+			isUpdated := false
+
+			lastGeneration := data["lastGeneration"].(int64)
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, transform); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isUpdated"]; ok {
+					isUpdated = b.(bool)
+				}
+				if !isUpdated || lastGeneration == transform.GetStatus().GetObservedGeneration() {
+					return errors.New("Not yet updated")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Transform: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(transform.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.True(t, *transform.Status.IsSync)
+
+			return nil
+		},
+	}
+}
+
+func doStopTransformStep() test.TestStep[*elasticsearchapicrd.Transform] {
+	return test.TestStep[*elasticsearchapicrd.Transform]{
+		Name: "stop",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Transform, data map[string]any) (err error) {
+			logrus.Infof("=== Stop transform %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Transform is null")
+			}
+
+			data["lastGeneration"] = o.GetStatus().GetObservedGeneration()
+			o.Spec.State = elasticsearchapicrd.TransformStateStopped
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Transform, data map[string]any) (err error) {
+			transform := &elasticsearchapicrd.Transform{}
+			isStopped := false
+
+			lastGeneration := data["lastGeneration"].(int64)
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, transform); err != nil {
+					t.Fatal(err)
+				}
+				if b, ok := data["isStopped"]; ok {
+					isStopped = b.(bool)
+				}
+				if !isStopped || lastGeneration == transform.GetStatus().GetObservedGeneration() {
+					return errors.New("Not yet stopped")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Transform: %s", err.Error())
+			}
+			assert.True(t, condition.IsStatusConditionPresentAndEqual(transform.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
+			assert.Equal(t, "stopped", transform.Status.State)
+
+			return nil
+		},
+	}
+}
+
+func doDeleteTransformStep() test.TestStep[*elasticsearchapicrd.Transform] {
+	return test.TestStep[*elasticsearchapicrd.Transform]{
+		Name: "delete",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Transform, data map[string]any) (err error) {
+			logrus.Infof("=== Delete transform %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Transform is null")
+			}
+
+			wait := int64(0)
+			if err = c.Delete(context.Background(), o, &client.DeleteOptions{GracePeriodSeconds: &wait}); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Transform, data map[string]any) (err error) {
+			transform := &elasticsearchapicrd.Transform{}
+			isDeleted := false
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err = c.Get(context.Background(), key, transform); err != nil {
+					if k8serrors.IsNotFound(err) {
+						isDeleted = true
+						return nil
+					}
+					t.Fatal(err)
+				}
+
+				return errors.New("Not yet deleted")
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Transform stil exist: %s", err.Error())
+			}
+			assert.True(t, isDeleted)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package elasticsearchapi
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	eshandler "github.com/disaster37/es-handler/v8"
+	"github.com/disaster37/generic-objectmatcher/patch"
+	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
+	"github.com/sirupsen/logrus"
+	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	transformStateStarted  = "started"
+	transformStateIndexing = "indexing"
+	transformStateStopped  = "stopped"
+
+	transformStatsRefreshInterval = 5 * time.Minute
+)
+
+type transformReconciler struct {
+	remote.RemoteReconcilerAction[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler]
+	name string
+}
+
+func newTransformReconciler(name string, client client.Client, recorder record.EventRecorder) remote.RemoteReconcilerAction[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler] {
+	return &transformReconciler{
+		RemoteReconcilerAction: remote.NewRemoteReconcilerAction[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler](
+			client,
+			recorder,
+		),
+		name: name,
+	}
+}
+
+func (h *transformReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Request, o *elasticsearchapicrd.Transform, logger *logrus.Entry) (handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler], res reconcile.Result, err error) {
+	esClient, err := GetElasticsearchHandler(ctx, o, o.Spec.ElasticsearchRef, h.Client(), logger)
+	if err != nil && o.DeletionTimestamp.IsZero() {
+		return nil, res, err
+	}
+
+	// Elastic not ready
+	if esClient == nil {
+		if o.DeletionTimestamp.IsZero() {
+			return nil, reconcile.Result{RequeueAfter: 60 * time.Second}, nil
+		}
+
+		return nil, res, nil
+	}
+
+	handler = newTransformApiClient(esClient)
+
+	return handler, res, nil
+}
+
+func (h *transformReconciler) Diff(ctx context.Context, o *elasticsearchapicrd.Transform, read remote.RemoteRead[*eshandler.Transform], data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler], logger *logrus.Entry, ignoreDiff ...patch.CalculateOption) (diff remote.RemoteDiff[*eshandler.Transform], res reconcile.Result, err error) {
+	// Pivot and latest can't be updated, so we need to recreate the transform
+	isRecreate, err := isTransformRecreateNeeded(read.GetCurrentObject(), read.GetExpectedObject())
+	if err != nil {
+		return diff, res, errors.Wrap(err, "Error when check if transform need to be recreated")
+	}
+	data["isRecreate"] = isRecreate
+
+	return h.RemoteReconcilerAction.Diff(ctx, o, read, data, handler, logger, ignoreDiff...)
+}
+
+func (h *transformReconciler) Update(ctx context.Context, o *elasticsearchapicrd.Transform, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler], object *eshandler.Transform, logger *logrus.Entry) (res reconcile.Result, err error) {
+	if isRecreate, ok := data["isRecreate"].(bool); !ok || !isRecreate {
+		return h.RemoteReconcilerAction.Update(ctx, o, data, handler, object, logger)
+	}
+
+	logger.Infof("Immutable fields of transform %s has changed, recreate it", o.GetExternalName())
+
+	if err = transformStop(handler.Client(), o.GetExternalName(), true); err != nil {
+		return res, errors.Wrapf(err, "Error when stop transform %s before recreate it", o.GetExternalName())
+	}
+	if err = handler.Delete(o); err != nil {
+		return res, errors.Wrapf(err, "Error when delete transform %s before recreate it", o.GetExternalName())
+	}
+	h.Recorder().Eventf(o, corev1.EventTypeNormal, "RecreateTransform", "Transform %s deleted because of immutable fields change", o.GetExternalName())
+
+	return h.RemoteReconcilerAction.Create(ctx, o, data, handler, object, logger)
+}
+
+func (h *transformReconciler) Delete(ctx context.Context, o *elasticsearchapicrd.Transform, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler], logger *logrus.Entry) (err error) {
+	// Transform must be stopped before delete it
+	if err = transformStop(handler.Client(), o.GetExternalName(), true); err != nil {
+		return errors.Wrapf(err, "Error when stop transform %s before delete it", o.GetExternalName())
+	}
+
+	return h.RemoteReconcilerAction.Delete(ctx, o, data, handler, logger)
+}
+
+func (h *transformReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.Transform, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Transform, *eshandler.Transform, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*eshandler.Transform], logger *logrus.Entry) (res reconcile.Result, err error) {
+	stats, err := transformGetStats(handler.Client(), o.GetExternalName())
+	if err != nil {
+		return res, errors.Wrap(err, "Error when get transform stats")
+	}
+	if stats == nil {
+		return res, errors.Errorf("Transform %s not found", o.GetExternalName())
+	}
+
+	// Manage the transform lifecycle
+	isStateChanged := false
+	switch o.GetExpectedState() {
+	case elasticsearchapicrd.TransformStateStarted:
+		if stats.State == transformStateStopped {
+			if err = transformStart(handler.Client(), o.GetExternalName()); err != nil {
+				return res, errors.Wrap(err, "Error when start transform")
+			}
+			isStateChanged = true
+			logger.Infof("Transform %s successfully started", o.GetExternalName())
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "StartCompleted", "Transform %s successfully started", o.GetExternalName())
+		}
+	case elasticsearchapicrd.TransformStateStopped:
+		if stats.State == transformStateStarted || stats.State == transformStateIndexing {
+			if err = transformStop(handler.Client(), o.GetExternalName(), false); err != nil {
+				return res, errors.Wrap(err, "Error when stop transform")
+			}
+			isStateChanged = true
+			logger.Infof("Transform %s successfully stopped", o.GetExternalName())
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "StopCompleted", "Transform %s successfully stopped", o.GetExternalName())
+		}
+	case elasticsearchapicrd.TransformStateReset:
+		if o.IsResetNeeded() {
+			if err = transformStop(handler.Client(), o.GetExternalName(), true); err != nil {
+				return res, errors.Wrap(err, "Error when stop transform before reset it")
+			}
+			if err = transformReset(handler.Client(), o.GetExternalName()); err != nil {
+				return res, errors.Wrap(err, "Error when reset transform")
+			}
+			o.Status.LastResetGeneration = o.GetGeneration()
+			isStateChanged = true
+			logger.Infof("Transform %s successfully reset", o.GetExternalName())
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "ResetCompleted", "Transform %s successfully reset", o.GetExternalName())
+		}
+	}
+
+	if isStateChanged {
+		if stats, err = transformGetStats(handler.Client(), o.GetExternalName()); err != nil {
+			return res, errors.Wrap(err, "Error when get transform stats")
+		}
+		if stats == nil {
+			return res, errors.Errorf("Transform %s not found", o.GetExternalName())
+		}
+	}
+
+	// Mirror stats on status
+	o.Status.State = stats.State
+	o.Status.Health = stats.Health.Status
+	o.Status.Checkpoint = stats.Checkpointing.Last.Checkpoint
+	o.Status.DocumentsProcessed = stats.Stats.DocumentsProcessed
+	o.Status.DocumentsIndexed = stats.Stats.DocumentsIndexed
+
+	if res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger); err != nil {
+		return res, err
+	}
+
+	// Refresh periodically the stats when transform run
+	if o.GetExpectedState() == elasticsearchapicrd.TransformStateStarted {
+		res.RequeueAfter = transformStatsRefreshInterval
+	}
+
+	return res, nil
+}
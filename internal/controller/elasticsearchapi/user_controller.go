@@ -16,6 +16,7 @@ package elasticsearchapi
 import (
 	"context"
 	"fmt"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller"
@@ -56,10 +57,13 @@ func NewUserReconciler(client client.Client, logger *logrus.Entry, recorder reco
 			logger,
 			recorder,
 		),
-		RemoteReconcilerAction: newUserReconciler(
-			userName,
-			client,
-			recorder,
+		RemoteReconcilerAction: common.NewRemoteReconcilerAction(
+			elasticsearchapicrd.ElasticsearchApiAnnotationKey,
+			newUserReconciler(
+				userName,
+				client,
+				recorder,
+			),
 		),
 		name: userName,
 	}
@@ -1,6 +1,14 @@
 package elasticsearchapi
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/generic-objectmatcher/patch"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
@@ -8,6 +16,59 @@ import (
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 )
 
+// watchSimulateBody is the body of _execute to simulate all actions, so no notification is sent
+var watchSimulateBody = []byte(`{"action_modes": {"_all": "simulate"}}`)
+
+// watchGetResponse is the watch returned by _watcher/watch
+type watchGetResponse struct {
+	Status *watchStatusResponse `json:"status,omitempty"`
+}
+
+// watchStatusResponse is the status of the watch
+type watchStatusResponse struct {
+	State *struct {
+		Active bool `json:"active"`
+	} `json:"state,omitempty"`
+	LastChecked      *time.Time                            `json:"last_checked,omitempty"`
+	LastMetCondition *time.Time                            `json:"last_met_condition,omitempty"`
+	ExecutionState   string                                `json:"execution_state,omitempty"`
+	Actions          map[string]*watchActionStatusResponse `json:"actions,omitempty"`
+}
+
+// watchActionStatusResponse is the status of an action of the watch
+type watchActionStatusResponse struct {
+	Ack *struct {
+		State string `json:"state"`
+	} `json:"ack,omitempty"`
+	LastExecution *struct {
+		Timestamp  time.Time `json:"timestamp"`
+		Successful bool      `json:"successful"`
+		Reason     string    `json:"reason,omitempty"`
+	} `json:"last_execution,omitempty"`
+	LastThrottle *struct {
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"last_throttle,omitempty"`
+}
+
+// watchExecuteResponse is the response of _watcher/watch/<id>/_execute
+type watchExecuteResponse struct {
+	WatchRecord struct {
+		State  string `json:"state"`
+		Result struct {
+			ExecutionTime *time.Time `json:"execution_time,omitempty"`
+			Condition     *struct {
+				Met bool `json:"met"`
+			} `json:"condition,omitempty"`
+			Actions []struct {
+				ID     string `json:"id"`
+				Type   string `json:"type"`
+				Status string `json:"status"`
+				Reason string `json:"reason,omitempty"`
+			} `json:"actions"`
+		} `json:"result"`
+	} `json:"watch_record"`
+}
+
 type watchApiClient struct {
 	remote.RemoteExternalReconciler[*elasticsearchapicrd.Watch, *olivere.XPackWatch, eshandler.ElasticsearchHandler]
 }
@@ -67,12 +128,14 @@ func (h *watchApiClient) Get(o *elasticsearchapicrd.Watch) (object *olivere.XPac
 	return h.Client().WatchGet(o.GetExternalName())
 }
 
+// Create put the watch with the expected state, so a watch created inactive is never triggered
 func (h *watchApiClient) Create(object *olivere.XPackWatch, o *elasticsearchapicrd.Watch) (err error) {
-	return h.Client().WatchUpdate(o.GetExternalName(), object)
+	return watchPut(h.Client(), o.GetExternalName(), object, o.IsActive())
 }
 
+// Update put the watch with the expected state, else Elasticsearch activate the watch each time it's updated
 func (h *watchApiClient) Update(object *olivere.XPackWatch, o *elasticsearchapicrd.Watch) (err error) {
-	return h.Client().WatchUpdate(o.GetExternalName(), object)
+	return watchPut(h.Client(), o.GetExternalName(), object, o.IsActive())
 }
 
 func (h *watchApiClient) Delete(o *elasticsearchapicrd.Watch) (err error) {
@@ -82,3 +145,129 @@ func (h *watchApiClient) Delete(o *elasticsearchapicrd.Watch) (err error) {
 func (h *watchApiClient) Diff(currentOject *olivere.XPackWatch, expectedObject *olivere.XPackWatch, originalObject *olivere.XPackWatch, o *elasticsearchapicrd.Watch, ignoresDiff ...patch.CalculateOption) (patchResult *patch.PatchResult, err error) {
 	return h.Client().WatchDiff(currentOject, expectedObject, originalObject)
 }
+
+// watchPut permit to create or update the watch with its state
+func watchPut(client eshandler.ElasticsearchHandler, name string, watch *olivere.XPackWatch, active bool) (err error) {
+	b, err := json.Marshal(watch)
+	if err != nil {
+		return errors.Wrapf(err, "Error when convert watch %s to JSON", name)
+	}
+
+	api := client.Client().API
+	res, err := api.Watcher.PutWatch(
+		name,
+		api.Watcher.PutWatch.WithContext(context.Background()),
+		api.Watcher.PutWatch.WithBody(bytes.NewReader(b)),
+		api.Watcher.PutWatch.WithActive(active),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when put watch %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// watchGetStatus permit to get the status of the watch
+// It return nil if the watch not exist
+func watchGetStatus(client eshandler.ElasticsearchHandler, name string) (status *watchStatusResponse, err error) {
+	api := client.Client().API
+	res, err := api.Watcher.GetWatch(
+		name,
+		api.Watcher.GetWatch.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, errors.Errorf("Error when get status of watch %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp := &watchGetResponse{}
+	if err = json.Unmarshal(b, resp); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode status of watch %s", name)
+	}
+
+	return resp.Status, nil
+}
+
+// watchActivate permit to activate the watch
+func watchActivate(client eshandler.ElasticsearchHandler, name string) (err error) {
+	api := client.Client().API
+	res, err := api.Watcher.ActivateWatch(
+		name,
+		api.Watcher.ActivateWatch.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when activate watch %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// watchDeactivate permit to deactivate the watch
+func watchDeactivate(client eshandler.ElasticsearchHandler, name string) (err error) {
+	api := client.Client().API
+	res, err := api.Watcher.DeactivateWatch(
+		name,
+		api.Watcher.DeactivateWatch.WithContext(context.Background()),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return errors.Errorf("Error when deactivate watch %s: %s", name, res.String())
+	}
+
+	return nil
+}
+
+// watchSimulate permit to execute the watch now with all actions simulated
+// The execution is not recorded on watcher history
+func watchSimulate(client eshandler.ElasticsearchHandler, name string) (result *watchExecuteResponse, err error) {
+	api := client.Client().API
+	res, err := api.Watcher.ExecuteWatch(
+		api.Watcher.ExecuteWatch.WithContext(context.Background()),
+		api.Watcher.ExecuteWatch.WithWatchID(name),
+		api.Watcher.ExecuteWatch.WithBody(bytes.NewReader(watchSimulateBody)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, errors.Errorf("Error when execute watch %s: %s", name, res.String())
+	}
+
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	result = &watchExecuteResponse{}
+	if err = json.Unmarshal(b, result); err != nil {
+		return nil, errors.Wrapf(err, "Error when decode execution of watch %s", name)
+	}
+
+	return result, nil
+}
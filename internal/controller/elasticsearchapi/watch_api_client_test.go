@@ -1,13 +1,18 @@
 package elasticsearchapi
 
 import (
+	"io"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/disaster37/es-handler/v8/mocks"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/apis"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/stretchr/testify/assert"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
 	"github.com/webcenter-fr/elasticsearch-operator/api/shared"
+	"go.uber.org/mock/gomock"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -201,3 +206,111 @@ func TestWatchBuild(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedWatch, watch)
 }
+
+func TestWatchApi(t *testing.T) {
+	var (
+		method string
+		path   string
+		query  string
+		body   string
+	)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockES := mocks.NewMockElasticsearchHandler(ctrl)
+	mockES.EXPECT().Client().AnyTimes().Return(newFakeElasticsearchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		path = r.URL.Path
+		query = r.URL.RawQuery
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+
+		switch r.URL.Path {
+		case "/_watcher/watch/my_watch":
+			_, _ = w.Write([]byte(`{"found": true, "_id": "my_watch", "status": {"version": 1, "state": {"active": true, "timestamp": "2024-01-01T00:00:00.000Z"}, "last_checked": "2024-01-02T10:00:00.000Z", "last_met_condition": "2024-01-02T10:00:00.000Z", "execution_state": "executed", "actions": {"email_admin": {"ack": {"timestamp": "2024-01-01T00:00:00.000Z", "state": "ackable"}, "last_execution": {"timestamp": "2024-01-02T10:00:00.000Z", "successful": false, "reason": "smtp unreachable"}, "last_throttle": {"timestamp": "2024-01-02T09:00:00.000Z", "reason": "throttling interval is set to [5m]"}}}}, "watch": {}}`))
+		case "/_watcher/watch/my_watch/_activate", "/_watcher/watch/my_watch/_deactivate":
+			_, _ = w.Write([]byte(`{"status": {"state": {"active": true}}}`))
+		case "/_watcher/watch/my_watch/_execute":
+			_, _ = w.Write([]byte(`{"_id": "my_watch_0-2024-01-02T10:05:00.000Z", "watch_record": {"watch_id": "my_watch", "state": "executed", "result": {"execution_time": "2024-01-02T10:05:00.000Z", "execution_duration": 12, "condition": {"type": "compare", "status": "success", "met": true}, "actions": [{"id": "email_admin", "type": "email", "status": "simulated"}]}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"found": false, "_id": "missing"}`))
+		}
+	}))
+
+	// Get status
+	status, err := watchGetStatus(mockES, "my_watch")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, method)
+	assert.Equal(t, "/_watcher/watch/my_watch", path)
+	assert.True(t, status.State.Active)
+	assert.Equal(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), *status.LastChecked)
+	assert.Equal(t, time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), *status.LastMetCondition)
+	assert.Equal(t, "executed", status.ExecutionState)
+	assert.Equal(t, "ackable", status.Actions["email_admin"].Ack.State)
+	assert.False(t, status.Actions["email_admin"].LastExecution.Successful)
+	assert.Equal(t, "smtp unreachable", status.Actions["email_admin"].LastExecution.Reason)
+	assert.Equal(t, time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), status.Actions["email_admin"].LastThrottle.Timestamp)
+
+	// Get status when watch not exist
+	status, err = watchGetStatus(mockES, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, status)
+
+	// Put with the state
+	err = watchPut(mockES, "my_watch", &olivere.XPackWatch{
+		Trigger: map[string]map[string]any{
+			"schedule": {
+				"interval": "10m",
+			},
+		},
+		Input: map[string]map[string]any{
+			"simple": {},
+		},
+		Condition: map[string]map[string]any{
+			"always": {},
+		},
+		Actions: map[string]map[string]any{
+			"log": {
+				"logging": map[string]any{"text": "test"},
+			},
+		},
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_watcher/watch/my_watch", path)
+	assert.Equal(t, "active=false", query)
+	assert.JSONEq(t, `{"trigger": {"schedule": {"interval": "10m"}}, "input": {"simple": {}}, "condition": {"always": {}}, "actions": {"log": {"logging": {"text": "test"}}}}`, body)
+
+	// Activate
+	err = watchActivate(mockES, "my_watch")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_watcher/watch/my_watch/_activate", path)
+
+	// Deactivate
+	err = watchDeactivate(mockES, "my_watch")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_watcher/watch/my_watch/_deactivate", path)
+
+	// Deactivate when watch not exist
+	err = watchDeactivate(mockES, "missing")
+	assert.Error(t, err)
+
+	// Execute with simulated actions
+	result, err := watchSimulate(mockES, "my_watch")
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, method)
+	assert.Equal(t, "/_watcher/watch/my_watch/_execute", path)
+	assert.JSONEq(t, `{"action_modes": {"_all": "simulate"}}`, body)
+	assert.Equal(t, "executed", result.WatchRecord.State)
+	assert.True(t, result.WatchRecord.Result.Condition.Met)
+	assert.Len(t, result.WatchRecord.Result.Actions, 1)
+	assert.Equal(t, "email_admin", result.WatchRecord.Result.Actions[0].ID)
+	assert.Equal(t, "simulated", result.WatchRecord.Result.Actions[0].Status)
+
+	// Execute when watch not exist
+	_, err = watchSimulate(mockES, "missing")
+	assert.Error(t, err)
+}
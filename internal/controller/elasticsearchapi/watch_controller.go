@@ -22,6 +22,7 @@ import (
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,10 +52,13 @@ func NewWatchReconciler(client client.Client, logger *logrus.Entry, recorder rec
 			logger,
 			recorder,
 		),
-		RemoteReconcilerAction: newWatchReconciler(
-			watchName,
-			client,
-			recorder,
+		RemoteReconcilerAction: common.NewRemoteReconcilerAction(
+			elasticsearchapicrd.ElasticsearchApiAnnotationKey,
+			newWatchReconciler(
+				watchName,
+				client,
+				recorder,
+			),
 		),
 		name: watchName,
 	}
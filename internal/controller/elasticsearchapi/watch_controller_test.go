@@ -2,6 +2,8 @@ package elasticsearchapi
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -22,6 +24,7 @@ import (
 	condition "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -36,18 +39,49 @@ func (t *ElasticsearchapiControllerTestSuite) TestWatchReconciler() {
 	testCase.Steps = []test.TestStep[*elasticsearchapicrd.Watch]{
 		doCreateWatcherStep(),
 		doUpdateWatcherStep(),
+		doExecuteWatcherStep(),
 		doDeleteWatcherStep(),
 	}
-	testCase.PreTest = doMockWatcher(t.mockElasticsearchHandler)
+	testCase.PreTest = doMockWatcher(t.mockElasticsearchHandler, t.fakeElasticsearchMux, key.Name)
 
 	testCase.Run()
 }
 
-func doMockWatcher(mockES *mocks.MockElasticsearchHandler) func(stepName *string, data map[string]any) error {
+func doMockWatcher(mockES *mocks.MockElasticsearchHandler, mux *http.ServeMux, name string) func(stepName *string, data map[string]any) error {
 	return func(stepName *string, data map[string]any) (err error) {
 		isCreated := false
 		isUpdated := false
 
+		mux.HandleFunc("/_watcher/watch/"+name, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPut {
+				switch *stepName {
+				case "create":
+					isCreated = true
+					data["isCreated"] = true
+				case "update":
+					isUpdated = true
+					data["isUpdated"] = true
+				}
+				data["active"] = r.URL.Query().Get("active")
+				if data["active"] == "false" {
+					data["isDeactivated"] = true
+				}
+				_, _ = w.Write([]byte(`{"_id": "` + name + `", "_version": 1, "created": true}`))
+				return
+			}
+
+			_, isDeactivated := data["isDeactivated"]
+			_, _ = w.Write([]byte(fmt.Sprintf(`{"found": true, "_id": "%s", "status": {"state": {"active": %t}, "last_checked": "2024-01-02T10:00:00.000Z", "last_met_condition": "2024-01-02T09:00:00.000Z", "execution_state": "execution_not_needed", "actions": {"email_admin": {"ack": {"state": "awaits_successful_execution"}}}}}`, name, !isDeactivated)))
+		})
+		mux.HandleFunc("/_watcher/watch/"+name+"/_deactivate", func(w http.ResponseWriter, r *http.Request) {
+			data["isDeactivated"] = true
+			_, _ = w.Write([]byte(`{"status": {"state": {"active": false}}}`))
+		})
+		mux.HandleFunc("/_watcher/watch/"+name+"/_execute", func(w http.ResponseWriter, r *http.Request) {
+			data["isExecuted"] = true
+			_, _ = w.Write([]byte(`{"watch_record": {"state": "executed", "result": {"execution_time": "2024-01-02T10:05:00.000Z", "condition": {"met": true}, "actions": [{"id": "email_admin", "type": "email", "status": "simulated"}]}}}`))
+		})
+
 		mockES.EXPECT().WatchGet(gomock.Any()).AnyTimes().DoAndReturn(func(name string) (*olivere.XPackWatch, error) {
 			switch *stepName {
 			case "create":
@@ -156,21 +190,6 @@ func doMockWatcher(mockES *mocks.MockElasticsearchHandler) func(stepName *string
 			return nil, nil
 		})
 
-		mockES.EXPECT().WatchUpdate(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(func(name string, policy *olivere.XPackWatch) error {
-			switch *stepName {
-			case "create":
-				isCreated = true
-				data["isCreated"] = true
-				return nil
-			case "update":
-				isUpdated = true
-				data["isUpdated"] = true
-				return nil
-			}
-
-			return nil
-		})
-
 		mockES.EXPECT().WatchDelete(gomock.Any()).AnyTimes().DoAndReturn(func(name string) error {
 			data["isDeleted"] = true
 			return nil
@@ -255,6 +274,11 @@ func doCreateWatcherStep() test.TestStep[*elasticsearchapicrd.Watch] {
 			}
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(watch.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
 			assert.True(t, *watch.Status.IsSync)
+			assert.True(t, *watch.Status.Active)
+			assert.NotNil(t, watch.Status.LastChecked)
+			assert.False(t, *watch.Status.ConditionMet)
+			assert.Equal(t, "execution_not_needed", watch.Status.ExecutionState)
+			assert.Equal(t, []elasticsearchapicrd.WatchActionStatus{{Name: "email_admin", AckState: "awaits_successful_execution"}}, watch.Status.Actions)
 
 			return nil
 		},
@@ -272,6 +296,7 @@ func doUpdateWatcherStep() test.TestStep[*elasticsearchapicrd.Watch] {
 			}
 
 			data["lastGeneration"] = o.GetStatus().GetObservedGeneration()
+			o.Spec.Active = ptr.To(false)
 			o.Spec.Actions = &apis.MapAny{
 				Data: map[string]any{
 					"email_admin": map[string]any{
@@ -301,6 +326,9 @@ func doUpdateWatcherStep() test.TestStep[*elasticsearchapicrd.Watch] {
 				if !isUpdated || lastGeneration == watch.GetStatus().GetObservedGeneration() {
 					return errors.New("Not yet updated")
 				}
+				if _, ok := data["isDeactivated"]; !ok {
+					return errors.New("Not yet deactivated")
+				}
 				return nil
 			}, time.Second*30, time.Second*1)
 			if err != nil || isTimeout {
@@ -308,6 +336,52 @@ func doUpdateWatcherStep() test.TestStep[*elasticsearchapicrd.Watch] {
 			}
 			assert.True(t, condition.IsStatusConditionPresentAndEqual(watch.Status.Conditions, controller.ReadyCondition.String(), metav1.ConditionTrue))
 			assert.True(t, *watch.Status.IsSync)
+			assert.False(t, *watch.Status.Active)
+			assert.Equal(t, "false", data["active"])
+
+			return nil
+		},
+	}
+}
+
+func doExecuteWatcherStep() test.TestStep[*elasticsearchapicrd.Watch] {
+	return test.TestStep[*elasticsearchapicrd.Watch]{
+		Name: "execute",
+		Do: func(c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Watch, data map[string]any) (err error) {
+			logrus.Infof("=== Execute watch %s/%s ===\n\n", key.Namespace, key.Name)
+
+			if o == nil {
+				return errors.New("Watch is null")
+			}
+
+			o.Annotations = map[string]string{
+				watchExecuteAnnotation: "true",
+			}
+			if err = c.Update(context.Background(), o); err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Check: func(t *testing.T, c client.Client, key types.NamespacedName, o *elasticsearchapicrd.Watch, data map[string]any) (err error) {
+			watch := &elasticsearchapicrd.Watch{}
+
+			isTimeout, err := test.RunWithTimeout(func() error {
+				if err := c.Get(context.Background(), key, watch); err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := data["isExecuted"]; !ok || watch.Status.LastSimulation == nil {
+					return errors.New("Not yet executed")
+				}
+				return nil
+			}, time.Second*30, time.Second*1)
+			if err != nil || isTimeout {
+				t.Fatalf("Failed to get Watch: %s", err.Error())
+			}
+			assert.Equal(t, "executed", watch.Status.LastSimulation.State)
+			assert.True(t, watch.Status.LastSimulation.ConditionMet)
+			assert.Equal(t, []elasticsearchapicrd.WatchSimulatedAction{{Name: "email_admin", Type: "email", Status: "simulated"}}, watch.Status.LastSimulation.Actions)
+			assert.NotContains(t, watch.Annotations, watchExecuteAnnotation)
 
 			return nil
 		},
@@ -2,18 +2,35 @@ package elasticsearchapi
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
+	"emperror.dev/errors"
 	eshandler "github.com/disaster37/es-handler/v8"
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
 	olivere "github.com/olivere/elastic/v7"
 	"github.com/sirupsen/logrus"
 	elasticsearchapicrd "github.com/webcenter-fr/elasticsearch-operator/api/elasticsearchapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// watchStatusRefreshInterval is the interval to refresh the status of the watch
+	watchStatusRefreshInterval = 5 * time.Minute
+)
+
+var (
+	// watchExecuteAnnotation is the annotation that execute the watch now with all actions simulated
+	watchExecuteAnnotation = fmt.Sprintf("%s/execute", elasticsearchapicrd.ElasticsearchApiAnnotationKey)
+)
+
 type watchReconciler struct {
 	remote.RemoteReconcilerAction[*elasticsearchapicrd.Watch, *olivere.XPackWatch, eshandler.ElasticsearchHandler]
 	name string
@@ -48,3 +65,157 @@ func (h *watchReconciler) GetRemoteHandler(ctx context.Context, req reconcile.Re
 
 	return handler, res, nil
 }
+
+// OnSuccess activate or deactivate the watch when its state drift, execute it with simulated actions when the execute annotation is set, then read the status of the watch
+func (h *watchReconciler) OnSuccess(ctx context.Context, o *elasticsearchapicrd.Watch, data map[string]any, handler remote.RemoteExternalReconciler[*elasticsearchapicrd.Watch, *olivere.XPackWatch, eshandler.ElasticsearchHandler], diff remote.RemoteDiff[*olivere.XPackWatch], logger *logrus.Entry) (res reconcile.Result, err error) {
+	status, err := watchGetStatus(handler.Client(), o.GetExternalName())
+	if err != nil {
+		return res, errors.Wrapf(err, "Error when get status of watch %s", o.GetExternalName())
+	}
+
+	// Not change the remote watch when the dry-run annotation is set, the watch can be not yet applied
+	if o.GetDryRunStatus() == nil {
+		// The watch is put with the expected state, it only converge the state changed outside the operator, like from Kibana
+		if status != nil && status.State != nil && status.State.Active != o.IsActive() {
+			if o.IsActive() {
+				if err = watchActivate(handler.Client(), o.GetExternalName()); err != nil {
+					return res, errors.Wrapf(err, "Error when activate watch %s", o.GetExternalName())
+				}
+				logger.Infof("Watch %s activated", o.GetExternalName())
+				h.Recorder().Eventf(o, corev1.EventTypeNormal, "Activated", "Watch %s activated", o.GetExternalName())
+			} else {
+				if err = watchDeactivate(handler.Client(), o.GetExternalName()); err != nil {
+					return res, errors.Wrapf(err, "Error when deactivate watch %s", o.GetExternalName())
+				}
+				logger.Infof("Watch %s deactivated", o.GetExternalName())
+				h.Recorder().Eventf(o, corev1.EventTypeNormal, "Deactivated", "Watch %s deactivated", o.GetExternalName())
+			}
+			status.State.Active = o.IsActive()
+		}
+
+		if o.GetAnnotations()[watchExecuteAnnotation] == "true" {
+			result, err := watchSimulate(handler.Client(), o.GetExternalName())
+			if err != nil {
+				return res, errors.Wrapf(err, "Error when execute watch %s", o.GetExternalName())
+			}
+			o.Status.LastSimulation = watchExecuteToStatus(result)
+			logger.Infof("Watch %s executed with simulated actions, condition met: %t", o.GetExternalName(), o.Status.LastSimulation.ConditionMet)
+			h.Recorder().Eventf(o, corev1.EventTypeNormal, "Executed", "Watch %s executed with simulated actions, state: %s, condition met: %t", o.GetExternalName(), o.Status.LastSimulation.State, o.Status.LastSimulation.ConditionMet)
+
+			if err = common.RemoveAnnotation(ctx, h.Client(), o, watchExecuteAnnotation); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	h.setWatchStatus(o, status)
+
+	res, err = h.RemoteReconcilerAction.OnSuccess(ctx, o, data, handler, diff, logger)
+	if err != nil {
+		return res, err
+	}
+
+	// Refresh the status to notice when the actions stop working
+	if !res.Requeue && (res.RequeueAfter == 0 || res.RequeueAfter > watchStatusRefreshInterval) {
+		res.RequeueAfter = watchStatusRefreshInterval
+	}
+
+	return res, nil
+}
+
+// setWatchStatus set the state, the last execution and the actions status of the watch on status
+// It notify when an action failed
+func (h *watchReconciler) setWatchStatus(o *elasticsearchapicrd.Watch, status *watchStatusResponse) {
+	if status == nil {
+		o.Status.Active = nil
+		o.Status.LastChecked = nil
+		o.Status.LastMetCondition = nil
+		o.Status.ConditionMet = nil
+		o.Status.ExecutionState = ""
+		o.Status.Actions = nil
+		return
+	}
+
+	if status.State != nil {
+		o.Status.Active = ptr.To(status.State.Active)
+	} else {
+		o.Status.Active = nil
+	}
+	o.Status.LastChecked = nil
+	o.Status.LastMetCondition = nil
+	o.Status.ConditionMet = nil
+	if status.LastChecked != nil {
+		o.Status.LastChecked = &metav1.Time{Time: *status.LastChecked}
+		o.Status.ConditionMet = ptr.To(status.LastMetCondition != nil && !status.LastMetCondition.Before(*status.LastChecked))
+	}
+	if status.LastMetCondition != nil {
+		o.Status.LastMetCondition = &metav1.Time{Time: *status.LastMetCondition}
+	}
+	o.Status.ExecutionState = status.ExecutionState
+
+	knownActions := make(map[string]elasticsearchapicrd.WatchActionStatus, len(o.Status.Actions))
+	for _, action := range o.Status.Actions {
+		knownActions[action.Name] = action
+	}
+	actions := make([]elasticsearchapicrd.WatchActionStatus, 0, len(status.Actions))
+	for name, actionStatus := range status.Actions {
+		if actionStatus == nil {
+			continue
+		}
+		action := elasticsearchapicrd.WatchActionStatus{
+			Name: name,
+		}
+		if actionStatus.Ack != nil {
+			action.AckState = actionStatus.Ack.State
+		}
+		if actionStatus.LastExecution != nil {
+			action.LastExecution = &metav1.Time{Time: actionStatus.LastExecution.Timestamp}
+			action.Successful = ptr.To(actionStatus.LastExecution.Successful)
+			action.Reason = actionStatus.LastExecution.Reason
+
+			if !actionStatus.LastExecution.Successful {
+				knownAction, ok := knownActions[name]
+				if !ok || knownAction.LastExecution == nil || !knownAction.LastExecution.Time.Equal(actionStatus.LastExecution.Timestamp) {
+					h.Recorder().Eventf(o, corev1.EventTypeWarning, "ActionFailed", "Action %s of watch %s failed: %s", name, o.GetExternalName(), actionStatus.LastExecution.Reason)
+				}
+			}
+		}
+		if actionStatus.LastThrottle != nil {
+			action.LastThrottle = &metav1.Time{Time: actionStatus.LastThrottle.Timestamp}
+		}
+		actions = append(actions, action)
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].Name < actions[j].Name
+	})
+
+	if len(actions) == 0 {
+		o.Status.Actions = nil
+	} else {
+		o.Status.Actions = actions
+	}
+}
+
+// watchExecuteToStatus convert the result of the simulated execution on status
+func watchExecuteToStatus(result *watchExecuteResponse) *elasticsearchapicrd.WatchSimulation {
+	simulation := &elasticsearchapicrd.WatchSimulation{
+		State: result.WatchRecord.State,
+		Time:  &metav1.Time{Time: time.Now()},
+	}
+	if result.WatchRecord.Result.ExecutionTime != nil {
+		simulation.Time = &metav1.Time{Time: *result.WatchRecord.Result.ExecutionTime}
+	}
+	if result.WatchRecord.Result.Condition != nil {
+		simulation.ConditionMet = result.WatchRecord.Result.Condition.Met
+	}
+	for _, action := range result.WatchRecord.Result.Actions {
+		simulation.Actions = append(simulation.Actions, elasticsearchapicrd.WatchSimulatedAction{
+			Name:   action.ID,
+			Type:   action.Type,
+			Status: action.Status,
+			Reason: action.Reason,
+		})
+	}
+
+	return simulation
+}
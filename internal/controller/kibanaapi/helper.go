@@ -54,7 +54,7 @@ func GetKibanaHandler(ctx context.Context, o client.Object, kbRef shared.KibanaR
 		// If no Kibana secret credential provided and Elasticsearch is also managed, we can use Elasticsearc credentials secret
 		if kbRef.KibanaCredentialSecretRef == nil {
 			if kb.Spec.ElasticsearchRef.IsManaged() {
-				es, err := common.GetElasticsearchFromRef(ctx, client, o, kb.Spec.ElasticsearchRef)
+				es, err := common.GetElasticsearchFromRef(ctx, client, kb, kb.Spec.ElasticsearchRef)
 				if err != nil {
 					return nil, errors.Wrap(err, "Error when get Elasticsearch object from ref")
 				}
@@ -22,6 +22,7 @@ import (
 	"github.com/disaster37/operator-sdk-extra/v2/pkg/controller/remote"
 	"github.com/sirupsen/logrus"
 	kibanaapicrd "github.com/webcenter-fr/elasticsearch-operator/api/kibanaapi/v1"
+	"github.com/webcenter-fr/elasticsearch-operator/internal/controller/common"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,10 +52,13 @@ func NewLogstashPipelineReconciler(client client.Client, logger *logrus.Entry, r
 			logger,
 			recorder,
 		),
-		RemoteReconcilerAction: newLogstashPipelineReconciler(
-			logstashPipelineName,
-			client,
-			recorder,
+		RemoteReconcilerAction: common.NewRemoteReconcilerAction(
+			kibanaapicrd.KibanaApiAnnotationKey,
+			newLogstashPipelineReconciler(
+				logstashPipelineName,
+				client,
+				recorder,
+			),
 		),
 		name: logstashPipelineName,
 	}